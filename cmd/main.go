@@ -126,6 +126,7 @@ func main() {
 	v1 := r.Group("/api/v1")
 	v1.Use(middleware.RemoteAuthMiddleware(cont.AuthClient))
 	v1.Use(ginmiddleware.AuditMiddleware(cont.AuditLogger))
+	v1.Use(middleware.SchoolWriteGuard(cont.SchoolService))
 	{
 		// Schools
 		schools := v1.Group("/schools")
@@ -133,6 +134,10 @@ func main() {
 			schools.POST("", ginmiddleware.RequirePermission(enum.PermissionSchoolsCreate), cont.SchoolHandler.CreateSchool)
 			schools.GET("", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.SchoolHandler.ListSchools)
 			schools.GET("/code/:code", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.SchoolHandler.GetSchoolByCode)
+			schools.GET("/code-availability", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.SchoolHandler.CheckSchoolCode)
+			schools.POST("/import-bundle", ginmiddleware.RequirePermission(enum.PermissionSchoolsCreate), cont.SchoolHandler.ImportSchoolBundle)
+			schools.GET("/:id/bundle", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.SchoolHandler.ExportSchoolBundle)
+			schools.POST("/:id/clone", ginmiddleware.RequirePermission(enum.PermissionSchoolsCreate), cont.SchoolHandler.CloneSchool)
 
 			// Academic Units nested under school
 			schools.POST("/:id/units", ginmiddleware.RequirePermission(enum.PermissionUnitsCreate), cont.AcademicUnitHandler.CreateUnit)
@@ -144,11 +149,37 @@ func main() {
 			schools.GET("/:id/concepts", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.ConceptTypeHandler.GetSchoolConcepts)
 			schools.GET("/:id/concepts/:conceptId", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.ConceptTypeHandler.GetSchoolConcept)
 			schools.PUT("/:id/concepts/:conceptId", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.ConceptTypeHandler.UpdateSchoolConcept)
+			schools.POST("/:id/concepts/reset", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.ConceptTypeHandler.ResetSchoolConcepts)
+			schools.POST("/:id/concepts/:conceptId/reset", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.ConceptTypeHandler.ResetSchoolConcept)
+			schools.PUT("/:id/concept-type", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.ConceptTypeHandler.ChangeSchoolConceptType)
+
+			// Curriculum
+			schools.GET("/:id/curriculum", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.CurriculumHandler.GetCurriculum)
+
+			// Subject templates
+			schools.POST("/:id/subject-templates/apply", ginmiddleware.RequirePermission(enum.PermissionSubjectsCreate), cont.SubjectTemplateHandler.ApplySubjectTemplates)
+
+			// Guardian relations of the school's students
+			schools.GET("/:id/guardian-relations", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsRead), cont.GuardianHandler.ListSchoolGuardianRelations)
+
+			// Custom fields of the school's users, units and subjects
+			schools.GET("/:id/custom-fields", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.CustomFieldHandler.ListFields)
+			schools.POST("/:id/custom-fields", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.CustomFieldHandler.CreateField)
+			schools.PUT("/:id/custom-fields/:fieldId", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.CustomFieldHandler.UpdateField)
+			schools.DELETE("/:id/custom-fields/:fieldId", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.CustomFieldHandler.DeleteField)
 
 			// School CRUD
 			schools.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.SchoolHandler.GetSchool)
 			schools.PUT("/:id", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.SchoolHandler.UpdateSchool)
 			schools.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionSchoolsDelete), cont.SchoolHandler.DeleteSchool)
+
+			// Deletion impact preview, its token is required by DELETE /:id
+			schools.GET("/:id/deletion-impact", ginmiddleware.RequirePermission(enum.PermissionSchoolsDelete), cont.SchoolHandler.GetDeletionImpact)
+
+			// Lifecycle
+			schools.GET("/:id/lifecycle", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.SchoolHandler.GetSchoolLifecycle)
+			schools.POST("/:id/lifecycle", ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.SchoolHandler.ChangeSchoolState)
+			schools.POST("/:id/restore", middleware.RequirePlatformScope(), ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.SchoolHandler.RestoreSchool)
 		}
 
 		// Concept Types
@@ -156,14 +187,39 @@ func main() {
 		{
 			conceptTypes.POST("", ginmiddleware.RequirePermission(enum.PermissionConceptTypesCreate), cont.ConceptTypeHandler.CreateConceptType)
 			conceptTypes.GET("", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.ListConceptTypes)
+			conceptTypes.POST("/import", ginmiddleware.RequirePermission(enum.PermissionConceptTypesCreate), cont.ConceptTypeHandler.ImportConceptPack)
 			conceptTypes.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.GetConceptType)
 			conceptTypes.PUT("/:id", ginmiddleware.RequirePermission(enum.PermissionConceptTypesUpdate), cont.ConceptTypeHandler.UpdateConceptType)
 			conceptTypes.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionConceptTypesDelete), cont.ConceptTypeHandler.DeleteConceptType)
+			conceptTypes.GET("/:id/usage", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.GetConceptTypeUsage)
 
 			conceptTypes.POST("/:id/definitions", ginmiddleware.RequirePermission(enum.PermissionConceptTypesUpdate), cont.ConceptTypeHandler.CreateDefinition)
 			conceptTypes.GET("/:id/definitions", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.ListDefinitions)
 			conceptTypes.PUT("/:id/definitions/:defId", ginmiddleware.RequirePermission(enum.PermissionConceptTypesUpdate), cont.ConceptTypeHandler.UpdateDefinition)
 			conceptTypes.DELETE("/:id/definitions/:defId", ginmiddleware.RequirePermission(enum.PermissionConceptTypesUpdate), cont.ConceptTypeHandler.DeleteDefinition)
+			conceptTypes.POST("/:id/sync", ginmiddleware.RequirePermission(enum.PermissionConceptTypesUpdate), cont.ConceptTypeHandler.SyncDefinitions)
+			conceptTypes.GET("/:id/export", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.ExportConceptType)
+			conceptTypes.GET("/:id/draft/diff", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.DiffDraft)
+			conceptTypes.POST("/:id/publish", ginmiddleware.RequirePermission(enum.PermissionConceptTypesUpdate), cont.ConceptTypeHandler.PublishDefinitions)
+			conceptTypes.GET("/:id/versions", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.ListVersions)
+			conceptTypes.GET("/:id/versions/:version", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.GetVersion)
+		}
+
+		// Concept category catalog
+		conceptCategories := v1.Group("/concept-categories")
+		{
+			conceptCategories.GET("", ginmiddleware.RequirePermission(enum.PermissionConceptTypesRead), cont.ConceptTypeHandler.ListConceptCategories)
+			conceptCategories.POST("", ginmiddleware.RequirePermission(enum.PermissionConceptTypesCreate), cont.ConceptTypeHandler.CreateConceptCategory)
+			conceptCategories.PUT("/:code", ginmiddleware.RequirePermission(enum.PermissionConceptTypesUpdate), cont.ConceptTypeHandler.UpdateConceptCategory)
+			conceptCategories.DELETE("/:code", ginmiddleware.RequirePermission(enum.PermissionConceptTypesDelete), cont.ConceptTypeHandler.DeleteConceptCategory)
+		}
+
+		// Metadata schemas
+		schemas := v1.Group("/schemas")
+		{
+			schemas.GET("/:entity", ginmiddleware.RequirePermission(enum.PermissionSchoolsRead), cont.MetadataSchemaHandler.GetSchema)
+			schemas.PUT("/:entity", middleware.RequirePlatformScope(), ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.MetadataSchemaHandler.PutSchema)
+			schemas.DELETE("/:entity", middleware.RequirePlatformScope(), ginmiddleware.RequirePermission(enum.PermissionSchoolsUpdate), cont.MetadataSchemaHandler.DeleteSchema)
 		}
 
 		// Academic Units (standalone)
@@ -174,6 +230,7 @@ func main() {
 			units.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionUnitsDelete), cont.AcademicUnitHandler.DeleteUnit)
 			units.POST("/:id/restore", ginmiddleware.RequirePermission(enum.PermissionUnitsUpdate), cont.AcademicUnitHandler.RestoreUnit)
 			units.GET("/:id/hierarchy-path", ginmiddleware.RequirePermission(enum.PermissionUnitsRead), cont.AcademicUnitHandler.GetHierarchyPath)
+			units.GET("/:id/teaching-assignments", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.TeachingAssignmentHandler.ListUnitAssignments)
 		}
 
 		// Memberships
@@ -226,6 +283,36 @@ func main() {
 			subjects.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.SubjectHandler.GetSubject)
 			subjects.PATCH("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.SubjectHandler.UpdateSubject)
 			subjects.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsDelete), cont.SubjectHandler.DeleteSubject)
+			subjects.POST("/:id/restore", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.SubjectHandler.RestoreSubject)
+			subjects.GET("/:id/teaching-assignments", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.TeachingAssignmentHandler.ListSubjectAssignments)
+			subjects.GET("/:id/prerequisites", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.CurriculumHandler.ListPrerequisites)
+			subjects.POST("/:id/prerequisites", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.CurriculumHandler.AddPrerequisite)
+			subjects.DELETE("/:id/prerequisites/:prerequisite_id", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.CurriculumHandler.RemovePrerequisite)
+			subjects.PUT("/:id/placement", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.CurriculumHandler.SetPlacement)
+			subjects.DELETE("/:id/placement", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.CurriculumHandler.RemovePlacement)
+		}
+
+		// Subject Templates (platform catalog)
+		subjectTemplates := v1.Group("/subject-templates")
+		{
+			subjectTemplates.POST("", ginmiddleware.RequirePermission(enum.PermissionSubjectsCreate), cont.SubjectTemplateHandler.CreateSubjectTemplate)
+			subjectTemplates.GET("", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.SubjectTemplateHandler.ListSubjectTemplates)
+			subjectTemplates.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.SubjectTemplateHandler.GetSubjectTemplate)
+			subjectTemplates.PUT("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.SubjectTemplateHandler.UpdateSubjectTemplate)
+			subjectTemplates.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsDelete), cont.SubjectTemplateHandler.DeleteSubjectTemplate)
+		}
+
+		// Teaching Assignments
+		teachingAssignments := v1.Group("/teaching-assignments")
+		{
+			teachingAssignments.POST("", ginmiddleware.RequirePermission(enum.PermissionSubjectsCreate), cont.TeachingAssignmentHandler.CreateTeachingAssignment)
+			teachingAssignments.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.TeachingAssignmentHandler.GetTeachingAssignment)
+			teachingAssignments.PATCH("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsUpdate), cont.TeachingAssignmentHandler.UpdateTeachingAssignment)
+			teachingAssignments.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionSubjectsDelete), cont.TeachingAssignmentHandler.DeleteTeachingAssignment)
+		}
+		teachers := v1.Group("/teachers")
+		{
+			teachers.GET("/:teacher_id/teaching-assignments", ginmiddleware.RequirePermission(enum.PermissionSubjectsRead), cont.TeachingAssignmentHandler.ListTeacherAssignments)
 		}
 
 		// Guardian Relations
@@ -243,6 +330,32 @@ func main() {
 		students := v1.Group("/students")
 		{
 			students.GET("/:student_id/guardians", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsRead), cont.GuardianHandler.GetStudentGuardians)
+			students.GET("/:student_id/authorized-pickups", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsRead), cont.GuardianHandler.GetAuthorizedPickups)
+			students.POST("/:student_id/guardian-invitations", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsManage), cont.GuardianInvitationHandler.CreateInvitation)
+			students.GET("/:student_id/guardian-invitations", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsRead), cont.GuardianInvitationHandler.ListInvitations)
+			students.GET("/:student_id/household", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsRead), cont.HouseholdHandler.GetStudentHousehold)
+		}
+
+		// Households
+		households := v1.Group("/households")
+		{
+			households.POST("", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsManage), cont.HouseholdHandler.CreateHousehold)
+			households.GET("/:id", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsRead), cont.HouseholdHandler.GetHousehold)
+			households.POST("/:id/members", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsManage), cont.HouseholdHandler.AddHouseholdMember)
+			households.DELETE("/:id/members/:user_id", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsManage), cont.HouseholdHandler.RemoveHouseholdMember)
+		}
+
+		// Guardian self-service linking
+		guardianInvitations := v1.Group("/guardian-invitations")
+		{
+			// Any authenticated guardian account may redeem a code for itself
+			guardianInvitations.POST("/redeem", cont.GuardianInvitationHandler.RedeemInvitation)
+			guardianInvitations.DELETE("/:id", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsManage), cont.GuardianInvitationHandler.RevokeInvitation)
+		}
+		guardianLinkRequests := v1.Group("/guardian-link-requests")
+		{
+			guardianLinkRequests.GET("", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsRead), cont.GuardianInvitationHandler.ListLinkRequests)
+			guardianLinkRequests.POST("/:id/review", ginmiddleware.RequirePermission(enum.PermissionGuardianRelationsManage), cont.GuardianInvitationHandler.ReviewLinkRequest)
 		}
 	}
 
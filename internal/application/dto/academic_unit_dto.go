@@ -15,6 +15,7 @@ type CreateAcademicUnitRequest struct {
 	Code         string                 `json:"code"`
 	Description  string                 `json:"description"`
 	Metadata     map[string]interface{} `json:"metadata"`
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 // UpdateAcademicUnitRequest represents the request to update an academic unit
@@ -23,6 +24,8 @@ type UpdateAcademicUnitRequest struct {
 	DisplayName  *string                `json:"display_name"`
 	Description  *string                `json:"description"`
 	Metadata     map[string]interface{} `json:"metadata"`
+	// CustomFields sets the given custom fields; a null value clears one
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 // AcademicUnitResponse represents an academic unit in API responses
@@ -38,6 +41,7 @@ type AcademicUnitResponse struct {
 	CreatedAt    time.Time              `json:"created_at"`
 	UpdatedAt    time.Time              `json:"updated_at"`
 	DeletedAt    *time.Time             `json:"deleted_at,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 // UnitTreeNode represents a node in the hierarchical tree
@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// ConceptPack is a portable, versioned document holding a concept type and its
+// definitions, used to promote concept types between environments. It is
+// exchanged as JSON or YAML.
+type ConceptPack struct {
+	Version     int                     `json:"version" yaml:"version" binding:"required"`
+	ExportedAt  *time.Time              `json:"exported_at,omitempty" yaml:"exported_at,omitempty"`
+	ConceptType ConceptPackType         `json:"concept_type" yaml:"concept_type"`
+	Definitions []ConceptPackDefinition `json:"definitions" yaml:"definitions" binding:"dive"`
+}
+
+// ConceptPackType is the concept type section of a ConceptPack
+type ConceptPackType struct {
+	Code        string `json:"code" yaml:"code" binding:"required,min=3"`
+	Name        string `json:"name" yaml:"name" binding:"required,min=3"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ConceptPackDefinition is a concept definition in a ConceptPack. On import,
+// omitting Translations leaves the stored translations untouched.
+type ConceptPackDefinition struct {
+	TermKey      string            `json:"term_key" yaml:"term_key" binding:"required"`
+	TermValue    string            `json:"term_value" yaml:"term_value" binding:"required"`
+	Category     string            `json:"category,omitempty" yaml:"category,omitempty"`
+	SortOrder    int               `json:"sort_order" yaml:"sort_order"`
+	Translations map[string]string `json:"translations,omitempty" yaml:"translations,omitempty"`
+}
+
+// ConceptPackDefinitionResult reports what an import did with one definition.
+// Reason explains conflicts.
+type ConceptPackDefinitionResult struct {
+	TermKey string `json:"term_key"`
+	Action  string `json:"action"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// ConceptPackImportResponse reports a concept pack import. ConceptTypeID is
+// omitted when a dry run would create the type.
+type ConceptPackImportResponse struct {
+	ConceptTypeID string                        `json:"concept_type_id,omitempty"`
+	Code          string                        `json:"code"`
+	Action        string                        `json:"action"`
+	DryRun        bool                          `json:"dry_run"`
+	Conflicts     int                           `json:"conflicts"`
+	Definitions   []ConceptPackDefinitionResult `json:"definitions"`
+}
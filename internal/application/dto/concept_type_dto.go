@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 )
 
@@ -26,29 +29,63 @@ type ConceptTypeResponse struct {
 	IsActive    bool   `json:"is_active"`
 }
 
-// ConceptDefinitionRequest represents the request to create/update a concept definition
+// ConceptTypeUsageSchool is a school that references a concept type
+type ConceptTypeUsageSchool struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Code     string `json:"code"`
+	IsActive bool   `json:"is_active"`
+}
+
+// ConceptTypeUsageResponse reports the schools and definitions of a concept type
+type ConceptTypeUsageResponse struct {
+	ConceptTypeID         string                   `json:"concept_type_id"`
+	Code                  string                   `json:"code"`
+	InUse                 bool                     `json:"in_use"`
+	SchoolCount           int                      `json:"school_count"`
+	Schools               []ConceptTypeUsageSchool `json:"schools"`
+	DefinitionCount       int                      `json:"definition_count"`
+	DefinitionsByCategory map[string]int           `json:"definitions_by_category"`
+}
+
+// ConceptDefinitionRequest represents the request to create/update a concept definition.
+// TermKey is a dotted identifier such as "unit.level", unique within the type.
+// Category is a code from the category catalog, "general" when omitted on create.
+// TermValue is in the default locale; Translations holds the other locales keyed
+// by locale. On update, omitting Translations leaves the stored ones untouched.
 type ConceptDefinitionRequest struct {
-	TermKey   string `json:"term_key" binding:"required"`
-	TermValue string `json:"term_value" binding:"required"`
-	Category  string `json:"category"`
-	SortOrder int    `json:"sort_order"`
+	TermKey      string            `json:"term_key" binding:"required"`
+	TermValue    string            `json:"term_value" binding:"required"`
+	Category     string            `json:"category"`
+	SortOrder    int               `json:"sort_order"`
+	Translations map[string]string `json:"translations"`
 }
 
 // ConceptDefinitionResponse represents a concept definition in API responses
 type ConceptDefinitionResponse struct {
-	ID        string `json:"id"`
-	TermKey   string `json:"term_key"`
-	TermValue string `json:"term_value"`
-	Category  string `json:"category"`
-	SortOrder int    `json:"sort_order"`
+	ID           string            `json:"id"`
+	TermKey      string            `json:"term_key"`
+	TermValue    string            `json:"term_value"`
+	Category     string            `json:"category"`
+	SortOrder    int               `json:"sort_order"`
+	Translations map[string]string `json:"translations,omitempty"`
 }
 
-// SchoolConceptResponse represents a school concept in API responses
+// SchoolConceptResponse represents a school concept in API responses.
+// SourceDefinitionID and DefaultValue are omitted for terms without a definition;
+// SourceVersion is the published version the default came from, if any.
+// Locale is the locale TermValue is expressed in, which falls back to the
+// default locale when no translation exists for the requested one.
 type SchoolConceptResponse struct {
-	ID        string `json:"id"`
-	TermKey   string `json:"term_key"`
-	TermValue string `json:"term_value"`
-	Category  string `json:"category"`
+	ID                 string  `json:"id"`
+	TermKey            string  `json:"term_key"`
+	TermValue          string  `json:"term_value"`
+	Category           string  `json:"category"`
+	SourceDefinitionID *string `json:"source_definition_id,omitempty"`
+	DefaultValue       *string `json:"default_value,omitempty"`
+	SourceVersion      *int    `json:"source_version,omitempty"`
+	Overridden         bool    `json:"overridden"`
+	Locale             string  `json:"locale"`
 }
 
 // UpdateSchoolConceptRequest represents the request to update a school concept
@@ -56,6 +93,139 @@ type UpdateSchoolConceptRequest struct {
 	TermValue string `json:"term_value" binding:"required"`
 }
 
+// SyncConceptDefinitionsRequest selects the schools to sync and how far to go.
+// An empty SchoolIDs syncs every school using the concept type.
+type SyncConceptDefinitionsRequest struct {
+	SchoolIDs      []string `json:"school_ids"`
+	UpdateDefaults bool     `json:"update_defaults"`
+	DryRun         bool     `json:"dry_run"`
+}
+
+// SchoolConceptDiffEntry compares one school term against its definition
+type SchoolConceptDiffEntry struct {
+	TermKey      string `json:"term_key"`
+	Status       string `json:"status"`
+	SchoolValue  string `json:"school_value,omitempty"`
+	DefaultValue string `json:"default_value"`
+	Applied      bool   `json:"applied"`
+}
+
+// SchoolConceptSyncResult reports the diff and the changes made for one school
+type SchoolConceptSyncResult struct {
+	SchoolID   string                   `json:"school_id"`
+	SchoolName string                   `json:"school_name"`
+	Added      int                      `json:"added"`
+	Updated    int                      `json:"updated"`
+	Entries    []SchoolConceptDiffEntry `json:"entries"`
+}
+
+// ConceptSyncResponse reports a definition sync across schools
+type ConceptSyncResponse struct {
+	ConceptTypeID  string                    `json:"concept_type_id"`
+	Version        int                       `json:"version"`
+	DryRun         bool                      `json:"dry_run"`
+	UpdateDefaults bool                      `json:"update_defaults"`
+	Schools        []SchoolConceptSyncResult `json:"schools"`
+}
+
+// ChangeSchoolConceptTypeRequest switches a school to another concept type.
+// With KeepOverrides, customised terms whose key exists in the new type keep
+// their value. DryRun only reports the changes.
+type ChangeSchoolConceptTypeRequest struct {
+	ConceptTypeID string `json:"concept_type_id" binding:"required"`
+	KeepOverrides bool   `json:"keep_overrides"`
+	DryRun        bool   `json:"dry_run"`
+}
+
+// SchoolConceptChange describes what happens to one term when the concept type changes
+type SchoolConceptChange struct {
+	TermKey  string `json:"term_key"`
+	Action   string `json:"action"`
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+// ChangeSchoolConceptTypeResponse reports a concept type change for a school
+type ChangeSchoolConceptTypeResponse struct {
+	SchoolID              string                `json:"school_id"`
+	PreviousConceptTypeID *string               `json:"previous_concept_type_id,omitempty"`
+	ConceptTypeID         string                `json:"concept_type_id"`
+	KeepOverrides         bool                  `json:"keep_overrides"`
+	DryRun                bool                  `json:"dry_run"`
+	Changes               []SchoolConceptChange `json:"changes"`
+}
+
+// ConceptDefinitionGroupResponse holds the definitions filed under one category.
+// Name falls back to the category code for categories missing from the catalog.
+type ConceptDefinitionGroupResponse struct {
+	Category    string                      `json:"category"`
+	Name        string                      `json:"name"`
+	Definitions []ConceptDefinitionResponse `json:"definitions"`
+}
+
+// CreateConceptCategoryRequest represents the request to add a category to the catalog
+type CreateConceptCategoryRequest struct {
+	Code        string `json:"code" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// UpdateConceptCategoryRequest represents the request to update a catalog category
+type UpdateConceptCategoryRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// ConceptCategoryResponse represents a catalog category in API responses
+type ConceptCategoryResponse struct {
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	SortOrder   int       `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PublishConceptDefinitionsRequest publishes the draft definitions as a new version
+type PublishConceptDefinitionsRequest struct {
+	Notes string `json:"notes"`
+}
+
+// ConceptTypeVersionResponse represents a published concept type version
+type ConceptTypeVersionResponse struct {
+	Version     int       `json:"version"`
+	Notes       string    `json:"notes,omitempty"`
+	PublishedBy string    `json:"published_by,omitempty"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// ConceptTypeVersionDetailResponse is a published version with its definitions
+type ConceptTypeVersionDetailResponse struct {
+	ConceptTypeVersionResponse
+	Definitions []ConceptDefinitionResponse `json:"definitions"`
+}
+
+// ConceptDraftDiffEntry compares a draft definition with its published counterpart.
+// ChangedFields lists the fields that differ for changed entries.
+type ConceptDraftDiffEntry struct {
+	TermKey        string   `json:"term_key"`
+	Status         string   `json:"status"`
+	PublishedValue string   `json:"published_value,omitempty"`
+	DraftValue     string   `json:"draft_value,omitempty"`
+	ChangedFields  []string `json:"changed_fields,omitempty"`
+}
+
+// ConceptDraftDiffResponse reports the differences between the draft and the
+// latest published version, which is 0 when the type was never published
+type ConceptDraftDiffResponse struct {
+	ConceptTypeID    string                  `json:"concept_type_id"`
+	PublishedVersion int                     `json:"published_version"`
+	HasChanges       bool                    `json:"has_changes"`
+	Entries          []ConceptDraftDiffEntry `json:"entries"`
+}
+
 // ToConceptTypeResponse converts a ConceptType entity to ConceptTypeResponse
 func ToConceptTypeResponse(ct *entities.ConceptType) ConceptTypeResponse {
 	var description string
@@ -110,6 +280,24 @@ func ToSchoolConceptResponse(sc *entities.SchoolConcept) SchoolConceptResponse {
 	}
 }
 
+// ToSchoolConceptResponseWithSource converts a SchoolConcept entity and its
+// source record (which may be nil) to SchoolConceptResponse
+func ToSchoolConceptResponseWithSource(sc *entities.SchoolConcept, source *entity.SchoolConceptSource) SchoolConceptResponse {
+	response := ToSchoolConceptResponse(sc)
+	if source != nil {
+		definitionID := source.DefinitionID.String()
+		defaultValue := source.DefaultValue
+		response.SourceDefinitionID = &definitionID
+		response.DefaultValue = &defaultValue
+		if source.Version > 0 {
+			version := source.Version
+			response.SourceVersion = &version
+		}
+		response.Overridden = source.IsOverridden(sc.TermValue)
+	}
+	return response
+}
+
 // ToSchoolConceptResponseList converts a slice of SchoolConcept entities to SchoolConceptResponse slice
 func ToSchoolConceptResponseList(concepts []*entities.SchoolConcept) []SchoolConceptResponse {
 	responses := make([]SchoolConceptResponse, len(concepts))
@@ -118,3 +306,30 @@ func ToSchoolConceptResponseList(concepts []*entities.SchoolConcept) []SchoolCon
 	}
 	return responses
 }
+
+// ToConceptTypeVersionResponse converts a ConceptTypeVersion entity to ConceptTypeVersionResponse
+func ToConceptTypeVersionResponse(v *entity.ConceptTypeVersion) ConceptTypeVersionResponse {
+	response := ConceptTypeVersionResponse{Version: v.Version, PublishedAt: v.PublishedAt}
+	if v.Notes != nil {
+		response.Notes = *v.Notes
+	}
+	if v.PublishedBy != nil {
+		response.PublishedBy = *v.PublishedBy
+	}
+	return response
+}
+
+// ToConceptCategoryResponse converts a ConceptCategory entity to ConceptCategoryResponse
+func ToConceptCategoryResponse(c *entity.ConceptCategory) ConceptCategoryResponse {
+	response := ConceptCategoryResponse{
+		Code:      c.Code,
+		Name:      c.Name,
+		SortOrder: c.SortOrder,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if c.Description != nil {
+		response.Description = *c.Description
+	}
+	return response
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddPrerequisiteRequest represents the request to add a prerequisite to a subject
+type AddPrerequisiteRequest struct {
+	PrerequisiteID string `json:"prerequisite_id" binding:"required"`
+}
+
+// SetSubjectPlacementRequest places a subject at a grade-level academic unit
+type SetSubjectPlacementRequest struct {
+	LevelUnitID string `json:"level_unit_id" binding:"required"`
+	Position    int    `json:"position" binding:"min=0"`
+}
+
+// SubjectPlacementResponse represents the curriculum placement of a subject
+type SubjectPlacementResponse struct {
+	SubjectID   string `json:"subject_id"`
+	LevelUnitID string `json:"level_unit_id"`
+	Position    int    `json:"position"`
+}
+
+// CurriculumSubject is a subject node of the curriculum graph
+type CurriculumSubject struct {
+	SubjectID     string   `json:"subject_id"`
+	Name          string   `json:"name"`
+	Code          *string  `json:"code,omitempty"`
+	Position      int      `json:"position"`
+	Prerequisites []string `json:"prerequisites"`
+}
+
+// CurriculumLevel groups the subjects placed at one grade-level unit,
+// ordered so that prerequisites within the level come first
+type CurriculumLevel struct {
+	LevelUnitID string              `json:"level_unit_id"`
+	Name        string              `json:"name"`
+	Type        string              `json:"type"`
+	Subjects    []CurriculumSubject `json:"subjects"`
+}
+
+// CurriculumResponse is the ordered subject graph of a school
+type CurriculumResponse struct {
+	SchoolID string              `json:"school_id"`
+	Levels   []CurriculumLevel   `json:"levels"`
+	Unplaced []CurriculumSubject `json:"unplaced"`
+}
+
+// DOT renders the curriculum as a Graphviz digraph with one cluster per level
+// and an edge from each prerequisite to the subject that requires it.
+func (r CurriculumResponse) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph curriculum {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	writeNodes := func(indent string, subjects []CurriculumSubject) {
+		for _, s := range subjects {
+			fmt.Fprintf(&b, "%s%s [label=%s];\n", indent, dotQuote(s.SubjectID), dotQuote(s.Name))
+		}
+	}
+	for i, level := range r.Levels {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(level.Name))
+		writeNodes("    ", level.Subjects)
+		b.WriteString("  }\n")
+	}
+	writeNodes("  ", r.Unplaced)
+
+	writeEdges := func(subjects []CurriculumSubject) {
+		for _, s := range subjects {
+			for _, p := range s.Prerequisites {
+				fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(p), dotQuote(s.SubjectID))
+			}
+		}
+	}
+	for _, level := range r.Levels {
+		writeEdges(level.Subjects)
+	}
+	writeEdges(r.Unplaced)
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote returns s as a quoted DOT identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+)
+
+// CreateCustomFieldRequest defines a custom field for one of the school's
+// entity types. Options lists the allowed values of select fields.
+type CreateCustomFieldRequest struct {
+	EntityType string   `json:"entity_type" binding:"required,oneof=user academic_unit subject"`
+	Key        string   `json:"key" binding:"required"`
+	Name       string   `json:"name" binding:"required"`
+	Type       string   `json:"type" binding:"required,oneof=text number boolean date select"`
+	Required   bool     `json:"required"`
+	Options    []string `json:"options"`
+	SortOrder  int      `json:"sort_order"`
+}
+
+// UpdateCustomFieldRequest changes a custom field. The key, entity type and
+// value type cannot change once values may exist.
+type UpdateCustomFieldRequest struct {
+	Name      *string  `json:"name"`
+	Required  *bool    `json:"required"`
+	Options   []string `json:"options"`
+	SortOrder *int     `json:"sort_order"`
+}
+
+// CustomFieldResponse represents a custom field definition
+type CustomFieldResponse struct {
+	ID         string    `json:"id"`
+	SchoolID   string    `json:"school_id"`
+	EntityType string    `json:"entity_type"`
+	Key        string    `json:"key"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	Required   bool      `json:"required"`
+	Options    []string  `json:"options,omitempty"`
+	SortOrder  int       `json:"sort_order"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ToCustomFieldResponse converts a CustomFieldDefinition entity to CustomFieldResponse
+func ToCustomFieldResponse(def *entity.CustomFieldDefinition) CustomFieldResponse {
+	var options []string
+	if len(def.Options) > 0 {
+		_ = json.Unmarshal(def.Options, &options)
+	}
+	return CustomFieldResponse{
+		ID:         def.ID.String(),
+		SchoolID:   def.SchoolID.String(),
+		EntityType: def.EntityType,
+		Key:        def.Key,
+		Name:       def.Name,
+		Type:       def.Type,
+		Required:   def.Required,
+		Options:    options,
+		SortOrder:  def.SortOrder,
+		CreatedAt:  def.CreatedAt,
+		UpdatedAt:  def.UpdatedAt,
+	}
+}
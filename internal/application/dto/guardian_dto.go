@@ -3,32 +3,62 @@ package dto
 import (
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 )
 
 // CreateGuardianRelationRequest represents the request to create a guardian relation
 type CreateGuardianRelationRequest struct {
-	GuardianID       string `json:"guardian_id" binding:"required"`
-	StudentID        string `json:"student_id" binding:"required"`
-	RelationshipType string `json:"relationship_type" binding:"required"`
+	GuardianID             string `json:"guardian_id" binding:"required"`
+	StudentID              string `json:"student_id" binding:"required"`
+	RelationshipType       string `json:"relationship_type" binding:"required"`
+	IsPrimaryContact       *bool  `json:"is_primary_contact,omitempty"`
+	HasLegalCustody        *bool  `json:"has_legal_custody,omitempty"`
+	CanPickup              *bool  `json:"can_pickup,omitempty"`
+	ReceivesCommunications *bool  `json:"receives_communications,omitempty"`
 }
 
 // UpdateGuardianRelationRequest represents the request to update a guardian relation
 type UpdateGuardianRelationRequest struct {
-	RelationshipType *string `json:"relationship_type,omitempty"`
-	IsActive         *bool   `json:"is_active,omitempty"`
+	RelationshipType       *string `json:"relationship_type,omitempty"`
+	IsActive               *bool   `json:"is_active,omitempty"`
+	IsPrimaryContact       *bool   `json:"is_primary_contact,omitempty"`
+	HasLegalCustody        *bool   `json:"has_legal_custody,omitempty"`
+	CanPickup              *bool   `json:"can_pickup,omitempty"`
+	ReceivesCommunications *bool   `json:"receives_communications,omitempty"`
 }
 
 // GuardianRelationResponse represents a guardian relation in API responses
 type GuardianRelationResponse struct {
-	ID               string    `json:"id"`
-	GuardianID       string    `json:"guardian_id"`
-	StudentID        string    `json:"student_id"`
-	RelationshipType string    `json:"relationship_type"`
-	IsActive         bool      `json:"is_active"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
-	CreatedBy        string    `json:"created_by"`
+	ID                     string    `json:"id"`
+	GuardianID             string    `json:"guardian_id"`
+	StudentID              string    `json:"student_id"`
+	RelationshipType       string    `json:"relationship_type"`
+	IsActive               bool      `json:"is_active"`
+	IsPrimaryContact       bool      `json:"is_primary_contact"`
+	HasLegalCustody        bool      `json:"has_legal_custody"`
+	CanPickup              bool      `json:"can_pickup"`
+	ReceivesCommunications bool      `json:"receives_communications"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+	CreatedBy              string    `json:"created_by"`
+}
+
+// GuardianRelationListFilters holds the relation-specific query filters of guardian relation listings
+type GuardianRelationListFilters struct {
+	IncludeInactive  bool
+	RelationshipType string
+}
+
+// AuthorizedPickupResponse represents a guardian allowed to pick up a student
+type AuthorizedPickupResponse struct {
+	RelationID       string `json:"relation_id"`
+	GuardianID       string `json:"guardian_id"`
+	GuardianName     string `json:"guardian_name"`
+	GuardianEmail    string `json:"guardian_email"`
+	RelationshipType string `json:"relationship_type"`
+	IsPrimaryContact bool   `json:"is_primary_contact"`
+	HasLegalCustody  bool   `json:"has_legal_custody"`
 }
 
 // ToGuardianRelationResponse converts a GuardianRelation entity to response
@@ -49,6 +79,35 @@ func ToGuardianRelationResponse(relation *entities.GuardianRelation) *GuardianRe
 	}
 }
 
+// WithDetail copies the relation detail attributes into the response.
+// A nil detail leaves the attributes at their zero values.
+func (r *GuardianRelationResponse) WithDetail(detail *entity.GuardianRelationDetail) *GuardianRelationResponse {
+	if detail == nil {
+		return r
+	}
+	r.IsPrimaryContact = detail.IsPrimaryContact
+	r.HasLegalCustody = detail.HasLegalCustody
+	r.CanPickup = detail.CanPickup
+	r.ReceivesCommunications = detail.ReceivesCommunications
+	return r
+}
+
+// ToAuthorizedPickupResponse builds an authorized pickup entry from a relation, its detail and the guardian user
+func ToAuthorizedPickupResponse(relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail, guardian *entities.User) AuthorizedPickupResponse {
+	response := AuthorizedPickupResponse{
+		RelationID:       relation.ID.String(),
+		GuardianID:       relation.GuardianID.String(),
+		RelationshipType: relation.RelationshipType,
+		IsPrimaryContact: detail.IsPrimaryContact,
+		HasLegalCustody:  detail.HasLegalCustody,
+	}
+	if guardian != nil {
+		response.GuardianName = guardian.FirstName + " " + guardian.LastName
+		response.GuardianEmail = guardian.Email
+	}
+	return response
+}
+
 // ToGuardianRelationResponseList converts a slice of GuardianRelation entities to responses
 func ToGuardianRelationResponseList(relations []*entities.GuardianRelation) []*GuardianRelationResponse {
 	responses := make([]*GuardianRelationResponse, len(relations))
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+)
+
+// CreateGuardianInvitationRequest represents the request to issue a guardian link code for a student
+type CreateGuardianInvitationRequest struct {
+	SchoolID       string `json:"school_id" binding:"required"`
+	MaxUses        *int   `json:"max_uses,omitempty"`
+	ExpiresInHours *int   `json:"expires_in_hours,omitempty"`
+}
+
+// GuardianInvitationResponse represents a guardian invitation in API responses
+type GuardianInvitationResponse struct {
+	ID        string     `json:"id"`
+	StudentID string     `json:"student_id"`
+	SchoolID  string     `json:"school_id"`
+	Code      string     `json:"code"`
+	MaxUses   int        `json:"max_uses"`
+	UsesCount int        `json:"uses_count"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// RedeemGuardianInvitationRequest represents a guardian redeeming a link code
+type RedeemGuardianInvitationRequest struct {
+	Code             string `json:"code" binding:"required"`
+	RelationshipType string `json:"relationship_type" binding:"required"`
+}
+
+// ReviewGuardianLinkRequest represents an admin decision on a pending link request
+type ReviewGuardianLinkRequest struct {
+	Decision string  `json:"decision" binding:"required,oneof=approve reject"`
+	Note     *string `json:"note,omitempty"`
+}
+
+// GuardianLinkRequestResponse represents a guardian link request in API responses
+type GuardianLinkRequestResponse struct {
+	ID               string     `json:"id"`
+	InvitationID     string     `json:"invitation_id"`
+	GuardianID       string     `json:"guardian_id"`
+	StudentID        string     `json:"student_id"`
+	SchoolID         string     `json:"school_id"`
+	RelationshipType string     `json:"relationship_type"`
+	Status           string     `json:"status"`
+	RelationID       *string    `json:"relation_id,omitempty"`
+	ReviewedBy       *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote       *string    `json:"review_note,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// ToGuardianInvitationResponse converts a GuardianInvitation entity to response
+func ToGuardianInvitationResponse(invitation *entity.GuardianInvitation) GuardianInvitationResponse {
+	return GuardianInvitationResponse{
+		ID:        invitation.ID.String(),
+		StudentID: invitation.StudentID.String(),
+		SchoolID:  invitation.SchoolID.String(),
+		Code:      invitation.Code,
+		MaxUses:   invitation.MaxUses,
+		UsesCount: invitation.UsesCount,
+		ExpiresAt: invitation.ExpiresAt,
+		RevokedAt: invitation.RevokedAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
+
+// ToGuardianLinkRequestResponse converts a GuardianLinkRequest entity to response
+func ToGuardianLinkRequestResponse(request *entity.GuardianLinkRequest) GuardianLinkRequestResponse {
+	response := GuardianLinkRequestResponse{
+		ID:               request.ID.String(),
+		InvitationID:     request.InvitationID.String(),
+		GuardianID:       request.GuardianID.String(),
+		StudentID:        request.StudentID.String(),
+		SchoolID:         request.SchoolID.String(),
+		RelationshipType: request.RelationshipType,
+		Status:           request.Status,
+		ReviewedAt:       request.ReviewedAt,
+		ReviewNote:       request.ReviewNote,
+		CreatedAt:        request.CreatedAt,
+		UpdatedAt:        request.UpdatedAt,
+	}
+	if request.RelationID != nil {
+		s := request.RelationID.String()
+		response.RelationID = &s
+	}
+	if request.ReviewedBy != nil {
+		s := request.ReviewedBy.String()
+		response.ReviewedBy = &s
+	}
+	return response
+}
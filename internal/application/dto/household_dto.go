@@ -0,0 +1,52 @@
+package dto
+
+// HouseholdMemberRequest assigns a user to a household with a role (guardian or student)
+type HouseholdMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required,oneof=guardian student"`
+}
+
+// CreateHouseholdRequest represents the request to create a household.
+// Either Members or FromStudentID must be given; FromStudentID persists the
+// household computed from that student's guardian relations.
+type CreateHouseholdRequest struct {
+	Name          string                   `json:"name" binding:"required,min=2"`
+	Members       []HouseholdMemberRequest `json:"members,omitempty"`
+	FromStudentID *string                  `json:"from_student_id,omitempty"`
+}
+
+// HouseholdUnitResponse is an active membership of a household member
+type HouseholdUnitResponse struct {
+	SchoolID       string `json:"school_id"`
+	AcademicUnitID string `json:"academic_unit_id,omitempty"`
+	Role           string `json:"role"`
+}
+
+// HouseholdMemberResponse represents a guardian or student in a household
+type HouseholdMemberResponse struct {
+	UserID    string                  `json:"user_id"`
+	FirstName string                  `json:"first_name"`
+	LastName  string                  `json:"last_name"`
+	Email     string                  `json:"email"`
+	Units     []HouseholdUnitResponse `json:"units"`
+}
+
+// HouseholdRelationResponse is a guardian relation between two household members
+type HouseholdRelationResponse struct {
+	RelationID       string `json:"relation_id"`
+	GuardianID       string `json:"guardian_id"`
+	StudentID        string `json:"student_id"`
+	RelationshipType string `json:"relationship_type"`
+}
+
+// HouseholdResponse represents a household in API responses.
+// Source is "assigned" for stored households and "computed" for households
+// derived from guardian relations, which have no ID.
+type HouseholdResponse struct {
+	ID        string                      `json:"id,omitempty"`
+	Name      string                      `json:"name,omitempty"`
+	Source    string                      `json:"source"`
+	Guardians []HouseholdMemberResponse   `json:"guardians"`
+	Students  []HouseholdMemberResponse   `json:"students"`
+	Relations []HouseholdRelationResponse `json:"relations"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// PutMetadataSchemaRequest registers the JSON Schema for an entity type's
+// metadata, limited to one concept type when ConceptTypeID is set
+type PutMetadataSchemaRequest struct {
+	ConceptTypeID string                 `json:"concept_type_id"`
+	Schema        map[string]interface{} `json:"schema" binding:"required"`
+}
+
+// MetadataSchemaResponse represents a metadata schema. ConceptTypeID is empty
+// for the schema that applies to every concept type.
+type MetadataSchemaResponse struct {
+	ID            string                 `json:"id"`
+	EntityType    string                 `json:"entity_type"`
+	ConceptTypeID string                 `json:"concept_type_id,omitempty"`
+	Schema        map[string]interface{} `json:"schema"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
@@ -0,0 +1,126 @@
+package dto
+
+import "time"
+
+// SchoolBundle is a portable, versioned document holding a school with its
+// concepts, unit tree, subjects, memberships and member users, used to copy a
+// school between environments. IDs only link the entries of the bundle to each
+// other; an import assigns new ones.
+type SchoolBundle struct {
+	Version     int                      `json:"version" binding:"required"`
+	ExportedAt  *time.Time               `json:"exported_at,omitempty"`
+	Anonymized  bool                     `json:"anonymized,omitempty"`
+	School      SchoolBundleSchool       `json:"school"`
+	Concepts    []SchoolBundleConcept    `json:"concepts" binding:"dive"`
+	Units       []SchoolBundleUnit       `json:"units" binding:"dive"`
+	Subjects    []SchoolBundleSubject    `json:"subjects" binding:"dive"`
+	Users       []SchoolBundleUser       `json:"users" binding:"dive"`
+	Memberships []SchoolBundleMembership `json:"memberships" binding:"dive"`
+}
+
+// SchoolBundleSchool is the school section of a SchoolBundle. The concept type
+// is referenced by code.
+type SchoolBundleSchool struct {
+	Name             string                 `json:"name" binding:"required,min=3"`
+	Code             string                 `json:"code" binding:"required,min=3"`
+	Address          *string                `json:"address,omitempty"`
+	City             *string                `json:"city,omitempty"`
+	Country          string                 `json:"country"`
+	ContactEmail     *string                `json:"contact_email,omitempty"`
+	ContactPhone     *string                `json:"contact_phone,omitempty"`
+	ConceptTypeCode  string                 `json:"concept_type_code,omitempty"`
+	SubscriptionTier string                 `json:"subscription_tier"`
+	MaxTeachers      int                    `json:"max_teachers"`
+	MaxStudents      int                    `json:"max_students"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// SchoolBundleConcept is a school term in a SchoolBundle
+type SchoolBundleConcept struct {
+	TermKey   string `json:"term_key" binding:"required"`
+	TermValue string `json:"term_value" binding:"required"`
+	Category  string `json:"category,omitempty"`
+}
+
+// SchoolBundleUnit is an academic unit in a SchoolBundle. ParentID refers to
+// another unit of the bundle.
+type SchoolBundleUnit struct {
+	ID           string                 `json:"id" binding:"required"`
+	ParentID     string                 `json:"parent_id,omitempty"`
+	Name         string                 `json:"name" binding:"required"`
+	Code         string                 `json:"code"`
+	Type         string                 `json:"type" binding:"required"`
+	Description  *string                `json:"description,omitempty"`
+	AcademicYear int                    `json:"academic_year"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	IsActive     bool                   `json:"is_active"`
+}
+
+// SchoolBundleSubject is a subject in a SchoolBundle. UnitID refers to a unit
+// of the bundle.
+type SchoolBundleSubject struct {
+	ID          string  `json:"id" binding:"required"`
+	UnitID      string  `json:"unit_id,omitempty"`
+	Name        string  `json:"name" binding:"required"`
+	Code        *string `json:"code,omitempty"`
+	Description *string `json:"description,omitempty"`
+	IsActive    bool    `json:"is_active"`
+}
+
+// SchoolBundleUser is a member user in a SchoolBundle. PasswordHash is only
+// present when the export asked for it and only platform admins may import it;
+// users imported without one must reset their password before signing in.
+type SchoolBundleUser struct {
+	ID           string `json:"id" binding:"required"`
+	Email        string `json:"email" binding:"required,email"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	PasswordHash string `json:"password_hash,omitempty"`
+	IsActive     bool   `json:"is_active"`
+}
+
+// SchoolBundleMembership is a membership in a SchoolBundle. UserID and UnitID
+// refer to a user and a unit of the bundle.
+type SchoolBundleMembership struct {
+	UserID      string                 `json:"user_id" binding:"required"`
+	UnitID      string                 `json:"unit_id,omitempty"`
+	Role        string                 `json:"role" binding:"required"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	IsActive    bool                   `json:"is_active"`
+	EnrolledAt  time.Time              `json:"enrolled_at"`
+	WithdrawnAt *time.Time             `json:"withdrawn_at,omitempty"`
+}
+
+// SchoolBundleUserResult reports what an import did with one user. Reason
+// explains conflicts.
+type SchoolBundleUserResult struct {
+	Email  string `json:"email"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SchoolBundleExportOptions controls what a school bundle export reveals about
+// users. Anonymize replaces names and emails and never includes password hashes.
+type SchoolBundleExportOptions struct {
+	IncludePasswordHashes bool
+	Anonymize             bool
+}
+
+// SchoolBundleImportResponse reports a school bundle import. SchoolID is
+// omitted when nothing was imported. UnknownConceptType holds the bundle's
+// concept type code when no such type exists here; the school is then
+// imported without one.
+type SchoolBundleImportResponse struct {
+	SchoolID           string                   `json:"school_id,omitempty"`
+	Code               string                   `json:"code"`
+	Action             string                   `json:"action"`
+	Reason             string                   `json:"reason,omitempty"`
+	DryRun             bool                     `json:"dry_run"`
+	UnknownConceptType string                   `json:"unknown_concept_type,omitempty"`
+	Concepts           int                      `json:"concepts"`
+	Units              int                      `json:"units"`
+	Subjects           int                      `json:"subjects"`
+	Memberships        int                      `json:"memberships"`
+	Conflicts          int                      `json:"conflicts"`
+	Users              []SchoolBundleUserResult `json:"users"`
+}
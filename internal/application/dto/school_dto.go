@@ -4,13 +4,15 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 )
 
-// CreateSchoolRequest represents the request to create a school
+// CreateSchoolRequest represents the request to create a school. The code is
+// generated from the name and city when left out.
 type CreateSchoolRequest struct {
 	Name             string                 `json:"name" binding:"required,min=3"`
-	Code             string                 `json:"code" binding:"required,min=3"`
+	Code             string                 `json:"code" binding:"omitempty,min=3"`
 	Address          string                 `json:"address"`
 	City             string                 `json:"city"`
 	Country          string                 `json:"country"`
@@ -52,6 +54,7 @@ type SchoolResponse struct {
 	MaxStudents      int                    `json:"max_students"`
 	ConceptTypeID    string                 `json:"concept_type_id,omitempty"`
 	IsActive         bool                   `json:"is_active"`
+	LifecycleState   string                 `json:"lifecycle_state,omitempty"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt        time.Time              `json:"created_at"`
 	UpdatedAt        time.Time              `json:"updated_at"`
@@ -111,3 +114,105 @@ func ToSchoolResponseList(schools []*entities.School) []SchoolResponse {
 	}
 	return responses
 }
+
+// CloneSchoolRequest represents the request to create a school from a template
+// school. The code is generated when left out. Concepts, Units and Subjects
+// choose the parts to copy and default to true; subjects of a clone without
+// units are not attached to any unit. Subjects in the trash are only copied
+// when InactiveSubjects is set.
+type CloneSchoolRequest struct {
+	Name             string `json:"name" binding:"required,min=3"`
+	Code             string `json:"code" binding:"omitempty,min=3"`
+	Concepts         *bool  `json:"concepts"`
+	Units            *bool  `json:"units"`
+	Subjects         *bool  `json:"subjects"`
+	InactiveSubjects bool   `json:"inactive_subjects"`
+}
+
+// SchoolCodeAvailabilityResponse reports whether a school code can be used.
+// Code is the normalised or generated code; Reason explains why it cannot.
+type SchoolCodeAvailabilityResponse struct {
+	Code      string `json:"code"`
+	Valid     bool   `json:"valid"`
+	Available bool   `json:"available"`
+	Generated bool   `json:"generated,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// SchoolCloneResponse represents a school created from a template school with
+// the number of entries copied from it
+type SchoolCloneResponse struct {
+	School         SchoolResponse `json:"school"`
+	SourceSchoolID string         `json:"source_school_id"`
+	Concepts       int            `json:"concepts"`
+	Units          int            `json:"units"`
+	Subjects       int            `json:"subjects"`
+}
+
+// ChangeSchoolStateRequest represents the request to move a school to another lifecycle state
+type ChangeSchoolStateRequest struct {
+	State  string `json:"state" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// RestoreSchoolRequest represents the request to restore an archived school
+type RestoreSchoolRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SchoolLifecycleTransitionResponse represents one lifecycle state change of a school
+type SchoolLifecycleTransitionResponse struct {
+	ID        string    `json:"id"`
+	FromState string    `json:"from_state,omitempty"`
+	ToState   string    `json:"to_state"`
+	Reason    *string   `json:"reason,omitempty"`
+	ChangedBy *string   `json:"changed_by,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// SchoolLifecycleResponse represents the current lifecycle state of a school and its history
+type SchoolLifecycleResponse struct {
+	SchoolID    string                              `json:"school_id"`
+	State       string                              `json:"state"`
+	Reason      *string                             `json:"reason,omitempty"`
+	ChangedBy   *string                             `json:"changed_by,omitempty"`
+	ChangedAt   *time.Time                          `json:"changed_at,omitempty"`
+	Transitions []SchoolLifecycleTransitionResponse `json:"transitions"`
+}
+
+// ToSchoolLifecycleTransitionResponse converts a SchoolLifecycleTransition entity to its response
+func ToSchoolLifecycleTransitionResponse(t *entity.SchoolLifecycleTransition) SchoolLifecycleTransitionResponse {
+	var changedBy *string
+	if t.ChangedBy != nil {
+		id := t.ChangedBy.String()
+		changedBy = &id
+	}
+	return SchoolLifecycleTransitionResponse{
+		ID:        t.ID.String(),
+		FromState: t.FromState,
+		ToState:   t.ToState,
+		Reason:    t.Reason,
+		ChangedBy: changedBy,
+		ChangedAt: t.ChangedAt,
+	}
+}
+
+// SchoolDeletionImpactResponse counts what deleting a school affects. The
+// confirmation token must be passed back to delete the school and stops
+// matching once any of the counts change.
+type SchoolDeletionImpactResponse struct {
+	SchoolID             string `json:"school_id"`
+	AcademicUnits        int64  `json:"academic_units"`
+	Subjects             int64  `json:"subjects"`
+	Memberships          int64  `json:"memberships"`
+	GuardianRelations    int64  `json:"guardian_relations"`
+	TeachingAssignments  int64  `json:"teaching_assignments"`
+	GuardianInvitations  int64  `json:"guardian_invitations"`
+	LinkRequests         int64  `json:"link_requests"`
+	SchoolConcepts       int64  `json:"school_concepts"`
+	SubjectPlacements    int64  `json:"subject_placements"`
+	SubjectPrerequisites int64  `json:"subject_prerequisites"`
+	CustomFields         int64  `json:"custom_fields"`
+	CustomFieldValues    int64  `json:"custom_field_values"`
+	ConfirmationToken    string `json:"confirmation_token"`
+}
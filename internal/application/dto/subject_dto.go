@@ -8,10 +8,11 @@ import (
 
 // CreateSubjectRequest represents the request to create a subject
 type CreateSubjectRequest struct {
-	Name           string `json:"name" binding:"required,min=2"`
-	Description    string `json:"description"`
-	AcademicUnitID string `json:"academic_unit_id"`
-	Code           string `json:"code"`
+	Name           string                 `json:"name" binding:"required,min=2"`
+	Description    string                 `json:"description"`
+	AcademicUnitID string                 `json:"academic_unit_id"`
+	Code           string                 `json:"code"`
+	CustomFields   map[string]interface{} `json:"custom_fields"`
 }
 
 // UpdateSubjectRequest represents the request to update a subject
@@ -20,19 +21,22 @@ type UpdateSubjectRequest struct {
 	Description    *string `json:"description"`
 	AcademicUnitID *string `json:"academic_unit_id"`
 	Code           *string `json:"code"`
+	// CustomFields sets the given custom fields; a null value clears one
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 // SubjectResponse represents a subject in API responses
 type SubjectResponse struct {
-	ID             string  `json:"id"`
-	SchoolID       string  `json:"school_id"`
-	AcademicUnitID *string `json:"academic_unit_id,omitempty"`
-	Name           string  `json:"name"`
-	Code           *string `json:"code,omitempty"`
-	Description    string  `json:"description,omitempty"`
-	IsActive       bool    `json:"is_active"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID             string                 `json:"id"`
+	SchoolID       string                 `json:"school_id"`
+	AcademicUnitID *string                `json:"academic_unit_id,omitempty"`
+	Name           string                 `json:"name"`
+	Code           *string                `json:"code,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	IsActive       bool                   `json:"is_active"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	CustomFields   map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 // ToSubjectResponse converts a Subject entity to SubjectResponse
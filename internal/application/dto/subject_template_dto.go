@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+)
+
+// CreateSubjectTemplateRequest represents the request to create a platform subject template
+type CreateSubjectTemplateRequest struct {
+	Name           string  `json:"name" binding:"required,min=2"`
+	Code           *string `json:"code,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	ConceptTypeID  *string `json:"concept_type_id,omitempty"`
+	EducationLevel *string `json:"education_level,omitempty"`
+	SortOrder      int     `json:"sort_order"`
+}
+
+// UpdateSubjectTemplateRequest represents the request to update a subject template.
+// An empty concept_type_id or education_level moves the template out of that group.
+type UpdateSubjectTemplateRequest struct {
+	Name           *string `json:"name,omitempty"`
+	Code           *string `json:"code,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	ConceptTypeID  *string `json:"concept_type_id,omitempty"`
+	EducationLevel *string `json:"education_level,omitempty"`
+	SortOrder      *int    `json:"sort_order,omitempty"`
+}
+
+// SubjectTemplateListFilters holds the query filters of subject template listings
+type SubjectTemplateListFilters struct {
+	ConceptTypeID  string
+	EducationLevel string
+}
+
+// SubjectTemplateResponse represents a subject template in API responses
+type SubjectTemplateResponse struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Code           *string   `json:"code,omitempty"`
+	Description    *string   `json:"description,omitempty"`
+	ConceptTypeID  *string   `json:"concept_type_id,omitempty"`
+	EducationLevel *string   `json:"education_level,omitempty"`
+	SortOrder      int       `json:"sort_order"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ApplySubjectTemplatesRequest selects the templates to copy into a school.
+// TemplateIDs takes precedence; otherwise templates are selected by group, and
+// when no concept_type_id is given the school's own concept type is used.
+type ApplySubjectTemplatesRequest struct {
+	TemplateIDs    []string `json:"template_ids,omitempty"`
+	ConceptTypeID  *string  `json:"concept_type_id,omitempty"`
+	EducationLevel *string  `json:"education_level,omitempty"`
+}
+
+// SkippedSubjectTemplate reports a template that was not applied
+type SkippedSubjectTemplate struct {
+	TemplateID string `json:"template_id"`
+	Name       string `json:"name"`
+	Reason     string `json:"reason"`
+}
+
+// ApplySubjectTemplatesResponse reports the subjects created and the templates skipped
+type ApplySubjectTemplatesResponse struct {
+	SchoolID string                   `json:"school_id"`
+	Created  []SubjectResponse        `json:"created"`
+	Skipped  []SkippedSubjectTemplate `json:"skipped"`
+}
+
+// ToSubjectTemplateResponse converts a SubjectTemplate entity to response
+func ToSubjectTemplateResponse(template *entity.SubjectTemplate) SubjectTemplateResponse {
+	var conceptTypeID *string
+	if template.ConceptTypeID != nil {
+		s := template.ConceptTypeID.String()
+		conceptTypeID = &s
+	}
+	return SubjectTemplateResponse{
+		ID:             template.ID.String(),
+		Name:           template.Name,
+		Code:           template.Code,
+		Description:    template.Description,
+		ConceptTypeID:  conceptTypeID,
+		EducationLevel: template.EducationLevel,
+		SortOrder:      template.SortOrder,
+		CreatedAt:      template.CreatedAt,
+		UpdatedAt:      template.UpdatedAt,
+	}
+}
+
+// ToSubjectTemplateResponseList converts a slice of SubjectTemplate entities to responses
+func ToSubjectTemplateResponseList(templates []*entity.SubjectTemplate) []SubjectTemplateResponse {
+	responses := make([]SubjectTemplateResponse, len(templates))
+	for i, t := range templates {
+		responses[i] = ToSubjectTemplateResponse(t)
+	}
+	return responses
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+)
+
+// CreateTeachingAssignmentRequest represents the request to assign a teacher to a subject in a unit
+type CreateTeachingAssignmentRequest struct {
+	TeacherID      string  `json:"teacher_id" binding:"required"`
+	SubjectID      string  `json:"subject_id" binding:"required"`
+	AcademicUnitID string  `json:"academic_unit_id" binding:"required"`
+	Period         *string `json:"period,omitempty"`
+}
+
+// UpdateTeachingAssignmentRequest represents the request to update a teaching assignment
+type UpdateTeachingAssignmentRequest struct {
+	TeacherID *string `json:"teacher_id,omitempty"`
+	Period    *string `json:"period,omitempty"`
+}
+
+// TeachingAssignmentResponse represents a teaching assignment in API responses
+type TeachingAssignmentResponse struct {
+	ID             string    `json:"id"`
+	TeacherID      string    `json:"teacher_id"`
+	SubjectID      string    `json:"subject_id"`
+	AcademicUnitID string    `json:"academic_unit_id"`
+	SchoolID       string    `json:"school_id"`
+	Period         *string   `json:"period,omitempty"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ToTeachingAssignmentResponse converts a TeachingAssignment entity to response
+func ToTeachingAssignmentResponse(assignment *entity.TeachingAssignment) TeachingAssignmentResponse {
+	return TeachingAssignmentResponse{
+		ID:             assignment.ID.String(),
+		TeacherID:      assignment.TeacherID.String(),
+		SubjectID:      assignment.SubjectID.String(),
+		AcademicUnitID: assignment.AcademicUnitID.String(),
+		SchoolID:       assignment.SchoolID.String(),
+		Period:         assignment.Period,
+		IsActive:       assignment.IsActive,
+		CreatedAt:      assignment.CreatedAt,
+		UpdatedAt:      assignment.UpdatedAt,
+	}
+}
+
+// ToTeachingAssignmentResponseList converts a slice of TeachingAssignment entities to responses
+func ToTeachingAssignmentResponseList(assignments []*entity.TeachingAssignment) []TeachingAssignmentResponse {
+	responses := make([]TeachingAssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		responses[i] = ToTeachingAssignmentResponse(a)
+	}
+	return responses
+}
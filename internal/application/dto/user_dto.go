@@ -20,6 +20,10 @@ type UpdateUserRequest struct {
 	FirstName *string     `json:"first_name,omitempty"`
 	LastName  *string     `json:"last_name,omitempty"`
 	IsActive  interface{} `json:"is_active,omitempty"`
+	// SchoolID scopes CustomFields, which are defined per school; the user
+	// must be an active member of it. A null custom field clears its value.
+	SchoolID     string                 `json:"school_id,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 // UserResponse represents a user in API responses
@@ -32,6 +36,8 @@ type UserResponse struct {
 	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// CustomFields holds the values of the custom fields of the requested school
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 // ToUserResponse converts a User entity to UserResponse
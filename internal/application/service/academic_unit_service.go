@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/audit"
@@ -19,9 +20,9 @@ import (
 type AcademicUnitService interface {
 	CreateUnit(ctx context.Context, schoolID string, req dto.CreateAcademicUnitRequest) (*dto.AcademicUnitResponse, error)
 	GetUnit(ctx context.Context, id string) (*dto.AcademicUnitResponse, error)
-	ListUnitsBySchool(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error)
+	ListUnitsBySchool(ctx context.Context, schoolID string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error)
 	GetUnitTree(ctx context.Context, schoolID string) ([]*dto.UnitTreeNode, error)
-	ListUnitsByType(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error)
+	ListUnitsByType(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error)
 	UpdateUnit(ctx context.Context, id string, req dto.UpdateAcademicUnitRequest) (*dto.AcademicUnitResponse, error)
 	DeleteUnit(ctx context.Context, id string) error
 	RestoreUnit(ctx context.Context, id string) (*dto.AcademicUnitResponse, error)
@@ -31,13 +32,77 @@ type AcademicUnitService interface {
 type academicUnitService struct {
 	unitRepo    repository.AcademicUnitRepository
 	schoolRepo  sharedrepo.SchoolRepository
+	schemaRepo  repository.MetadataSchemaRepository
+	fieldRepo   repository.CustomFieldRepository
 	logger      logger.Logger
 	auditLogger audit.AuditLogger
 }
 
 // NewAcademicUnitService creates a new academic unit service
-func NewAcademicUnitService(unitRepo repository.AcademicUnitRepository, schoolRepo sharedrepo.SchoolRepository, logger logger.Logger, auditLogger audit.AuditLogger) AcademicUnitService {
-	return &academicUnitService{unitRepo: unitRepo, schoolRepo: schoolRepo, logger: logger, auditLogger: auditLogger}
+func NewAcademicUnitService(unitRepo repository.AcademicUnitRepository, schoolRepo sharedrepo.SchoolRepository, schemaRepo repository.MetadataSchemaRepository, fieldRepo repository.CustomFieldRepository, logger logger.Logger, auditLogger audit.AuditLogger) AcademicUnitService {
+	return &academicUnitService{unitRepo: unitRepo, schoolRepo: schoolRepo, schemaRepo: schemaRepo, fieldRepo: fieldRepo, logger: logger, auditLogger: auditLogger}
+}
+
+// withCustomFields converts units of one school to responses carrying their
+// custom field values
+func (s *academicUnitService) withCustomFields(ctx context.Context, set *customFieldSet, units []*entities.AcademicUnit) ([]dto.AcademicUnitResponse, error) {
+	ids := make([]uuid.UUID, len(units))
+	for i, unit := range units {
+		ids[i] = unit.ID
+	}
+	values, err := set.values(ctx, s.fieldRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	responses := dto.ToAcademicUnitResponseList(units)
+	for i := range responses {
+		responses[i].CustomFields = values[units[i].ID]
+	}
+	return responses, nil
+}
+
+// unitResponse converts a unit to a response carrying its custom field
+// values. The school's field set is loaded when set is nil.
+func (s *academicUnitService) unitResponse(ctx context.Context, set *customFieldSet, unit *entities.AcademicUnit) (*dto.AcademicUnitResponse, error) {
+	if set == nil {
+		var err error
+		if set, err = loadCustomFieldSet(ctx, s.fieldRepo, unit.SchoolID, entity.CustomFieldEntityAcademicUnit); err != nil {
+			return nil, err
+		}
+	}
+	responses, err := s.withCustomFields(ctx, set, []*entities.AcademicUnit{unit})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// listUnits lists the school's live units, of one type when unitType is set,
+// keeping those matching the custom field filters
+func (s *academicUnitService) listUnits(ctx context.Context, schoolID uuid.UUID, unitType string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error) {
+	set, err := loadCustomFieldSet(ctx, s.fieldRepo, schoolID, entity.CustomFieldEntityAcademicUnit)
+	if err != nil {
+		return nil, 0, err
+	}
+	repoFilters, err := set.filters(fieldFilters)
+	if err != nil {
+		return nil, 0, err
+	}
+	var units []*entities.AcademicUnit
+	var total int
+	if unitType != "" {
+		units, total, err = s.unitRepo.FindByType(ctx, schoolID, unitType, false, filters, repoFilters...)
+	} else {
+		units, total, err = s.unitRepo.FindBySchoolID(ctx, schoolID, false, filters, repoFilters...)
+	}
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list units", err)
+	}
+	responses, err := s.withCustomFields(ctx, set, units)
+	if err != nil {
+		return nil, 0, err
+	}
+	return responses, total, nil
 }
 
 func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, req dto.CreateAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
@@ -76,11 +141,24 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 		return nil, errors.NewAlreadyExistsError("academic_unit").WithField("code", code)
 	}
 
+	if err := validateMetadata(ctx, s.schemaRepo, entity.MetadataEntityAcademicUnit, school.ConceptTypeID, req.Metadata); err != nil {
+		return nil, err
+	}
 	metadataJSON := []byte("{}")
 	if req.Metadata != nil {
 		metadataJSON, _ = json.Marshal(req.Metadata)
 	}
 
+	unitID := uuid.New()
+	fieldSet, err := loadCustomFieldSet(ctx, s.fieldRepo, sid, entity.CustomFieldEntityAcademicUnit)
+	if err != nil {
+		return nil, err
+	}
+	fieldValues, _, err := fieldSet.prepare(unitID, req.CustomFields, true)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	var desc *string
 	if req.Description != "" {
@@ -88,7 +166,7 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 	}
 
 	unit := &entities.AcademicUnit{
-		ID:           uuid.New(),
+		ID:           unitID,
 		ParentUnitID: parentID,
 		SchoolID:     sid,
 		Name:         req.DisplayName,
@@ -102,7 +180,8 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 		UpdatedAt:    now,
 	}
 
-	if err := s.unitRepo.Create(ctx, unit); err != nil {
+	create := func() error { return s.unitRepo.Create(ctx, unit) }
+	if err := createWithCustomFields(ctx, s.fieldRepo, unit, fieldValues, create); err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "create", ResourceType: "academic_unit",
@@ -123,8 +202,7 @@ func (s *academicUnitService) CreateUnit(ctx context.Context, schoolID string, r
 	}); err != nil {
 		s.logger.Error("failed to write audit log", "error", err)
 	}
-	response := dto.ToAcademicUnitResponse(unit)
-	return &response, nil
+	return s.unitResponse(ctx, fieldSet, unit)
 }
 
 func (s *academicUnitService) GetUnit(ctx context.Context, id string) (*dto.AcademicUnitResponse, error) {
@@ -139,20 +217,15 @@ func (s *academicUnitService) GetUnit(ctx context.Context, id string) (*dto.Acad
 	if unit == nil {
 		return nil, errors.NewNotFoundError("academic_unit")
 	}
-	response := dto.ToAcademicUnitResponse(unit)
-	return &response, nil
+	return s.unitResponse(ctx, nil, unit)
 }
 
-func (s *academicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error) {
+func (s *academicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error) {
 	sid, err := uuid.Parse(schoolID)
 	if err != nil {
 		return nil, 0, errors.NewValidationError("invalid school ID")
 	}
-	units, total, err := s.unitRepo.FindBySchoolID(ctx, sid, false, filters)
-	if err != nil {
-		return nil, 0, errors.NewDatabaseError("list units", err)
-	}
-	return dto.ToAcademicUnitResponseList(units), total, nil
+	return s.listUnits(ctx, sid, "", filters, fieldFilters)
 }
 
 func (s *academicUnitService) GetUnitTree(ctx context.Context, schoolID string) ([]*dto.UnitTreeNode, error) {
@@ -167,7 +240,7 @@ func (s *academicUnitService) GetUnitTree(ctx context.Context, schoolID string)
 	return dto.BuildUnitTree(units), nil
 }
 
-func (s *academicUnitService) ListUnitsByType(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error) {
+func (s *academicUnitService) ListUnitsByType(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error) {
 	sid, err := uuid.Parse(schoolID)
 	if err != nil {
 		return nil, 0, errors.NewValidationError("invalid school ID")
@@ -175,11 +248,7 @@ func (s *academicUnitService) ListUnitsByType(ctx context.Context, schoolID, uni
 	if unitType == "" {
 		return nil, 0, errors.NewValidationError("type query parameter is required")
 	}
-	units, total, err := s.unitRepo.FindByType(ctx, sid, unitType, false, filters)
-	if err != nil {
-		return nil, 0, errors.NewDatabaseError("list units by type", err)
-	}
-	return dto.ToAcademicUnitResponseList(units), total, nil
+	return s.listUnits(ctx, sid, unitType, filters, fieldFilters)
 }
 
 func (s *academicUnitService) UpdateUnit(ctx context.Context, id string, req dto.UpdateAcademicUnitRequest) (*dto.AcademicUnitResponse, error) {
@@ -213,18 +282,37 @@ func (s *academicUnitService) UpdateUnit(ctx context.Context, id string, req dto
 		}
 	}
 	if req.Metadata != nil {
+		school, err := s.schoolRepo.FindByID(ctx, unit.SchoolID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find school", err)
+		}
+		var conceptTypeID *uuid.UUID
+		if school != nil {
+			conceptTypeID = school.ConceptTypeID
+		}
+		if err := validateMetadata(ctx, s.schemaRepo, entity.MetadataEntityAcademicUnit, conceptTypeID, req.Metadata); err != nil {
+			return nil, err
+		}
 		metadataJSON, _ := json.Marshal(req.Metadata)
 		unit.Metadata = metadataJSON
 	}
+	fieldSet, err := loadCustomFieldSet(ctx, s.fieldRepo, unit.SchoolID, entity.CustomFieldEntityAcademicUnit)
+	if err != nil {
+		return nil, err
+	}
+	fieldValues, cleared, err := fieldSet.prepare(unit.ID, req.CustomFields, false)
+	if err != nil {
+		return nil, err
+	}
 
 	unit.UpdatedAt = time.Now()
-	if err := s.unitRepo.Update(ctx, unit); err != nil {
+	update := func() error { return s.unitRepo.Update(ctx, unit) }
+	if err := updateWithCustomFields(ctx, s.fieldRepo, unit, unit.ID, fieldValues, cleared, update); err != nil {
 		return nil, errors.NewDatabaseError("update unit", err)
 	}
 
 	s.logger.Info("entity updated", "entity_type", "academic_unit", "entity_id", id)
-	response := dto.ToAcademicUnitResponse(unit)
-	return &response, nil
+	return s.unitResponse(ctx, fieldSet, unit)
 }
 
 func (s *academicUnitService) DeleteUnit(ctx context.Context, id string) error {
@@ -275,8 +363,7 @@ func (s *academicUnitService) RestoreUnit(ctx context.Context, id string) (*dto.
 		return nil, errors.NewDatabaseError("find restored unit", err)
 	}
 	s.logger.Info("entity restored", "entity_type", "academic_unit", "entity_id", id)
-	response := dto.ToAcademicUnitResponse(unit)
-	return &response, nil
+	return s.unitResponse(ctx, nil, unit)
 }
 
 func (s *academicUnitService) GetHierarchyPath(ctx context.Context, id string) ([]dto.AcademicUnitResponse, error) {
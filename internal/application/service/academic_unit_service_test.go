@@ -7,6 +7,7 @@ import (
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
@@ -95,7 +96,7 @@ func TestAcademicUnitService_CreateUnit(t *testing.T) {
 				tt.setupMock(unitRepo, schoolRepo)
 			}
 
-			svc := service.NewAcademicUnitService(unitRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := service.NewAcademicUnitService(unitRepo, schoolRepo, &mock.MockMetadataSchemaRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
 			result, err := svc.CreateUnit(context.Background(), tt.schoolID, tt.request)
 
 			if tt.wantErr {
@@ -158,7 +159,7 @@ func TestAcademicUnitService_GetUnit(t *testing.T) {
 				tt.setupMock(unitRepo)
 			}
 
-			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, &mock.MockMetadataSchemaRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
 			result, err := svc.GetUnit(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -215,7 +216,7 @@ func TestAcademicUnitService_DeleteUnit(t *testing.T) {
 				tt.setupMock(unitRepo)
 			}
 
-			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, &mock.MockMetadataSchemaRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
 			err := svc.DeleteUnit(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -244,7 +245,7 @@ func TestAcademicUnitService_ListUnitsBySchool(t *testing.T) {
 			name:     "success - returns units",
 			schoolID: schoolID.String(),
 			setupMock: func(m *mock.MockAcademicUnitRepository) {
-				m.FindBySchoolIDFn = func(_ context.Context, _ uuid.UUID, _ bool, _ sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error) {
+				m.FindBySchoolIDFn = func(_ context.Context, _ uuid.UUID, _ bool, _ sharedrepo.ListFilters, _ []repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error) {
 					return []*entities.AcademicUnit{
 						{ID: uuid.New(), SchoolID: schoolID, Name: "Unit 1"},
 					}, 1, nil
@@ -268,8 +269,8 @@ func TestAcademicUnitService_ListUnitsBySchool(t *testing.T) {
 				tt.setupMock(unitRepo)
 			}
 
-			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
-			result, _, err := svc.ListUnitsBySchool(context.Background(), tt.schoolID, sharedrepo.ListFilters{})
+			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, &mock.MockMetadataSchemaRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			result, _, err := svc.ListUnitsBySchool(context.Background(), tt.schoolID, sharedrepo.ListFilters{}, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -297,7 +298,7 @@ func TestAcademicUnitService_ListUnitsByType(t *testing.T) {
 			schoolID: schoolID.String(),
 			unitType: "grade",
 			setupMock: func(m *mock.MockAcademicUnitRepository) {
-				m.FindByTypeFn = func(_ context.Context, _ uuid.UUID, _ string, _ bool, _ sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error) {
+				m.FindByTypeFn = func(_ context.Context, _ uuid.UUID, _ string, _ bool, _ sharedrepo.ListFilters, _ []repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error) {
 					return []*entities.AcademicUnit{}, 0, nil
 				}
 			},
@@ -320,8 +321,8 @@ func TestAcademicUnitService_ListUnitsByType(t *testing.T) {
 				tt.setupMock(unitRepo)
 			}
 
-			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
-			_, _, err := svc.ListUnitsByType(context.Background(), tt.schoolID, tt.unitType, sharedrepo.ListFilters{})
+			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, &mock.MockMetadataSchemaRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			_, _, err := svc.ListUnitsByType(context.Background(), tt.schoolID, tt.unitType, sharedrepo.ListFilters{}, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -379,7 +380,7 @@ func TestAcademicUnitService_RestoreUnit(t *testing.T) {
 				tt.setupMock(unitRepo)
 			}
 
-			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := service.NewAcademicUnitService(unitRepo, &mock.MockSchoolRepository{}, &mock.MockMetadataSchemaRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
 			result, err := svc.RestoreUnit(context.Background(), tt.id)
 
 			if tt.wantErr {
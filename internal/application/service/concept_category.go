@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// DefaultConceptCategory is the category of definitions created without one.
+// It is always accepted and cannot be removed from the catalog.
+const DefaultConceptCategory = "general"
+
+var (
+	// termKeyPattern matches dotted identifiers such as "unit.level"
+	termKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)+$`)
+	// categoryCodePattern matches category codes such as "units"
+	categoryCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// validTermKey reports whether key is a dotted identifier of lowercase segments
+func validTermKey(key string) bool {
+	return termKeyPattern.MatchString(key)
+}
+
+// normalizeTermKey trims a term key and checks its format
+func normalizeTermKey(raw string) (string, error) {
+	key := strings.TrimSpace(raw)
+	if !validTermKey(key) {
+		return "", errors.NewValidationErrorWithFields("invalid concept definition", map[string]string{
+			"term_key": "must be a dotted identifier of lowercase segments, such as unit.level",
+		})
+	}
+	return key, nil
+}
+
+// categoryExists reports whether code is the default category or in the catalog
+func (s *conceptTypeService) categoryExists(ctx context.Context, code string) (bool, error) {
+	if code == DefaultConceptCategory {
+		return true, nil
+	}
+	category, err := s.categoryRepo.FindByCode(ctx, code)
+	if err != nil {
+		return false, errors.NewDatabaseError("find concept category", err)
+	}
+	return category != nil, nil
+}
+
+// resolveCategory returns the catalog category for raw, or the default
+// category when raw is empty
+func (s *conceptTypeService) resolveCategory(ctx context.Context, raw string) (string, error) {
+	code := strings.TrimSpace(raw)
+	if code == "" {
+		return DefaultConceptCategory, nil
+	}
+	exists, err := s.categoryExists(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.NewValidationErrorWithFields("invalid concept definition", map[string]string{
+			"category": "unknown category: " + code,
+		})
+	}
+	return code, nil
+}
+
+// ensureUniqueTermKey rejects a term key already used by another definition of the type
+func (s *conceptTypeService) ensureUniqueTermKey(ctx context.Context, typeID uuid.UUID, key string, exceptID uuid.UUID) error {
+	existing, err := s.conceptDefRepo.FindByTermKey(ctx, typeID, key)
+	if err != nil {
+		return errors.NewDatabaseError("find concept definition", err)
+	}
+	if existing != nil && existing.ID != exceptID {
+		return errors.NewAlreadyExistsError("concept_definition").WithField("term_key", key)
+	}
+	return nil
+}
+
+// ListDefinitionGroups lists the type's definitions grouped by category, in
+// catalog order, each group ordered by SortOrder. Categories missing from the
+// catalog come last, ordered by code.
+func (s *conceptTypeService) ListDefinitionGroups(ctx context.Context, typeID uuid.UUID) ([]dto.ConceptDefinitionGroupResponse, error) {
+	definitions, err := s.ListDefinitions(ctx, typeID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept categories", err)
+	}
+
+	byCategory := map[string][]dto.ConceptDefinitionResponse{}
+	for _, def := range definitions {
+		byCategory[def.Category] = append(byCategory[def.Category], def)
+	}
+	groups := make([]dto.ConceptDefinitionGroupResponse, 0, len(byCategory))
+	for _, category := range categories {
+		if defs, ok := byCategory[category.Code]; ok {
+			groups = append(groups, dto.ConceptDefinitionGroupResponse{Category: category.Code, Name: category.Name, Definitions: defs})
+			delete(byCategory, category.Code)
+		}
+	}
+	uncatalogued := make([]string, 0, len(byCategory))
+	for code := range byCategory {
+		uncatalogued = append(uncatalogued, code)
+	}
+	sort.Strings(uncatalogued)
+	for _, code := range uncatalogued {
+		groups = append(groups, dto.ConceptDefinitionGroupResponse{Category: code, Name: code, Definitions: byCategory[code]})
+	}
+	for _, group := range groups {
+		sort.SliceStable(group.Definitions, func(i, j int) bool {
+			return group.Definitions[i].SortOrder < group.Definitions[j].SortOrder
+		})
+	}
+	return groups, nil
+}
+
+// ==================== Category Catalog ====================
+
+func (s *conceptTypeService) ListCategories(ctx context.Context) ([]dto.ConceptCategoryResponse, error) {
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept categories", err)
+	}
+	responses := make([]dto.ConceptCategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = dto.ToConceptCategoryResponse(category)
+	}
+	return responses, nil
+}
+
+func (s *conceptTypeService) CreateCategory(ctx context.Context, req *dto.CreateConceptCategoryRequest) (*dto.ConceptCategoryResponse, error) {
+	code := strings.TrimSpace(req.Code)
+	if !categoryCodePattern.MatchString(code) {
+		return nil, errors.NewValidationErrorWithFields("invalid concept category", map[string]string{
+			"code": "must be a lowercase identifier, such as units",
+		})
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required")
+	}
+	existing, err := s.categoryRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept category", err)
+	}
+	if existing != nil {
+		return nil, errors.NewAlreadyExistsError("concept_category").WithField("code", code)
+	}
+
+	now := time.Now()
+	category := &entity.ConceptCategory{
+		Code:        code,
+		Name:        name,
+		Description: trimOptional(&req.Description),
+		SortOrder:   req.SortOrder,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "create", ResourceType: "concept_category", ResourceID: code,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("create concept category", err)
+	}
+
+	s.logger.Info("entity created", "entity_type", "concept_category", "entity_id", code)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "create", ResourceType: "concept_category", ResourceID: code,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToConceptCategoryResponse(category)
+	return &response, nil
+}
+
+func (s *conceptTypeService) UpdateCategory(ctx context.Context, code string, req *dto.UpdateConceptCategoryRequest) (*dto.ConceptCategoryResponse, error) {
+	category, err := s.categoryRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept category", err)
+	}
+	if category == nil {
+		return nil, errors.NewNotFoundError("concept_category")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.NewValidationError("name is required")
+	}
+	category.Name = name
+	category.Description = trimOptional(&req.Description)
+	category.SortOrder = req.SortOrder
+	category.UpdatedAt = time.Now()
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.categoryRepo.Update(ctx, category); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "update", ResourceType: "concept_category", ResourceID: code,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("update concept category", err)
+	}
+
+	s.logger.Info("entity updated", "entity_type", "concept_category", "entity_id", code)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "update", ResourceType: "concept_category", ResourceID: code,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToConceptCategoryResponse(category)
+	return &response, nil
+}
+
+// DeleteCategory removes a category from the catalog. The default category
+// and categories still used by definitions cannot be removed.
+func (s *conceptTypeService) DeleteCategory(ctx context.Context, code string) error {
+	if code == DefaultConceptCategory {
+		return errors.NewValidationError("the default concept category cannot be deleted")
+	}
+	category, err := s.categoryRepo.FindByCode(ctx, code)
+	if err != nil {
+		return errors.NewDatabaseError("find concept category", err)
+	}
+	if category == nil {
+		return errors.NewNotFoundError("concept_category")
+	}
+	inUse, err := s.categoryRepo.CountDefinitions(ctx, code)
+	if err != nil {
+		return errors.NewDatabaseError("count concept category definitions", err)
+	}
+	if inUse > 0 {
+		return errors.NewValidationError("concept category is used by concept definitions")
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.categoryRepo.Delete(ctx, code); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "delete", ResourceType: "concept_category", ResourceID: code,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return errors.NewDatabaseError("delete concept category", err)
+	}
+
+	s.logger.Info("entity deleted", "entity_type", "concept_category", "entity_id", code)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "delete", ResourceType: "concept_category", ResourceID: code,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
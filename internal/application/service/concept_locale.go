@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// DefaultLocale is the locale of the base term values stored on concept
+// definitions and school concepts
+const DefaultLocale = "es"
+
+// SupportedLocales lists the locales concept terms can be expressed in
+var SupportedLocales = []string{"es", "en", "pt"}
+
+// NormalizeLocale reduces a language tag such as "pt-BR" to a supported
+// locale ("pt"). It reports false for unsupported languages.
+func NormalizeLocale(tag string) (string, bool) {
+	lang := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	for _, locale := range SupportedLocales {
+		if lang == locale {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// resolveLocale validates a requested locale; an empty one means DefaultLocale
+func resolveLocale(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return DefaultLocale, nil
+	}
+	locale, ok := NormalizeLocale(raw)
+	if !ok {
+		return "", errors.NewValidationErrorWithFields("unsupported locale", map[string]string{
+			"locale": "must be one of " + strings.Join(SupportedLocales, ", "),
+		})
+	}
+	return locale, nil
+}
+
+// buildDefinitionTranslations validates translations keyed by locale. The
+// default locale is rejected because it lives on the definition itself.
+func buildDefinitionTranslations(definitionID uuid.UUID, raw map[string]string, now time.Time) ([]*entity.ConceptDefinitionTranslation, error) {
+	translations := make([]*entity.ConceptDefinitionTranslation, 0, len(raw))
+	for tag, value := range raw {
+		locale, ok := NormalizeLocale(tag)
+		if !ok || locale == DefaultLocale {
+			return nil, errors.NewValidationErrorWithFields("invalid translations", map[string]string{
+				"translations." + tag: "unsupported locale",
+			})
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, errors.NewValidationErrorWithFields("invalid translations", map[string]string{
+				"translations." + tag: "must not be empty",
+			})
+		}
+		translations = append(translations, &entity.ConceptDefinitionTranslation{
+			DefinitionID: definitionID,
+			Locale:       locale,
+			TermValue:    value,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+	sort.Slice(translations, func(i, j int) bool { return translations[i].Locale < translations[j].Locale })
+	return translations, nil
+}
+
+// translationMap indexes translations by locale, returning nil when there are none
+func translationMap(translations []*entity.ConceptDefinitionTranslation) map[string]string {
+	if len(translations) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(translations))
+	for _, t := range translations {
+		values[t.Locale] = t.TermValue
+	}
+	return values
+}
+
+// attachDefinitionTranslations loads the translations of the given definitions
+// into their responses, which must be in the same order
+func (s *conceptTypeService) attachDefinitionTranslations(ctx context.Context, defs []*entities.ConceptDefinition, responses []dto.ConceptDefinitionResponse) error {
+	translations, err := s.definitionTranslations(ctx, defs)
+	if err != nil {
+		return err
+	}
+	for i, def := range defs {
+		responses[i].Translations = translations[def.ID]
+	}
+	return nil
+}
+
+// localizeSchoolConcepts builds responses in the given locale. Each value is
+// the first found of: the school's override for the locale, the source
+// definition's translation in the published version, the base value in
+// DefaultLocale.
+func (s *conceptTypeService) localizeSchoolConcepts(ctx context.Context, schoolID uuid.UUID, locale string, concepts []*entities.SchoolConcept, sources map[uuid.UUID]*entity.SchoolConceptSource) ([]dto.SchoolConceptResponse, error) {
+	responses := toSchoolConceptResponses(concepts, sources)
+	for i := range responses {
+		responses[i].Locale = DefaultLocale
+	}
+	if locale == DefaultLocale || len(concepts) == 0 {
+		return responses, nil
+	}
+
+	overrides, err := s.schoolConceptRepo.FindTranslationsBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list school concept translations", err)
+	}
+	overrideByConcept := make(map[uuid.UUID]string, len(overrides))
+	for _, t := range overrides {
+		if t.Locale == locale {
+			overrideByConcept[t.SchoolConceptID] = t.TermValue
+		}
+	}
+
+	set, err := s.schoolDefinitionSet(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	translationByDefinition := make(map[uuid.UUID]string, len(set.Translations))
+	for definitionID, values := range set.Translations {
+		if value, ok := values[locale]; ok {
+			translationByDefinition[definitionID] = value
+		}
+	}
+
+	for i, concept := range concepts {
+		translated, hasTranslation := "", false
+		if source := sources[concept.ID]; source != nil {
+			translated, hasTranslation = translationByDefinition[source.DefinitionID]
+		}
+		if hasTranslation {
+			value := translated
+			responses[i].DefaultValue = &value
+		}
+		if override, ok := overrideByConcept[concept.ID]; ok {
+			responses[i].TermValue = override
+			responses[i].Locale = locale
+			responses[i].Overridden = !hasTranslation || override != translated
+		} else if hasTranslation {
+			responses[i].TermValue = translated
+			responses[i].Locale = locale
+			responses[i].Overridden = false
+		}
+	}
+	return responses, nil
+}
+
+// localizeSchoolConcept is localizeSchoolConcepts for a single term
+func (s *conceptTypeService) localizeSchoolConcept(ctx context.Context, locale string, concept *entities.SchoolConcept, source *entity.SchoolConceptSource) (*dto.SchoolConceptResponse, error) {
+	sources := map[uuid.UUID]*entity.SchoolConceptSource{}
+	if source != nil {
+		sources[concept.ID] = source
+	}
+	responses, err := s.localizeSchoolConcepts(ctx, concept.SchoolID, locale, []*entities.SchoolConcept{concept}, sources)
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
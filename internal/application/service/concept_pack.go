@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// ConceptPackVersion is the concept pack document version produced by exports
+// and accepted by imports
+const ConceptPackVersion = 1
+
+// Actions reported for the concept type and each definition of an imported pack
+const (
+	ConceptPackCreated   = "created"
+	ConceptPackUpdated   = "updated"
+	ConceptPackUnchanged = "unchanged"
+	ConceptPackConflict  = "conflict"
+)
+
+// Reasons reported for conflicting definitions. Conflicts are never applied:
+// duplicated or malformed keys and unknown categories are skipped, and
+// definitions missing from the pack are kept.
+const (
+	ConceptPackDuplicateTermKey = "duplicate_term_key"
+	ConceptPackInvalidTermKey   = "invalid_term_key"
+	ConceptPackUnknownCategory  = "unknown_category"
+	ConceptPackMissingFromPack  = "missing_from_pack"
+)
+
+// ExportConceptType builds a concept pack with the type and all its definitions
+func (s *conceptTypeService) ExportConceptType(ctx context.Context, id uuid.UUID) (*dto.ConceptPack, error) {
+	ct, err := s.conceptTypeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	if ct == nil {
+		return nil, errors.NewNotFoundError("concept_type")
+	}
+	defs, err := s.conceptDefRepo.FindByTypeID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept definitions", err)
+	}
+	translations, err := s.definitionTranslations(ctx, defs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	pack := &dto.ConceptPack{
+		Version:     ConceptPackVersion,
+		ExportedAt:  &now,
+		ConceptType: dto.ConceptPackType{Code: ct.Code, Name: ct.Name},
+		Definitions: make([]dto.ConceptPackDefinition, 0, len(defs)),
+	}
+	if ct.Description != nil {
+		pack.ConceptType.Description = *ct.Description
+	}
+	for _, def := range defs {
+		pack.Definitions = append(pack.Definitions, dto.ConceptPackDefinition{
+			TermKey:      def.TermKey,
+			TermValue:    def.TermValue,
+			Category:     def.Category,
+			SortOrder:    def.SortOrder,
+			Translations: translations[def.ID],
+		})
+	}
+
+	s.logger.Info("concept type exported", "entity_id", id.String(), "definitions", len(defs))
+	return pack, nil
+}
+
+// ImportConceptPack upserts a concept type by code and its definitions by
+// term key. Definitions of the existing type that the pack does not mention
+// are reported as conflicts and left in place; nothing is ever deleted.
+// Imports land in the draft and reach schools once published.
+func (s *conceptTypeService) ImportConceptPack(ctx context.Context, pack *dto.ConceptPack, dryRun bool) (*dto.ConceptPackImportResponse, error) {
+	if pack.Version != ConceptPackVersion {
+		return nil, errors.NewValidationErrorWithFields("unsupported concept pack", map[string]string{
+			"version": fmt.Sprintf("must be %d", ConceptPackVersion),
+		})
+	}
+	code := strings.TrimSpace(pack.ConceptType.Code)
+	name := strings.TrimSpace(pack.ConceptType.Name)
+	if len(code) < 3 || len(name) < 3 {
+		return nil, errors.NewValidationErrorWithFields("invalid concept pack", map[string]string{
+			"concept_type": "code and name must be at least 3 characters",
+		})
+	}
+
+	now := time.Now()
+	ct, err := s.conceptTypeRepo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	changes := &repository.ConceptPackChanges{
+		Type:         ct,
+		NewType:      ct == nil,
+		Translations: map[uuid.UUID][]*entity.ConceptDefinitionTranslation{},
+	}
+	response := &dto.ConceptPackImportResponse{Code: code, DryRun: dryRun}
+
+	var description *string
+	if d := strings.TrimSpace(pack.ConceptType.Description); d != "" {
+		description = &d
+	}
+	typeChanged := false
+	if ct == nil {
+		changes.Type = &entities.ConceptType{
+			ID:          uuid.New(),
+			Name:        name,
+			Code:        code,
+			Description: description,
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		response.Action = ConceptPackCreated
+	} else {
+		if ct.Name != name || !sameDescription(ct.Description, description) {
+			ct.Name = name
+			ct.Description = description
+			ct.UpdatedAt = now
+			typeChanged = true
+		}
+		response.Action = ConceptPackUnchanged
+	}
+
+	existing := map[string]*entities.ConceptDefinition{}
+	var existingTranslations map[uuid.UUID]map[string]string
+	if ct != nil {
+		defs, err := s.conceptDefRepo.FindByTypeID(ctx, ct.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("list concept definitions", err)
+		}
+		for _, def := range defs {
+			existing[def.TermKey] = def
+		}
+		if existingTranslations, err = s.definitionTranslations(ctx, defs); err != nil {
+			return nil, err
+		}
+	}
+
+	occurrences := map[string]int{}
+	for _, item := range pack.Definitions {
+		occurrences[strings.TrimSpace(item.TermKey)]++
+	}
+
+	seen := map[string]bool{}
+	knownCategories := map[string]bool{}
+	for _, item := range pack.Definitions {
+		key := strings.TrimSpace(item.TermKey)
+		seen[key] = true
+		category := strings.TrimSpace(item.Category)
+		if category == "" {
+			category = DefaultConceptCategory
+		}
+		known, checked := knownCategories[category]
+		if !checked {
+			if known, err = s.categoryExists(ctx, category); err != nil {
+				return nil, err
+			}
+			knownCategories[category] = known
+		}
+
+		reason := ""
+		switch {
+		case occurrences[key] > 1:
+			reason = ConceptPackDuplicateTermKey
+		case !validTermKey(key):
+			reason = ConceptPackInvalidTermKey
+		case !known:
+			reason = ConceptPackUnknownCategory
+		}
+		if reason != "" {
+			response.Definitions = append(response.Definitions, dto.ConceptPackDefinitionResult{
+				TermKey: key, Action: ConceptPackConflict, Reason: reason,
+			})
+			response.Conflicts++
+			continue
+		}
+		def := existing[key]
+		action := ConceptPackUnchanged
+		if def == nil {
+			def = &entities.ConceptDefinition{
+				ID:            uuid.New(),
+				ConceptTypeID: changes.Type.ID,
+				TermKey:       key,
+				TermValue:     item.TermValue,
+				Category:      category,
+				SortOrder:     item.SortOrder,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			changes.Created = append(changes.Created, def)
+			action = ConceptPackCreated
+		} else if def.TermValue != item.TermValue || def.Category != category || def.SortOrder != item.SortOrder {
+			def.TermValue = item.TermValue
+			def.Category = category
+			def.SortOrder = item.SortOrder
+			def.UpdatedAt = now
+			changes.Updated = append(changes.Updated, def)
+			action = ConceptPackUpdated
+		}
+
+		if item.Translations != nil {
+			translations, err := buildDefinitionTranslations(def.ID, item.Translations, now)
+			if err != nil {
+				return nil, err
+			}
+			if !sameTranslations(existingTranslations[def.ID], translations) {
+				changes.Translations[def.ID] = translations
+				if action == ConceptPackUnchanged {
+					action = ConceptPackUpdated
+				}
+			}
+		}
+		response.Definitions = append(response.Definitions, dto.ConceptPackDefinitionResult{TermKey: key, Action: action})
+	}
+
+	var missing []string
+	for key := range existing {
+		if !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		response.Definitions = append(response.Definitions, dto.ConceptPackDefinitionResult{
+			TermKey: key, Action: ConceptPackConflict, Reason: ConceptPackMissingFromPack,
+		})
+		response.Conflicts++
+	}
+
+	definitionsChanged := len(changes.Created) > 0 || len(changes.Updated) > 0 || len(changes.Translations) > 0
+	if ct != nil && (typeChanged || definitionsChanged) {
+		response.Action = ConceptPackUpdated
+	}
+	if !dryRun || ct != nil {
+		response.ConceptTypeID = changes.Type.ID.String()
+	}
+	if dryRun || (ct != nil && !typeChanged && !definitionsChanged) {
+		return response, nil
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.conceptTypeRepo.Import(ctx, changes); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "import", ResourceType: "concept_type", ResourceID: response.ConceptTypeID,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("import concept pack", err)
+	}
+
+	s.logger.Info("concept pack imported", "entity_id", response.ConceptTypeID, "code", code,
+		"created", len(changes.Created), "updated", len(changes.Updated), "conflicts", response.Conflicts)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "import", ResourceType: "concept_type", ResourceID: response.ConceptTypeID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{
+			"action":    response.Action,
+			"created":   len(changes.Created),
+			"updated":   len(changes.Updated),
+			"conflicts": response.Conflicts,
+		},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return response, nil
+}
+
+// definitionTranslations loads the translations of the given definitions
+// indexed by definition and locale
+func (s *conceptTypeService) definitionTranslations(ctx context.Context, defs []*entities.ConceptDefinition) (map[uuid.UUID]map[string]string, error) {
+	ids := make([]uuid.UUID, len(defs))
+	for i, def := range defs {
+		ids[i] = def.ID
+	}
+	translations, err := s.conceptDefRepo.FindTranslations(ctx, ids)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept definition translations", err)
+	}
+	byDefinition := make(map[uuid.UUID]map[string]string, len(defs))
+	for _, t := range translations {
+		if byDefinition[t.DefinitionID] == nil {
+			byDefinition[t.DefinitionID] = map[string]string{}
+		}
+		byDefinition[t.DefinitionID][t.Locale] = t.TermValue
+	}
+	return byDefinition, nil
+}
+
+// sameTranslations reports whether the stored translations match the given ones
+func sameTranslations(stored map[string]string, translations []*entity.ConceptDefinitionTranslation) bool {
+	if len(stored) != len(translations) {
+		return false
+	}
+	for _, t := range translations {
+		if value, ok := stored[t.Locale]; !ok || value != t.TermValue {
+			return false
+		}
+	}
+	return true
+}
+
+// sameDescription compares two optional descriptions
+func sameDescription(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -2,41 +2,86 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/audit"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 	"github.com/google/uuid"
 )
 
+// Statuses reported when comparing a school's terms with the concept definitions
+const (
+	ConceptSyncMissing        = "missing"
+	ConceptSyncMatchesDefault = "matches_default"
+	ConceptSyncOutdated       = "outdated_default"
+	ConceptSyncCustomised     = "customised"
+)
+
+// Actions reported for each term when a school changes concept type
+const (
+	SchoolConceptAdded        = "added"
+	SchoolConceptReplaced     = "replaced"
+	SchoolConceptUnchanged    = "unchanged"
+	SchoolConceptKeptOverride = "kept_override"
+	SchoolConceptRemoved      = "removed"
+)
+
 // ConceptTypeService defines the concept type service interface
 type ConceptTypeService interface {
 	CreateConceptType(ctx context.Context, req *dto.CreateConceptTypeRequest) (*dto.ConceptTypeResponse, error)
 	ListConceptTypes(ctx context.Context) ([]dto.ConceptTypeResponse, error)
 	GetConceptType(ctx context.Context, id uuid.UUID) (*dto.ConceptTypeResponse, error)
 	UpdateConceptType(ctx context.Context, id uuid.UUID, req *dto.UpdateConceptTypeRequest) (*dto.ConceptTypeResponse, error)
-	DeleteConceptType(ctx context.Context, id uuid.UUID) error
+	GetConceptTypeUsage(ctx context.Context, id uuid.UUID) (*dto.ConceptTypeUsageResponse, error)
+	DeleteConceptType(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error
 
 	// Definitions
 	CreateDefinition(ctx context.Context, typeID uuid.UUID, req *dto.ConceptDefinitionRequest) (*dto.ConceptDefinitionResponse, error)
 	ListDefinitions(ctx context.Context, typeID uuid.UUID) ([]dto.ConceptDefinitionResponse, error)
+	ListDefinitionGroups(ctx context.Context, typeID uuid.UUID) ([]dto.ConceptDefinitionGroupResponse, error)
 	UpdateDefinition(ctx context.Context, typeID uuid.UUID, defID uuid.UUID, req *dto.ConceptDefinitionRequest) (*dto.ConceptDefinitionResponse, error)
 	DeleteDefinition(ctx context.Context, typeID uuid.UUID, defID uuid.UUID) error
+	SyncDefinitions(ctx context.Context, typeID uuid.UUID, req *dto.SyncConceptDefinitionsRequest) (*dto.ConceptSyncResponse, error)
+
+	// Category catalog
+	ListCategories(ctx context.Context) ([]dto.ConceptCategoryResponse, error)
+	CreateCategory(ctx context.Context, req *dto.CreateConceptCategoryRequest) (*dto.ConceptCategoryResponse, error)
+	UpdateCategory(ctx context.Context, code string, req *dto.UpdateConceptCategoryRequest) (*dto.ConceptCategoryResponse, error)
+	DeleteCategory(ctx context.Context, code string) error
+
+	// Packs
+	ExportConceptType(ctx context.Context, id uuid.UUID) (*dto.ConceptPack, error)
+	ImportConceptPack(ctx context.Context, pack *dto.ConceptPack, dryRun bool) (*dto.ConceptPackImportResponse, error)
+
+	// Versions
+	ListVersions(ctx context.Context, typeID uuid.UUID) ([]dto.ConceptTypeVersionResponse, error)
+	GetVersion(ctx context.Context, typeID uuid.UUID, version int) (*dto.ConceptTypeVersionDetailResponse, error)
+	DiffDraft(ctx context.Context, typeID uuid.UUID) (*dto.ConceptDraftDiffResponse, error)
+	PublishDefinitions(ctx context.Context, typeID uuid.UUID, req *dto.PublishConceptDefinitionsRequest) (*dto.ConceptTypeVersionDetailResponse, error)
 
 	// School concepts (read + personalize)
-	GetSchoolConcepts(ctx context.Context, schoolID uuid.UUID) ([]dto.SchoolConceptResponse, error)
-	GetSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID) (*dto.SchoolConceptResponse, error)
-	UpdateSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID, req *dto.UpdateSchoolConceptRequest) (*dto.SchoolConceptResponse, error)
+	GetSchoolConcepts(ctx context.Context, schoolID uuid.UUID, locale string) ([]dto.SchoolConceptResponse, error)
+	GetSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID, locale string) (*dto.SchoolConceptResponse, error)
+	UpdateSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID, locale string, req *dto.UpdateSchoolConceptRequest) (*dto.SchoolConceptResponse, error)
+	ResetSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID) (*dto.SchoolConceptResponse, error)
+	ResetSchoolConcepts(ctx context.Context, schoolID uuid.UUID) ([]dto.SchoolConceptResponse, error)
+	ChangeSchoolConceptType(ctx context.Context, schoolID uuid.UUID, req *dto.ChangeSchoolConceptTypeRequest) (*dto.ChangeSchoolConceptTypeResponse, error)
 }
 
 type conceptTypeService struct {
 	conceptTypeRepo   repository.ConceptTypeRepository
 	conceptDefRepo    repository.ConceptDefinitionRepository
+	categoryRepo      repository.ConceptCategoryRepository
 	schoolConceptRepo repository.SchoolConceptRepository
+	schoolRepo        sharedrepo.SchoolRepository
 	logger            logger.Logger
 	auditLogger       audit.AuditLogger
 }
@@ -45,14 +90,18 @@ type conceptTypeService struct {
 func NewConceptTypeService(
 	conceptTypeRepo repository.ConceptTypeRepository,
 	conceptDefRepo repository.ConceptDefinitionRepository,
+	categoryRepo repository.ConceptCategoryRepository,
 	schoolConceptRepo repository.SchoolConceptRepository,
+	schoolRepo sharedrepo.SchoolRepository,
 	logger logger.Logger,
 	auditLogger audit.AuditLogger,
 ) ConceptTypeService {
 	return &conceptTypeService{
 		conceptTypeRepo:   conceptTypeRepo,
 		conceptDefRepo:    conceptDefRepo,
+		categoryRepo:      categoryRepo,
 		schoolConceptRepo: schoolConceptRepo,
+		schoolRepo:        schoolRepo,
 		logger:            logger,
 		auditLogger:       auditLogger,
 	}
@@ -180,7 +229,50 @@ func (s *conceptTypeService) UpdateConceptType(ctx context.Context, id uuid.UUID
 	return &response, nil
 }
 
-func (s *conceptTypeService) DeleteConceptType(ctx context.Context, id uuid.UUID) error {
+// GetConceptTypeUsage lists the schools referencing a concept type and counts
+// its definitions
+func (s *conceptTypeService) GetConceptTypeUsage(ctx context.Context, id uuid.UUID) (*dto.ConceptTypeUsageResponse, error) {
+	ct, err := s.conceptTypeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	if ct == nil {
+		return nil, errors.NewNotFoundError("concept_type")
+	}
+	schools, err := s.conceptTypeRepo.FindSchools(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept type schools", err)
+	}
+	defs, err := s.conceptDefRepo.FindByTypeID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept definitions", err)
+	}
+
+	response := &dto.ConceptTypeUsageResponse{
+		ConceptTypeID:         id.String(),
+		Code:                  ct.Code,
+		InUse:                 len(schools) > 0,
+		SchoolCount:           len(schools),
+		Schools:               make([]dto.ConceptTypeUsageSchool, 0, len(schools)),
+		DefinitionCount:       len(defs),
+		DefinitionsByCategory: make(map[string]int),
+	}
+	for _, school := range schools {
+		response.Schools = append(response.Schools, dto.ConceptTypeUsageSchool{
+			ID: school.ID.String(), Name: school.Name, Code: school.Code, IsActive: school.IsActive,
+		})
+	}
+	for _, def := range defs {
+		response.DefinitionsByCategory[def.Category]++
+	}
+	return response, nil
+}
+
+// DeleteConceptType soft-deletes a concept type. While schools use it the
+// deletion is rejected unless reassignTo names another type, in which case the
+// schools are re-seeded from it, keeping their overrides. The schools are read
+// and moved in the transaction that deletes the type, with the type locked.
+func (s *conceptTypeService) DeleteConceptType(ctx context.Context, id uuid.UUID, reassignTo *uuid.UUID) error {
 	ct, err := s.conceptTypeRepo.FindByID(ctx, id)
 	if err != nil {
 		return errors.NewDatabaseError("find concept type", err)
@@ -189,8 +281,27 @@ func (s *conceptTypeService) DeleteConceptType(ctx context.Context, id uuid.UUID
 		return errors.NewNotFoundError("concept_type")
 	}
 
+	var migrations []*repository.SchoolConceptMigration
+	plan := func(schools []*entities.School) ([]*repository.SchoolConceptMigration, error) {
+		if reassignTo == nil {
+			if len(schools) > 0 {
+				return nil, errors.NewValidationErrorWithFields("concept type is in use", map[string]string{
+					"reassign_to": fmt.Sprintf("required while %d schools use this concept type", len(schools)),
+				})
+			}
+			return nil, nil
+		}
+		var err error
+		migrations, err = s.planReassignment(ctx, id, *reassignTo, schools)
+		return migrations, err
+	}
+
 	actorID, actorEmail, actorRole := actorFromContext(ctx)
-	if err := s.conceptTypeRepo.SoftDelete(ctx, id); err != nil {
+	if err := s.conceptTypeRepo.ReassignAndDelete(ctx, id, reassignTo, plan); err != nil {
+		// Errors from planning already describe the problem
+		if _, ok := errors.GetAppError(err); ok {
+			return err
+		}
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "delete", ResourceType: "concept_type", ResourceID: id.String(),
 			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
@@ -200,17 +311,65 @@ func (s *conceptTypeService) DeleteConceptType(ctx context.Context, id uuid.UUID
 		}
 		return errors.NewDatabaseError("delete concept type", err)
 	}
-	s.logger.Info("entity deleted", "entity_type", "concept_type", "entity_id", id.String())
-	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+	s.logger.Info("entity deleted", "entity_type", "concept_type", "entity_id", id.String(), "reassigned_schools", len(migrations))
+	event := audit.AuditEvent{
 		Action: "delete", ResourceType: "concept_type", ResourceID: id.String(),
 		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
 		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
-	}); err != nil {
+	}
+	if reassignTo != nil {
+		event.Metadata = map[string]interface{}{"reassign_to": reassignTo.String(), "reassigned_schools": len(migrations)}
+	}
+	if err := s.auditLogger.Log(ctx, event); err != nil {
 		s.logger.Error("failed to write audit log", "error", err)
 	}
 	return nil
 }
 
+// planReassignment validates the replacement concept type and re-seeds the
+// terms of every school moving to it
+func (s *conceptTypeService) planReassignment(ctx context.Context, id, targetID uuid.UUID, schools []*entities.School) ([]*repository.SchoolConceptMigration, error) {
+	if targetID == id {
+		return nil, errors.NewValidationErrorWithFields("invalid reassignment", map[string]string{
+			"reassign_to": "must be a different concept type",
+		})
+	}
+	target, err := s.conceptTypeRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	if target == nil {
+		return nil, errors.NewValidationErrorWithFields("invalid reassignment", map[string]string{
+			"reassign_to": "concept type not found",
+		})
+	}
+	if !target.IsActive {
+		return nil, errors.NewValidationErrorWithFields("invalid reassignment", map[string]string{
+			"reassign_to": "concept type is inactive",
+		})
+	}
+	set, err := loadPublishedDefinitions(ctx, s.conceptDefRepo, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	migrations := make([]*repository.SchoolConceptMigration, 0, len(schools))
+	for _, school := range schools {
+		current, err := s.schoolConceptRepo.FindBySchoolID(ctx, school.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("list school concepts", err)
+		}
+		currentSources, err := s.conceptSources(ctx, school.ID, current)
+		if err != nil {
+			return nil, err
+		}
+		concepts, sources, _ := planConceptTypeChange(school.ID, set, current, currentSources, true, now)
+		migrations = append(migrations, &repository.SchoolConceptMigration{SchoolID: school.ID, Concepts: concepts, Sources: sources})
+	}
+	return migrations, nil
+}
+
 // ==================== Definitions ====================
 
 func (s *conceptTypeService) CreateDefinition(ctx context.Context, typeID uuid.UUID, req *dto.ConceptDefinitionRequest) (*dto.ConceptDefinitionResponse, error) {
@@ -222,16 +381,28 @@ func (s *conceptTypeService) CreateDefinition(ctx context.Context, typeID uuid.U
 		return nil, errors.NewNotFoundError("concept_type")
 	}
 
-	category := req.Category
-	if category == "" {
-		category = "general"
+	termKey, err := normalizeTermKey(req.TermKey)
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.resolveCategory(ctx, req.Category)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUniqueTermKey(ctx, typeID, termKey, uuid.Nil); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
+	defID := uuid.New()
+	translations, err := buildDefinitionTranslations(defID, req.Translations, now)
+	if err != nil {
+		return nil, err
+	}
 	def := &entities.ConceptDefinition{
-		ID:            uuid.New(),
+		ID:            defID,
 		ConceptTypeID: typeID,
-		TermKey:       req.TermKey,
+		TermKey:       termKey,
 		TermValue:     req.TermValue,
 		Category:      category,
 		SortOrder:     req.SortOrder,
@@ -239,7 +410,7 @@ func (s *conceptTypeService) CreateDefinition(ctx context.Context, typeID uuid.U
 		UpdatedAt:     now,
 	}
 
-	if err := s.conceptDefRepo.Create(ctx, def); err != nil {
+	if err := s.conceptDefRepo.Create(ctx, def, translations); err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "create", ResourceType: "concept_definition",
@@ -261,6 +432,7 @@ func (s *conceptTypeService) CreateDefinition(ctx context.Context, typeID uuid.U
 		s.logger.Error("failed to write audit log", "error", err)
 	}
 	response := dto.ToConceptDefinitionResponse(def)
+	response.Translations = translationMap(translations)
 	return &response, nil
 }
 
@@ -277,7 +449,11 @@ func (s *conceptTypeService) ListDefinitions(ctx context.Context, typeID uuid.UU
 	if err != nil {
 		return nil, errors.NewDatabaseError("list concept definitions", err)
 	}
-	return dto.ToConceptDefinitionResponseList(defs), nil
+	responses := dto.ToConceptDefinitionResponseList(defs)
+	if err := s.attachDefinitionTranslations(ctx, defs, responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
 }
 
 func (s *conceptTypeService) UpdateDefinition(ctx context.Context, typeID uuid.UUID, defID uuid.UUID, req *dto.ConceptDefinitionRequest) (*dto.ConceptDefinitionResponse, error) {
@@ -298,15 +474,35 @@ func (s *conceptTypeService) UpdateDefinition(ctx context.Context, typeID uuid.U
 		return nil, errors.NewNotFoundError("concept_definition")
 	}
 
-	target.TermKey = req.TermKey
-	target.TermValue = req.TermValue
-	if req.Category != "" {
-		target.Category = req.Category
+	// Only changed values are validated, so definitions stored before the key
+	// format and the category catalog existed can still be edited
+	termKey := strings.TrimSpace(req.TermKey)
+	if termKey != target.TermKey {
+		if termKey, err = normalizeTermKey(termKey); err != nil {
+			return nil, err
+		}
+		if err := s.ensureUniqueTermKey(ctx, typeID, termKey, target.ID); err != nil {
+			return nil, err
+		}
+	}
+	if category := strings.TrimSpace(req.Category); category != "" && category != target.Category {
+		if target.Category, err = s.resolveCategory(ctx, category); err != nil {
+			return nil, err
+		}
 	}
+	var translations []*entity.ConceptDefinitionTranslation
+	if req.Translations != nil {
+		if translations, err = buildDefinitionTranslations(target.ID, req.Translations, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	target.TermKey = termKey
+	target.TermValue = req.TermValue
 	target.SortOrder = req.SortOrder
 	target.UpdatedAt = time.Now()
 
-	if err := s.conceptDefRepo.Update(ctx, target); err != nil {
+	if err := s.conceptDefRepo.Update(ctx, target, translations); err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "update", ResourceType: "concept_definition", ResourceID: defID.String(),
@@ -327,8 +523,11 @@ func (s *conceptTypeService) UpdateDefinition(ctx context.Context, typeID uuid.U
 	}); err != nil {
 		s.logger.Error("failed to write audit log", "error", err)
 	}
-	response := dto.ToConceptDefinitionResponse(target)
-	return &response, nil
+	responses := []dto.ConceptDefinitionResponse{dto.ToConceptDefinitionResponse(target)}
+	if err := s.attachDefinitionTranslations(ctx, []*entities.ConceptDefinition{target}, responses); err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
 }
 
 func (s *conceptTypeService) DeleteDefinition(ctx context.Context, typeID uuid.UUID, defID uuid.UUID) error {
@@ -372,17 +571,228 @@ func (s *conceptTypeService) DeleteDefinition(ctx context.Context, typeID uuid.U
 	return nil
 }
 
+// SyncDefinitions propagates the type's published definitions to the schools
+// using it; types that were never published sync their draft. Missing keys
+// are always added; terms still holding the default they were seeded with are
+// moved to the current default only when UpdateDefaults is set. Terms
+// customised by the school are never touched.
+func (s *conceptTypeService) SyncDefinitions(ctx context.Context, typeID uuid.UUID, req *dto.SyncConceptDefinitionsRequest) (*dto.ConceptSyncResponse, error) {
+	ct, err := s.conceptTypeRepo.FindByID(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	if ct == nil {
+		return nil, errors.NewNotFoundError("concept_type")
+	}
+
+	set, err := loadPublishedDefinitions(ctx, s.conceptDefRepo, typeID)
+	if err != nil {
+		return nil, err
+	}
+	schools, err := s.conceptTypeRepo.FindSchools(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept type schools", err)
+	}
+	schools, err = selectSchools(schools, req.SchoolIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ConceptSyncResponse{
+		ConceptTypeID:  typeID.String(),
+		Version:        set.Version,
+		DryRun:         req.DryRun,
+		UpdateDefaults: req.UpdateDefaults,
+		Schools:        make([]dto.SchoolConceptSyncResult, 0, len(schools)),
+	}
+	added, updated := 0, 0
+	for _, school := range schools {
+		result, err := s.syncSchool(ctx, school, set, req)
+		if err != nil {
+			return nil, err
+		}
+		added += result.Added
+		updated += result.Updated
+		response.Schools = append(response.Schools, result)
+	}
+	if req.DryRun {
+		return response, nil
+	}
+
+	s.logger.Info("concept definitions synced", "concept_type_id", typeID.String(), "schools", len(schools), "added", added, "updated", updated)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "sync", ResourceType: "concept_type", ResourceID: typeID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{"schools": len(schools), "added": added, "updated": updated},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return response, nil
+}
+
+// syncSchool diffs one school's terms against the definitions and, unless the
+// request is a dry run, applies the resulting changes
+func (s *conceptTypeService) syncSchool(ctx context.Context, school *entities.School, set *definitionSet, req *dto.SyncConceptDefinitionsRequest) (dto.SchoolConceptSyncResult, error) {
+	result := dto.SchoolConceptSyncResult{
+		SchoolID:   school.ID.String(),
+		SchoolName: school.Name,
+		Entries:    make([]dto.SchoolConceptDiffEntry, 0, len(set.Definitions)),
+	}
+
+	concepts, err := s.schoolConceptRepo.FindBySchoolID(ctx, school.ID)
+	if err != nil {
+		return result, errors.NewDatabaseError("list school concepts", err)
+	}
+	sources, err := s.schoolConceptRepo.FindSourcesBySchoolID(ctx, school.ID)
+	if err != nil {
+		return result, errors.NewDatabaseError("list school concept sources", err)
+	}
+	byKey := make(map[string]*entities.SchoolConcept, len(concepts))
+	for _, concept := range concepts {
+		byKey[concept.TermKey] = concept
+	}
+	sourceByConcept := make(map[uuid.UUID]*entity.SchoolConceptSource, len(sources))
+	for _, source := range sources {
+		sourceByConcept[source.SchoolConceptID] = source
+	}
+
+	now := time.Now()
+	var created, updated []*entities.SchoolConcept
+	var touched []*entity.SchoolConceptSource
+	for _, def := range set.Definitions {
+		entry := dto.SchoolConceptDiffEntry{TermKey: def.TermKey, DefaultValue: def.TermValue}
+		concept, ok := byKey[def.TermKey]
+		if !ok {
+			entry.Status = ConceptSyncMissing
+			entry.Applied = true
+			concept, source := seedSchoolConcept(school.ID, def, set.Version, now)
+			created = append(created, concept)
+			touched = append(touched, source)
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+
+		entry.SchoolValue = concept.TermValue
+		source := sourceByConcept[concept.ID]
+		switch {
+		case concept.TermValue == def.TermValue:
+			entry.Status = ConceptSyncMatchesDefault
+			// Record the baseline so later renames of this default can be detected
+			if source == nil || source.DefinitionID != def.ID || source.DefaultValue != def.TermValue || source.Version != set.Version {
+				touched = append(touched, rebaseSource(source, concept, def, set.Version, now))
+			}
+		case source == nil || source.IsOverridden(concept.TermValue):
+			entry.Status = ConceptSyncCustomised
+		default:
+			entry.Status = ConceptSyncOutdated
+			if req.UpdateDefaults {
+				entry.Applied = true
+				concept.TermValue = def.TermValue
+				concept.UpdatedAt = now
+				updated = append(updated, concept)
+				touched = append(touched, rebaseSource(source, concept, def, set.Version, now))
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+	result.Added = len(created)
+	result.Updated = len(updated)
+
+	if req.DryRun || len(touched) == 0 {
+		return result, nil
+	}
+	if err := s.schoolConceptRepo.SaveWithSources(ctx, created, updated, touched); err != nil {
+		return result, errors.NewDatabaseError("sync school concepts", err)
+	}
+	return result, nil
+}
+
+// selectSchools narrows schools to the requested IDs, all of which must be
+// among the given schools
+func selectSchools(schools []*entities.School, rawIDs []string) ([]*entities.School, error) {
+	if len(rawIDs) == 0 {
+		return schools, nil
+	}
+	byID := make(map[uuid.UUID]*entities.School, len(schools))
+	for _, school := range schools {
+		byID[school.ID] = school
+	}
+	selected := make([]*entities.School, 0, len(rawIDs))
+	for _, raw := range rawIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, errors.NewValidationErrorWithFields("invalid sync request", map[string]string{
+				"school_ids": "invalid school ID: " + raw,
+			})
+		}
+		school, ok := byID[id]
+		if !ok {
+			return nil, errors.NewValidationErrorWithFields("invalid sync request", map[string]string{
+				"school_ids": "school " + raw + " does not use this concept type",
+			})
+		}
+		selected = append(selected, school)
+	}
+	return selected, nil
+}
+
+// seedSchoolConcept copies a definition of the given published version into a
+// new school term along with the source record used to detect later customisations
+func seedSchoolConcept(schoolID uuid.UUID, def *entities.ConceptDefinition, version int, now time.Time) (*entities.SchoolConcept, *entity.SchoolConceptSource) {
+	concept := &entities.SchoolConcept{
+		ID:        uuid.New(),
+		SchoolID:  schoolID,
+		TermKey:   def.TermKey,
+		TermValue: def.TermValue,
+		Category:  def.Category,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return concept, rebaseSource(nil, concept, def, version, now)
+}
+
+// rebaseSource points a term's source record at the definition's value in the
+// given version, creating the record when the term has none
+func rebaseSource(source *entity.SchoolConceptSource, concept *entities.SchoolConcept, def *entities.ConceptDefinition, version int, now time.Time) *entity.SchoolConceptSource {
+	if source == nil {
+		source = &entity.SchoolConceptSource{
+			SchoolConceptID: concept.ID,
+			SchoolID:        concept.SchoolID,
+			CreatedAt:       now,
+		}
+	}
+	source.DefinitionID = def.ID
+	source.DefaultValue = def.TermValue
+	source.Version = version
+	source.UpdatedAt = now
+	return source
+}
+
 // ==================== School Concepts ====================
 
-func (s *conceptTypeService) GetSchoolConcepts(ctx context.Context, schoolID uuid.UUID) ([]dto.SchoolConceptResponse, error) {
+func (s *conceptTypeService) GetSchoolConcepts(ctx context.Context, schoolID uuid.UUID, locale string) ([]dto.SchoolConceptResponse, error) {
+	locale, err := resolveLocale(locale)
+	if err != nil {
+		return nil, err
+	}
 	concepts, err := s.schoolConceptRepo.FindBySchoolID(ctx, schoolID)
 	if err != nil {
 		return nil, errors.NewDatabaseError("list school concepts", err)
 	}
-	return dto.ToSchoolConceptResponseList(concepts), nil
+	sources, err := s.conceptSources(ctx, schoolID, concepts)
+	if err != nil {
+		return nil, err
+	}
+	return s.localizeSchoolConcepts(ctx, schoolID, locale, concepts, sources)
 }
 
-func (s *conceptTypeService) GetSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID) (*dto.SchoolConceptResponse, error) {
+func (s *conceptTypeService) GetSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID, locale string) (*dto.SchoolConceptResponse, error) {
+	locale, err := resolveLocale(locale)
+	if err != nil {
+		return nil, err
+	}
 	concept, err := s.schoolConceptRepo.FindByID(ctx, conceptID)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find school concept", err)
@@ -390,11 +800,21 @@ func (s *conceptTypeService) GetSchoolConcept(ctx context.Context, schoolID uuid
 	if concept == nil || concept.SchoolID != schoolID {
 		return nil, errors.NewNotFoundError("school_concept")
 	}
-	response := dto.ToSchoolConceptResponse(concept)
-	return &response, nil
+	source, err := s.conceptSource(ctx, concept)
+	if err != nil {
+		return nil, err
+	}
+	return s.localizeSchoolConcept(ctx, locale, concept, source)
 }
 
-func (s *conceptTypeService) UpdateSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID, req *dto.UpdateSchoolConceptRequest) (*dto.SchoolConceptResponse, error) {
+// UpdateSchoolConcept personalizes a school term. In the default locale the
+// base value is changed; in any other locale a per-locale override is stored
+// and the base value is left as is.
+func (s *conceptTypeService) UpdateSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID, locale string, req *dto.UpdateSchoolConceptRequest) (*dto.SchoolConceptResponse, error) {
+	locale, err := resolveLocale(locale)
+	if err != nil {
+		return nil, err
+	}
 	concept, err := s.schoolConceptRepo.FindByID(ctx, conceptID)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find school concept", err)
@@ -405,11 +825,34 @@ func (s *conceptTypeService) UpdateSchoolConcept(ctx context.Context, schoolID u
 	if concept.SchoolID != schoolID {
 		return nil, errors.NewNotFoundError("school_concept")
 	}
+	source, err := s.conceptSource(ctx, concept)
+	if err != nil {
+		return nil, err
+	}
 
-	concept.TermValue = req.TermValue
-	concept.UpdatedAt = time.Now()
-
-	if err := s.schoolConceptRepo.Update(ctx, concept); err != nil {
+	now := time.Now()
+	switch {
+	case locale != DefaultLocale:
+		err = s.schoolConceptRepo.SaveTranslation(ctx, &entity.SchoolConceptTranslation{
+			SchoolConceptID: concept.ID,
+			Locale:          locale,
+			SchoolID:        schoolID,
+			TermValue:       req.TermValue,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+	case source != nil:
+		concept.TermValue = req.TermValue
+		concept.UpdatedAt = now
+		source.Overridden = true
+		source.UpdatedAt = now
+		err = s.schoolConceptRepo.SaveWithSources(ctx, nil, []*entities.SchoolConcept{concept}, []*entity.SchoolConceptSource{source})
+	default:
+		concept.TermValue = req.TermValue
+		concept.UpdatedAt = now
+		err = s.schoolConceptRepo.Update(ctx, concept)
+	}
+	if err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "update", ResourceType: "school_concept", ResourceID: conceptID.String(),
@@ -421,15 +864,368 @@ func (s *conceptTypeService) UpdateSchoolConcept(ctx context.Context, schoolID u
 		return nil, errors.NewDatabaseError("update school concept", err)
 	}
 
-	s.logger.Info("entity updated", "entity_type", "school_concept", "entity_id", conceptID.String())
+	s.logger.Info("entity updated", "entity_type", "school_concept", "entity_id", conceptID.String(), "locale", locale)
 	actorID, actorEmail, actorRole := actorFromContext(ctx)
 	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
 		Action: "update", ResourceType: "school_concept", ResourceID: conceptID.String(),
 		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
 		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{"locale": locale},
 	}); err != nil {
 		s.logger.Error("failed to write audit log", "error", err)
 	}
-	response := dto.ToSchoolConceptResponse(concept)
-	return &response, nil
+	return s.localizeSchoolConcept(ctx, locale, concept, source)
+}
+
+// ResetSchoolConcept restores a school term to the current value of the
+// definition it was seeded from
+func (s *conceptTypeService) ResetSchoolConcept(ctx context.Context, schoolID uuid.UUID, conceptID uuid.UUID) (*dto.SchoolConceptResponse, error) {
+	concept, err := s.schoolConceptRepo.FindByID(ctx, conceptID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school concept", err)
+	}
+	if concept == nil || concept.SchoolID != schoolID {
+		return nil, errors.NewNotFoundError("school_concept")
+	}
+	source, err := s.conceptSource(ctx, concept)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, errors.NewValidationError("school concept has no default definition")
+	}
+	set, err := s.schoolDefinitionSet(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	resetSchoolConcept(concept, source, set.byID()[source.DefinitionID], set.Version, now)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.schoolConceptRepo.ResetWithSources(ctx, []*entities.SchoolConcept{concept}, []*entity.SchoolConceptSource{source}, []uuid.UUID{concept.ID}); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "reset", ResourceType: "school_concept", ResourceID: conceptID.String(),
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("reset school concept", err)
+	}
+
+	s.logger.Info("school concept reset", "school_id", schoolID.String(), "entity_id", conceptID.String())
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "reset", ResourceType: "school_concept", ResourceID: conceptID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return s.localizeSchoolConcept(ctx, DefaultLocale, concept, source)
+}
+
+// ResetSchoolConcepts restores every school term that has a source definition
+// to its current default and drops its per-locale overrides. Terms without a
+// definition are left untouched.
+func (s *conceptTypeService) ResetSchoolConcepts(ctx context.Context, schoolID uuid.UUID) ([]dto.SchoolConceptResponse, error) {
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	concepts, err := s.schoolConceptRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list school concepts", err)
+	}
+	sources, err := s.conceptSources(ctx, schoolID, concepts)
+	if err != nil {
+		return nil, err
+	}
+	set := &definitionSet{}
+	if school.ConceptTypeID != nil {
+		if set, err = loadPublishedDefinitions(ctx, s.conceptDefRepo, *school.ConceptTypeID); err != nil {
+			return nil, err
+		}
+	}
+	defsByID := set.byID()
+
+	now := time.Now()
+	var updated []*entities.SchoolConcept
+	var touched []*entity.SchoolConceptSource
+	var seeded []uuid.UUID
+	for _, concept := range concepts {
+		source := sources[concept.ID]
+		if source == nil {
+			continue
+		}
+		seeded = append(seeded, concept.ID)
+		def := defsByID[source.DefinitionID]
+		if !source.IsOverridden(concept.TermValue) && (def == nil || def.TermValue == source.DefaultValue) {
+			continue
+		}
+		resetSchoolConcept(concept, source, def, set.Version, now)
+		updated = append(updated, concept)
+		touched = append(touched, source)
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if len(seeded) > 0 {
+		if err := s.schoolConceptRepo.ResetWithSources(ctx, updated, touched, seeded); err != nil {
+			if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+				Action: "reset_all", ResourceType: "school_concept", ResourceID: schoolID.String(),
+				ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+				ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+			}); logErr != nil {
+				s.logger.Error("failed to write audit log", "error", logErr)
+			}
+			return nil, errors.NewDatabaseError("reset school concepts", err)
+		}
+	}
+
+	s.logger.Info("school concepts reset", "school_id", schoolID.String(), "count", len(updated))
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "reset_all", ResourceType: "school_concept", ResourceID: schoolID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{"reset": len(updated)},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return s.localizeSchoolConcepts(ctx, schoolID, DefaultLocale, concepts, sources)
+}
+
+// ChangeSchoolConceptType switches a school to another concept type and
+// re-seeds its terms from the new type's published definitions. Terms keep
+// their ID when the key exists in both types; keys missing from the new type
+// are removed.
+func (s *conceptTypeService) ChangeSchoolConceptType(ctx context.Context, schoolID uuid.UUID, req *dto.ChangeSchoolConceptTypeRequest) (*dto.ChangeSchoolConceptTypeResponse, error) {
+	typeID, err := uuid.Parse(req.ConceptTypeID)
+	if err != nil {
+		return nil, errors.NewValidationErrorWithFields("invalid concept type change", map[string]string{
+			"concept_type_id": "must be a valid UUID",
+		})
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	if school.ConceptTypeID != nil && *school.ConceptTypeID == typeID {
+		return nil, errors.NewValidationErrorWithFields("invalid concept type change", map[string]string{
+			"concept_type_id": "school already uses this concept type",
+		})
+	}
+	ct, err := s.conceptTypeRepo.FindByID(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	if ct == nil {
+		return nil, errors.NewNotFoundError("concept_type")
+	}
+	if !ct.IsActive {
+		return nil, errors.NewValidationErrorWithFields("invalid concept type change", map[string]string{
+			"concept_type_id": "concept type is inactive",
+		})
+	}
+
+	set, err := loadPublishedDefinitions(ctx, s.conceptDefRepo, typeID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.schoolConceptRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list school concepts", err)
+	}
+	currentSources, err := s.conceptSources(ctx, schoolID, current)
+	if err != nil {
+		return nil, err
+	}
+
+	concepts, sources, changes := planConceptTypeChange(schoolID, set, current, currentSources, req.KeepOverrides, time.Now())
+	response := &dto.ChangeSchoolConceptTypeResponse{
+		SchoolID:      schoolID.String(),
+		ConceptTypeID: typeID.String(),
+		KeepOverrides: req.KeepOverrides,
+		DryRun:        req.DryRun,
+		Changes:       changes,
+	}
+	if school.ConceptTypeID != nil {
+		previous := school.ConceptTypeID.String()
+		response.PreviousConceptTypeID = &previous
+	}
+	if req.DryRun {
+		return response, nil
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.schoolConceptRepo.ReplaceForSchool(ctx, schoolID, typeID, concepts, sources, req.KeepOverrides); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "change_concept_type", ResourceType: "school", ResourceID: schoolID.String(),
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("change school concept type", err)
+	}
+
+	s.logger.Info("school concept type changed", "school_id", schoolID.String(), "concept_type_id", typeID.String(), "terms", len(concepts))
+	metadata := map[string]interface{}{"concept_type_id": typeID.String(), "keep_overrides": req.KeepOverrides}
+	if response.PreviousConceptTypeID != nil {
+		metadata["previous_concept_type_id"] = *response.PreviousConceptTypeID
+	}
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "change_concept_type", ResourceType: "school", ResourceID: schoolID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: metadata,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return response, nil
+}
+
+// planConceptTypeChange re-seeds a school's terms from the published
+// definitions of another concept type. Terms whose key survives keep their ID
+// and, with keepOverrides, their customised value.
+func planConceptTypeChange(schoolID uuid.UUID, set *definitionSet, current []*entities.SchoolConcept, currentSources map[uuid.UUID]*entity.SchoolConceptSource, keepOverrides bool, now time.Time) ([]*entities.SchoolConcept, []*entity.SchoolConceptSource, []dto.SchoolConceptChange) {
+	byKey := make(map[string]*entities.SchoolConcept, len(current))
+	for _, concept := range current {
+		byKey[concept.TermKey] = concept
+	}
+
+	concepts := make([]*entities.SchoolConcept, 0, len(set.Definitions))
+	sources := make([]*entity.SchoolConceptSource, 0, len(set.Definitions))
+	changes := make([]dto.SchoolConceptChange, 0, len(set.Definitions)+len(current))
+	kept := make(map[uuid.UUID]bool, len(current))
+	for _, def := range set.Definitions {
+		concept, source := seedSchoolConcept(schoolID, def, set.Version, now)
+		change := dto.SchoolConceptChange{TermKey: def.TermKey, NewValue: def.TermValue}
+
+		old, ok := byKey[def.TermKey]
+		if !ok {
+			change.Action = SchoolConceptAdded
+		} else {
+			kept[old.ID] = true
+			concept.ID = old.ID
+			concept.CreatedAt = old.CreatedAt
+			source.SchoolConceptID = old.ID
+			change.OldValue = old.TermValue
+
+			oldSource := currentSources[old.ID]
+			overridden := oldSource == nil || oldSource.IsOverridden(old.TermValue)
+			switch {
+			case old.TermValue == def.TermValue:
+				change.Action = SchoolConceptUnchanged
+			case keepOverrides && overridden:
+				change.Action = SchoolConceptKeptOverride
+				change.NewValue = old.TermValue
+				concept.TermValue = old.TermValue
+				source.Overridden = true
+			default:
+				change.Action = SchoolConceptReplaced
+			}
+		}
+		concepts = append(concepts, concept)
+		sources = append(sources, source)
+		changes = append(changes, change)
+	}
+	for _, old := range current {
+		if !kept[old.ID] {
+			changes = append(changes, dto.SchoolConceptChange{
+				TermKey: old.TermKey, Action: SchoolConceptRemoved, OldValue: old.TermValue,
+			})
+		}
+	}
+	return concepts, sources, changes
+}
+
+// conceptSource returns the source of a school term, falling back to the
+// definition with the same key in the school's concept type when none was
+// recorded. Fallback sources are built in memory and not persisted here.
+func (s *conceptTypeService) conceptSource(ctx context.Context, concept *entities.SchoolConcept) (*entity.SchoolConceptSource, error) {
+	source, err := s.schoolConceptRepo.FindSourceByConceptID(ctx, concept.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school concept source", err)
+	}
+	if source != nil {
+		return source, nil
+	}
+	set, err := s.schoolDefinitionSet(ctx, concept.SchoolID)
+	if err != nil {
+		return nil, err
+	}
+	if def, ok := set.byKey()[concept.TermKey]; ok {
+		return rebaseSource(nil, concept, def, set.Version, time.Now()), nil
+	}
+	return nil, nil
+}
+
+// conceptSources is the bulk form of conceptSource, keyed by school concept ID
+func (s *conceptTypeService) conceptSources(ctx context.Context, schoolID uuid.UUID, concepts []*entities.SchoolConcept) (map[uuid.UUID]*entity.SchoolConceptSource, error) {
+	sources, err := s.schoolConceptRepo.FindSourcesBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list school concept sources", err)
+	}
+	byConcept := make(map[uuid.UUID]*entity.SchoolConceptSource, len(concepts))
+	for _, source := range sources {
+		byConcept[source.SchoolConceptID] = source
+	}
+
+	var set *definitionSet
+	var defs map[string]*entities.ConceptDefinition
+	now := time.Now()
+	for _, concept := range concepts {
+		if byConcept[concept.ID] != nil {
+			continue
+		}
+		if set == nil {
+			if set, err = s.schoolDefinitionSet(ctx, schoolID); err != nil {
+				return nil, err
+			}
+			defs = set.byKey()
+		}
+		if def, ok := defs[concept.TermKey]; ok {
+			byConcept[concept.ID] = rebaseSource(nil, concept, def, set.Version, now)
+		}
+	}
+	return byConcept, nil
+}
+
+// schoolDefinitionSet returns the published definitions of the school's concept
+// type, or an empty set when the school has none
+func (s *conceptTypeService) schoolDefinitionSet(ctx context.Context, schoolID uuid.UUID) (*definitionSet, error) {
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil || school.ConceptTypeID == nil {
+		return &definitionSet{}, nil
+	}
+	return loadPublishedDefinitions(ctx, s.conceptDefRepo, *school.ConceptTypeID)
+}
+
+// resetSchoolConcept moves a term back to its default in the given version.
+// def may be nil when the source definition is no longer published, in which
+// case the recorded default is used.
+func resetSchoolConcept(concept *entities.SchoolConcept, source *entity.SchoolConceptSource, def *entities.ConceptDefinition, version int, now time.Time) {
+	if def != nil {
+		rebaseSource(source, concept, def, version, now)
+	}
+	source.Overridden = false
+	source.UpdatedAt = now
+	concept.TermValue = source.DefaultValue
+	concept.UpdatedAt = now
+}
+
+func toSchoolConceptResponses(concepts []*entities.SchoolConcept, sources map[uuid.UUID]*entity.SchoolConceptSource) []dto.SchoolConceptResponse {
+	responses := make([]dto.SchoolConceptResponse, len(concepts))
+	for i, concept := range concepts {
+		responses[i] = dto.ToSchoolConceptResponseWithSource(concept, sources[concept.ID])
+	}
+	return responses
 }
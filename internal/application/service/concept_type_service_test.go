@@ -0,0 +1,1258 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conceptSyncFixture holds one school whose terms were seeded from an older
+// version of the definitions
+type conceptSyncFixture struct {
+	typeID     uuid.UUID
+	school     *entities.School
+	typeRepo   *mock.MockConceptTypeRepository
+	defRepo    *mock.MockConceptDefinitionRepository
+	schoolRepo *mock.MockSchoolConceptRepository
+
+	created, updated []*entities.SchoolConcept
+	sources          []*entity.SchoolConceptSource
+}
+
+func newConceptSyncFixture() *conceptSyncFixture {
+	f := &conceptSyncFixture{typeID: uuid.New()}
+	f.school = &entities.School{ID: uuid.New(), Name: "Colegio Andino", ConceptTypeID: &f.typeID, IsActive: true}
+
+	defs := []*entities.ConceptDefinition{
+		{ID: uuid.New(), ConceptTypeID: f.typeID, TermKey: "unit.level", TermValue: "Curso", Category: "units"},
+		{ID: uuid.New(), ConceptTypeID: f.typeID, TermKey: "unit.section", TermValue: "Sección", Category: "units"},
+		{ID: uuid.New(), ConceptTypeID: f.typeID, TermKey: "member.teacher", TermValue: "Profesor", Category: "members"},
+		{ID: uuid.New(), ConceptTypeID: f.typeID, TermKey: "member.student", TermValue: "Estudiante", Category: "members"},
+	}
+	// unit.level was renamed from "Grado" after seeding, unit.section was
+	// customised by the school and member.student was added later
+	level := &entities.SchoolConcept{ID: uuid.New(), SchoolID: f.school.ID, TermKey: "unit.level", TermValue: "Grado"}
+	section := &entities.SchoolConcept{ID: uuid.New(), SchoolID: f.school.ID, TermKey: "unit.section", TermValue: "Paralelo"}
+	teacher := &entities.SchoolConcept{ID: uuid.New(), SchoolID: f.school.ID, TermKey: "member.teacher", TermValue: "Profesor"}
+	sources := []*entity.SchoolConceptSource{
+		{SchoolConceptID: level.ID, SchoolID: f.school.ID, DefinitionID: defs[0].ID, DefaultValue: "Grado"},
+		{SchoolConceptID: section.ID, SchoolID: f.school.ID, DefinitionID: defs[1].ID, DefaultValue: "Sección"},
+		{SchoolConceptID: teacher.ID, SchoolID: f.school.ID, DefinitionID: defs[2].ID, DefaultValue: "Profesor"},
+	}
+
+	f.typeRepo = &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: id, IsActive: true}, nil
+		},
+		FindSchoolsFn: func(_ context.Context, _ uuid.UUID) ([]*entities.School, error) {
+			return []*entities.School{f.school}, nil
+		},
+	}
+	f.defRepo = &mock.MockConceptDefinitionRepository{
+		FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) { return defs, nil },
+	}
+	f.schoolRepo = &mock.MockSchoolConceptRepository{
+		FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.SchoolConcept, error) {
+			return []*entities.SchoolConcept{level, section, teacher}, nil
+		},
+		FindSourcesBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptSource, error) {
+			return sources, nil
+		},
+		SaveWithSourcesFn: func(_ context.Context, created, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource) error {
+			f.created, f.updated, f.sources = created, updated, sources
+			return nil
+		},
+	}
+	return f
+}
+
+// conceptCatalog mocks a category catalog holding the given codes in order
+func conceptCatalog(codes ...string) *mock.MockConceptCategoryRepository {
+	categories := make([]*entity.ConceptCategory, len(codes))
+	for i, code := range codes {
+		categories[i] = &entity.ConceptCategory{Code: code, Name: "Category " + code, SortOrder: i}
+	}
+	return &mock.MockConceptCategoryRepository{
+		FindAllFn: func(_ context.Context) ([]*entity.ConceptCategory, error) { return categories, nil },
+		FindByCodeFn: func(_ context.Context, code string) (*entity.ConceptCategory, error) {
+			for _, category := range categories {
+				if category.Code == code {
+					return category, nil
+				}
+			}
+			return nil, nil
+		},
+	}
+}
+
+func (f *conceptSyncFixture) service() service.ConceptTypeService {
+	return service.NewConceptTypeService(f.typeRepo, f.defRepo, &mock.MockConceptCategoryRepository{}, f.schoolRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+}
+
+func TestConceptTypeService_SyncDefinitions(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        dto.SyncConceptDefinitionsRequest
+		wantAdded      int
+		wantUpdated    int
+		wantPersisted  bool
+		wantLevelValue string
+	}{
+		{
+			name:           "adds missing keys only",
+			request:        dto.SyncConceptDefinitionsRequest{},
+			wantAdded:      1,
+			wantPersisted:  true,
+			wantLevelValue: "Grado",
+		},
+		{
+			name:           "also moves uncustomised terms to the new default",
+			request:        dto.SyncConceptDefinitionsRequest{UpdateDefaults: true},
+			wantAdded:      1,
+			wantUpdated:    1,
+			wantPersisted:  true,
+			wantLevelValue: "Curso",
+		},
+		{
+			name:           "dry run reports without writing",
+			request:        dto.SyncConceptDefinitionsRequest{UpdateDefaults: true, DryRun: true},
+			wantAdded:      1,
+			wantUpdated:    1,
+			wantLevelValue: "Grado",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newConceptSyncFixture()
+			persisted := false
+			apply := f.schoolRepo.SaveWithSourcesFn
+			f.schoolRepo.SaveWithSourcesFn = func(ctx context.Context, created, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource) error {
+				persisted = true
+				return apply(ctx, created, updated, sources)
+			}
+
+			result, err := f.service().SyncDefinitions(context.Background(), f.typeID, &tt.request)
+			require.NoError(t, err)
+			require.Len(t, result.Schools, 1)
+
+			school := result.Schools[0]
+			statuses := make(map[string]string, len(school.Entries))
+			for _, e := range school.Entries {
+				statuses[e.TermKey] = e.Status
+			}
+			assert.Equal(t, map[string]string{
+				"unit.level":     service.ConceptSyncOutdated,
+				"unit.section":   service.ConceptSyncCustomised,
+				"member.teacher": service.ConceptSyncMatchesDefault,
+				"member.student": service.ConceptSyncMissing,
+			}, statuses)
+			assert.Equal(t, tt.wantAdded, school.Added)
+			assert.Equal(t, tt.wantUpdated, school.Updated)
+			assert.Equal(t, tt.wantPersisted, persisted)
+
+			if tt.wantPersisted {
+				require.Len(t, f.created, 1)
+				assert.Equal(t, "Estudiante", f.created[0].TermValue)
+				require.Len(t, f.updated, tt.wantUpdated)
+				if tt.wantUpdated > 0 {
+					assert.Equal(t, tt.wantLevelValue, f.updated[0].TermValue)
+				}
+				// Every created or updated term gets its baseline recorded
+				assert.Len(t, f.sources, tt.wantAdded+tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestConceptTypeService_SyncDefinitions_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(f *conceptSyncFixture)
+		request    dto.SyncConceptDefinitionsRequest
+		wantStatus int
+	}{
+		{
+			name: "concept type not found",
+			setup: func(f *conceptSyncFixture) {
+				f.typeRepo.FindByIDFn = func(_ context.Context, _ uuid.UUID) (*entities.ConceptType, error) { return nil, nil }
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "malformed school id",
+			request:    dto.SyncConceptDefinitionsRequest{SchoolIDs: []string{"bad-uuid"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "school not using the concept type",
+			request:    dto.SyncConceptDefinitionsRequest{SchoolIDs: []string{uuid.New().String()}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newConceptSyncFixture()
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			_, err := f.service().SyncDefinitions(context.Background(), f.typeID, &tt.request)
+			require.Error(t, err)
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+		})
+	}
+}
+
+func TestConceptTypeService_ResetSchoolConcept(t *testing.T) {
+	schoolID, typeID := uuid.New(), uuid.New()
+	def := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "unit.level", TermValue: "Curso"}
+
+	tests := []struct {
+		name       string
+		concept    *entities.SchoolConcept
+		source     *entity.SchoolConceptSource
+		wantStatus int
+	}{
+		{
+			name:    "overridden term returns to the current default",
+			concept: &entities.SchoolConcept{ID: uuid.New(), SchoolID: schoolID, TermKey: "unit.level", TermValue: "Nivel"},
+			source:  &entity.SchoolConceptSource{SchoolID: schoolID, DefinitionID: def.ID, DefaultValue: "Grado", Overridden: true},
+		},
+		{
+			name:       "term of another school",
+			concept:    &entities.SchoolConcept{ID: uuid.New(), SchoolID: uuid.New(), TermKey: "unit.level", TermValue: "Nivel"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "term without a definition",
+			concept:    &entities.SchoolConcept{ID: uuid.New(), SchoolID: schoolID, TermKey: "custom.key", TermValue: "Valor"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.source != nil {
+				tt.source.SchoolConceptID = tt.concept.ID
+			}
+			var saved []*entity.SchoolConceptSource
+			var dropped []uuid.UUID
+			conceptRepo := &mock.MockSchoolConceptRepository{
+				FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.SchoolConcept, error) { return tt.concept, nil },
+				FindSourceByConceptIDFn: func(_ context.Context, _ uuid.UUID) (*entity.SchoolConceptSource, error) {
+					return tt.source, nil
+				},
+				ResetWithSourcesFn: func(_ context.Context, _ []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, conceptIDs []uuid.UUID) error {
+					saved = sources
+					dropped = conceptIDs
+					return nil
+				},
+			}
+			defRepo := &mock.MockConceptDefinitionRepository{
+				FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) {
+					return []*entities.ConceptDefinition{def}, nil
+				},
+			}
+			schoolRepo := &mock.MockSchoolRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) {
+					return &entities.School{ID: id, ConceptTypeID: &typeID, IsActive: true}, nil
+				},
+			}
+			svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, defRepo, &mock.MockConceptCategoryRepository{}, conceptRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			result, err := svc.ResetSchoolConcept(context.Background(), schoolID, tt.concept.ID)
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Curso", result.TermValue)
+			assert.False(t, result.Overridden)
+			require.NotNil(t, result.DefaultValue)
+			assert.Equal(t, "Curso", *result.DefaultValue)
+			require.Len(t, saved, 1)
+			assert.False(t, saved[0].Overridden)
+			// Translation overrides are dropped in the same write
+			assert.Equal(t, []uuid.UUID{tt.concept.ID}, dropped)
+		})
+	}
+}
+
+func TestConceptTypeService_ResetSchoolConcepts(t *testing.T) {
+	f := newConceptSyncFixture()
+	f.schoolRepo.FindSourceByConceptIDFn = nil
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) { return f.school, nil },
+	}
+	svc := service.NewConceptTypeService(f.typeRepo, f.defRepo, &mock.MockConceptCategoryRepository{}, f.schoolRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	result, err := svc.ResetSchoolConcepts(context.Background(), f.school.ID)
+	require.NoError(t, err)
+
+	// The renamed default and the customised term are reset; the matching term is left alone
+	require.Len(t, f.updated, 2)
+	values := map[string]string{}
+	for _, r := range result {
+		values[r.TermKey] = r.TermValue
+		assert.False(t, r.Overridden, r.TermKey)
+	}
+	assert.Equal(t, map[string]string{"unit.level": "Curso", "unit.section": "Sección", "member.teacher": "Profesor"}, values)
+}
+
+func TestConceptTypeService_UpdateSchoolConcept_MarksOverridden(t *testing.T) {
+	schoolID := uuid.New()
+	concept := &entities.SchoolConcept{ID: uuid.New(), SchoolID: schoolID, TermKey: "unit.level", TermValue: "Curso"}
+	source := &entity.SchoolConceptSource{SchoolConceptID: concept.ID, SchoolID: schoolID, DefinitionID: uuid.New(), DefaultValue: "Curso"}
+	var saved []*entity.SchoolConceptSource
+	conceptRepo := &mock.MockSchoolConceptRepository{
+		FindByIDFn:              func(_ context.Context, _ uuid.UUID) (*entities.SchoolConcept, error) { return concept, nil },
+		FindSourceByConceptIDFn: func(_ context.Context, _ uuid.UUID) (*entity.SchoolConceptSource, error) { return source, nil },
+		SaveWithSourcesFn: func(_ context.Context, _, _ []*entities.SchoolConcept, sources []*entity.SchoolConceptSource) error {
+			saved = sources
+			return nil
+		},
+	}
+	svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockConceptCategoryRepository{}, conceptRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	result, err := svc.UpdateSchoolConcept(context.Background(), schoolID, concept.ID, "", &dto.UpdateSchoolConceptRequest{TermValue: "Nivel"})
+	require.NoError(t, err)
+	assert.True(t, result.Overridden)
+	require.Len(t, saved, 1)
+	assert.True(t, saved[0].Overridden)
+}
+
+func TestConceptTypeService_GetSchoolConcepts_LocaleFallback(t *testing.T) {
+	schoolID := uuid.New()
+	level := &entities.SchoolConcept{ID: uuid.New(), SchoolID: schoolID, TermKey: "unit.level", TermValue: "Curso"}
+	teacher := &entities.SchoolConcept{ID: uuid.New(), SchoolID: schoolID, TermKey: "member.teacher", TermValue: "Profesor"}
+	student := &entities.SchoolConcept{ID: uuid.New(), SchoolID: schoolID, TermKey: "member.student", TermValue: "Estudiante"}
+	levelDef, teacherDef, studentDef := uuid.New(), uuid.New(), uuid.New()
+	conceptRepo := &mock.MockSchoolConceptRepository{
+		FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.SchoolConcept, error) {
+			return []*entities.SchoolConcept{level, teacher, student}, nil
+		},
+		FindSourcesBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptSource, error) {
+			return []*entity.SchoolConceptSource{
+				{SchoolConceptID: level.ID, DefinitionID: levelDef, DefaultValue: "Curso"},
+				{SchoolConceptID: teacher.ID, DefinitionID: teacherDef, DefaultValue: "Profesor"},
+				{SchoolConceptID: student.ID, DefinitionID: studentDef, DefaultValue: "Estudiante"},
+			}, nil
+		},
+		FindTranslationsBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptTranslation, error) {
+			return []*entity.SchoolConceptTranslation{
+				{SchoolConceptID: teacher.ID, Locale: "en", TermValue: "Tutor"},
+				{SchoolConceptID: level.ID, Locale: "pt", TermValue: "Série"},
+			}, nil
+		},
+	}
+	defRepo := &mock.MockConceptDefinitionRepository{
+		FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) {
+			return []*entities.ConceptDefinition{
+				{ID: levelDef, TermKey: "unit.level", TermValue: "Curso"},
+				{ID: teacherDef, TermKey: "member.teacher", TermValue: "Profesor"},
+				{ID: studentDef, TermKey: "member.student", TermValue: "Estudiante"},
+			}, nil
+		},
+		FindTranslationsFn: func(_ context.Context, _ []uuid.UUID) ([]*entity.ConceptDefinitionTranslation, error) {
+			return []*entity.ConceptDefinitionTranslation{
+				{DefinitionID: levelDef, Locale: "en", TermValue: "Grade"},
+				{DefinitionID: teacherDef, Locale: "en", TermValue: "Teacher"},
+			}, nil
+		},
+	}
+	typeID := uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) {
+			return &entities.School{ID: id, ConceptTypeID: &typeID, IsActive: true}, nil
+		},
+	}
+	svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, defRepo, &mock.MockConceptCategoryRepository{}, conceptRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	result, err := svc.GetSchoolConcepts(context.Background(), schoolID, "en-US")
+	require.NoError(t, err)
+	byKey := map[string]dto.SchoolConceptResponse{}
+	for _, r := range result {
+		byKey[r.TermKey] = r
+	}
+
+	// Definition translation
+	assert.Equal(t, "Grade", byKey["unit.level"].TermValue)
+	assert.Equal(t, "en", byKey["unit.level"].Locale)
+	assert.False(t, byKey["unit.level"].Overridden)
+	// School override wins over the definition translation
+	assert.Equal(t, "Tutor", byKey["member.teacher"].TermValue)
+	assert.True(t, byKey["member.teacher"].Overridden)
+	require.NotNil(t, byKey["member.teacher"].DefaultValue)
+	assert.Equal(t, "Teacher", *byKey["member.teacher"].DefaultValue)
+	// No translation at all falls back to the default locale
+	assert.Equal(t, "Estudiante", byKey["member.student"].TermValue)
+	assert.Equal(t, service.DefaultLocale, byKey["member.student"].Locale)
+
+	_, err = svc.GetSchoolConcepts(context.Background(), schoolID, "fr")
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	assert.Contains(t, appErr.Fields, "locale")
+}
+
+func TestConceptTypeService_UpdateSchoolConcept_LocaleOverride(t *testing.T) {
+	schoolID := uuid.New()
+	concept := &entities.SchoolConcept{ID: uuid.New(), SchoolID: schoolID, TermKey: "unit.level", TermValue: "Curso"}
+	source := &entity.SchoolConceptSource{SchoolConceptID: concept.ID, SchoolID: schoolID, DefinitionID: uuid.New(), DefaultValue: "Curso"}
+	var translations []*entity.SchoolConceptTranslation
+	baseSaved := false
+	conceptRepo := &mock.MockSchoolConceptRepository{
+		FindByIDFn:              func(_ context.Context, _ uuid.UUID) (*entities.SchoolConcept, error) { return concept, nil },
+		FindSourceByConceptIDFn: func(_ context.Context, _ uuid.UUID) (*entity.SchoolConceptSource, error) { return source, nil },
+		SaveWithSourcesFn: func(_ context.Context, _, _ []*entities.SchoolConcept, _ []*entity.SchoolConceptSource) error {
+			baseSaved = true
+			return nil
+		},
+		SaveTranslationFn: func(_ context.Context, translation *entity.SchoolConceptTranslation) error {
+			translations = append(translations, translation)
+			return nil
+		},
+		FindTranslationsBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptTranslation, error) {
+			return translations, nil
+		},
+	}
+	svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockConceptCategoryRepository{}, conceptRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	result, err := svc.UpdateSchoolConcept(context.Background(), schoolID, concept.ID, "pt-BR", &dto.UpdateSchoolConceptRequest{TermValue: "Série"})
+	require.NoError(t, err)
+	assert.False(t, baseSaved)
+	assert.Equal(t, "Curso", concept.TermValue)
+	assert.False(t, source.Overridden)
+	require.Len(t, translations, 1)
+	assert.Equal(t, "pt", translations[0].Locale)
+	assert.Equal(t, "Série", result.TermValue)
+	assert.Equal(t, "pt", result.Locale)
+	assert.True(t, result.Overridden)
+}
+
+func TestConceptTypeService_CreateDefinition_Translations(t *testing.T) {
+	typeID := uuid.New()
+	typeRepo := &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: typeID}, nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		translations map[string]string
+		wantErr      bool
+		wantField    string
+		wantLocales  []string
+	}{
+		{name: "success", translations: map[string]string{"EN": "Grade", "pt-BR": "Série"}, wantLocales: []string{"en", "pt"}},
+		{name: "success - no translations"},
+		{name: "error - unsupported locale", translations: map[string]string{"fr": "Classe"}, wantErr: true, wantField: "translations.fr"},
+		{name: "error - default locale", translations: map[string]string{"es": "Curso"}, wantErr: true, wantField: "translations.es"},
+		{name: "error - empty value", translations: map[string]string{"en": " "}, wantErr: true, wantField: "translations.en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved []*entity.ConceptDefinitionTranslation
+			created := false
+			defRepo := &mock.MockConceptDefinitionRepository{
+				CreateFn: func(_ context.Context, _ *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error {
+					created = true
+					saved = translations
+					return nil
+				},
+			}
+			svc := service.NewConceptTypeService(typeRepo, defRepo, &mock.MockConceptCategoryRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			result, err := svc.CreateDefinition(context.Background(), typeID, &dto.ConceptDefinitionRequest{
+				TermKey: "unit.level", TermValue: "Curso", Translations: tt.translations,
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+				assert.Contains(t, appErr.Fields, tt.wantField)
+				assert.False(t, created)
+				return
+			}
+			require.NoError(t, err)
+			var locales []string
+			for _, tr := range saved {
+				locales = append(locales, tr.Locale)
+			}
+			assert.Equal(t, tt.wantLocales, locales)
+			assert.Len(t, result.Translations, len(tt.wantLocales))
+		})
+	}
+}
+
+func TestConceptTypeService_ChangeSchoolConceptType(t *testing.T) {
+	oldTypeID, newTypeID := uuid.New(), uuid.New()
+	school := &entities.School{ID: uuid.New(), ConceptTypeID: &oldTypeID, IsActive: true}
+	newDefs := []*entities.ConceptDefinition{
+		{ID: uuid.New(), ConceptTypeID: newTypeID, TermKey: "unit.level", TermValue: "Semestre"},
+		{ID: uuid.New(), ConceptTypeID: newTypeID, TermKey: "member.teacher", TermValue: "Docente"},
+		{ID: uuid.New(), ConceptTypeID: newTypeID, TermKey: "member.student", TermValue: "Alumno"},
+	}
+	level := &entities.SchoolConcept{ID: uuid.New(), SchoolID: school.ID, TermKey: "unit.level", TermValue: "Grado"}
+	teacher := &entities.SchoolConcept{ID: uuid.New(), SchoolID: school.ID, TermKey: "member.teacher", TermValue: "Maestro"}
+	section := &entities.SchoolConcept{ID: uuid.New(), SchoolID: school.ID, TermKey: "unit.section", TermValue: "Sección"}
+	oldSources := []*entity.SchoolConceptSource{
+		{SchoolConceptID: level.ID, DefinitionID: uuid.New(), DefaultValue: "Grado"},
+		{SchoolConceptID: teacher.ID, DefinitionID: uuid.New(), DefaultValue: "Profesor", Overridden: true},
+		{SchoolConceptID: section.ID, DefinitionID: uuid.New(), DefaultValue: "Sección"},
+	}
+
+	tests := []struct {
+		name          string
+		request       dto.ChangeSchoolConceptTypeRequest
+		wantActions   map[string]string
+		wantTeacher   string
+		wantPersisted bool
+	}{
+		{
+			name:    "replace everything",
+			request: dto.ChangeSchoolConceptTypeRequest{ConceptTypeID: newTypeID.String()},
+			wantActions: map[string]string{
+				"unit.level": service.SchoolConceptReplaced, "member.teacher": service.SchoolConceptReplaced,
+				"member.student": service.SchoolConceptAdded, "unit.section": service.SchoolConceptRemoved,
+			},
+			wantTeacher:   "Docente",
+			wantPersisted: true,
+		},
+		{
+			name:    "keep overrides",
+			request: dto.ChangeSchoolConceptTypeRequest{ConceptTypeID: newTypeID.String(), KeepOverrides: true},
+			wantActions: map[string]string{
+				"unit.level": service.SchoolConceptReplaced, "member.teacher": service.SchoolConceptKeptOverride,
+				"member.student": service.SchoolConceptAdded, "unit.section": service.SchoolConceptRemoved,
+			},
+			wantTeacher:   "Maestro",
+			wantPersisted: true,
+		},
+		{
+			name:    "preview only",
+			request: dto.ChangeSchoolConceptTypeRequest{ConceptTypeID: newTypeID.String(), DryRun: true},
+			wantActions: map[string]string{
+				"unit.level": service.SchoolConceptReplaced, "member.teacher": service.SchoolConceptReplaced,
+				"member.student": service.SchoolConceptAdded, "unit.section": service.SchoolConceptRemoved,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var replaced []*entities.SchoolConcept
+			persisted := false
+			conceptRepo := &mock.MockSchoolConceptRepository{
+				FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.SchoolConcept, error) {
+					return []*entities.SchoolConcept{level, teacher, section}, nil
+				},
+				FindSourcesBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptSource, error) {
+					return oldSources, nil
+				},
+				ReplaceForSchoolFn: func(_ context.Context, _, typeID uuid.UUID, concepts []*entities.SchoolConcept, _ []*entity.SchoolConceptSource, _ bool) error {
+					assert.Equal(t, newTypeID, typeID)
+					persisted = true
+					replaced = concepts
+					return nil
+				},
+			}
+			typeRepo := &mock.MockConceptTypeRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+					return &entities.ConceptType{ID: id, IsActive: true}, nil
+				},
+			}
+			defRepo := &mock.MockConceptDefinitionRepository{
+				FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) { return newDefs, nil },
+			}
+			schoolRepo := &mock.MockSchoolRepository{
+				FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) { return school, nil },
+			}
+			svc := service.NewConceptTypeService(typeRepo, defRepo, &mock.MockConceptCategoryRepository{}, conceptRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			result, err := svc.ChangeSchoolConceptType(context.Background(), school.ID, &tt.request)
+			require.NoError(t, err)
+
+			actions := map[string]string{}
+			for _, c := range result.Changes {
+				actions[c.TermKey] = c.Action
+			}
+			assert.Equal(t, tt.wantActions, actions)
+			require.NotNil(t, result.PreviousConceptTypeID)
+			assert.Equal(t, oldTypeID.String(), *result.PreviousConceptTypeID)
+			assert.Equal(t, tt.wantPersisted, persisted)
+
+			if tt.wantPersisted {
+				require.Len(t, replaced, 3)
+				// Terms present in both types keep their ID
+				assert.Equal(t, teacher.ID, replaced[1].ID)
+				assert.Equal(t, tt.wantTeacher, replaced[1].TermValue)
+			}
+		})
+	}
+}
+
+func TestConceptTypeService_ChangeSchoolConceptType_SameType(t *testing.T) {
+	typeID := uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) {
+			return &entities.School{ID: id, ConceptTypeID: &typeID, IsActive: true}, nil
+		},
+	}
+	svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockConceptCategoryRepository{}, &mock.MockSchoolConceptRepository{}, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	_, err := svc.ChangeSchoolConceptType(context.Background(), uuid.New(), &dto.ChangeSchoolConceptTypeRequest{ConceptTypeID: typeID.String()})
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
+
+func TestConceptTypeService_ChangeSchoolConceptType_InactiveType(t *testing.T) {
+	oldTypeID, newTypeID := uuid.New(), uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) {
+			return &entities.School{ID: id, ConceptTypeID: &oldTypeID, IsActive: true}, nil
+		},
+	}
+	typeRepo := &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: id, IsActive: false}, nil
+		},
+	}
+	conceptRepo := &mock.MockSchoolConceptRepository{
+		ReplaceForSchoolFn: func(_ context.Context, _, _ uuid.UUID, _ []*entities.SchoolConcept, _ []*entity.SchoolConceptSource, _ bool) error {
+			t.Fatal("an inactive concept type must not be applied")
+			return nil
+		},
+	}
+	svc := service.NewConceptTypeService(typeRepo, &mock.MockConceptDefinitionRepository{}, &mock.MockConceptCategoryRepository{}, conceptRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	_, err := svc.ChangeSchoolConceptType(context.Background(), uuid.New(), &dto.ChangeSchoolConceptTypeRequest{ConceptTypeID: newTypeID.String()})
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
+
+func TestConceptTypeService_ImportConceptPack(t *testing.T) {
+	ct := &entities.ConceptType{ID: uuid.New(), Code: "school", Name: "Colegio"}
+	level := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: ct.ID, TermKey: "unit.level", TermValue: "Curso", Category: "units"}
+	teacher := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: ct.ID, TermKey: "member.teacher", TermValue: "Profesor", Category: "members"}
+	guardian := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: ct.ID, TermKey: "member.guardian", TermValue: "Apoderado", Category: "members"}
+	pack := &dto.ConceptPack{
+		Version:     service.ConceptPackVersion,
+		ConceptType: dto.ConceptPackType{Code: "school", Name: "Colegio"},
+		Definitions: []dto.ConceptPackDefinition{
+			{TermKey: "unit.level", TermValue: "Curso", Category: "units"},
+			{TermKey: "member.teacher", TermValue: "Docente", Category: "members", Translations: map[string]string{"en": "Teacher"}},
+			{TermKey: "member.student", TermValue: "Estudiante", Category: "members"},
+			{TermKey: "unit.section", TermValue: "Sección"},
+			{TermKey: "unit.section", TermValue: "Paralelo"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		existing   *entities.ConceptType
+		dryRun     bool
+		wantAction string
+		wantSaved  bool
+		wantTerms  map[string]string
+	}{
+		{
+			name: "success - upsert existing type", existing: ct, wantAction: service.ConceptPackUpdated, wantSaved: true,
+			wantTerms: map[string]string{
+				"unit.level": service.ConceptPackUnchanged, "member.teacher": service.ConceptPackUpdated,
+				"member.student": service.ConceptPackCreated, "unit.section": service.ConceptPackConflict,
+				"member.guardian": service.ConceptPackConflict,
+			},
+		},
+		{
+			name: "success - dry run", existing: ct, dryRun: true, wantAction: service.ConceptPackUpdated,
+			wantTerms: map[string]string{
+				"unit.level": service.ConceptPackUnchanged, "member.teacher": service.ConceptPackUpdated,
+				"member.student": service.ConceptPackCreated, "unit.section": service.ConceptPackConflict,
+				"member.guardian": service.ConceptPackConflict,
+			},
+		},
+		{
+			name: "success - new type", wantAction: service.ConceptPackCreated, wantSaved: true,
+			wantTerms: map[string]string{
+				"unit.level": service.ConceptPackCreated, "member.teacher": service.ConceptPackCreated,
+				"member.student": service.ConceptPackCreated, "unit.section": service.ConceptPackConflict,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Fresh copies so updates from one case do not leak into the next
+			defs := []*entities.ConceptDefinition{}
+			for _, d := range []*entities.ConceptDefinition{level, teacher, guardian} {
+				copied := *d
+				defs = append(defs, &copied)
+			}
+			var saved *repository.ConceptPackChanges
+			typeRepo := &mock.MockConceptTypeRepository{
+				FindByCodeFn: func(_ context.Context, _ string) (*entities.ConceptType, error) {
+					if tt.existing == nil {
+						return nil, nil
+					}
+					copied := *tt.existing
+					return &copied, nil
+				},
+				ImportFn: func(_ context.Context, changes *repository.ConceptPackChanges) error {
+					saved = changes
+					return nil
+				},
+			}
+			defRepo := &mock.MockConceptDefinitionRepository{
+				FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) { return defs, nil },
+			}
+			svc := service.NewConceptTypeService(typeRepo, defRepo, conceptCatalog("units", "members"), &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			result, err := svc.ImportConceptPack(context.Background(), pack, tt.dryRun)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAction, result.Action)
+			terms := map[string]string{}
+			conflicts := 0
+			for _, d := range result.Definitions {
+				terms[d.TermKey] = d.Action
+				if d.Action == service.ConceptPackConflict {
+					conflicts++
+				}
+			}
+			assert.Equal(t, tt.wantTerms, terms)
+			assert.Equal(t, conflicts, result.Conflicts)
+
+			if !tt.wantSaved {
+				assert.Nil(t, saved)
+				return
+			}
+			require.NotNil(t, saved)
+			assert.Equal(t, tt.existing == nil, saved.NewType)
+			if tt.existing != nil {
+				require.Len(t, saved.Created, 1)
+				require.Len(t, saved.Updated, 1)
+				assert.Equal(t, "Docente", saved.Updated[0].TermValue)
+				assert.Len(t, saved.Translations[teacher.ID], 1)
+			}
+		})
+	}
+}
+
+func TestConceptTypeService_ImportConceptPack_UnsupportedVersion(t *testing.T) {
+	svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockConceptCategoryRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	_, err := svc.ImportConceptPack(context.Background(), &dto.ConceptPack{Version: 99, ConceptType: dto.ConceptPackType{Code: "school", Name: "Colegio"}}, false)
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	assert.Contains(t, appErr.Fields, "version")
+}
+
+func TestConceptTypeService_DeleteConceptType(t *testing.T) {
+	typeID, targetID := uuid.New(), uuid.New()
+	school := &entities.School{ID: uuid.New(), Name: "Colegio Andino", ConceptTypeID: &typeID, IsActive: true}
+	level := &entities.SchoolConcept{ID: uuid.New(), SchoolID: school.ID, TermKey: "unit.level", TermValue: "Grado"}
+	targetDefs := []*entities.ConceptDefinition{
+		{ID: uuid.New(), ConceptTypeID: targetID, TermKey: "unit.level", TermValue: "Semestre"},
+		{ID: uuid.New(), ConceptTypeID: targetID, TermKey: "member.student", TermValue: "Alumno"},
+	}
+	missing, inactive := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		schools      []*entities.School
+		reassignTo   *uuid.UUID
+		wantErr      bool
+		wantField    string
+		wantDeleted  bool
+		wantMigrated int
+	}{
+		{name: "success - unused type", wantDeleted: true},
+		{name: "success - reassign schools", schools: []*entities.School{school}, reassignTo: &targetID, wantDeleted: true, wantMigrated: 1},
+		{name: "error - in use", schools: []*entities.School{school}, wantErr: true, wantField: "reassign_to"},
+		{name: "error - reassign to itself", schools: []*entities.School{school}, reassignTo: &typeID, wantErr: true, wantField: "reassign_to"},
+		{name: "error - reassign to missing type", schools: []*entities.School{school}, reassignTo: &missing, wantErr: true, wantField: "reassign_to"},
+		{name: "error - reassign to inactive type", schools: []*entities.School{school}, reassignTo: &inactive, wantErr: true, wantField: "reassign_to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			var migrations []*repository.SchoolConceptMigration
+			typeRepo := &mock.MockConceptTypeRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+					if id == missing {
+						return nil, nil
+					}
+					return &entities.ConceptType{ID: id, IsActive: id != inactive}, nil
+				},
+				// The repository hands over the schools it read under the lock
+				ReassignAndDeleteFn: func(_ context.Context, _ uuid.UUID, target *uuid.UUID, plan func([]*entities.School) ([]*repository.SchoolConceptMigration, error)) error {
+					m, err := plan(tt.schools)
+					if err != nil {
+						return err
+					}
+					if target != nil {
+						assert.Equal(t, targetID, *target)
+						migrations = m
+					}
+					deleted = true
+					return nil
+				},
+			}
+			defRepo := &mock.MockConceptDefinitionRepository{
+				FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) { return targetDefs, nil },
+			}
+			conceptRepo := &mock.MockSchoolConceptRepository{
+				FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.SchoolConcept, error) {
+					return []*entities.SchoolConcept{level}, nil
+				},
+				FindSourcesBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptSource, error) {
+					return []*entity.SchoolConceptSource{{SchoolConceptID: level.ID, DefinitionID: uuid.New(), DefaultValue: "Curso"}}, nil
+				},
+			}
+			svc := service.NewConceptTypeService(typeRepo, defRepo, &mock.MockConceptCategoryRepository{}, conceptRepo, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			err := svc.DeleteConceptType(context.Background(), typeID, tt.reassignTo)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+				assert.Contains(t, appErr.Fields, tt.wantField)
+				assert.False(t, deleted)
+				assert.Nil(t, migrations)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDeleted, deleted)
+			require.Len(t, migrations, tt.wantMigrated)
+			if tt.wantMigrated > 0 {
+				// The customised level keeps its value and ID; the new key is seeded
+				values := map[string]string{}
+				for _, c := range migrations[0].Concepts {
+					values[c.TermKey] = c.TermValue
+				}
+				assert.Equal(t, map[string]string{"unit.level": "Grado", "member.student": "Alumno"}, values)
+				assert.Equal(t, level.ID, migrations[0].Concepts[0].ID)
+			}
+		})
+	}
+}
+
+// publishedVersion mocks a concept type whose latest published version holds
+// the given definitions
+func publishedVersion(defRepo *mock.MockConceptDefinitionRepository, typeID uuid.UUID, number int, defs ...*entities.ConceptDefinition) *entity.ConceptTypeVersion {
+	version := &entity.ConceptTypeVersion{ID: uuid.New(), ConceptTypeID: typeID, Version: number}
+	defRepo.FindLatestVersionFn = func(_ context.Context, _ uuid.UUID) (*entity.ConceptTypeVersion, error) {
+		return version, nil
+	}
+	defRepo.FindVersionDefinitionsFn = func(_ context.Context, _ uuid.UUID) ([]*entity.ConceptVersionDefinition, error) {
+		snapshots := make([]*entity.ConceptVersionDefinition, len(defs))
+		for i, def := range defs {
+			snapshots[i] = &entity.ConceptVersionDefinition{
+				VersionID: version.ID, DefinitionID: def.ID, TermKey: def.TermKey,
+				TermValue: def.TermValue, Category: def.Category, SortOrder: def.SortOrder,
+			}
+		}
+		return snapshots, nil
+	}
+	return version
+}
+
+func TestConceptTypeService_PublishDefinitions(t *testing.T) {
+	typeID := uuid.New()
+	level := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "unit.level", TermValue: "Curso", Category: "units"}
+	teacher := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "member.teacher", TermValue: "Profesor", Category: "members"}
+	renamed := *level
+	renamed.TermValue = "Grado"
+
+	tests := []struct {
+		name        string
+		published   []*entities.ConceptDefinition
+		latest      int
+		wantVersion int
+		wantStatus  int
+	}{
+		{name: "first publish", wantVersion: 1},
+		{name: "draft changed since the latest version", published: []*entities.ConceptDefinition{&renamed, teacher}, latest: 2, wantVersion: 3},
+		{name: "draft without changes", published: []*entities.ConceptDefinition{level, teacher}, latest: 2, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var version *entity.ConceptTypeVersion
+			var snapshots []*entity.ConceptVersionDefinition
+			var translations []*entity.ConceptVersionTranslation
+			defRepo := &mock.MockConceptDefinitionRepository{
+				FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) {
+					return []*entities.ConceptDefinition{level, teacher}, nil
+				},
+				FindTranslationsFn: func(_ context.Context, _ []uuid.UUID) ([]*entity.ConceptDefinitionTranslation, error) {
+					return []*entity.ConceptDefinitionTranslation{{DefinitionID: level.ID, Locale: "en", TermValue: "Grade"}}, nil
+				},
+				PublishFn: func(_ context.Context, v *entity.ConceptTypeVersion, defs []*entity.ConceptVersionDefinition, ts []*entity.ConceptVersionTranslation) error {
+					version, snapshots, translations = v, defs, ts
+					return nil
+				},
+			}
+			if tt.latest > 0 {
+				published := publishedVersion(defRepo, typeID, tt.latest, tt.published...)
+				defRepo.FindVersionTranslationsFn = func(_ context.Context, _ uuid.UUID) ([]*entity.ConceptVersionTranslation, error) {
+					return []*entity.ConceptVersionTranslation{{VersionID: published.ID, DefinitionID: level.ID, Locale: "en", TermValue: "Grade"}}, nil
+				}
+			}
+			typeRepo := &mock.MockConceptTypeRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+					return &entities.ConceptType{ID: id, IsActive: true}, nil
+				},
+			}
+			svc := service.NewConceptTypeService(typeRepo, defRepo, &mock.MockConceptCategoryRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			result, err := svc.PublishDefinitions(context.Background(), typeID, &dto.PublishConceptDefinitionsRequest{Notes: "Renamed levels"})
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				assert.Nil(t, version)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, result.Version)
+			assert.Equal(t, "Renamed levels", result.Notes)
+			require.Len(t, result.Definitions, 2)
+			require.NotNil(t, version)
+			assert.Equal(t, tt.wantVersion, version.Version)
+			require.Len(t, snapshots, 2)
+			assert.Equal(t, "Curso", snapshots[0].TermValue)
+			assert.Equal(t, level.ID, snapshots[0].DefinitionID)
+			require.Len(t, translations, 1)
+			assert.Equal(t, "Grade", translations[0].TermValue)
+		})
+	}
+}
+
+func TestConceptTypeService_DiffDraft(t *testing.T) {
+	typeID := uuid.New()
+	level := &entities.ConceptDefinition{ID: uuid.New(), TermKey: "unit.level", TermValue: "Curso", Category: "units"}
+	teacher := &entities.ConceptDefinition{ID: uuid.New(), TermKey: "member.teacher", TermValue: "Profesor", Category: "members"}
+	student := &entities.ConceptDefinition{ID: uuid.New(), TermKey: "member.student", TermValue: "Estudiante", Category: "members"}
+	section := &entities.ConceptDefinition{ID: uuid.New(), TermKey: "unit.section", TermValue: "Sección", Category: "units"}
+	oldLevel := *level
+	oldLevel.TermValue = "Grado"
+
+	defRepo := &mock.MockConceptDefinitionRepository{
+		FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) {
+			return []*entities.ConceptDefinition{level, teacher, student}, nil
+		},
+	}
+	publishedVersion(defRepo, typeID, 4, &oldLevel, teacher, section)
+	typeRepo := &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: id, IsActive: true}, nil
+		},
+	}
+	svc := service.NewConceptTypeService(typeRepo, defRepo, &mock.MockConceptCategoryRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	diff, err := svc.DiffDraft(context.Background(), typeID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, diff.PublishedVersion)
+	assert.True(t, diff.HasChanges)
+	byKey := map[string]dto.ConceptDraftDiffEntry{}
+	for _, e := range diff.Entries {
+		byKey[e.TermKey] = e
+	}
+	assert.Equal(t, service.ConceptDraftChanged, byKey["unit.level"].Status)
+	assert.Equal(t, []string{"term_value"}, byKey["unit.level"].ChangedFields)
+	assert.Equal(t, "Grado", byKey["unit.level"].PublishedValue)
+	assert.Equal(t, "Curso", byKey["unit.level"].DraftValue)
+	assert.Equal(t, service.ConceptDraftUnchanged, byKey["member.teacher"].Status)
+	assert.Equal(t, service.ConceptDraftAdded, byKey["member.student"].Status)
+	assert.Equal(t, service.ConceptDraftRemoved, byKey["unit.section"].Status)
+}
+
+func TestConceptTypeService_SyncDefinitions_UsesPublishedVersion(t *testing.T) {
+	f := newConceptSyncFixture()
+	// The draft renamed unit.level again but only "Curso" was published
+	draft, err := f.defRepo.FindByTypeID(context.Background(), f.typeID)
+	require.NoError(t, err)
+	published := make([]*entities.ConceptDefinition, len(draft))
+	for i, def := range draft {
+		copied := *def
+		published[i] = &copied
+	}
+	draft[0].TermValue = "Nivel"
+	publishedVersion(f.defRepo, f.typeID, 3, published...)
+
+	result, err := f.service().SyncDefinitions(context.Background(), f.typeID, &dto.SyncConceptDefinitionsRequest{UpdateDefaults: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Version)
+	require.Len(t, f.updated, 1)
+	assert.Equal(t, "Curso", f.updated[0].TermValue)
+	require.NotEmpty(t, f.sources)
+	for _, source := range f.sources {
+		assert.Equal(t, 3, source.Version)
+	}
+}
+
+func TestConceptTypeService_CreateDefinition_Validation(t *testing.T) {
+	typeID := uuid.New()
+	existing := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "unit.level", TermValue: "Curso", Category: "units"}
+
+	tests := []struct {
+		name         string
+		request      dto.ConceptDefinitionRequest
+		wantStatus   int
+		wantField    string
+		wantCategory string
+	}{
+		{name: "success - catalog category", request: dto.ConceptDefinitionRequest{TermKey: "unit.section", TermValue: "Sección", Category: "units"}, wantCategory: "units"},
+		{name: "success - default category", request: dto.ConceptDefinitionRequest{TermKey: " member.tutor ", TermValue: "Tutor"}, wantCategory: service.DefaultConceptCategory},
+		{name: "term key without segments", request: dto.ConceptDefinitionRequest{TermKey: "level", TermValue: "Curso"}, wantStatus: http.StatusBadRequest, wantField: "term_key"},
+		{name: "term key with uppercase", request: dto.ConceptDefinitionRequest{TermKey: "Unit.Level", TermValue: "Curso"}, wantStatus: http.StatusBadRequest, wantField: "term_key"},
+		{name: "unknown category", request: dto.ConceptDefinitionRequest{TermKey: "unit.section", TermValue: "Sección", Category: "rooms"}, wantStatus: http.StatusBadRequest, wantField: "category"},
+		{name: "duplicate term key", request: dto.ConceptDefinitionRequest{TermKey: "unit.level", TermValue: "Grado"}, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *entities.ConceptDefinition
+			typeRepo := &mock.MockConceptTypeRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+					return &entities.ConceptType{ID: id, IsActive: true}, nil
+				},
+			}
+			defRepo := &mock.MockConceptDefinitionRepository{
+				FindByTermKeyFn: func(_ context.Context, _ uuid.UUID, key string) (*entities.ConceptDefinition, error) {
+					if key == existing.TermKey {
+						return existing, nil
+					}
+					return nil, nil
+				},
+				CreateFn: func(_ context.Context, def *entities.ConceptDefinition, _ []*entity.ConceptDefinitionTranslation) error {
+					created = def
+					return nil
+				},
+			}
+			svc := service.NewConceptTypeService(typeRepo, defRepo, conceptCatalog("units", "members"), &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			result, err := svc.CreateDefinition(context.Background(), typeID, &tt.request)
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				if tt.wantField != "" {
+					assert.Contains(t, appErr.Fields, tt.wantField)
+				}
+				assert.Nil(t, created)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCategory, result.Category)
+			require.NotNil(t, created)
+			assert.Equal(t, strings.TrimSpace(tt.request.TermKey), created.TermKey)
+		})
+	}
+}
+
+func TestConceptTypeService_UpdateDefinition_DuplicateTermKey(t *testing.T) {
+	typeID := uuid.New()
+	level := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "unit.level", TermValue: "Curso", Category: "units"}
+	section := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "unit.section", TermValue: "Sección", Category: "units"}
+	typeRepo := &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: id, IsActive: true}, nil
+		},
+	}
+	defRepo := &mock.MockConceptDefinitionRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.ConceptDefinition, error) {
+			copied := *section
+			return &copied, nil
+		},
+		FindByTermKeyFn: func(_ context.Context, _ uuid.UUID, key string) (*entities.ConceptDefinition, error) {
+			for _, def := range []*entities.ConceptDefinition{level, section} {
+				if def.TermKey == key {
+					return def, nil
+				}
+			}
+			return nil, nil
+		},
+	}
+	svc := service.NewConceptTypeService(typeRepo, defRepo, conceptCatalog("units"), &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	_, err := svc.UpdateDefinition(context.Background(), typeID, section.ID, &dto.ConceptDefinitionRequest{TermKey: "unit.level", TermValue: "Sección"})
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+
+	// Keeping its own key is not a conflict
+	result, err := svc.UpdateDefinition(context.Background(), typeID, section.ID, &dto.ConceptDefinitionRequest{TermKey: "unit.section", TermValue: "Paralelo"})
+	require.NoError(t, err)
+	assert.Equal(t, "Paralelo", result.TermValue)
+	assert.Equal(t, "units", result.Category)
+}
+
+func TestConceptTypeService_UpdateDefinition_LegacyValues(t *testing.T) {
+	typeID := uuid.New()
+	legacy := &entities.ConceptDefinition{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "Legacy_Term", TermValue: "Antiguo", Category: "legacy"}
+	typeRepo := &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: id, IsActive: true}, nil
+		},
+	}
+	defRepo := &mock.MockConceptDefinitionRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.ConceptDefinition, error) {
+			copied := *legacy
+			return &copied, nil
+		},
+	}
+	svc := service.NewConceptTypeService(typeRepo, defRepo, conceptCatalog("units"), &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	// Unchanged key and category are not revalidated
+	result, err := svc.UpdateDefinition(context.Background(), typeID, legacy.ID, &dto.ConceptDefinitionRequest{TermKey: "Legacy_Term", TermValue: "Nuevo", Category: "legacy"})
+	require.NoError(t, err)
+	assert.Equal(t, "Nuevo", result.TermValue)
+	assert.Equal(t, "legacy", result.Category)
+
+	// Changing either one still is
+	_, err = svc.UpdateDefinition(context.Background(), typeID, legacy.ID, &dto.ConceptDefinitionRequest{TermKey: "Other_Term", TermValue: "Nuevo"})
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Contains(t, appErr.Fields, "term_key")
+
+	_, err = svc.UpdateDefinition(context.Background(), typeID, legacy.ID, &dto.ConceptDefinitionRequest{TermKey: "Legacy_Term", TermValue: "Nuevo", Category: "other"})
+	require.Error(t, err)
+	appErr, ok = errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Contains(t, appErr.Fields, "category")
+}
+
+func TestConceptTypeService_ListDefinitionGroups(t *testing.T) {
+	typeID := uuid.New()
+	defs := []*entities.ConceptDefinition{
+		{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "member.student", TermValue: "Estudiante", Category: "members", SortOrder: 2},
+		{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "unit.level", TermValue: "Curso", Category: "units", SortOrder: 1},
+		{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "member.teacher", TermValue: "Profesor", Category: "members", SortOrder: 1},
+		{ID: uuid.New(), ConceptTypeID: typeID, TermKey: "legacy.term", TermValue: "Antiguo", Category: "legacy"},
+	}
+	typeRepo := &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: id, IsActive: true}, nil
+		},
+	}
+	defRepo := &mock.MockConceptDefinitionRepository{
+		FindByTypeIDFn: func(_ context.Context, _ uuid.UUID) ([]*entities.ConceptDefinition, error) { return defs, nil },
+	}
+	svc := service.NewConceptTypeService(typeRepo, defRepo, conceptCatalog("units", "members"), &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	groups, err := svc.ListDefinitionGroups(context.Background(), typeID)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+	assert.Equal(t, "units", groups[0].Category)
+	assert.Equal(t, "members", groups[1].Category)
+	assert.Equal(t, "Category members", groups[1].Name)
+	require.Len(t, groups[1].Definitions, 2)
+	assert.Equal(t, "member.teacher", groups[1].Definitions[0].TermKey)
+	assert.Equal(t, "member.student", groups[1].Definitions[1].TermKey)
+	// Categories missing from the catalog come last under their code
+	assert.Equal(t, "legacy", groups[2].Category)
+	assert.Equal(t, "legacy", groups[2].Name)
+}
+
+func TestConceptTypeService_ImportConceptPack_InvalidDefinitions(t *testing.T) {
+	pack := &dto.ConceptPack{
+		Version:     service.ConceptPackVersion,
+		ConceptType: dto.ConceptPackType{Code: "school", Name: "Colegio"},
+		Definitions: []dto.ConceptPackDefinition{
+			{TermKey: "unit.level", TermValue: "Curso", Category: "units"},
+			{TermKey: "Unit Section", TermValue: "Sección", Category: "units"},
+			{TermKey: "room.lab", TermValue: "Laboratorio", Category: "rooms"},
+		},
+	}
+	svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, conceptCatalog("units"), &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	result, err := svc.ImportConceptPack(context.Background(), pack, true)
+	require.NoError(t, err)
+	reasons := map[string]string{}
+	for _, d := range result.Definitions {
+		reasons[d.TermKey] = d.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"unit.level":   "",
+		"Unit Section": service.ConceptPackInvalidTermKey,
+		"room.lab":     service.ConceptPackUnknownCategory,
+	}, reasons)
+	assert.Equal(t, 2, result.Conflicts)
+}
+
+func TestConceptTypeService_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        string
+		inUse       int64
+		wantStatus  int
+		wantDeleted bool
+	}{
+		{name: "success", code: "units", wantDeleted: true},
+		{name: "used by definitions", code: "units", inUse: 3, wantStatus: http.StatusBadRequest},
+		{name: "default category", code: service.DefaultConceptCategory, wantStatus: http.StatusBadRequest},
+		{name: "not found", code: "rooms", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			catalog := conceptCatalog("units", service.DefaultConceptCategory)
+			catalog.CountDefinitionsFn = func(_ context.Context, _ string) (int64, error) { return tt.inUse, nil }
+			catalog.DeleteFn = func(_ context.Context, _ string) error {
+				deleted = true
+				return nil
+			}
+			svc := service.NewConceptTypeService(&mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, catalog, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			err := svc.DeleteCategory(context.Background(), tt.code)
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// Statuses reported when diffing the draft definitions against the latest
+// published version
+const (
+	ConceptDraftAdded     = "added"
+	ConceptDraftChanged   = "changed"
+	ConceptDraftRemoved   = "removed"
+	ConceptDraftUnchanged = "unchanged"
+)
+
+// definitionSet is a concept type's definitions as of one version, with their
+// translations indexed by definition ID and locale. Version is 0 for the draft.
+type definitionSet struct {
+	Version      int
+	Definitions  []*entities.ConceptDefinition
+	Translations map[uuid.UUID]map[string]string
+}
+
+// byKey indexes the definitions by term key
+func (d *definitionSet) byKey() map[string]*entities.ConceptDefinition {
+	byKey := make(map[string]*entities.ConceptDefinition, len(d.Definitions))
+	for _, def := range d.Definitions {
+		byKey[def.TermKey] = def
+	}
+	return byKey
+}
+
+// byID indexes the definitions by ID
+func (d *definitionSet) byID() map[uuid.UUID]*entities.ConceptDefinition {
+	byID := make(map[uuid.UUID]*entities.ConceptDefinition, len(d.Definitions))
+	for _, def := range d.Definitions {
+		byID[def.ID] = def
+	}
+	return byID
+}
+
+// loadPublishedDefinitions returns the definitions schools are seeded from:
+// the latest published version of the type or, when it was never published,
+// its draft so that unversioned types keep working.
+func loadPublishedDefinitions(ctx context.Context, repo repository.ConceptDefinitionRepository, typeID uuid.UUID) (*definitionSet, error) {
+	version, err := repo.FindLatestVersion(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type version", err)
+	}
+	if version != nil {
+		return loadVersionDefinitions(ctx, repo, typeID, version)
+	}
+	return loadDraftDefinitions(ctx, repo, typeID)
+}
+
+// loadDraftDefinitions returns the definitions currently being edited
+func loadDraftDefinitions(ctx context.Context, repo repository.ConceptDefinitionRepository, typeID uuid.UUID) (*definitionSet, error) {
+	defs, err := repo.FindByTypeID(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept definitions", err)
+	}
+	ids := make([]uuid.UUID, len(defs))
+	for i, def := range defs {
+		ids[i] = def.ID
+	}
+	translations, err := repo.FindTranslations(ctx, ids)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept definition translations", err)
+	}
+	set := &definitionSet{Definitions: defs, Translations: make(map[uuid.UUID]map[string]string, len(defs))}
+	for _, t := range translations {
+		set.addTranslation(t.DefinitionID, t.Locale, t.TermValue)
+	}
+	return set, nil
+}
+
+// loadVersionDefinitions rebuilds the definitions as they were published in a version
+func loadVersionDefinitions(ctx context.Context, repo repository.ConceptDefinitionRepository, typeID uuid.UUID, version *entity.ConceptTypeVersion) (*definitionSet, error) {
+	snapshots, err := repo.FindVersionDefinitions(ctx, version.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept version definitions", err)
+	}
+	translations, err := repo.FindVersionTranslations(ctx, version.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept version translations", err)
+	}
+	set := &definitionSet{
+		Version:      version.Version,
+		Definitions:  make([]*entities.ConceptDefinition, len(snapshots)),
+		Translations: make(map[uuid.UUID]map[string]string, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		set.Definitions[i] = &entities.ConceptDefinition{
+			ID:            snapshot.DefinitionID,
+			ConceptTypeID: typeID,
+			TermKey:       snapshot.TermKey,
+			TermValue:     snapshot.TermValue,
+			Category:      snapshot.Category,
+			SortOrder:     snapshot.SortOrder,
+			CreatedAt:     version.PublishedAt,
+			UpdatedAt:     version.PublishedAt,
+		}
+	}
+	for _, t := range translations {
+		set.addTranslation(t.DefinitionID, t.Locale, t.TermValue)
+	}
+	return set, nil
+}
+
+// addTranslation records a definition's value in a locale
+func (d *definitionSet) addTranslation(definitionID uuid.UUID, locale, value string) {
+	if d.Translations[definitionID] == nil {
+		d.Translations[definitionID] = map[string]string{}
+	}
+	d.Translations[definitionID][locale] = value
+}
+
+// responses converts the definitions to API responses with their translations
+func (d *definitionSet) responses() []dto.ConceptDefinitionResponse {
+	responses := dto.ToConceptDefinitionResponseList(d.Definitions)
+	for i, def := range d.Definitions {
+		responses[i].Translations = d.Translations[def.ID]
+	}
+	return responses
+}
+
+// ListVersions lists the published versions of a concept type, newest first
+func (s *conceptTypeService) ListVersions(ctx context.Context, typeID uuid.UUID) ([]dto.ConceptTypeVersionResponse, error) {
+	if _, err := s.findConceptType(ctx, typeID); err != nil {
+		return nil, err
+	}
+	versions, err := s.conceptDefRepo.FindVersions(ctx, typeID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list concept type versions", err)
+	}
+	responses := make([]dto.ConceptTypeVersionResponse, len(versions))
+	for i, v := range versions {
+		responses[i] = dto.ToConceptTypeVersionResponse(v)
+	}
+	return responses, nil
+}
+
+// GetVersion returns a published version with its definitions
+func (s *conceptTypeService) GetVersion(ctx context.Context, typeID uuid.UUID, version int) (*dto.ConceptTypeVersionDetailResponse, error) {
+	if _, err := s.findConceptType(ctx, typeID); err != nil {
+		return nil, err
+	}
+	v, err := s.conceptDefRepo.FindVersion(ctx, typeID, version)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type version", err)
+	}
+	if v == nil {
+		return nil, errors.NewNotFoundError("concept_type_version")
+	}
+	set, err := loadVersionDefinitions(ctx, s.conceptDefRepo, typeID, v)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ConceptTypeVersionDetailResponse{
+		ConceptTypeVersionResponse: dto.ToConceptTypeVersionResponse(v),
+		Definitions:                set.responses(),
+	}, nil
+}
+
+// DiffDraft compares the draft definitions with the latest published version
+func (s *conceptTypeService) DiffDraft(ctx context.Context, typeID uuid.UUID) (*dto.ConceptDraftDiffResponse, error) {
+	if _, err := s.findConceptType(ctx, typeID); err != nil {
+		return nil, err
+	}
+	draft, published, err := s.draftAndPublished(ctx, typeID)
+	if err != nil {
+		return nil, err
+	}
+	return diffDefinitionSets(typeID, draft, published), nil
+}
+
+// PublishDefinitions snapshots the draft as the next version of the concept
+// type. Publishing is rejected when the draft has no changes.
+func (s *conceptTypeService) PublishDefinitions(ctx context.Context, typeID uuid.UUID, req *dto.PublishConceptDefinitionsRequest) (*dto.ConceptTypeVersionDetailResponse, error) {
+	if _, err := s.findConceptType(ctx, typeID); err != nil {
+		return nil, err
+	}
+	draft, published, err := s.draftAndPublished(ctx, typeID)
+	if err != nil {
+		return nil, err
+	}
+	diff := diffDefinitionSets(typeID, draft, published)
+	if !diff.HasChanges {
+		if published.Version == 0 {
+			return nil, errors.NewValidationError("concept type has no definitions to publish")
+		}
+		return nil, errors.NewValidationError(fmt.Sprintf("draft has no changes since version %d", published.Version))
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	version := &entity.ConceptTypeVersion{
+		ID:            uuid.New(),
+		ConceptTypeID: typeID,
+		Version:       published.Version + 1,
+		PublishedAt:   time.Now(),
+	}
+	if req.Notes != "" {
+		version.Notes = &req.Notes
+	}
+	if actorID != "" {
+		version.PublishedBy = &actorID
+	}
+	snapshots := make([]*entity.ConceptVersionDefinition, len(draft.Definitions))
+	var translations []*entity.ConceptVersionTranslation
+	for i, def := range draft.Definitions {
+		snapshots[i] = &entity.ConceptVersionDefinition{
+			VersionID:    version.ID,
+			DefinitionID: def.ID,
+			TermKey:      def.TermKey,
+			TermValue:    def.TermValue,
+			Category:     def.Category,
+			SortOrder:    def.SortOrder,
+		}
+		for locale, value := range draft.Translations[def.ID] {
+			translations = append(translations, &entity.ConceptVersionTranslation{
+				VersionID: version.ID, DefinitionID: def.ID, Locale: locale, TermValue: value,
+			})
+		}
+	}
+
+	if err := s.conceptDefRepo.Publish(ctx, version, snapshots, translations); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "publish", ResourceType: "concept_type", ResourceID: typeID.String(),
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("publish concept definitions", err)
+	}
+
+	s.logger.Info("concept definitions published", "concept_type_id", typeID.String(), "version", version.Version, "definitions", len(snapshots))
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "publish", ResourceType: "concept_type", ResourceID: typeID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{"version": version.Version, "definitions": len(snapshots)},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return &dto.ConceptTypeVersionDetailResponse{
+		ConceptTypeVersionResponse: dto.ToConceptTypeVersionResponse(version),
+		Definitions:                draft.responses(),
+	}, nil
+}
+
+// findConceptType loads a concept type, active or not, or fails with not found
+func (s *conceptTypeService) findConceptType(ctx context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+	ct, err := s.conceptTypeRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	if ct == nil {
+		return nil, errors.NewNotFoundError("concept_type")
+	}
+	return ct, nil
+}
+
+// draftAndPublished loads the draft and the latest published version, which
+// is an empty set with Version 0 when the type was never published
+func (s *conceptTypeService) draftAndPublished(ctx context.Context, typeID uuid.UUID) (*definitionSet, *definitionSet, error) {
+	draft, err := loadDraftDefinitions(ctx, s.conceptDefRepo, typeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	latest, err := s.conceptDefRepo.FindLatestVersion(ctx, typeID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("find concept type version", err)
+	}
+	if latest == nil {
+		return draft, &definitionSet{}, nil
+	}
+	published, err := loadVersionDefinitions(ctx, s.conceptDefRepo, typeID, latest)
+	if err != nil {
+		return nil, nil, err
+	}
+	return draft, published, nil
+}
+
+// diffDefinitionSets compares draft definitions with published ones by term key
+func diffDefinitionSets(typeID uuid.UUID, draft, published *definitionSet) *dto.ConceptDraftDiffResponse {
+	response := &dto.ConceptDraftDiffResponse{
+		ConceptTypeID:    typeID.String(),
+		PublishedVersion: published.Version,
+		Entries:          make([]dto.ConceptDraftDiffEntry, 0, len(draft.Definitions)),
+	}
+	publishedByKey := published.byKey()
+	inDraft := make(map[string]bool, len(draft.Definitions))
+	for _, def := range draft.Definitions {
+		inDraft[def.TermKey] = true
+		entry := dto.ConceptDraftDiffEntry{TermKey: def.TermKey, DraftValue: def.TermValue}
+		old, ok := publishedByKey[def.TermKey]
+		if !ok {
+			entry.Status = ConceptDraftAdded
+		} else {
+			entry.PublishedValue = old.TermValue
+			if old.TermValue != def.TermValue {
+				entry.ChangedFields = append(entry.ChangedFields, "term_value")
+			}
+			if old.Category != def.Category {
+				entry.ChangedFields = append(entry.ChangedFields, "category")
+			}
+			if old.SortOrder != def.SortOrder {
+				entry.ChangedFields = append(entry.ChangedFields, "sort_order")
+			}
+			if !sameTranslationMaps(published.Translations[old.ID], draft.Translations[def.ID]) {
+				entry.ChangedFields = append(entry.ChangedFields, "translations")
+			}
+			entry.Status = ConceptDraftUnchanged
+			if len(entry.ChangedFields) > 0 {
+				entry.Status = ConceptDraftChanged
+			}
+		}
+		response.Entries = append(response.Entries, entry)
+	}
+	for _, old := range published.Definitions {
+		if !inDraft[old.TermKey] {
+			response.Entries = append(response.Entries, dto.ConceptDraftDiffEntry{
+				TermKey: old.TermKey, Status: ConceptDraftRemoved, PublishedValue: old.TermValue,
+			})
+		}
+	}
+	for _, entry := range response.Entries {
+		if entry.Status != ConceptDraftUnchanged {
+			response.HasChanges = true
+			break
+		}
+	}
+	return response
+}
+
+// sameTranslationMaps treats nil and empty translation maps as equal
+func sameTranslationMaps(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
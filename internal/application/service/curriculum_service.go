@@ -0,0 +1,473 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// CurriculumService defines the curriculum (prerequisites and placements) service interface
+type CurriculumService interface {
+	ListPrerequisites(ctx context.Context, subjectID string) ([]dto.SubjectResponse, error)
+	AddPrerequisite(ctx context.Context, subjectID string, req dto.AddPrerequisiteRequest) ([]dto.SubjectResponse, error)
+	RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID string) error
+	SetPlacement(ctx context.Context, subjectID string, req dto.SetSubjectPlacementRequest) (*dto.SubjectPlacementResponse, error)
+	RemovePlacement(ctx context.Context, subjectID string) error
+	GetCurriculum(ctx context.Context, schoolID string) (*dto.CurriculumResponse, error)
+}
+
+type curriculumService struct {
+	curriculumRepo repository.CurriculumRepository
+	subjectRepo    repository.SubjectRepository
+	unitRepo       repository.AcademicUnitRepository
+	logger         logger.Logger
+	auditLogger    audit.AuditLogger
+}
+
+// NewCurriculumService creates a new curriculum service
+func NewCurriculumService(
+	curriculumRepo repository.CurriculumRepository,
+	subjectRepo repository.SubjectRepository,
+	unitRepo repository.AcademicUnitRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) CurriculumService {
+	return &curriculumService{
+		curriculumRepo: curriculumRepo,
+		subjectRepo:    subjectRepo,
+		unitRepo:       unitRepo,
+		logger:         logger,
+		auditLogger:    auditLogger,
+	}
+}
+
+func (s *curriculumService) ListPrerequisites(ctx context.Context, subjectID string) ([]dto.SubjectResponse, error) {
+	subject, err := s.findSubject(ctx, subjectID, "invalid subject ID")
+	if err != nil {
+		return nil, err
+	}
+	return s.loadPrerequisites(ctx, subject.ID)
+}
+
+func (s *curriculumService) AddPrerequisite(ctx context.Context, subjectID string, req dto.AddPrerequisiteRequest) ([]dto.SubjectResponse, error) {
+	subject, err := s.findSubject(ctx, subjectID, "invalid subject ID")
+	if err != nil {
+		return nil, err
+	}
+	prerequisite, err := s.findSubject(ctx, req.PrerequisiteID, "invalid prerequisite_id")
+	if err != nil {
+		return nil, err
+	}
+	if subject.ID == prerequisite.ID {
+		return nil, errors.NewValidationError("a subject cannot be its own prerequisite")
+	}
+	if subject.SchoolID != prerequisite.SchoolID {
+		return nil, errors.NewValidationError("prerequisite belongs to a different school").
+			WithField("prerequisite_id", req.PrerequisiteID)
+	}
+
+	edges, err := s.curriculumRepo.FindPrerequisitesBySchool(ctx, subject.SchoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find prerequisites", err)
+	}
+	requires := make(map[uuid.UUID][]uuid.UUID)
+	for _, e := range edges {
+		if e.SubjectID == subject.ID && e.PrerequisiteID == prerequisite.ID {
+			return nil, errors.NewAlreadyExistsError("subject_prerequisite").WithField("prerequisite_id", req.PrerequisiteID)
+		}
+		requires[e.SubjectID] = append(requires[e.SubjectID], e.PrerequisiteID)
+	}
+	// The new edge closes a cycle if the prerequisite already (transitively) requires the subject
+	if path := requirementPath(requires, prerequisite.ID, subject.ID); path != nil {
+		return nil, errors.NewValidationError("prerequisite would create a cycle").
+			WithField("prerequisite_id", req.PrerequisiteID).
+			WithField("cycle", formatCycle(append([]uuid.UUID{subject.ID}, path...)))
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.curriculumRepo.AddPrerequisite(ctx, &entity.SubjectPrerequisite{
+		SubjectID:      subject.ID,
+		PrerequisiteID: prerequisite.ID,
+		SchoolID:       subject.SchoolID,
+		CreatedAt:      time.Now(),
+	}); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "add_prerequisite", ResourceType: "subject", ResourceID: subjectID,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("add prerequisite", err)
+	}
+
+	s.logger.Info("subject prerequisite added", "subject_id", subjectID, "prerequisite_id", req.PrerequisiteID)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "add_prerequisite", ResourceType: "subject", ResourceID: subjectID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"prerequisite_id": req.PrerequisiteID},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return s.loadPrerequisites(ctx, subject.ID)
+}
+
+func (s *curriculumService) RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID string) error {
+	sid, err := uuid.Parse(subjectID)
+	if err != nil {
+		return errors.NewValidationError("invalid subject ID")
+	}
+	pid, err := uuid.Parse(prerequisiteID)
+	if err != nil {
+		return errors.NewValidationError("invalid prerequisite ID")
+	}
+	edges, err := s.curriculumRepo.FindPrerequisites(ctx, sid)
+	if err != nil {
+		return errors.NewDatabaseError("find prerequisites", err)
+	}
+	found := false
+	for _, e := range edges {
+		if e.PrerequisiteID == pid {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.NewNotFoundError("subject_prerequisite")
+	}
+	if err := s.curriculumRepo.RemovePrerequisite(ctx, sid, pid); err != nil {
+		return errors.NewDatabaseError("remove prerequisite", err)
+	}
+
+	s.logger.Info("subject prerequisite removed", "subject_id", subjectID, "prerequisite_id", prerequisiteID)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "remove_prerequisite", ResourceType: "subject", ResourceID: subjectID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"prerequisite_id": prerequisiteID},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
+
+func (s *curriculumService) SetPlacement(ctx context.Context, subjectID string, req dto.SetSubjectPlacementRequest) (*dto.SubjectPlacementResponse, error) {
+	subject, err := s.findSubject(ctx, subjectID, "invalid subject ID")
+	if err != nil {
+		return nil, err
+	}
+	unitID, err := uuid.Parse(req.LevelUnitID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid level_unit_id")
+	}
+	unit, err := s.unitRepo.FindByID(ctx, unitID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find academic unit", err)
+	}
+	if unit == nil {
+		return nil, errors.NewNotFoundError("academic_unit")
+	}
+	if unit.SchoolID != subject.SchoolID {
+		return nil, errors.NewValidationError("level unit belongs to a different school").
+			WithField("level_unit_id", req.LevelUnitID)
+	}
+	if req.Position < 0 {
+		return nil, errors.NewValidationError("position must not be negative")
+	}
+
+	existing, err := s.curriculumRepo.FindPlacement(ctx, subject.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find placement", err)
+	}
+	now := time.Now()
+	placement := &entity.SubjectPlacement{
+		SubjectID:   subject.ID,
+		SchoolID:    subject.SchoolID,
+		LevelUnitID: unitID,
+		Position:    req.Position,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if existing != nil {
+		placement.CreatedAt = existing.CreatedAt
+	}
+	if err := s.curriculumRepo.SavePlacement(ctx, placement); err != nil {
+		return nil, errors.NewDatabaseError("save placement", err)
+	}
+
+	s.logger.Info("subject placement updated", "subject_id", subjectID, "level_unit_id", req.LevelUnitID)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "set_placement", ResourceType: "subject", ResourceID: subjectID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"level_unit_id": req.LevelUnitID, "position": req.Position},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return &dto.SubjectPlacementResponse{SubjectID: subjectID, LevelUnitID: req.LevelUnitID, Position: req.Position}, nil
+}
+
+func (s *curriculumService) RemovePlacement(ctx context.Context, subjectID string) error {
+	sid, err := uuid.Parse(subjectID)
+	if err != nil {
+		return errors.NewValidationError("invalid subject ID")
+	}
+	existing, err := s.curriculumRepo.FindPlacement(ctx, sid)
+	if err != nil {
+		return errors.NewDatabaseError("find placement", err)
+	}
+	if existing == nil {
+		return errors.NewNotFoundError("subject_placement")
+	}
+	if err := s.curriculumRepo.DeletePlacement(ctx, sid); err != nil {
+		return errors.NewDatabaseError("delete placement", err)
+	}
+
+	s.logger.Info("subject placement removed", "subject_id", subjectID)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "remove_placement", ResourceType: "subject", ResourceID: subjectID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
+
+// GetCurriculum builds the school's subject graph grouped by level. Subjects
+// without a placement (or placed at a deleted unit) are listed as unplaced.
+func (s *curriculumService) GetCurriculum(ctx context.Context, schoolID string) (*dto.CurriculumResponse, error) {
+	sid, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	subjects, _, err := s.subjectRepo.FindBySchoolID(ctx, sid, false, sharedrepo.ListFilters{})
+	if err != nil {
+		return nil, errors.NewDatabaseError("list subjects", err)
+	}
+	edges, err := s.curriculumRepo.FindPrerequisitesBySchool(ctx, sid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find prerequisites", err)
+	}
+	placements, err := s.curriculumRepo.FindPlacementsBySchool(ctx, sid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find placements", err)
+	}
+
+	byID := make(map[uuid.UUID]*entities.Subject, len(subjects))
+	for _, subj := range subjects {
+		byID[subj.ID] = subj
+	}
+	requires := make(map[uuid.UUID][]uuid.UUID)
+	for _, e := range edges {
+		if byID[e.SubjectID] != nil && byID[e.PrerequisiteID] != nil {
+			requires[e.SubjectID] = append(requires[e.SubjectID], e.PrerequisiteID)
+		}
+	}
+
+	placementOf := make(map[uuid.UUID]*entity.SubjectPlacement)
+	units := make(map[uuid.UUID]*entities.AcademicUnit)
+	for _, p := range placements {
+		if byID[p.SubjectID] == nil {
+			continue
+		}
+		unit, loaded := units[p.LevelUnitID]
+		if !loaded {
+			unit, err = s.unitRepo.FindByID(ctx, p.LevelUnitID, false)
+			if err != nil {
+				return nil, errors.NewDatabaseError("find academic unit", err)
+			}
+			units[p.LevelUnitID] = unit
+		}
+		if unit != nil {
+			placementOf[p.SubjectID] = p
+		}
+	}
+
+	toNode := func(subj *entities.Subject) dto.CurriculumSubject {
+		node := dto.CurriculumSubject{SubjectID: subj.ID.String(), Name: subj.Name, Code: subj.Code, Prerequisites: []string{}}
+		if p := placementOf[subj.ID]; p != nil {
+			node.Position = p.Position
+		}
+		for _, pid := range requires[subj.ID] {
+			node.Prerequisites = append(node.Prerequisites, pid.String())
+		}
+		sort.Strings(node.Prerequisites)
+		return node
+	}
+
+	levelSubjects := make(map[uuid.UUID][]*entities.Subject)
+	var unplaced []*entities.Subject
+	for _, subj := range subjects {
+		if p := placementOf[subj.ID]; p != nil {
+			levelSubjects[p.LevelUnitID] = append(levelSubjects[p.LevelUnitID], subj)
+		} else {
+			unplaced = append(unplaced, subj)
+		}
+	}
+
+	response := &dto.CurriculumResponse{SchoolID: schoolID, Levels: []dto.CurriculumLevel{}, Unplaced: []dto.CurriculumSubject{}}
+	levelIDs := make([]uuid.UUID, 0, len(levelSubjects))
+	for id := range levelSubjects {
+		levelIDs = append(levelIDs, id)
+	}
+	sort.Slice(levelIDs, func(i, j int) bool {
+		a, b := units[levelIDs[i]], units[levelIDs[j]]
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		return a.Name < b.Name
+	})
+	for _, id := range levelIDs {
+		unit := units[id]
+		level := dto.CurriculumLevel{LevelUnitID: id.String(), Name: unit.Name, Type: unit.Type, Subjects: []dto.CurriculumSubject{}}
+		for _, subj := range orderLevel(levelSubjects[id], requires, placementOf) {
+			level.Subjects = append(level.Subjects, toNode(subj))
+		}
+		response.Levels = append(response.Levels, level)
+	}
+	sort.Slice(unplaced, func(i, j int) bool { return unplaced[i].Name < unplaced[j].Name })
+	for _, subj := range unplaced {
+		response.Unplaced = append(response.Unplaced, toNode(subj))
+	}
+	return response, nil
+}
+
+// findSubject parses the ID and loads an active subject
+func (s *curriculumService) findSubject(ctx context.Context, id, invalidMsg string) (*entities.Subject, error) {
+	sid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError(invalidMsg)
+	}
+	subject, err := s.subjectRepo.FindByID(ctx, sid, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find subject", err)
+	}
+	if subject == nil {
+		return nil, errors.NewNotFoundError("subject")
+	}
+	return subject, nil
+}
+
+// loadPrerequisites returns the active direct prerequisites of a subject
+func (s *curriculumService) loadPrerequisites(ctx context.Context, subjectID uuid.UUID) ([]dto.SubjectResponse, error) {
+	edges, err := s.curriculumRepo.FindPrerequisites(ctx, subjectID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find prerequisites", err)
+	}
+	result := make([]dto.SubjectResponse, 0, len(edges))
+	for _, e := range edges {
+		prerequisite, err := s.subjectRepo.FindByID(ctx, e.PrerequisiteID, false)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find subject", err)
+		}
+		if prerequisite != nil {
+			result = append(result, dto.ToSubjectResponse(prerequisite))
+		}
+	}
+	return result, nil
+}
+
+// requirementPath returns the chain of subjects from `from` to `to` following
+// "requires" edges, or nil if `to` is not reachable.
+func requirementPath(requires map[uuid.UUID][]uuid.UUID, from, to uuid.UUID) []uuid.UUID {
+	visited := make(map[uuid.UUID]bool)
+	var walk func(id uuid.UUID) []uuid.UUID
+	walk = func(id uuid.UUID) []uuid.UUID {
+		if id == to {
+			return []uuid.UUID{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		for _, next := range requires[id] {
+			if path := walk(next); path != nil {
+				return append([]uuid.UUID{id}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// formatCycle renders a cycle of subject IDs as "a -> b -> a"
+func formatCycle(ids []uuid.UUID) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// orderLevel sorts the subjects of one level topologically over the
+// prerequisites inside that level, breaking ties by position and name.
+func orderLevel(subjects []*entities.Subject, requires map[uuid.UUID][]uuid.UUID, placementOf map[uuid.UUID]*entity.SubjectPlacement) []*entities.Subject {
+	inLevel := make(map[uuid.UUID]bool, len(subjects))
+	for _, subj := range subjects {
+		inLevel[subj.ID] = true
+	}
+	pending := make(map[uuid.UUID]int, len(subjects))
+	dependents := make(map[uuid.UUID][]uuid.UUID)
+	for _, subj := range subjects {
+		for _, pid := range requires[subj.ID] {
+			if inLevel[pid] {
+				pending[subj.ID]++
+				dependents[pid] = append(dependents[pid], subj.ID)
+			}
+		}
+	}
+	less := func(a, b *entities.Subject) bool {
+		pa, pb := placementOf[a.ID].Position, placementOf[b.ID].Position
+		if pa != pb {
+			return pa < pb
+		}
+		return a.Name < b.Name
+	}
+
+	byID := make(map[uuid.UUID]*entities.Subject, len(subjects))
+	var ready []*entities.Subject
+	for _, subj := range subjects {
+		byID[subj.ID] = subj
+		if pending[subj.ID] == 0 {
+			ready = append(ready, subj)
+		}
+	}
+	ordered := make([]*entities.Subject, 0, len(subjects))
+	done := make(map[uuid.UUID]bool, len(subjects))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, next)
+		done[next.ID] = true
+		for _, dep := range dependents[next.ID] {
+			pending[dep]--
+			if pending[dep] == 0 {
+				ready = append(ready, byID[dep])
+			}
+		}
+	}
+	// Cycles are rejected on write; keep any leftovers rather than dropping them
+	for _, subj := range subjects {
+		if !done[subj.ID] {
+			ordered = append(ordered, subj)
+		}
+	}
+	return ordered
+}
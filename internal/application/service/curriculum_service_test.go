@@ -0,0 +1,152 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schoolSubjects returns a subject repository serving the given subjects by ID and by school
+func schoolSubjects(subjects ...*entities.Subject) *mock.MockSubjectRepository {
+	return &mock.MockSubjectRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID, _ bool) (*entities.Subject, error) {
+			for _, s := range subjects {
+				if s.ID == id {
+					return s, nil
+				}
+			}
+			return nil, nil
+		},
+		FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID, _ bool, _ sharedrepo.ListFilters, _ []repository.CustomFieldFilter) ([]*entities.Subject, int, error) {
+			return subjects, len(subjects), nil
+		},
+	}
+}
+
+func TestCurriculumService_AddPrerequisite(t *testing.T) {
+	schoolID := uuid.New()
+	algebra := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Álgebra", IsActive: true}
+	calculus := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Cálculo", IsActive: true}
+	physics := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Física", IsActive: true}
+	foreign := &entities.Subject{ID: uuid.New(), SchoolID: uuid.New(), Name: "Historia", IsActive: true}
+
+	// Existing graph: physics requires calculus, calculus requires algebra
+	existing := []*entity.SubjectPrerequisite{
+		{SubjectID: physics.ID, PrerequisiteID: calculus.ID, SchoolID: schoolID},
+		{SubjectID: calculus.ID, PrerequisiteID: algebra.ID, SchoolID: schoolID},
+	}
+
+	tests := []struct {
+		name       string
+		subject    *entities.Subject
+		prereq     *entities.Subject
+		wantErr    bool
+		wantStatus int
+	}{
+		{name: "success - transitive edge", subject: physics, prereq: algebra},
+		{name: "error - self prerequisite", subject: algebra, prereq: algebra, wantErr: true, wantStatus: http.StatusBadRequest},
+		{name: "error - direct cycle", subject: calculus, prereq: physics, wantErr: true, wantStatus: http.StatusBadRequest},
+		{name: "error - transitive cycle", subject: algebra, prereq: physics, wantErr: true, wantStatus: http.StatusBadRequest},
+		{name: "error - duplicate edge", subject: physics, prereq: calculus, wantErr: true, wantStatus: http.StatusConflict},
+		{name: "error - other school", subject: physics, prereq: foreign, wantErr: true, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added := false
+			curriculumRepo := &mock.MockCurriculumRepository{
+				FindPrerequisitesBySchoolFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SubjectPrerequisite, error) { return existing, nil },
+				AddPrerequisiteFn: func(_ context.Context, _ *entity.SubjectPrerequisite) error {
+					added = true
+					return nil
+				},
+			}
+			svc := service.NewCurriculumService(curriculumRepo, schoolSubjects(algebra, calculus, physics, foreign), &mock.MockAcademicUnitRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			_, err := svc.AddPrerequisite(context.Background(), tt.subject.ID.String(), dto.AddPrerequisiteRequest{PrerequisiteID: tt.prereq.ID.String()})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				assert.False(t, added)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, added)
+		})
+	}
+}
+
+func TestCurriculumService_GetCurriculum(t *testing.T) {
+	schoolID := uuid.New()
+	grade1 := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "1° Medio", Code: "M1", Type: "grade"}
+	grade2 := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, Name: "2° Medio", Code: "M2", Type: "grade"}
+	algebra := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Álgebra", IsActive: true}
+	geometry := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Geometría", IsActive: true}
+	trig := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Trigonometría", IsActive: true}
+	calculus := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Cálculo", IsActive: true}
+	art := &entities.Subject{ID: uuid.New(), SchoolID: schoolID, Name: "Artes", IsActive: true}
+
+	curriculumRepo := &mock.MockCurriculumRepository{
+		FindPrerequisitesBySchoolFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SubjectPrerequisite, error) {
+			return []*entity.SubjectPrerequisite{
+				// Within grade 1, trigonometry requires algebra despite its lower position
+				{SubjectID: trig.ID, PrerequisiteID: algebra.ID},
+				{SubjectID: calculus.ID, PrerequisiteID: trig.ID},
+			}, nil
+		},
+		FindPlacementsBySchoolFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SubjectPlacement, error) {
+			return []*entity.SubjectPlacement{
+				{SubjectID: calculus.ID, LevelUnitID: grade2.ID, Position: 0},
+				{SubjectID: trig.ID, LevelUnitID: grade1.ID, Position: 0},
+				{SubjectID: algebra.ID, LevelUnitID: grade1.ID, Position: 2},
+				{SubjectID: geometry.ID, LevelUnitID: grade1.ID, Position: 1},
+			}, nil
+		},
+	}
+	unitRepo := &mock.MockAcademicUnitRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID, _ bool) (*entities.AcademicUnit, error) {
+			for _, u := range []*entities.AcademicUnit{grade1, grade2} {
+				if u.ID == id {
+					return u, nil
+				}
+			}
+			return nil, nil
+		},
+	}
+	svc := service.NewCurriculumService(curriculumRepo, schoolSubjects(algebra, geometry, trig, calculus, art), unitRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	result, err := svc.GetCurriculum(context.Background(), schoolID.String())
+	require.NoError(t, err)
+
+	require.Len(t, result.Levels, 2)
+	assert.Equal(t, grade1.ID.String(), result.Levels[0].LevelUnitID)
+	var names []string
+	for _, s := range result.Levels[0].Subjects {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"Geometría", "Álgebra", "Trigonometría"}, names)
+	require.Len(t, result.Levels[1].Subjects, 1)
+	assert.Equal(t, []string{trig.ID.String()}, result.Levels[1].Subjects[0].Prerequisites)
+	require.Len(t, result.Unplaced, 1)
+	assert.Equal(t, "Artes", result.Unplaced[0].Name)
+
+	dot := result.DOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph curriculum {"))
+	assert.Contains(t, dot, `label="1° Medio";`)
+	assert.Contains(t, dot, `"`+algebra.ID.String()+`" -> "`+trig.ID.String()+`";`)
+}
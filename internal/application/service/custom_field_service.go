@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// customFieldKeyPattern restricts keys to what can be used as a JSON key and
+// in cf.<key> query filters without escaping
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// CustomFieldService defines the custom field definition service interface
+type CustomFieldService interface {
+	ListFields(ctx context.Context, schoolID, entityType string) ([]dto.CustomFieldResponse, error)
+	CreateField(ctx context.Context, schoolID string, req dto.CreateCustomFieldRequest) (*dto.CustomFieldResponse, error)
+	UpdateField(ctx context.Context, schoolID, fieldID string, req dto.UpdateCustomFieldRequest) (*dto.CustomFieldResponse, error)
+	DeleteField(ctx context.Context, schoolID, fieldID string) error
+}
+
+type customFieldService struct {
+	fieldRepo   repository.CustomFieldRepository
+	schoolRepo  sharedrepo.SchoolRepository
+	logger      logger.Logger
+	auditLogger audit.AuditLogger
+}
+
+// NewCustomFieldService creates a new custom field service
+func NewCustomFieldService(
+	fieldRepo repository.CustomFieldRepository,
+	schoolRepo sharedrepo.SchoolRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) CustomFieldService {
+	return &customFieldService{
+		fieldRepo:   fieldRepo,
+		schoolRepo:  schoolRepo,
+		logger:      logger,
+		auditLogger: auditLogger,
+	}
+}
+
+// validCustomFieldEntity reports whether entityType can carry custom fields
+func validCustomFieldEntity(entityType string) bool {
+	switch entityType {
+	case entity.CustomFieldEntityUser, entity.CustomFieldEntityAcademicUnit, entity.CustomFieldEntitySubject:
+		return true
+	}
+	return false
+}
+
+// validCustomFieldType reports whether fieldType is a supported value type
+func validCustomFieldType(fieldType string) bool {
+	switch fieldType {
+	case entity.CustomFieldTypeText, entity.CustomFieldTypeNumber, entity.CustomFieldTypeBoolean,
+		entity.CustomFieldTypeDate, entity.CustomFieldTypeSelect:
+		return true
+	}
+	return false
+}
+
+// normalizeCustomFieldOptions trims the options of a select field and
+// rejects empty or repeated ones
+func normalizeCustomFieldOptions(options []string) ([]string, error) {
+	if len(options) == 0 {
+		return nil, errors.NewValidationErrorWithFields("invalid custom field", map[string]string{
+			"options": "select fields need at least one option",
+		})
+	}
+	seen := make(map[string]bool, len(options))
+	normalized := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || seen[option] {
+			return nil, errors.NewValidationErrorWithFields("invalid custom field", map[string]string{
+				"options": "options must be non-empty and unique",
+			})
+		}
+		seen[option] = true
+		normalized = append(normalized, option)
+	}
+	return normalized, nil
+}
+
+// findSchool parses the school ID and checks the school exists
+func (s *customFieldService) findSchool(ctx context.Context, schoolID string) (uuid.UUID, error) {
+	sid, err := uuid.Parse(schoolID)
+	if err != nil {
+		return uuid.Nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, sid)
+	if err != nil {
+		return uuid.Nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return uuid.Nil, errors.NewNotFoundError("school")
+	}
+	return sid, nil
+}
+
+// findField loads a custom field of the school
+func (s *customFieldService) findField(ctx context.Context, schoolID, fieldID string) (*entity.CustomFieldDefinition, error) {
+	sid, err := s.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	fid, err := uuid.Parse(fieldID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid custom field ID")
+	}
+	def, err := s.fieldRepo.FindDefinitionByID(ctx, fid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find custom field", err)
+	}
+	if def == nil || def.SchoolID != sid {
+		return nil, errors.NewNotFoundError("custom_field")
+	}
+	return def, nil
+}
+
+// logAudit writes an audit event for a custom field change
+func (s *customFieldService) logAudit(ctx context.Context, action, resourceID string, metadata map[string]interface{}, cause error) {
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	event := audit.AuditEvent{
+		Action: action, ResourceType: "custom_field", ResourceID: resourceID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin, Metadata: metadata,
+	}
+	if cause != nil {
+		event.ErrorMessage = cause.Error()
+		event.Severity = audit.SeverityWarning
+	}
+	if err := s.auditLogger.Log(ctx, event); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+}
+
+// ListFields lists the school's custom fields, limited to one entity type when given
+func (s *customFieldService) ListFields(ctx context.Context, schoolID, entityType string) ([]dto.CustomFieldResponse, error) {
+	sid, err := s.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	if entityType != "" && !validCustomFieldEntity(entityType) {
+		return nil, errors.NewValidationError("invalid entity_type")
+	}
+	defs, err := s.fieldRepo.ListDefinitions(ctx, sid, entityType)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list custom fields", err)
+	}
+	responses := make([]dto.CustomFieldResponse, len(defs))
+	for i, def := range defs {
+		responses[i] = dto.ToCustomFieldResponse(def)
+	}
+	return responses, nil
+}
+
+// CreateField defines a custom field. Keys are unique per school and entity type.
+func (s *customFieldService) CreateField(ctx context.Context, schoolID string, req dto.CreateCustomFieldRequest) (*dto.CustomFieldResponse, error) {
+	sid, err := s.findSchool(ctx, schoolID)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.TrimSpace(req.Key)
+	name := strings.TrimSpace(req.Name)
+	switch {
+	case !validCustomFieldEntity(req.EntityType):
+		return nil, errors.NewValidationError("invalid entity_type")
+	case !validCustomFieldType(req.Type):
+		return nil, errors.NewValidationError("invalid type")
+	case !customFieldKeyPattern.MatchString(key):
+		return nil, errors.NewValidationErrorWithFields("invalid custom field", map[string]string{
+			"key": "must start with a lowercase letter and contain only lowercase letters, digits and underscores",
+		})
+	case name == "":
+		return nil, errors.NewValidationError("name is required")
+	}
+
+	var options []byte
+	if req.Type == entity.CustomFieldTypeSelect {
+		normalized, err := normalizeCustomFieldOptions(req.Options)
+		if err != nil {
+			return nil, err
+		}
+		options, _ = json.Marshal(normalized)
+	} else if len(req.Options) > 0 {
+		return nil, errors.NewValidationErrorWithFields("invalid custom field", map[string]string{
+			"options": "only select fields have options",
+		})
+	}
+
+	exists, err := s.fieldRepo.ExistsDefinitionKey(ctx, sid, req.EntityType, key)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check custom field", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("custom_field").WithField("key", key)
+	}
+
+	now := time.Now()
+	def := &entity.CustomFieldDefinition{
+		ID:         uuid.New(),
+		SchoolID:   sid,
+		EntityType: req.EntityType,
+		Key:        key,
+		Name:       name,
+		Type:       req.Type,
+		Required:   req.Required,
+		Options:    options,
+		SortOrder:  req.SortOrder,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	metadata := map[string]interface{}{"school_id": schoolID, "entity_type": def.EntityType, "key": def.Key}
+	if err := s.fieldRepo.CreateDefinition(ctx, def); err != nil {
+		s.logAudit(ctx, "create", def.ID.String(), metadata, err)
+		return nil, errors.NewDatabaseError("create custom field", err)
+	}
+
+	s.logger.Info("entity created", "entity_type", "custom_field", "entity_id", def.ID.String(), "school_id", schoolID, "key", key)
+	s.logAudit(ctx, "create", def.ID.String(), metadata, nil)
+	response := dto.ToCustomFieldResponse(def)
+	return &response, nil
+}
+
+// UpdateField changes a custom field's name, required flag, order or select
+// options. Options still held by some entity cannot be removed, and a field
+// only becomes required once every entity of its type has a value.
+func (s *customFieldService) UpdateField(ctx context.Context, schoolID, fieldID string, req dto.UpdateCustomFieldRequest) (*dto.CustomFieldResponse, error) {
+	def, err := s.findField(ctx, schoolID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.NewValidationError("name is required")
+		}
+		def.Name = name
+	}
+	if req.Required != nil {
+		if *req.Required && !def.Required {
+			missing, err := s.fieldRepo.CountMissingValues(ctx, def)
+			if err != nil {
+				return nil, errors.NewDatabaseError("check custom field values", err)
+			}
+			if missing > 0 {
+				return nil, errors.NewValidationErrorWithFields("invalid custom field", map[string]string{
+					"required": fmt.Sprintf("%d %s records have no value", missing, def.EntityType),
+				})
+			}
+		}
+		def.Required = *req.Required
+	}
+	if req.SortOrder != nil {
+		def.SortOrder = *req.SortOrder
+	}
+	if req.Options != nil {
+		if def.Type != entity.CustomFieldTypeSelect {
+			return nil, errors.NewValidationErrorWithFields("invalid custom field", map[string]string{
+				"options": "only select fields have options",
+			})
+		}
+		normalized, err := normalizeCustomFieldOptions(req.Options)
+		if err != nil {
+			return nil, err
+		}
+		kept := make(map[string]bool, len(normalized))
+		for _, option := range normalized {
+			kept[option] = true
+		}
+		var removed []string
+		for _, option := range customFieldOptions(def) {
+			if !kept[option] {
+				removed = append(removed, option)
+			}
+		}
+		inUse, err := s.fieldRepo.CountValues(ctx, def.ID, removed)
+		if err != nil {
+			return nil, errors.NewDatabaseError("check custom field values", err)
+		}
+		if inUse > 0 {
+			return nil, errors.NewValidationErrorWithFields("invalid custom field", map[string]string{
+				"options": "removed options are still in use: " + strings.Join(removed, ", "),
+			})
+		}
+		def.Options, _ = json.Marshal(normalized)
+	}
+
+	def.UpdatedAt = time.Now()
+	metadata := map[string]interface{}{"school_id": schoolID, "entity_type": def.EntityType, "key": def.Key}
+	if err := s.fieldRepo.UpdateDefinition(ctx, def); err != nil {
+		s.logAudit(ctx, "update", fieldID, metadata, err)
+		return nil, errors.NewDatabaseError("update custom field", err)
+	}
+
+	s.logger.Info("entity updated", "entity_type", "custom_field", "entity_id", fieldID)
+	s.logAudit(ctx, "update", fieldID, metadata, nil)
+	response := dto.ToCustomFieldResponse(def)
+	return &response, nil
+}
+
+// DeleteField removes a custom field together with its values
+func (s *customFieldService) DeleteField(ctx context.Context, schoolID, fieldID string) error {
+	def, err := s.findField(ctx, schoolID, fieldID)
+	if err != nil {
+		return err
+	}
+	metadata := map[string]interface{}{"school_id": schoolID, "entity_type": def.EntityType, "key": def.Key}
+	if err := s.fieldRepo.DeleteDefinition(ctx, def.ID); err != nil {
+		s.logAudit(ctx, "delete", fieldID, metadata, err)
+		return errors.NewDatabaseError("delete custom field", err)
+	}
+	s.logger.Info("entity deleted", "entity_type", "custom_field", "entity_id", fieldID)
+	s.logAudit(ctx, "delete", fieldID, metadata, nil)
+	return nil
+}
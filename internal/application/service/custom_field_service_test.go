@@ -0,0 +1,426 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// customFieldDef builds a custom field definition of the school
+func customFieldDef(t *testing.T, schoolID uuid.UUID, entityType, key, fieldType string, required bool, options ...string) *entity.CustomFieldDefinition {
+	def := &entity.CustomFieldDefinition{
+		ID: uuid.New(), SchoolID: schoolID, EntityType: entityType, Key: key, Name: key, Type: fieldType, Required: required,
+	}
+	if len(options) > 0 {
+		encoded, err := json.Marshal(options)
+		require.NoError(t, err)
+		def.Options = encoded
+	}
+	return def
+}
+
+// customFieldRepoWith returns a repository holding the given definitions
+func customFieldRepoWith(defs ...*entity.CustomFieldDefinition) *mock.MockCustomFieldRepository {
+	return &mock.MockCustomFieldRepository{
+		ListDefinitionsFn: func(_ context.Context, schoolID uuid.UUID, entityType string) ([]*entity.CustomFieldDefinition, error) {
+			var result []*entity.CustomFieldDefinition
+			for _, def := range defs {
+				if def.SchoolID == schoolID && (entityType == "" || def.EntityType == entityType) {
+					result = append(result, def)
+				}
+			}
+			return result, nil
+		},
+		FindDefinitionByIDFn: func(_ context.Context, id uuid.UUID) (*entity.CustomFieldDefinition, error) {
+			for _, def := range defs {
+				if def.ID == id {
+					return def, nil
+				}
+			}
+			return nil, nil
+		},
+	}
+}
+
+func TestCustomFieldService_CreateField(t *testing.T) {
+	schoolID := uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) {
+			if id == schoolID {
+				return &entities.School{ID: id}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		schoolID  string
+		req       dto.CreateCustomFieldRequest
+		keyExists bool
+		wantErr   string
+		wantField string
+	}{
+		{"success - select field", schoolID.String(),
+			dto.CreateCustomFieldRequest{EntityType: "user", Key: "blood_type", Name: "Blood type", Type: "select", Options: []string{"O+", "A+"}}, false, "", ""},
+		{"success - number field", schoolID.String(),
+			dto.CreateCustomFieldRequest{EntityType: "subject", Key: "credit_hours", Name: "Credit hours", Type: "number", Required: true}, false, "", ""},
+		{"error - invalid key", schoolID.String(),
+			dto.CreateCustomFieldRequest{EntityType: "user", Key: "Blood Type", Name: "Blood type", Type: "text"}, false, "invalid custom field", "key"},
+		{"error - select without options", schoolID.String(),
+			dto.CreateCustomFieldRequest{EntityType: "user", Key: "blood_type", Name: "Blood type", Type: "select"}, false, "invalid custom field", "options"},
+		{"error - options on a text field", schoolID.String(),
+			dto.CreateCustomFieldRequest{EntityType: "user", Key: "nickname", Name: "Nickname", Type: "text", Options: []string{"a"}}, false, "invalid custom field", "options"},
+		{"error - duplicate key", schoolID.String(),
+			dto.CreateCustomFieldRequest{EntityType: "academic_unit", Key: "room", Name: "Room", Type: "text"}, true, "already exists", ""},
+		{"error - unknown school", uuid.New().String(),
+			dto.CreateCustomFieldRequest{EntityType: "user", Key: "national_id", Name: "National ID", Type: "text"}, false, "not found", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *entity.CustomFieldDefinition
+			fieldRepo := &mock.MockCustomFieldRepository{
+				ExistsDefinitionKeyFn: func(_ context.Context, _ uuid.UUID, _, _ string) (bool, error) { return tt.keyExists, nil },
+				CreateDefinitionFn: func(_ context.Context, def *entity.CustomFieldDefinition) error {
+					created = def
+					return nil
+				},
+			}
+			svc := service.NewCustomFieldService(fieldRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			result, err := svc.CreateField(context.Background(), tt.schoolID, tt.req)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				if tt.wantField != "" {
+					appErr, ok := errors.GetAppError(err)
+					require.True(t, ok)
+					assert.Contains(t, appErr.Fields, tt.wantField)
+				}
+				assert.Nil(t, created)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, created)
+			assert.Equal(t, tt.req.Key, result.Key)
+			assert.Equal(t, tt.req.Options, result.Options)
+			assert.Equal(t, tt.req.Required, result.Required)
+		})
+	}
+}
+
+func TestCustomFieldService_UpdateField(t *testing.T) {
+	schoolID := uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) { return &entities.School{ID: id}, nil },
+	}
+
+	t.Run("error - removed option still in use", func(t *testing.T) {
+		def := customFieldDef(t, schoolID, entity.CustomFieldEntityUser, "blood_type", entity.CustomFieldTypeSelect, false, "O+", "A+")
+		fieldRepo := customFieldRepoWith(def)
+		fieldRepo.CountValuesFn = func(_ context.Context, _ uuid.UUID, values []string) (int64, error) {
+			assert.Equal(t, []string{"A+"}, values)
+			return 2, nil
+		}
+		svc := service.NewCustomFieldService(fieldRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, err := svc.UpdateField(context.Background(), schoolID.String(), def.ID.String(), dto.UpdateCustomFieldRequest{Options: []string{"O+"}})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid custom field")
+	})
+
+	t.Run("error - required while some entities have no value", func(t *testing.T) {
+		def := customFieldDef(t, schoolID, entity.CustomFieldEntitySubject, "credit_hours", entity.CustomFieldTypeNumber, false)
+		fieldRepo := customFieldRepoWith(def)
+		fieldRepo.CountMissingValuesFn = func(_ context.Context, counted *entity.CustomFieldDefinition) (int64, error) {
+			assert.Equal(t, def.ID, counted.ID)
+			return 3, nil
+		}
+		fieldRepo.UpdateDefinitionFn = func(_ context.Context, _ *entity.CustomFieldDefinition) error {
+			t.Fatal("definition must not be saved")
+			return nil
+		}
+		svc := service.NewCustomFieldService(fieldRepo, schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+		required := true
+
+		_, err := svc.UpdateField(context.Background(), schoolID.String(), def.ID.String(), dto.UpdateCustomFieldRequest{Required: &required})
+
+		require.Error(t, err)
+		appErr, ok := errors.GetAppError(err)
+		require.True(t, ok)
+		assert.Contains(t, appErr.Fields["required"], "3 subject records")
+	})
+
+	t.Run("success - required once every entity has a value", func(t *testing.T) {
+		def := customFieldDef(t, schoolID, entity.CustomFieldEntitySubject, "credit_hours", entity.CustomFieldTypeNumber, false)
+		svc := service.NewCustomFieldService(customFieldRepoWith(def), schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+		required := true
+
+		result, err := svc.UpdateField(context.Background(), schoolID.String(), def.ID.String(), dto.UpdateCustomFieldRequest{Required: &required})
+
+		require.NoError(t, err)
+		assert.True(t, result.Required)
+	})
+
+	t.Run("error - field of another school", func(t *testing.T) {
+		def := customFieldDef(t, uuid.New(), entity.CustomFieldEntityUser, "national_id", entity.CustomFieldTypeText, false)
+		svc := service.NewCustomFieldService(customFieldRepoWith(def), schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, err := svc.UpdateField(context.Background(), schoolID.String(), def.ID.String(), dto.UpdateCustomFieldRequest{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("success - adds options and renames", func(t *testing.T) {
+		def := customFieldDef(t, schoolID, entity.CustomFieldEntityUser, "blood_type", entity.CustomFieldTypeSelect, false, "O+")
+		svc := service.NewCustomFieldService(customFieldRepoWith(def), schoolRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+		name := "Blood group"
+
+		result, err := svc.UpdateField(context.Background(), schoolID.String(), def.ID.String(),
+			dto.UpdateCustomFieldRequest{Name: &name, Options: []string{"O+", "B-"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Blood group", result.Name)
+		assert.Equal(t, []string{"O+", "B-"}, result.Options)
+	})
+}
+
+func TestSubjectService_CustomFields(t *testing.T) {
+	schoolID := uuid.New()
+	credits := customFieldDef(t, schoolID, entity.CustomFieldEntitySubject, "credit_hours", entity.CustomFieldTypeNumber, true)
+	lab := customFieldDef(t, schoolID, entity.CustomFieldEntitySubject, "has_lab", entity.CustomFieldTypeBoolean, false)
+	start := customFieldDef(t, schoolID, entity.CustomFieldEntitySubject, "starts_on", entity.CustomFieldTypeDate, false)
+	subjectRepo := &mock.MockSubjectRepository{
+		ExistsBySchoolIDAndNameFn: func(_ context.Context, _ uuid.UUID, _ string) (bool, error) { return false, nil },
+	}
+
+	t.Run("success - stores canonical values with the subject and returns typed ones", func(t *testing.T) {
+		var saved []*entity.CustomFieldValue
+		fieldRepo := customFieldRepoWith(credits, lab, start)
+		fieldRepo.CreateWithValuesFn = func(_ context.Context, record interface{}, values []*entity.CustomFieldValue) error {
+			require.IsType(t, &entities.Subject{}, record)
+			saved = values
+			return nil
+		}
+		fieldRepo.FindValuesFn = func(_ context.Context, _ uuid.UUID, _ string, _ []uuid.UUID) ([]*entity.CustomFieldValue, error) {
+			return saved, nil
+		}
+		repo := &mock.MockSubjectRepository{
+			ExistsBySchoolIDAndNameFn: subjectRepo.ExistsBySchoolIDAndNameFn,
+			CreateFn: func(_ context.Context, _ *entities.Subject) error {
+				t.Fatal("subject must be created together with its custom fields")
+				return nil
+			},
+		}
+		svc := service.NewSubjectService(repo, &mock.MockAcademicUnitRepository{}, fieldRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		result, err := svc.CreateSubject(context.Background(), schoolID.String(), dto.CreateSubjectRequest{
+			Name:         "Chemistry",
+			CustomFields: map[string]interface{}{"credit_hours": 4, "has_lab": true, "starts_on": "2026-02-01"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, saved, 3)
+		stored := map[uuid.UUID]string{}
+		for _, value := range saved {
+			stored[value.DefinitionID] = value.Value
+		}
+		assert.Equal(t, "4", stored[credits.ID])
+		assert.Equal(t, "true", stored[lab.ID])
+		assert.Equal(t, map[string]interface{}{"credit_hours": float64(4), "has_lab": true, "starts_on": "2026-02-01"}, result.CustomFields)
+	})
+
+	t.Run("error - failed write stores neither subject nor values", func(t *testing.T) {
+		fieldRepo := customFieldRepoWith(credits)
+		fieldRepo.CreateWithValuesFn = func(_ context.Context, _ interface{}, _ []*entity.CustomFieldValue) error {
+			return fmt.Errorf("db error")
+		}
+		svc := service.NewSubjectService(subjectRepo, &mock.MockAcademicUnitRepository{}, fieldRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, err := svc.CreateSubject(context.Background(), schoolID.String(), dto.CreateSubjectRequest{
+			Name:         "Chemistry",
+			CustomFields: map[string]interface{}{"credit_hours": 4},
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "create subject")
+	})
+
+	t.Run("error - invalid, unknown and missing required fields", func(t *testing.T) {
+		svc := service.NewSubjectService(subjectRepo, &mock.MockAcademicUnitRepository{}, customFieldRepoWith(credits, lab, start),
+			mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, err := svc.CreateSubject(context.Background(), schoolID.String(), dto.CreateSubjectRequest{
+			Name:         "Chemistry",
+			CustomFields: map[string]interface{}{"has_lab": "yes", "starts_on": "01/02/2026", "room": "B2"},
+		})
+
+		require.Error(t, err)
+		appErr, ok := errors.GetAppError(err)
+		require.True(t, ok)
+		for _, field := range []string{"custom_fields.credit_hours", "custom_fields.has_lab", "custom_fields.starts_on", "custom_fields.room"} {
+			assert.Contains(t, appErr.Fields, field)
+		}
+	})
+
+	t.Run("success - list filters by custom field", func(t *testing.T) {
+		repo := &mock.MockSubjectRepository{
+			FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID, _ bool, _ sharedrepo.ListFilters, fieldFilters []repository.CustomFieldFilter) ([]*entities.Subject, int, error) {
+				assert.Equal(t, []repository.CustomFieldFilter{{DefinitionID: credits.ID, Value: "4.5"}}, fieldFilters)
+				return []*entities.Subject{{ID: uuid.New(), SchoolID: schoolID, Name: "Chemistry", IsActive: true}}, 1, nil
+			},
+		}
+		svc := service.NewSubjectService(repo, &mock.MockAcademicUnitRepository{}, customFieldRepoWith(credits, lab, start), mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		result, total, err := svc.ListSubjects(context.Background(), schoolID.String(), false, sharedrepo.ListFilters{},
+			map[string]string{"credit_hours": "4.50"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, result, 1)
+	})
+
+	t.Run("error - filter on unknown field", func(t *testing.T) {
+		svc := service.NewSubjectService(subjectRepo, &mock.MockAcademicUnitRepository{}, customFieldRepoWith(credits), mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, _, err := svc.ListSubjects(context.Background(), schoolID.String(), false, sharedrepo.ListFilters{}, map[string]string{"room": "B2"})
+
+		require.Error(t, err)
+		appErr, ok := errors.GetAppError(err)
+		require.True(t, ok)
+		assert.Contains(t, appErr.Fields, "cf.room")
+	})
+}
+
+func TestUserService_CustomFields(t *testing.T) {
+	schoolID := uuid.New()
+	blood := customFieldDef(t, schoolID, entity.CustomFieldEntityUser, "blood_type", entity.CustomFieldTypeSelect, true, "O+", "A+")
+	user := &entities.User{ID: uuid.New(), Email: "ana@example.com", FirstName: "Ana", LastName: "Ruiz", IsActive: true}
+	userRepo := &mock.MockUserRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.User, error) { return user, nil },
+	}
+	memberships := &mock.MockMembershipRepository{
+		FindByUserAndSchoolFn: func(_ context.Context, userID, sid uuid.UUID) (*entities.Membership, error) {
+			return &entities.Membership{ID: uuid.New(), UserID: userID, SchoolID: sid, IsActive: true}, nil
+		},
+	}
+
+	t.Run("error - custom fields without a school", func(t *testing.T) {
+		svc := service.NewUserService(userRepo, memberships, customFieldRepoWith(blood), mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, err := svc.UpdateUser(context.Background(), user.ID.String(), dto.UpdateUserRequest{
+			CustomFields: map[string]interface{}{"blood_type": "O+"},
+		})
+
+		require.Error(t, err)
+		appErr, ok := errors.GetAppError(err)
+		require.True(t, ok)
+		assert.Contains(t, appErr.Fields, "school_id")
+	})
+
+	t.Run("error - clearing a required field", func(t *testing.T) {
+		svc := service.NewUserService(userRepo, memberships, customFieldRepoWith(blood), mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, err := svc.UpdateUser(context.Background(), user.ID.String(), dto.UpdateUserRequest{
+			SchoolID: schoolID.String(), CustomFields: map[string]interface{}{"blood_type": nil},
+		})
+
+		require.Error(t, err)
+		appErr, ok := errors.GetAppError(err)
+		require.True(t, ok)
+		assert.Contains(t, appErr.Fields, "custom_fields.blood_type")
+	})
+
+	t.Run("success - get returns the school's values", func(t *testing.T) {
+		fieldRepo := customFieldRepoWith(blood)
+		fieldRepo.FindValuesFn = func(_ context.Context, sid uuid.UUID, entityType string, _ []uuid.UUID) ([]*entity.CustomFieldValue, error) {
+			assert.Equal(t, schoolID, sid)
+			assert.Equal(t, entity.CustomFieldEntityUser, entityType)
+			return []*entity.CustomFieldValue{{DefinitionID: blood.ID, EntityID: user.ID, Value: "A+"}}, nil
+		}
+		svc := service.NewUserService(userRepo, memberships, fieldRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		withSchool, err := svc.GetUser(context.Background(), user.ID.String(), schoolID.String())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"blood_type": "A+"}, withSchool.CustomFields)
+
+		withoutSchool, err := svc.GetUser(context.Background(), user.ID.String(), "")
+		require.NoError(t, err)
+		assert.Nil(t, withoutSchool.CustomFields)
+	})
+
+	t.Run("error - school the user is not a member of", func(t *testing.T) {
+		inactive := &mock.MockMembershipRepository{
+			FindByUserAndSchoolFn: func(_ context.Context, userID, sid uuid.UUID) (*entities.Membership, error) {
+				return &entities.Membership{ID: uuid.New(), UserID: userID, SchoolID: sid}, nil
+			},
+		}
+		for name, membershipRepo := range map[string]*mock.MockMembershipRepository{"none": {}, "inactive": inactive} {
+			fieldRepo := customFieldRepoWith(blood)
+			fieldRepo.UpdateWithValuesFn = func(_ context.Context, _ interface{}, _ uuid.UUID, _ []*entity.CustomFieldValue, _ []uuid.UUID) error {
+				t.Fatal("values must not be written")
+				return nil
+			}
+			svc := service.NewUserService(userRepo, membershipRepo, fieldRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+			_, err := svc.UpdateUser(context.Background(), user.ID.String(), dto.UpdateUserRequest{
+				SchoolID: schoolID.String(), CustomFields: map[string]interface{}{"blood_type": "O+"},
+			})
+			require.Error(t, err, name)
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Contains(t, appErr.Fields, "school_id")
+
+			_, err = svc.GetUser(context.Background(), user.ID.String(), schoolID.String())
+			require.Error(t, err, name)
+		}
+	})
+
+	t.Run("success - list with a school only lists its members", func(t *testing.T) {
+		fieldRepo := customFieldRepoWith(blood)
+		fieldRepo.ListUsersFn = func(_ context.Context, _ sharedrepo.ListFilters, sid uuid.UUID, fieldFilters []repository.CustomFieldFilter) ([]*entities.User, int64, error) {
+			assert.Equal(t, schoolID, sid)
+			assert.Empty(t, fieldFilters)
+			return []*entities.User{user}, 1, nil
+		}
+		allUsers := &mock.MockUserRepository{
+			ListFn: func(_ context.Context, _ sharedrepo.ListFilters) ([]*entities.User, int64, error) {
+				t.Fatal("a school list must not list every user")
+				return nil, 0, nil
+			},
+		}
+		svc := service.NewUserService(allUsers, memberships, fieldRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		result, total, err := svc.ListUsers(context.Background(), sharedrepo.ListFilters{}, schoolID.String(), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, result, 1)
+		assert.Equal(t, user.ID.String(), result[0].ID)
+	})
+
+	t.Run("error - list filter without a school", func(t *testing.T) {
+		svc := service.NewUserService(userRepo, memberships, customFieldRepoWith(blood), mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+		_, _, err := svc.ListUsers(context.Background(), sharedrepo.ListFilters{}, "", map[string]string{"blood_type": "O+"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid custom field filters")
+	})
+}
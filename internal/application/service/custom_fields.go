@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// customFieldDateLayout is the format of date custom field values
+const customFieldDateLayout = "2006-01-02"
+
+// customFieldSet holds the custom field definitions of one entity type of a school
+type customFieldSet struct {
+	schoolID   uuid.UUID
+	entityType string
+	byKey      map[string]*entity.CustomFieldDefinition
+	byID       map[uuid.UUID]*entity.CustomFieldDefinition
+}
+
+// loadCustomFieldSet reads the school's custom field definitions for an entity type
+func loadCustomFieldSet(ctx context.Context, repo repository.CustomFieldRepository, schoolID uuid.UUID, entityType string) (*customFieldSet, error) {
+	defs, err := repo.ListDefinitions(ctx, schoolID, entityType)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list custom fields", err)
+	}
+	set := &customFieldSet{
+		schoolID:   schoolID,
+		entityType: entityType,
+		byKey:      make(map[string]*entity.CustomFieldDefinition, len(defs)),
+		byID:       make(map[uuid.UUID]*entity.CustomFieldDefinition, len(defs)),
+	}
+	for _, def := range defs {
+		set.byKey[def.Key] = def
+		set.byID[def.ID] = def
+	}
+	return set, nil
+}
+
+// customFieldOptions returns the allowed values of a select field
+func customFieldOptions(def *entity.CustomFieldDefinition) []string {
+	var options []string
+	if len(def.Options) > 0 {
+		_ = json.Unmarshal(def.Options, &options)
+	}
+	return options
+}
+
+// canonicalCustomFieldValue checks a decoded JSON value against the field
+// type and returns its stored text form
+func canonicalCustomFieldValue(def *entity.CustomFieldDefinition, value interface{}) (string, error) {
+	// Round-trip through JSON so values have the types of a decoded request body
+	encoded, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(encoded, &value) != nil {
+		return "", fmt.Errorf("must be a JSON value")
+	}
+	switch def.Type {
+	case entity.CustomFieldTypeNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return "", fmt.Errorf("must be a number")
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case entity.CustomFieldTypeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("must be a boolean")
+		}
+		return strconv.FormatBool(b), nil
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("must be a string")
+	}
+	return parseCustomFieldText(def, text)
+}
+
+// parseCustomFieldText checks a value given as text, such as a query
+// parameter, and returns its stored text form
+func parseCustomFieldText(def *entity.CustomFieldDefinition, text string) (string, error) {
+	switch def.Type {
+	case entity.CustomFieldTypeNumber:
+		n, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return "", fmt.Errorf("must be a number")
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case entity.CustomFieldTypeBoolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return "", fmt.Errorf("must be a boolean")
+		}
+		return strconv.FormatBool(b), nil
+	case entity.CustomFieldTypeDate:
+		if _, err := time.Parse(customFieldDateLayout, text); err != nil {
+			return "", fmt.Errorf("must be a date formatted as YYYY-MM-DD")
+		}
+	case entity.CustomFieldTypeSelect:
+		for _, option := range customFieldOptions(def) {
+			if option == text {
+				return text, nil
+			}
+		}
+		return "", fmt.Errorf("must be one of %s", strings.Join(customFieldOptions(def), ", "))
+	}
+	return text, nil
+}
+
+// typedCustomFieldValue converts a stored value back to its JSON type
+func typedCustomFieldValue(def *entity.CustomFieldDefinition, stored string) interface{} {
+	switch def.Type {
+	case entity.CustomFieldTypeNumber:
+		if n, err := strconv.ParseFloat(stored, 64); err == nil {
+			return n
+		}
+	case entity.CustomFieldTypeBoolean:
+		if b, err := strconv.ParseBool(stored); err == nil {
+			return b
+		}
+	}
+	return stored
+}
+
+// prepare validates the custom fields of a write and returns the values to
+// store and the definitions whose values are cleared. On create every
+// required field must be given; on update only the given fields change and
+// required fields cannot be cleared.
+func (set *customFieldSet) prepare(entityID uuid.UUID, input map[string]interface{}, creating bool) ([]*entity.CustomFieldValue, []uuid.UUID, error) {
+	fieldErrs := map[string]string{}
+	keys := make([]string, 0, len(input))
+	for key := range input {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	now := time.Now()
+	var values []*entity.CustomFieldValue
+	var cleared []uuid.UUID
+	for _, key := range keys {
+		def, ok := set.byKey[key]
+		if !ok {
+			fieldErrs["custom_fields."+key] = "is not a custom field of this school"
+			continue
+		}
+		if input[key] == nil {
+			if def.Required {
+				fieldErrs["custom_fields."+key] = "is required"
+				continue
+			}
+			cleared = append(cleared, def.ID)
+			continue
+		}
+		stored, err := canonicalCustomFieldValue(def, input[key])
+		if err != nil {
+			fieldErrs["custom_fields."+key] = err.Error()
+			continue
+		}
+		values = append(values, &entity.CustomFieldValue{
+			DefinitionID: def.ID, EntityID: entityID, SchoolID: set.schoolID, EntityType: set.entityType,
+			Value: stored, CreatedAt: now, UpdatedAt: now,
+		})
+	}
+	if creating {
+		for key, def := range set.byKey {
+			if _, ok := input[key]; def.Required && !ok {
+				fieldErrs["custom_fields."+key] = "is required"
+			}
+		}
+	}
+	if len(fieldErrs) > 0 {
+		return nil, nil, errors.NewValidationErrorWithFields("invalid custom fields", fieldErrs)
+	}
+	return values, cleared, nil
+}
+
+// filters converts custom field query filters, keyed by field key, to
+// repository filters
+func (set *customFieldSet) filters(raw map[string]string) ([]repository.CustomFieldFilter, error) {
+	fieldErrs := map[string]string{}
+	filters := make([]repository.CustomFieldFilter, 0, len(raw))
+	for key, text := range raw {
+		def, ok := set.byKey[key]
+		if !ok {
+			fieldErrs["cf."+key] = "is not a custom field of this school"
+			continue
+		}
+		stored, err := parseCustomFieldText(def, text)
+		if err != nil {
+			fieldErrs["cf."+key] = err.Error()
+			continue
+		}
+		filters = append(filters, repository.CustomFieldFilter{DefinitionID: def.ID, Value: stored})
+	}
+	if len(fieldErrs) > 0 {
+		return nil, errors.NewValidationErrorWithFields("invalid custom field filters", fieldErrs)
+	}
+	return filters, nil
+}
+
+// values reads the custom field values of the given entities, keyed by
+// entity and then by field key
+func (set *customFieldSet) values(ctx context.Context, repo repository.CustomFieldRepository, entityIDs []uuid.UUID) (map[uuid.UUID]map[string]interface{}, error) {
+	result := map[uuid.UUID]map[string]interface{}{}
+	if len(set.byID) == 0 || len(entityIDs) == 0 {
+		return result, nil
+	}
+	stored, err := repo.FindValues(ctx, set.schoolID, set.entityType, entityIDs)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find custom field values", err)
+	}
+	for _, value := range stored {
+		def, ok := set.byID[value.DefinitionID]
+		if !ok {
+			continue
+		}
+		if result[value.EntityID] == nil {
+			result[value.EntityID] = map[string]interface{}{}
+		}
+		result[value.EntityID][def.Key] = typedCustomFieldValue(def, value.Value)
+	}
+	return result, nil
+}
+
+// createWithCustomFields inserts an entity through create, or together with
+// its custom field values in one transaction when there are any
+func createWithCustomFields(ctx context.Context, repo repository.CustomFieldRepository, record interface{}, values []*entity.CustomFieldValue, create func() error) error {
+	if len(values) == 0 {
+		return create()
+	}
+	return repo.CreateWithValues(ctx, record, values)
+}
+
+// updateWithCustomFields saves an entity through update, or together with its
+// custom field changes in one transaction when there are any
+func updateWithCustomFields(ctx context.Context, repo repository.CustomFieldRepository, record interface{}, entityID uuid.UUID, values []*entity.CustomFieldValue, cleared []uuid.UUID, update func() error) error {
+	if len(values) == 0 && len(cleared) == 0 {
+		return update()
+	}
+	return repo.UpdateWithValues(ctx, record, entityID, values, cleared)
+}
@@ -0,0 +1,484 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// Guardian invitation limits
+const (
+	defaultInvitationMaxUses     = 1
+	maxInvitationMaxUses         = 10
+	defaultInvitationExpiryHours = 72
+	maxInvitationExpiryHours     = 720
+)
+
+// invitationCodeAttempts bounds the retries when a generated code is taken
+const invitationCodeAttempts = 5
+
+// invitationCodeAlphabet omits characters that are easy to confuse (0/O, 1/I/L)
+const invitationCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// GuardianInvitationService defines the guardian self-service linking interface
+type GuardianInvitationService interface {
+	CreateInvitation(ctx context.Context, studentID string, req dto.CreateGuardianInvitationRequest, createdBy string) (*dto.GuardianInvitationResponse, error)
+	ListInvitations(ctx context.Context, studentID string) ([]dto.GuardianInvitationResponse, error)
+	RevokeInvitation(ctx context.Context, id string) error
+	RedeemInvitation(ctx context.Context, req dto.RedeemGuardianInvitationRequest, guardianID string) (*dto.GuardianLinkRequestResponse, error)
+	ListLinkRequests(ctx context.Context, schoolID, status string) ([]dto.GuardianLinkRequestResponse, error)
+	ReviewLinkRequest(ctx context.Context, id string, req dto.ReviewGuardianLinkRequest, reviewerID string) (*dto.GuardianLinkRequestResponse, error)
+}
+
+type guardianInvitationService struct {
+	invitationRepo  repository.GuardianInvitationRepository
+	guardianRepo    repository.GuardianRepository
+	guardianService GuardianService
+	userRepo        sharedrepo.UserRepository
+	membershipRepo  sharedrepo.MembershipRepository
+	logger          logger.Logger
+	auditLogger     audit.AuditLogger
+}
+
+// NewGuardianInvitationService creates a new guardian invitation service
+func NewGuardianInvitationService(
+	invitationRepo repository.GuardianInvitationRepository,
+	guardianRepo repository.GuardianRepository,
+	guardianService GuardianService,
+	userRepo sharedrepo.UserRepository,
+	membershipRepo sharedrepo.MembershipRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) GuardianInvitationService {
+	return &guardianInvitationService{
+		invitationRepo:  invitationRepo,
+		guardianRepo:    guardianRepo,
+		guardianService: guardianService,
+		userRepo:        userRepo,
+		membershipRepo:  membershipRepo,
+		logger:          logger,
+		auditLogger:     auditLogger,
+	}
+}
+
+func (s *guardianInvitationService) CreateInvitation(ctx context.Context, studentID string, req dto.CreateGuardianInvitationRequest, createdBy string) (*dto.GuardianInvitationResponse, error) {
+	sid, err := uuid.Parse(studentID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid student_id")
+	}
+	schoolID, err := uuid.Parse(req.SchoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school_id")
+	}
+
+	maxUses := defaultInvitationMaxUses
+	if req.MaxUses != nil {
+		maxUses = *req.MaxUses
+	}
+	if maxUses < 1 || maxUses > maxInvitationMaxUses {
+		return nil, errors.NewValidationErrorWithFields("invalid invitation", map[string]string{
+			"max_uses": "must be between 1 and 10",
+		})
+	}
+	expiresInHours := defaultInvitationExpiryHours
+	if req.ExpiresInHours != nil {
+		expiresInHours = *req.ExpiresInHours
+	}
+	if expiresInHours < 1 || expiresInHours > maxInvitationExpiryHours {
+		return nil, errors.NewValidationErrorWithFields("invalid invitation", map[string]string{
+			"expires_in_hours": "must be between 1 and 720",
+		})
+	}
+
+	if err := s.validateStudentInSchool(ctx, sid, schoolID); err != nil {
+		return nil, err
+	}
+
+	var createdByUUID *uuid.UUID
+	if createdBy != "" {
+		parsed, err := uuid.Parse(createdBy)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid created_by")
+		}
+		createdByUUID = &parsed
+	}
+
+	code, err := s.newInvitationCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation := &entity.GuardianInvitation{
+		ID:        uuid.New(),
+		StudentID: sid,
+		SchoolID:  schoolID,
+		Code:      code,
+		MaxUses:   maxUses,
+		ExpiresAt: now.Add(time.Duration(expiresInHours) * time.Hour),
+		CreatedBy: createdByUUID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.invitationRepo.CreateInvitation(ctx, invitation); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "create", ResourceType: "guardian_invitation",
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("create guardian invitation", err)
+	}
+
+	s.logger.Info("entity created", "entity_type", "guardian_invitation", "entity_id", invitation.ID.String())
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "create", ResourceType: "guardian_invitation", ResourceID: invitation.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"student_id": sid.String(), "school_id": schoolID.String(), "max_uses": maxUses},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+
+	resp := dto.ToGuardianInvitationResponse(invitation)
+	return &resp, nil
+}
+
+func (s *guardianInvitationService) ListInvitations(ctx context.Context, studentID string) ([]dto.GuardianInvitationResponse, error) {
+	sid, err := uuid.Parse(studentID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid student_id")
+	}
+	invitations, err := s.invitationRepo.FindInvitationsByStudent(ctx, sid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian invitations", err)
+	}
+	responses := make([]dto.GuardianInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = dto.ToGuardianInvitationResponse(invitation)
+	}
+	return responses, nil
+}
+
+func (s *guardianInvitationService) RevokeInvitation(ctx context.Context, id string) error {
+	iid, err := uuid.Parse(id)
+	if err != nil {
+		return errors.NewValidationError("invalid invitation ID")
+	}
+	invitation, err := s.invitationRepo.FindInvitationByID(ctx, iid)
+	if err != nil {
+		return errors.NewDatabaseError("find guardian invitation", err)
+	}
+	if invitation == nil {
+		return errors.NewNotFoundError("guardian_invitation")
+	}
+	if invitation.RevokedAt != nil {
+		return errors.NewValidationError("invitation is already revoked")
+	}
+
+	now := time.Now()
+	invitation.RevokedAt = &now
+	invitation.UpdatedAt = now
+	if err := s.invitationRepo.UpdateInvitation(ctx, invitation); err != nil {
+		return errors.NewDatabaseError("revoke guardian invitation", err)
+	}
+
+	s.logger.Info("entity updated", "entity_type", "guardian_invitation", "entity_id", id, "action", "revoke")
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "revoke", ResourceType: "guardian_invitation", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
+
+func (s *guardianInvitationService) RedeemInvitation(ctx context.Context, req dto.RedeemGuardianInvitationRequest, guardianID string) (*dto.GuardianLinkRequestResponse, error) {
+	gid, err := uuid.Parse(guardianID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid guardian_id")
+	}
+	if err := validateRelationshipType(req.RelationshipType); err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	invitation, err := s.invitationRepo.FindInvitationByCode(ctx, code)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian invitation", err)
+	}
+	if invitation == nil {
+		s.auditRedeemFailure(ctx, "", "unknown invitation code")
+		return nil, errors.NewNotFoundError("guardian_invitation")
+	}
+	if reason := invitationUnusableReason(invitation, time.Now()); reason != "" {
+		s.auditRedeemFailure(ctx, invitation.ID.String(), reason)
+		return nil, errors.NewValidationError(reason)
+	}
+	if gid == invitation.StudentID {
+		return nil, errors.NewValidationError("guardian_id and student_id must be different")
+	}
+
+	exists, err := s.guardianRepo.ExistsActiveRelation(ctx, gid, invitation.StudentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check guardian relation", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("guardian_relation")
+	}
+	pending, err := s.invitationRepo.ExistsPendingLinkRequest(ctx, gid, invitation.StudentID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check guardian link request", err)
+	}
+	if pending {
+		return nil, errors.NewAlreadyExistsError("guardian_link_request")
+	}
+
+	now := time.Now()
+	request := &entity.GuardianLinkRequest{
+		ID:               uuid.New(),
+		InvitationID:     invitation.ID,
+		GuardianID:       gid,
+		StudentID:        invitation.StudentID,
+		SchoolID:         invitation.SchoolID,
+		RelationshipType: req.RelationshipType,
+		Status:           entity.LinkRequestStatusPending,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	redeemed, err := s.invitationRepo.Redeem(ctx, invitation.ID, request)
+	if err != nil {
+		return nil, errors.NewDatabaseError("redeem guardian invitation", err)
+	}
+	if !redeemed {
+		s.auditRedeemFailure(ctx, invitation.ID.String(), "invitation has no uses left")
+		return nil, errors.NewValidationError("invitation has no uses left")
+	}
+
+	s.logger.Info("entity created", "entity_type", "guardian_link_request", "entity_id", request.ID.String())
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "redeem", ResourceType: "guardian_invitation", ResourceID: invitation.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"link_request_id": request.ID.String(), "student_id": request.StudentID.String()},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+
+	resp := dto.ToGuardianLinkRequestResponse(request)
+	return &resp, nil
+}
+
+func (s *guardianInvitationService) ListLinkRequests(ctx context.Context, schoolID, status string) ([]dto.GuardianLinkRequestResponse, error) {
+	var sid *uuid.UUID
+	if schoolID != "" {
+		parsed, err := uuid.Parse(schoolID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid school_id")
+		}
+		sid = &parsed
+	}
+	switch status {
+	case "", entity.LinkRequestStatusPending, entity.LinkRequestStatusApproved, entity.LinkRequestStatusRejected:
+	default:
+		return nil, errors.NewValidationError("invalid status").WithField("allowed", "pending,approved,rejected")
+	}
+
+	requests, err := s.invitationRepo.FindLinkRequests(ctx, sid, status)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian link requests", err)
+	}
+	responses := make([]dto.GuardianLinkRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = dto.ToGuardianLinkRequestResponse(request)
+	}
+	return responses, nil
+}
+
+func (s *guardianInvitationService) ReviewLinkRequest(ctx context.Context, id string, req dto.ReviewGuardianLinkRequest, reviewerID string) (*dto.GuardianLinkRequestResponse, error) {
+	rid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid link request ID")
+	}
+	if req.Decision != "approve" && req.Decision != "reject" {
+		return nil, errors.NewValidationError("decision must be approve or reject")
+	}
+	var reviewer *uuid.UUID
+	if reviewerID != "" {
+		parsed, err := uuid.Parse(reviewerID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid reviewed_by")
+		}
+		reviewer = &parsed
+	}
+
+	request, err := s.invitationRepo.FindLinkRequestByID(ctx, rid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian link request", err)
+	}
+	if request == nil {
+		return nil, errors.NewNotFoundError("guardian_link_request")
+	}
+	if request.Status != entity.LinkRequestStatusPending {
+		return nil, errors.NewValidationError("link request has already been reviewed").WithField("status", request.Status)
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	auditFailure := func(err error) {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: req.Decision, ResourceType: "guardian_link_request", ResourceID: id,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+	}
+
+	now := time.Now()
+	request.ReviewedBy = reviewer
+	request.ReviewedAt = &now
+	request.ReviewNote = req.Note
+	request.UpdatedAt = now
+	if req.Decision == "approve" {
+		// Preparing the relation re-runs participant, relationship type and primary contact rules
+		relation, detail, err := s.guardianService.PrepareRelation(ctx, dto.CreateGuardianRelationRequest{
+			GuardianID:       request.GuardianID.String(),
+			StudentID:        request.StudentID.String(),
+			RelationshipType: request.RelationshipType,
+		}, reviewerID)
+		if err != nil {
+			auditFailure(err)
+			return nil, err
+		}
+		request.RelationID = &relation.ID
+		request.Status = entity.LinkRequestStatusApproved
+		if err := s.invitationRepo.ApproveLinkRequest(ctx, request, relation, detail); err != nil {
+			auditFailure(err)
+			return nil, errors.NewDatabaseError("approve guardian link request", err)
+		}
+	} else {
+		request.Status = entity.LinkRequestStatusRejected
+		if err := s.invitationRepo.UpdateLinkRequest(ctx, request); err != nil {
+			auditFailure(err)
+			return nil, errors.NewDatabaseError("update guardian link request", err)
+		}
+	}
+
+	s.logger.Info("entity updated", "entity_type", "guardian_link_request", "entity_id", id, "status", request.Status)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: req.Decision, ResourceType: "guardian_link_request", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"guardian_id": request.GuardianID.String(), "student_id": request.StudentID.String()},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+
+	resp := dto.ToGuardianLinkRequestResponse(request)
+	return &resp, nil
+}
+
+// validateStudentInSchool verifies the student is an active user with an active
+// student membership in the given school.
+func (s *guardianInvitationService) validateStudentInSchool(ctx context.Context, studentID, schoolID uuid.UUID) error {
+	student, err := s.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return errors.NewDatabaseError("find student user", err)
+	}
+	if student == nil {
+		return errors.NewNotFoundError("student")
+	}
+	if !student.IsActive {
+		return errors.NewValidationError("student user is not active").WithField("student_id", studentID.String())
+	}
+
+	membership, err := s.membershipRepo.FindByUserAndSchool(ctx, studentID, schoolID)
+	if err != nil {
+		return errors.NewDatabaseError("find student membership", err)
+	}
+	if membership == nil || !membership.IsActive || membership.Role != membershipRoleStudent {
+		return errors.NewValidationError("user has no active student membership in this school").
+			WithField("student_id", studentID.String()).
+			WithField("school_id", schoolID.String())
+	}
+	return nil
+}
+
+// newInvitationCode generates a code that is not already in use
+func (s *guardianInvitationService) newInvitationCode(ctx context.Context) (string, error) {
+	for attempt := 0; attempt < invitationCodeAttempts; attempt++ {
+		code, err := generateInvitationCode()
+		if err != nil {
+			return "", errors.NewDatabaseError("generate invitation code", err)
+		}
+		existing, err := s.invitationRepo.FindInvitationByCode(ctx, code)
+		if err != nil {
+			return "", errors.NewDatabaseError("find guardian invitation", err)
+		}
+		if existing == nil {
+			return code, nil
+		}
+	}
+	return "", errors.NewDatabaseError("generate invitation code", fmt.Errorf("no unique code after %d attempts", invitationCodeAttempts))
+}
+
+// auditRedeemFailure records a rejected redemption attempt
+func (s *guardianInvitationService) auditRedeemFailure(ctx context.Context, invitationID, reason string) {
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "redeem", ResourceType: "guardian_invitation", ResourceID: invitationID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		ErrorMessage: reason, Severity: audit.SeverityWarning, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+}
+
+// invitationUnusableReason explains why an invitation cannot be redeemed, or returns "" if it can
+func invitationUnusableReason(invitation *entity.GuardianInvitation, now time.Time) string {
+	switch {
+	case invitation.RevokedAt != nil:
+		return "invitation has been revoked"
+	case !now.Before(invitation.ExpiresAt):
+		return "invitation has expired"
+	case invitation.UsesCount >= invitation.MaxUses:
+		return "invitation has no uses left"
+	}
+	return ""
+}
+
+// generateInvitationCode returns a random code formatted as XXXX-XXXX
+func generateInvitationCode() (string, error) {
+	const length = 8
+	max := big.NewInt(int64(len(invitationCodeAlphabet)))
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		if i == length/2 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(invitationCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
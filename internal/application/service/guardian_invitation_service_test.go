@@ -0,0 +1,419 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInvitationService(
+	invitationRepo *mock.MockGuardianInvitationRepository,
+	guardianRepo *mock.MockGuardianRepository,
+	guardianService *mock.MockGuardianService,
+	membershipRepo *mock.MockMembershipRepository,
+) service.GuardianInvitationService {
+	userRepo, defaultMemberships := activeParticipantMocks()
+	if membershipRepo == nil {
+		membershipRepo = defaultMemberships
+	}
+	return service.NewGuardianInvitationService(invitationRepo, guardianRepo, guardianService, userRepo, membershipRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+}
+
+func TestGuardianInvitationService_CreateInvitation(t *testing.T) {
+	studentID := uuid.New().String()
+	schoolID := uuid.New().String()
+	studentMembership := &mock.MockMembershipRepository{
+		FindByUserAndSchoolFn: func(_ context.Context, userID, sid uuid.UUID) (*entities.Membership, error) {
+			return &entities.Membership{ID: uuid.New(), UserID: userID, SchoolID: sid, Role: "student", IsActive: true}, nil
+		},
+	}
+	zero, tooLong := 0, 721
+
+	tests := []struct {
+		name           string
+		studentID      string
+		request        dto.CreateGuardianInvitationRequest
+		membershipRepo *mock.MockMembershipRepository
+		wantErr        bool
+	}{
+		{
+			name:           "success - defaults",
+			studentID:      studentID,
+			request:        dto.CreateGuardianInvitationRequest{SchoolID: schoolID},
+			membershipRepo: studentMembership,
+			wantErr:        false,
+		},
+		{
+			name:           "error - invalid student_id",
+			studentID:      "bad",
+			request:        dto.CreateGuardianInvitationRequest{SchoolID: schoolID},
+			membershipRepo: studentMembership,
+			wantErr:        true,
+		},
+		{
+			name:           "error - invalid school_id",
+			studentID:      studentID,
+			request:        dto.CreateGuardianInvitationRequest{SchoolID: "bad"},
+			membershipRepo: studentMembership,
+			wantErr:        true,
+		},
+		{
+			name:           "error - max_uses below range",
+			studentID:      studentID,
+			request:        dto.CreateGuardianInvitationRequest{SchoolID: schoolID, MaxUses: &zero},
+			membershipRepo: studentMembership,
+			wantErr:        true,
+		},
+		{
+			name:           "error - expires_in_hours above range",
+			studentID:      studentID,
+			request:        dto.CreateGuardianInvitationRequest{SchoolID: schoolID, ExpiresInHours: &tooLong},
+			membershipRepo: studentMembership,
+			wantErr:        true,
+		},
+		{
+			name:           "error - student not enrolled in school",
+			studentID:      studentID,
+			request:        dto.CreateGuardianInvitationRequest{SchoolID: schoolID},
+			membershipRepo: &mock.MockMembershipRepository{},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestInvitationService(&mock.MockGuardianInvitationRepository{}, &mock.MockGuardianRepository{}, &mock.MockGuardianService{}, tt.membershipRepo)
+			result, err := svc.CreateInvitation(context.Background(), tt.studentID, tt.request, "")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Regexp(t, regexp.MustCompile(`^[A-Z2-9]{4}-[A-Z2-9]{4}$`), result.Code)
+				assert.Equal(t, 1, result.MaxUses)
+				assert.True(t, result.ExpiresAt.After(time.Now()))
+			}
+		})
+	}
+}
+
+func TestGuardianInvitationService_RedeemInvitation(t *testing.T) {
+	guardianID := uuid.New()
+	studentID := uuid.New()
+	validInvitation := func() *entity.GuardianInvitation {
+		return &entity.GuardianInvitation{
+			ID: uuid.New(), StudentID: studentID, SchoolID: uuid.New(), Code: "ABCD-EFGH",
+			MaxUses: 1, ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		request    dto.RedeemGuardianInvitationRequest
+		guardianID string
+		setupMock  func(inv *mock.MockGuardianInvitationRepository, rel *mock.MockGuardianRepository)
+		wantErr    bool
+		wantStatus int
+	}{
+		{
+			name:       "success - code is normalized",
+			request:    dto.RedeemGuardianInvitationRequest{Code: " abcd-efgh ", RelationshipType: "mother"},
+			guardianID: guardianID.String(),
+			setupMock: func(inv *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {
+				inv.FindInvitationByCodeFn = func(_ context.Context, code string) (*entity.GuardianInvitation, error) {
+					if code != "ABCD-EFGH" {
+						return nil, nil
+					}
+					return validInvitation(), nil
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name:       "error - unknown code",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ZZZZ-ZZZZ", RelationshipType: "mother"},
+			guardianID: guardianID.String(),
+			setupMock:  func(_ *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {},
+			wantErr:    true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error - invalid relationship type",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ABCD-EFGH", RelationshipType: "neighbor"},
+			guardianID: guardianID.String(),
+			setupMock:  func(_ *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - expired invitation",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ABCD-EFGH", RelationshipType: "mother"},
+			guardianID: guardianID.String(),
+			setupMock: func(inv *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {
+				inv.FindInvitationByCodeFn = func(_ context.Context, _ string) (*entity.GuardianInvitation, error) {
+					i := validInvitation()
+					i.ExpiresAt = time.Now().Add(-time.Hour)
+					return i, nil
+				}
+			},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - revoked invitation",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ABCD-EFGH", RelationshipType: "mother"},
+			guardianID: guardianID.String(),
+			setupMock: func(inv *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {
+				inv.FindInvitationByCodeFn = func(_ context.Context, _ string) (*entity.GuardianInvitation, error) {
+					i := validInvitation()
+					i.RevokedAt = &revokedAt
+					return i, nil
+				}
+			},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - no uses left at redemption",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ABCD-EFGH", RelationshipType: "mother"},
+			guardianID: guardianID.String(),
+			setupMock: func(inv *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {
+				inv.FindInvitationByCodeFn = func(_ context.Context, _ string) (*entity.GuardianInvitation, error) { return validInvitation(), nil }
+				inv.RedeemFn = func(_ context.Context, _ uuid.UUID, _ *entity.GuardianLinkRequest) (bool, error) { return false, nil }
+			},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - student redeems own code",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ABCD-EFGH", RelationshipType: "mother"},
+			guardianID: studentID.String(),
+			setupMock: func(inv *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {
+				inv.FindInvitationByCodeFn = func(_ context.Context, _ string) (*entity.GuardianInvitation, error) { return validInvitation(), nil }
+			},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - relation already active",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ABCD-EFGH", RelationshipType: "mother"},
+			guardianID: guardianID.String(),
+			setupMock: func(inv *mock.MockGuardianInvitationRepository, rel *mock.MockGuardianRepository) {
+				inv.FindInvitationByCodeFn = func(_ context.Context, _ string) (*entity.GuardianInvitation, error) { return validInvitation(), nil }
+				rel.ExistsActiveRelationFn = func(_ context.Context, _, _ uuid.UUID) (bool, error) { return true, nil }
+			},
+			wantErr:    true,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "error - link request already pending",
+			request:    dto.RedeemGuardianInvitationRequest{Code: "ABCD-EFGH", RelationshipType: "mother"},
+			guardianID: guardianID.String(),
+			setupMock: func(inv *mock.MockGuardianInvitationRepository, _ *mock.MockGuardianRepository) {
+				inv.FindInvitationByCodeFn = func(_ context.Context, _ string) (*entity.GuardianInvitation, error) { return validInvitation(), nil }
+				inv.ExistsPendingLinkRequestFn = func(_ context.Context, _, _ uuid.UUID) (bool, error) { return true, nil }
+			},
+			wantErr:    true,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitationRepo := &mock.MockGuardianInvitationRepository{}
+			guardianRepo := &mock.MockGuardianRepository{}
+			tt.setupMock(invitationRepo, guardianRepo)
+
+			svc := newTestInvitationService(invitationRepo, guardianRepo, &mock.MockGuardianService{}, nil)
+			result, err := svc.RedeemInvitation(context.Background(), tt.request, tt.guardianID)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entity.LinkRequestStatusPending, result.Status)
+				assert.Equal(t, studentID.String(), result.StudentID)
+				assert.Equal(t, tt.guardianID, result.GuardianID)
+			}
+		})
+	}
+}
+
+func TestGuardianInvitationService_ReviewLinkRequest(t *testing.T) {
+	requestID := uuid.New()
+	relationID := uuid.New()
+	pendingRequest := func(status string) func(_ context.Context, _ uuid.UUID) (*entity.GuardianLinkRequest, error) {
+		return func(_ context.Context, _ uuid.UUID) (*entity.GuardianLinkRequest, error) {
+			return &entity.GuardianLinkRequest{
+				ID: requestID, GuardianID: uuid.New(), StudentID: uuid.New(), SchoolID: uuid.New(),
+				RelationshipType: "father", Status: status,
+			}, nil
+		}
+	}
+
+	tests := []struct {
+		name         string
+		decision     string
+		findRequest  func(_ context.Context, _ uuid.UUID) (*entity.GuardianLinkRequest, error)
+		createErr    error
+		approveErr   error
+		wantErr      bool
+		wantStatus   string
+		wantRelation bool
+	}{
+		{
+			name:         "approve creates the relation",
+			decision:     "approve",
+			findRequest:  pendingRequest(entity.LinkRequestStatusPending),
+			wantStatus:   entity.LinkRequestStatusApproved,
+			wantRelation: true,
+		},
+		{
+			name:        "reject closes the request",
+			decision:    "reject",
+			findRequest: pendingRequest(entity.LinkRequestStatusPending),
+			wantStatus:  entity.LinkRequestStatusRejected,
+		},
+		{
+			name:        "error - already reviewed",
+			decision:    "approve",
+			findRequest: pendingRequest(entity.LinkRequestStatusRejected),
+			wantErr:     true,
+		},
+		{
+			name:     "error - not found",
+			decision: "approve",
+			findRequest: func(_ context.Context, _ uuid.UUID) (*entity.GuardianLinkRequest, error) {
+				return nil, nil
+			},
+			wantErr: true,
+		},
+		{
+			name:        "error - relation creation fails",
+			decision:    "approve",
+			findRequest: pendingRequest(entity.LinkRequestStatusPending),
+			createErr:   errors.NewValidationError("student user is not active"),
+			wantErr:     true,
+		},
+		{
+			name:        "error - approval write fails",
+			decision:    "approve",
+			findRequest: pendingRequest(entity.LinkRequestStatusPending),
+			approveErr:  assert.AnError,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := false
+			var approved *entities.GuardianRelation
+			invitationRepo := &mock.MockGuardianInvitationRepository{
+				FindLinkRequestByIDFn: tt.findRequest,
+				UpdateLinkRequestFn: func(_ context.Context, _ *entity.GuardianLinkRequest) error {
+					updated = true
+					return nil
+				},
+				// The relation and the reviewed request are written together
+				ApproveLinkRequestFn: func(_ context.Context, _ *entity.GuardianLinkRequest, relation *entities.GuardianRelation, _ *entity.GuardianRelationDetail) error {
+					if tt.approveErr != nil {
+						return tt.approveErr
+					}
+					updated = true
+					approved = relation
+					return nil
+				},
+			}
+			guardianService := &mock.MockGuardianService{
+				PrepareRelationFn: func(_ context.Context, req dto.CreateGuardianRelationRequest, _ string) (*entities.GuardianRelation, *entity.GuardianRelationDetail, error) {
+					if tt.createErr != nil {
+						return nil, nil, tt.createErr
+					}
+					relation := &entities.GuardianRelation{ID: relationID, RelationshipType: req.RelationshipType, IsActive: true}
+					return relation, &entity.GuardianRelationDetail{GuardianRelationID: relationID}, nil
+				},
+			}
+
+			svc := newTestInvitationService(invitationRepo, &mock.MockGuardianRepository{}, guardianService, nil)
+			result, err := svc.ReviewLinkRequest(context.Background(), requestID.String(), dto.ReviewGuardianLinkRequest{Decision: tt.decision}, uuid.New().String())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.False(t, updated)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, updated)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.NotNil(t, result.ReviewedAt)
+			if tt.wantRelation {
+				require.NotNil(t, result.RelationID)
+				assert.Equal(t, relationID.String(), *result.RelationID)
+				require.NotNil(t, approved)
+				assert.Equal(t, relationID, approved.ID)
+			} else {
+				assert.Nil(t, result.RelationID)
+				assert.Nil(t, approved)
+			}
+		})
+	}
+}
+
+func TestGuardianInvitationService_RevokeInvitation(t *testing.T) {
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		invitation *entity.GuardianInvitation
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			invitation: &entity.GuardianInvitation{ID: uuid.New(), MaxUses: 1, ExpiresAt: time.Now().Add(time.Hour)},
+			wantErr:    false,
+		},
+		{
+			name:       "error - already revoked",
+			invitation: &entity.GuardianInvitation{ID: uuid.New(), RevokedAt: &revokedAt},
+			wantErr:    true,
+		},
+		{
+			name:       "error - not found",
+			invitation: nil,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitationRepo := &mock.MockGuardianInvitationRepository{
+				FindInvitationByIDFn: func(_ context.Context, _ uuid.UUID) (*entity.GuardianInvitation, error) { return tt.invitation, nil },
+			}
+			svc := newTestInvitationService(invitationRepo, &mock.MockGuardianRepository{}, &mock.MockGuardianService{}, nil)
+			err := svc.RevokeInvitation(context.Background(), uuid.New().String())
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
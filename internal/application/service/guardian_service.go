@@ -2,64 +2,195 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/audit"
 	"github.com/EduGoGroup/edugo-shared/common/errors"
 	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 	"github.com/google/uuid"
 )
 
+// Relationship types accepted for guardian relations
+const (
+	RelationshipTypeMother        = "mother"
+	RelationshipTypeFather        = "father"
+	RelationshipTypeLegalGuardian = "legal_guardian"
+	RelationshipTypeGrandparent   = "grandparent"
+	RelationshipTypeSibling       = "sibling"
+	RelationshipTypeUncleAunt     = "uncle_aunt"
+	RelationshipTypeStepparent    = "stepparent"
+	RelationshipTypeFosterParent  = "foster_parent"
+	RelationshipTypeOther         = "other"
+)
+
+// RelationshipTypes is the catalog of valid relationship types, in display order
+var RelationshipTypes = []string{
+	RelationshipTypeMother,
+	RelationshipTypeFather,
+	RelationshipTypeLegalGuardian,
+	RelationshipTypeGrandparent,
+	RelationshipTypeSibling,
+	RelationshipTypeUncleAunt,
+	RelationshipTypeStepparent,
+	RelationshipTypeFosterParent,
+	RelationshipTypeOther,
+}
+
+// membershipRoleStudent is the membership role that identifies a student
+const membershipRoleStudent = "student"
+
+// IsValidRelationshipType reports whether t belongs to the relationship type catalog
+func IsValidRelationshipType(t string) bool {
+	for _, rt := range RelationshipTypes {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
+
 // GuardianService defines the guardian service interface
 type GuardianService interface {
 	CreateRelation(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*dto.GuardianRelationResponse, error)
+	// PrepareRelation runs the CreateRelation rules and builds the relation and
+	// its detail without storing them, for callers that save them together
+	// with their own changes
+	PrepareRelation(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*entities.GuardianRelation, *entity.GuardianRelationDetail, error)
 	GetRelation(ctx context.Context, id string) (*dto.GuardianRelationResponse, error)
 	UpdateRelation(ctx context.Context, id string, req dto.UpdateGuardianRelationRequest) (*dto.GuardianRelationResponse, error)
 	DeleteRelation(ctx context.Context, id string) error
-	GetGuardianRelations(ctx context.Context, guardianID string) ([]*dto.GuardianRelationResponse, error)
-	GetStudentGuardians(ctx context.Context, studentID string) ([]*dto.GuardianRelationResponse, error)
+	GetGuardianRelations(ctx context.Context, guardianID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error)
+	GetStudentGuardians(ctx context.Context, studentID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error)
+	ListSchoolRelations(ctx context.Context, schoolID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error)
+	GetAuthorizedPickups(ctx context.Context, studentID string) ([]dto.AuthorizedPickupResponse, error)
 }
 
 type guardianService struct {
-	guardianRepo repository.GuardianRepository
-	logger       logger.Logger
-	auditLogger  audit.AuditLogger
+	guardianRepo   repository.GuardianRepository
+	userRepo       sharedrepo.UserRepository
+	membershipRepo sharedrepo.MembershipRepository
+	logger         logger.Logger
+	auditLogger    audit.AuditLogger
 }
 
 // NewGuardianService creates a new guardian service
-func NewGuardianService(guardianRepo repository.GuardianRepository, logger logger.Logger, auditLogger audit.AuditLogger) GuardianService {
-	return &guardianService{guardianRepo: guardianRepo, logger: logger, auditLogger: auditLogger}
+func NewGuardianService(
+	guardianRepo repository.GuardianRepository,
+	userRepo sharedrepo.UserRepository,
+	membershipRepo sharedrepo.MembershipRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) GuardianService {
+	return &guardianService{
+		guardianRepo:   guardianRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		logger:         logger,
+		auditLogger:    auditLogger,
+	}
 }
 
-func (s *guardianService) CreateRelation(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*dto.GuardianRelationResponse, error) {
+// validateRelationshipType checks the relationship type against the catalog
+func validateRelationshipType(relationshipType string) error {
+	if relationshipType == "" {
+		return errors.NewValidationError("relationship_type is required")
+	}
+	if !IsValidRelationshipType(relationshipType) {
+		return errors.NewValidationError("invalid relationship_type").
+			WithField("relationship_type", relationshipType).
+			WithField("allowed", strings.Join(RelationshipTypes, ","))
+	}
+	return nil
+}
+
+// validateParticipants verifies that guardian and student are distinct, existing,
+// active users and that the student holds an active student membership.
+func (s *guardianService) validateParticipants(ctx context.Context, guardianID, studentID uuid.UUID) error {
+	if guardianID == studentID {
+		return errors.NewValidationError("guardian_id and student_id must be different")
+	}
+
+	guardian, err := s.userRepo.FindByID(ctx, guardianID)
+	if err != nil {
+		return errors.NewDatabaseError("find guardian user", err)
+	}
+	if guardian == nil {
+		return errors.NewNotFoundError("guardian")
+	}
+	if !guardian.IsActive {
+		return errors.NewValidationError("guardian user is not active").WithField("guardian_id", guardianID.String())
+	}
+
+	student, err := s.userRepo.FindByID(ctx, studentID)
+	if err != nil {
+		return errors.NewDatabaseError("find student user", err)
+	}
+	if student == nil {
+		return errors.NewNotFoundError("student")
+	}
+	if !student.IsActive {
+		return errors.NewValidationError("student user is not active").WithField("student_id", studentID.String())
+	}
+
+	memberships, _, err := s.membershipRepo.FindByUser(ctx, studentID, sharedrepo.ListFilters{})
+	if err != nil {
+		return errors.NewDatabaseError("find student memberships", err)
+	}
+	for _, m := range memberships {
+		if m.IsActive && m.Role == membershipRoleStudent {
+			return nil
+		}
+	}
+	return errors.NewValidationError("user has no active student membership").WithField("student_id", studentID.String())
+}
+
+func (s *guardianService) PrepareRelation(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*entities.GuardianRelation, *entity.GuardianRelationDetail, error) {
 	guardianID, err := uuid.Parse(req.GuardianID)
 	if err != nil {
-		return nil, errors.NewValidationError("invalid guardian_id")
+		return nil, nil, errors.NewValidationError("invalid guardian_id")
 	}
 	studentID, err := uuid.Parse(req.StudentID)
 	if err != nil {
-		return nil, errors.NewValidationError("invalid student_id")
+		return nil, nil, errors.NewValidationError("invalid student_id")
 	}
-	if req.RelationshipType == "" {
-		return nil, errors.NewValidationError("relationship_type is required")
+	if err := validateRelationshipType(req.RelationshipType); err != nil {
+		return nil, nil, err
+	}
+	if err := s.validateParticipants(ctx, guardianID, studentID); err != nil {
+		return nil, nil, err
 	}
 
 	exists, err := s.guardianRepo.ExistsActiveRelation(ctx, guardianID, studentID)
 	if err != nil {
-		return nil, errors.NewDatabaseError("check guardian relation", err)
+		return nil, nil, errors.NewDatabaseError("check guardian relation", err)
 	}
 	if exists {
-		return nil, errors.NewAlreadyExistsError("guardian_relation")
+		return nil, nil, errors.NewAlreadyExistsError("guardian_relation")
+	}
+
+	currentPrimary, err := s.primaryContactOf(ctx, studentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	isPrimary := currentPrimary == uuid.Nil
+	if req.IsPrimaryContact != nil {
+		isPrimary = *req.IsPrimaryContact
+	}
+	if !isPrimary && currentPrimary == uuid.Nil {
+		return nil, nil, errors.NewValidationError("student has no primary contact; this relation must be the primary contact")
 	}
 
 	var createdByUUID *uuid.UUID
 	if createdBy != "" {
 		parsed, err := uuid.Parse(createdBy)
 		if err != nil {
-			return nil, errors.NewValidationError("invalid created_by")
+			return nil, nil, errors.NewValidationError("invalid created_by")
 		}
 		createdByUUID = &parsed
 	}
@@ -76,7 +207,25 @@ func (s *guardianService) CreateRelation(ctx context.Context, req dto.CreateGuar
 		CreatedBy:        createdByUUID,
 	}
 
-	if err := s.guardianRepo.Create(ctx, relation); err != nil {
+	detail := &entity.GuardianRelationDetail{
+		GuardianRelationID:     relation.ID,
+		IsPrimaryContact:       isPrimary,
+		HasLegalCustody:        boolOrDefault(req.HasLegalCustody, false),
+		CanPickup:              boolOrDefault(req.CanPickup, false),
+		ReceivesCommunications: boolOrDefault(req.ReceivesCommunications, true),
+		CreatedAt:              now,
+		UpdatedAt:              now,
+	}
+	return relation, detail, nil
+}
+
+func (s *guardianService) CreateRelation(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*dto.GuardianRelationResponse, error) {
+	relation, detail, err := s.PrepareRelation(ctx, req, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.guardianRepo.CreateWithDetail(ctx, relation, detail); err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "create", ResourceType: "guardian_relation",
@@ -97,7 +246,7 @@ func (s *guardianService) CreateRelation(ctx context.Context, req dto.CreateGuar
 	}); err != nil {
 		s.logger.Error("failed to write audit log", "error", err)
 	}
-	return dto.ToGuardianRelationResponse(relation), nil
+	return dto.ToGuardianRelationResponse(relation).WithDetail(detail), nil
 }
 
 func (s *guardianService) GetRelation(ctx context.Context, id string) (*dto.GuardianRelationResponse, error) {
@@ -112,7 +261,11 @@ func (s *guardianService) GetRelation(ctx context.Context, id string) (*dto.Guar
 	if relation == nil {
 		return nil, errors.NewNotFoundError("guardian_relation")
 	}
-	return dto.ToGuardianRelationResponse(relation), nil
+	detail, err := s.findDetail(ctx, relation.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToGuardianRelationResponse(relation).WithDetail(detail), nil
 }
 
 func (s *guardianService) UpdateRelation(ctx context.Context, id string, req dto.UpdateGuardianRelationRequest) (*dto.GuardianRelationResponse, error) {
@@ -129,19 +282,60 @@ func (s *guardianService) UpdateRelation(ctx context.Context, id string, req dto
 	}
 
 	if req.RelationshipType != nil {
+		if err := validateRelationshipType(*req.RelationshipType); err != nil {
+			return nil, err
+		}
 		relation.RelationshipType = *req.RelationshipType
 	}
 	if req.IsActive != nil {
 		relation.IsActive = *req.IsActive
 	}
-	relation.UpdatedAt = time.Now()
+	if relation.IsActive {
+		if err := s.validateParticipants(ctx, relation.GuardianID, relation.StudentID); err != nil {
+			return nil, err
+		}
+	}
 
-	if err := s.guardianRepo.Update(ctx, relation); err != nil {
+	detail, err := s.findDetail(ctx, relation.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if detail == nil {
+		detail = &entity.GuardianRelationDetail{GuardianRelationID: relation.ID, ReceivesCommunications: true, CreatedAt: now}
+	}
+	if req.IsPrimaryContact != nil {
+		if !*req.IsPrimaryContact && detail.IsPrimaryContact {
+			return nil, errors.NewValidationError("a student must keep exactly one primary contact; assign another primary contact instead")
+		}
+		if *req.IsPrimaryContact && !relation.IsActive {
+			return nil, errors.NewValidationError("an inactive relation cannot be the primary contact")
+		}
+		detail.IsPrimaryContact = *req.IsPrimaryContact
+	}
+	if req.HasLegalCustody != nil {
+		detail.HasLegalCustody = *req.HasLegalCustody
+	}
+	if req.CanPickup != nil {
+		detail.CanPickup = *req.CanPickup
+	}
+	if req.ReceivesCommunications != nil {
+		detail.ReceivesCommunications = *req.ReceivesCommunications
+	}
+	if !relation.IsActive {
+		if err := s.ensureNotSolePrimary(ctx, relation, detail); err != nil {
+			return nil, err
+		}
+	}
+	relation.UpdatedAt = now
+	detail.UpdatedAt = now
+
+	if err := s.guardianRepo.UpdateWithDetail(ctx, relation, detail); err != nil {
 		return nil, errors.NewDatabaseError("update guardian relation", err)
 	}
 
 	s.logger.Info("entity updated", "entity_type", "guardian_relation", "entity_id", id)
-	return dto.ToGuardianRelationResponse(relation), nil
+	return dto.ToGuardianRelationResponse(relation).WithDetail(detail), nil
 }
 
 func (s *guardianService) DeleteRelation(ctx context.Context, id string) error {
@@ -149,6 +343,20 @@ func (s *guardianService) DeleteRelation(ctx context.Context, id string) error {
 	if err != nil {
 		return errors.NewValidationError("invalid relation ID")
 	}
+	relation, err := s.guardianRepo.FindByID(ctx, rid)
+	if err != nil {
+		return errors.NewDatabaseError("find guardian relation", err)
+	}
+	if relation == nil {
+		return errors.NewNotFoundError("guardian_relation")
+	}
+	detail, err := s.findDetail(ctx, rid)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureNotSolePrimary(ctx, relation, detail); err != nil {
+		return err
+	}
 	if err := s.guardianRepo.Delete(ctx, rid); err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
@@ -172,19 +380,70 @@ func (s *guardianService) DeleteRelation(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *guardianService) GetGuardianRelations(ctx context.Context, guardianID string) ([]*dto.GuardianRelationResponse, error) {
+func (s *guardianService) GetGuardianRelations(ctx context.Context, guardianID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error) {
 	gid, err := uuid.Parse(guardianID)
 	if err != nil {
-		return nil, errors.NewValidationError("invalid guardian_id")
+		return nil, 0, errors.NewValidationError("invalid guardian_id")
 	}
-	relations, err := s.guardianRepo.FindByGuardian(ctx, gid)
+	if err := validateRelationFilters(relFilters); err != nil {
+		return nil, 0, err
+	}
+	relations, total, err := s.guardianRepo.ListByGuardian(ctx, gid, toRepoRelationFilters(relFilters), filters)
 	if err != nil {
-		return nil, errors.NewDatabaseError("find guardian relations", err)
+		return nil, 0, errors.NewDatabaseError("find guardian relations", err)
 	}
-	return dto.ToGuardianRelationResponseList(relations), nil
+	responses, err := s.toResponses(ctx, relations)
+	return responses, total, err
 }
 
-func (s *guardianService) GetStudentGuardians(ctx context.Context, studentID string) ([]*dto.GuardianRelationResponse, error) {
+func (s *guardianService) GetStudentGuardians(ctx context.Context, studentID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error) {
+	sid, err := uuid.Parse(studentID)
+	if err != nil {
+		return nil, 0, errors.NewValidationError("invalid student_id")
+	}
+	if err := validateRelationFilters(relFilters); err != nil {
+		return nil, 0, err
+	}
+	relations, total, err := s.guardianRepo.ListByStudent(ctx, sid, toRepoRelationFilters(relFilters), filters)
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("find student guardians", err)
+	}
+	responses, err := s.toResponses(ctx, relations)
+	return responses, total, err
+}
+
+func (s *guardianService) ListSchoolRelations(ctx context.Context, schoolID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error) {
+	sid, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, 0, errors.NewValidationError("invalid school ID")
+	}
+	if err := validateRelationFilters(relFilters); err != nil {
+		return nil, 0, err
+	}
+	relations, total, err := s.guardianRepo.ListBySchool(ctx, sid, toRepoRelationFilters(relFilters), filters)
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("find school guardian relations", err)
+	}
+	responses, err := s.toResponses(ctx, relations)
+	return responses, total, err
+}
+
+// validateRelationFilters checks the relationship type filter against the catalog
+func validateRelationFilters(relFilters dto.GuardianRelationListFilters) error {
+	if relFilters.RelationshipType == "" {
+		return nil
+	}
+	return validateRelationshipType(relFilters.RelationshipType)
+}
+
+func toRepoRelationFilters(relFilters dto.GuardianRelationListFilters) repository.GuardianRelationFilters {
+	return repository.GuardianRelationFilters{
+		IncludeInactive:  relFilters.IncludeInactive,
+		RelationshipType: relFilters.RelationshipType,
+	}
+}
+
+func (s *guardianService) GetAuthorizedPickups(ctx context.Context, studentID string) ([]dto.AuthorizedPickupResponse, error) {
 	sid, err := uuid.Parse(studentID)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid student_id")
@@ -193,5 +452,108 @@ func (s *guardianService) GetStudentGuardians(ctx context.Context, studentID str
 	if err != nil {
 		return nil, errors.NewDatabaseError("find student guardians", err)
 	}
-	return dto.ToGuardianRelationResponseList(relations), nil
+	details, err := s.loadDetails(ctx, relations)
+	if err != nil {
+		return nil, err
+	}
+
+	pickups := make([]dto.AuthorizedPickupResponse, 0, len(relations))
+	for _, relation := range relations {
+		detail := details[relation.ID]
+		if detail == nil || !detail.CanPickup {
+			continue
+		}
+		guardian, err := s.userRepo.FindByID(ctx, relation.GuardianID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find guardian user", err)
+		}
+		if guardian == nil || !guardian.IsActive {
+			continue
+		}
+		pickups = append(pickups, dto.ToAuthorizedPickupResponse(relation, detail, guardian))
+	}
+	return pickups, nil
+}
+
+// loadDetails returns the details of the given relations indexed by relation ID
+func (s *guardianService) loadDetails(ctx context.Context, relations []*entities.GuardianRelation) (map[uuid.UUID]*entity.GuardianRelationDetail, error) {
+	ids := make([]uuid.UUID, len(relations))
+	for i, r := range relations {
+		ids[i] = r.ID
+	}
+	details, err := s.guardianRepo.FindDetailsByRelationIDs(ctx, ids)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find guardian relation details", err)
+	}
+	byRelation := make(map[uuid.UUID]*entity.GuardianRelationDetail, len(details))
+	for _, d := range details {
+		byRelation[d.GuardianRelationID] = d
+	}
+	return byRelation, nil
+}
+
+// findDetail returns the detail of a single relation, or nil if none was stored
+func (s *guardianService) findDetail(ctx context.Context, relationID uuid.UUID) (*entity.GuardianRelationDetail, error) {
+	details, err := s.loadDetails(ctx, []*entities.GuardianRelation{{ID: relationID}})
+	if err != nil {
+		return nil, err
+	}
+	return details[relationID], nil
+}
+
+// toResponses converts relations to responses including their details
+func (s *guardianService) toResponses(ctx context.Context, relations []*entities.GuardianRelation) ([]*dto.GuardianRelationResponse, error) {
+	details, err := s.loadDetails(ctx, relations)
+	if err != nil {
+		return nil, err
+	}
+	responses := dto.ToGuardianRelationResponseList(relations)
+	for i, r := range relations {
+		responses[i].WithDetail(details[r.ID])
+	}
+	return responses, nil
+}
+
+// primaryContactOf returns the active relation flagged as the student's primary contact, or uuid.Nil
+func (s *guardianService) primaryContactOf(ctx context.Context, studentID uuid.UUID) (uuid.UUID, error) {
+	relations, err := s.guardianRepo.FindByStudent(ctx, studentID)
+	if err != nil {
+		return uuid.Nil, errors.NewDatabaseError("find student guardians", err)
+	}
+	details, err := s.loadDetails(ctx, relations)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, r := range relations {
+		if d := details[r.ID]; d != nil && d.IsPrimaryContact {
+			return r.ID, nil
+		}
+	}
+	return uuid.Nil, nil
+}
+
+// ensureNotSolePrimary rejects removing the primary contact while the student
+// still has other active guardians that would be left without one.
+func (s *guardianService) ensureNotSolePrimary(ctx context.Context, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error {
+	if detail == nil || !detail.IsPrimaryContact {
+		return nil
+	}
+	relations, err := s.guardianRepo.FindByStudent(ctx, relation.StudentID)
+	if err != nil {
+		return errors.NewDatabaseError("find student guardians", err)
+	}
+	for _, r := range relations {
+		if r.ID != relation.ID {
+			return errors.NewValidationError("relation is the student's primary contact; assign another primary contact first")
+		}
+	}
+	return nil
+}
+
+// boolOrDefault dereferences an optional bool
+func boolOrDefault(v *bool, def bool) bool {
+	if v == nil {
+		return def
+	}
+	return *v
 }
@@ -7,13 +7,42 @@ import (
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// activeParticipantMocks returns user and membership mocks where every user exists,
+// is active and holds an active student membership.
+func activeParticipantMocks() (*mock.MockUserRepository, *mock.MockMembershipRepository) {
+	userRepo := &mock.MockUserRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+			return &entities.User{ID: id, IsActive: true}, nil
+		},
+	}
+	membershipRepo := &mock.MockMembershipRepository{
+		FindByUserFn: func(_ context.Context, userID uuid.UUID, _ sharedrepo.ListFilters) ([]*entities.Membership, int64, error) {
+			return []*entities.Membership{{ID: uuid.New(), UserID: userID, Role: "student", IsActive: true}}, 1, nil
+		},
+	}
+	return userRepo, membershipRepo
+}
+
+// existingRelation returns an active relation with the requested ID.
+func existingRelation(_ context.Context, id uuid.UUID) (*entities.GuardianRelation, error) {
+	return &entities.GuardianRelation{ID: id, GuardianID: uuid.New(), StudentID: uuid.New(), RelationshipType: "mother", IsActive: true}, nil
+}
+
+func newTestGuardianService(repo *mock.MockGuardianRepository) service.GuardianService {
+	userRepo, membershipRepo := activeParticipantMocks()
+	return service.NewGuardianService(repo, userRepo, membershipRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+}
+
 func TestGuardianService_CreateRelation(t *testing.T) {
 	guardianID := uuid.New().String()
 	studentID := uuid.New().String()
@@ -83,6 +112,20 @@ func TestGuardianService_CreateRelation(t *testing.T) {
 			wantErr:     true,
 			errContains: "relationship_type is required",
 		},
+		{
+			name:        "error - relationship type not in catalog",
+			request:     dto.CreateGuardianRelationRequest{GuardianID: guardianID, StudentID: studentID, RelationshipType: "neighbor"},
+			setupMock:   func(_ *mock.MockGuardianRepository) {},
+			wantErr:     true,
+			errContains: "invalid relationship_type",
+		},
+		{
+			name:        "error - guardian and student are the same user",
+			request:     dto.CreateGuardianRelationRequest{GuardianID: guardianID, StudentID: guardianID, RelationshipType: "father"},
+			setupMock:   func(_ *mock.MockGuardianRepository) {},
+			wantErr:     true,
+			errContains: "must be different",
+		},
 		{
 			name:    "error - relation already exists",
 			request: dto.CreateGuardianRelationRequest{GuardianID: guardianID, StudentID: studentID, RelationshipType: "father"},
@@ -110,7 +153,7 @@ func TestGuardianService_CreateRelation(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewGuardianService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := newTestGuardianService(mockRepo)
 			result, err := svc.CreateRelation(context.Background(), tt.request, tt.createdBy)
 
 			if tt.wantErr {
@@ -170,7 +213,7 @@ func TestGuardianService_GetRelation(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewGuardianService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := newTestGuardianService(mockRepo)
 			result, err := svc.GetRelation(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -194,6 +237,7 @@ func TestGuardianService_DeleteRelation(t *testing.T) {
 			name: "success",
 			id:   uuid.New().String(),
 			setupMock: func(m *mock.MockGuardianRepository) {
+				m.FindByIDFn = existingRelation
 				m.DeleteFn = func(_ context.Context, _ uuid.UUID) error { return nil }
 			},
 			wantErr: false,
@@ -208,10 +252,17 @@ func TestGuardianService_DeleteRelation(t *testing.T) {
 			name: "error - database error",
 			id:   uuid.New().String(),
 			setupMock: func(m *mock.MockGuardianRepository) {
+				m.FindByIDFn = existingRelation
 				m.DeleteFn = func(_ context.Context, _ uuid.UUID) error { return fmt.Errorf("db error") }
 			},
 			wantErr: true,
 		},
+		{
+			name:      "error - not found",
+			id:        uuid.New().String(),
+			setupMock: func(_ *mock.MockGuardianRepository) {},
+			wantErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -221,7 +272,7 @@ func TestGuardianService_DeleteRelation(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewGuardianService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := newTestGuardianService(mockRepo)
 			err := svc.DeleteRelation(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -237,20 +288,21 @@ func TestGuardianService_GetGuardianRelations(t *testing.T) {
 	guardianID := uuid.New()
 
 	tests := []struct {
-		name      string
-		id        string
-		setupMock func(m *mock.MockGuardianRepository)
-		wantErr   bool
-		wantCount int
+		name       string
+		id         string
+		relFilters dto.GuardianRelationListFilters
+		setupMock  func(m *mock.MockGuardianRepository)
+		wantErr    bool
+		wantCount  int
 	}{
 		{
 			name: "success",
 			id:   guardianID.String(),
 			setupMock: func(m *mock.MockGuardianRepository) {
-				m.FindByGuardianFn = func(_ context.Context, _ uuid.UUID) ([]*entities.GuardianRelation, error) {
+				m.ListByGuardianFn = func(_ context.Context, _ uuid.UUID, _ repository.GuardianRelationFilters, _ sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
 					return []*entities.GuardianRelation{
 						{ID: uuid.New(), GuardianID: guardianID, StudentID: uuid.New(), RelationshipType: "father"},
-					}, nil
+					}, 1, nil
 				}
 			},
 			wantErr:   false,
@@ -262,6 +314,13 @@ func TestGuardianService_GetGuardianRelations(t *testing.T) {
 			setupMock: func(_ *mock.MockGuardianRepository) {},
 			wantErr:   true,
 		},
+		{
+			name:       "error - relationship type filter not in catalog",
+			id:         guardianID.String(),
+			relFilters: dto.GuardianRelationListFilters{RelationshipType: "neighbor"},
+			setupMock:  func(_ *mock.MockGuardianRepository) {},
+			wantErr:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -271,14 +330,15 @@ func TestGuardianService_GetGuardianRelations(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewGuardianService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
-			result, err := svc.GetGuardianRelations(context.Background(), tt.id)
+			svc := newTestGuardianService(mockRepo)
+			result, total, err := svc.GetGuardianRelations(context.Background(), tt.id, tt.relFilters, sharedrepo.ListFilters{})
 
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
 				require.NoError(t, err)
 				assert.Len(t, result, tt.wantCount)
+				assert.Equal(t, tt.wantCount, total)
 			}
 		})
 	}
@@ -297,8 +357,8 @@ func TestGuardianService_GetStudentGuardians(t *testing.T) {
 			name: "success",
 			id:   studentID.String(),
 			setupMock: func(m *mock.MockGuardianRepository) {
-				m.FindByStudentFn = func(_ context.Context, _ uuid.UUID) ([]*entities.GuardianRelation, error) {
-					return []*entities.GuardianRelation{}, nil
+				m.ListByStudentFn = func(_ context.Context, _ uuid.UUID, _ repository.GuardianRelationFilters, _ sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+					return []*entities.GuardianRelation{}, 0, nil
 				}
 			},
 			wantErr: false,
@@ -318,14 +378,278 @@ func TestGuardianService_GetStudentGuardians(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewGuardianService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
-			_, err := svc.GetStudentGuardians(context.Background(), tt.id)
+			svc := newTestGuardianService(mockRepo)
+			_, _, err := svc.GetStudentGuardians(context.Background(), tt.id, dto.GuardianRelationListFilters{}, sharedrepo.ListFilters{})
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGuardianService_CreateRelation_ParticipantValidation(t *testing.T) {
+	guardianID := uuid.New()
+	studentID := uuid.New()
+	request := dto.CreateGuardianRelationRequest{GuardianID: guardianID.String(), StudentID: studentID.String(), RelationshipType: "mother"}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(u *mock.MockUserRepository, m *mock.MockMembershipRepository)
+		errContains string
+	}{
+		{
+			name: "error - guardian does not exist",
+			setupMocks: func(u *mock.MockUserRepository, _ *mock.MockMembershipRepository) {
+				u.FindByIDFn = func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+					if id == guardianID {
+						return nil, nil
+					}
+					return &entities.User{ID: id, IsActive: true}, nil
+				}
+			},
+			errContains: "guardian not found",
+		},
+		{
+			name: "error - student is inactive",
+			setupMocks: func(u *mock.MockUserRepository, _ *mock.MockMembershipRepository) {
+				u.FindByIDFn = func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+					return &entities.User{ID: id, IsActive: id != studentID}, nil
+				}
+			},
+			errContains: "student user is not active",
+		},
+		{
+			name: "error - student has no active student membership",
+			setupMocks: func(_ *mock.MockUserRepository, m *mock.MockMembershipRepository) {
+				m.FindByUserFn = func(_ context.Context, userID uuid.UUID, _ sharedrepo.ListFilters) ([]*entities.Membership, int64, error) {
+					return []*entities.Membership{
+						{ID: uuid.New(), UserID: userID, Role: "teacher", IsActive: true},
+						{ID: uuid.New(), UserID: userID, Role: "student", IsActive: false},
+					}, 2, nil
+				}
+			},
+			errContains: "no active student membership",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo, membershipRepo := activeParticipantMocks()
+			tt.setupMocks(userRepo, membershipRepo)
+			guardianRepo := &mock.MockGuardianRepository{
+				CreateFn: func(_ context.Context, _ *entities.GuardianRelation) error {
+					t.Fatal("Create no debe llamarse cuando la validación falla")
+					return nil
+				},
+			}
+
+			svc := service.NewGuardianService(guardianRepo, userRepo, membershipRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			_, err := svc.CreateRelation(context.Background(), request, "")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+func TestGuardianService_UpdateRelation(t *testing.T) {
+	relationID := uuid.New()
+	existing := func() *entities.GuardianRelation {
+		return &entities.GuardianRelation{ID: relationID, GuardianID: uuid.New(), StudentID: uuid.New(), RelationshipType: "father", IsActive: true}
+	}
+	invalidType := "neighbor"
+	validType := "legal_guardian"
+
+	tests := []struct {
+		name        string
+		request     dto.UpdateGuardianRelationRequest
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:    "success - valid relationship type",
+			request: dto.UpdateGuardianRelationRequest{RelationshipType: &validType},
+			wantErr: false,
+		},
+		{
+			name:        "error - relationship type not in catalog",
+			request:     dto.UpdateGuardianRelationRequest{RelationshipType: &invalidType},
+			wantErr:     true,
+			errContains: "invalid relationship_type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mock.MockGuardianRepository{
+				FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.GuardianRelation, error) { return existing(), nil },
+			}
+
+			svc := newTestGuardianService(mockRepo)
+			result, err := svc.UpdateRelation(context.Background(), relationID.String(), tt.request)
 
 			if tt.wantErr {
 				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
 			} else {
 				require.NoError(t, err)
+				assert.Equal(t, validType, result.RelationshipType)
 			}
 		})
 	}
 }
+
+func TestGuardianService_PrimaryContact(t *testing.T) {
+	studentID := uuid.New()
+	primaryID := uuid.New()
+	secondaryID := uuid.New()
+	yes, no := true, false
+
+	relations := func(_ context.Context, _ uuid.UUID) ([]*entities.GuardianRelation, error) {
+		return []*entities.GuardianRelation{
+			{ID: primaryID, GuardianID: uuid.New(), StudentID: studentID, IsActive: true},
+			{ID: secondaryID, GuardianID: uuid.New(), StudentID: studentID, IsActive: true},
+		}, nil
+	}
+	details := func(_ context.Context, _ []uuid.UUID) ([]*entity.GuardianRelationDetail, error) {
+		return []*entity.GuardianRelationDetail{
+			{GuardianRelationID: primaryID, IsPrimaryContact: true, ReceivesCommunications: true},
+			{GuardianRelationID: secondaryID, ReceivesCommunications: true},
+		}, nil
+	}
+	findByID := func(_ context.Context, id uuid.UUID) (*entities.GuardianRelation, error) {
+		return &entities.GuardianRelation{ID: id, GuardianID: uuid.New(), StudentID: studentID, RelationshipType: "mother", IsActive: true}, nil
+	}
+
+	t.Run("first guardian becomes primary contact by default", func(t *testing.T) {
+		var saved *entity.GuardianRelationDetail
+		mockRepo := &mock.MockGuardianRepository{
+			SaveDetailFn: func(_ context.Context, _ uuid.UUID, d *entity.GuardianRelationDetail) error { saved = d; return nil },
+		}
+		svc := newTestGuardianService(mockRepo)
+		result, err := svc.CreateRelation(context.Background(), dto.CreateGuardianRelationRequest{
+			GuardianID: uuid.New().String(), StudentID: studentID.String(), RelationshipType: "mother",
+		}, "")
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.True(t, result.IsPrimaryContact)
+		assert.True(t, result.ReceivesCommunications)
+		assert.False(t, result.CanPickup)
+	})
+
+	t.Run("error - first guardian explicitly not primary", func(t *testing.T) {
+		svc := newTestGuardianService(&mock.MockGuardianRepository{})
+		_, err := svc.CreateRelation(context.Background(), dto.CreateGuardianRelationRequest{
+			GuardianID: uuid.New().String(), StudentID: studentID.String(), RelationshipType: "mother", IsPrimaryContact: &no,
+		}, "")
+
+		require.Error(t, err)
+	})
+
+	t.Run("additional guardian is not primary unless requested", func(t *testing.T) {
+		mockRepo := &mock.MockGuardianRepository{FindByStudentFn: relations, FindDetailsByRelationIDsFn: details}
+		svc := newTestGuardianService(mockRepo)
+		result, err := svc.CreateRelation(context.Background(), dto.CreateGuardianRelationRequest{
+			GuardianID: uuid.New().String(), StudentID: studentID.String(), RelationshipType: "father", CanPickup: &yes,
+		}, "")
+
+		require.NoError(t, err)
+		assert.False(t, result.IsPrimaryContact)
+		assert.True(t, result.CanPickup)
+	})
+
+	t.Run("error - unsetting the current primary contact", func(t *testing.T) {
+		mockRepo := &mock.MockGuardianRepository{FindByIDFn: findByID, FindByStudentFn: relations, FindDetailsByRelationIDsFn: details}
+		svc := newTestGuardianService(mockRepo)
+		_, err := svc.UpdateRelation(context.Background(), primaryID.String(), dto.UpdateGuardianRelationRequest{IsPrimaryContact: &no})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "primary contact")
+	})
+
+	t.Run("error - deactivating the primary contact with other guardians", func(t *testing.T) {
+		mockRepo := &mock.MockGuardianRepository{FindByIDFn: findByID, FindByStudentFn: relations, FindDetailsByRelationIDsFn: details}
+		svc := newTestGuardianService(mockRepo)
+		_, err := svc.UpdateRelation(context.Background(), primaryID.String(), dto.UpdateGuardianRelationRequest{IsActive: &no})
+
+		require.Error(t, err)
+	})
+
+	t.Run("error - deleting the primary contact with other guardians", func(t *testing.T) {
+		mockRepo := &mock.MockGuardianRepository{FindByIDFn: findByID, FindByStudentFn: relations, FindDetailsByRelationIDsFn: details}
+		svc := newTestGuardianService(mockRepo)
+		err := svc.DeleteRelation(context.Background(), primaryID.String())
+
+		require.Error(t, err)
+	})
+
+	t.Run("deleting a secondary guardian", func(t *testing.T) {
+		mockRepo := &mock.MockGuardianRepository{FindByIDFn: findByID, FindByStudentFn: relations, FindDetailsByRelationIDsFn: details}
+		svc := newTestGuardianService(mockRepo)
+		err := svc.DeleteRelation(context.Background(), secondaryID.String())
+
+		require.NoError(t, err)
+	})
+}
+
+func TestGuardianService_GetAuthorizedPickups(t *testing.T) {
+	studentID := uuid.New()
+	pickupID := uuid.New()
+	otherID := uuid.New()
+
+	mockRepo := &mock.MockGuardianRepository{
+		FindByStudentFn: func(_ context.Context, _ uuid.UUID) ([]*entities.GuardianRelation, error) {
+			return []*entities.GuardianRelation{
+				{ID: pickupID, GuardianID: uuid.New(), StudentID: studentID, RelationshipType: "grandparent", IsActive: true},
+				{ID: otherID, GuardianID: uuid.New(), StudentID: studentID, RelationshipType: "uncle_aunt", IsActive: true},
+			}, nil
+		},
+		FindDetailsByRelationIDsFn: func(_ context.Context, _ []uuid.UUID) ([]*entity.GuardianRelationDetail, error) {
+			return []*entity.GuardianRelationDetail{
+				{GuardianRelationID: pickupID, CanPickup: true},
+				{GuardianRelationID: otherID, CanPickup: false},
+			}, nil
+		},
+	}
+	svc := newTestGuardianService(mockRepo)
+
+	pickups, err := svc.GetAuthorizedPickups(context.Background(), studentID.String())
+	require.NoError(t, err)
+	require.Len(t, pickups, 1)
+	assert.Equal(t, pickupID.String(), pickups[0].RelationID)
+
+	_, err = svc.GetAuthorizedPickups(context.Background(), "bad")
+	require.Error(t, err)
+}
+
+func TestGuardianService_ListSchoolRelations(t *testing.T) {
+	schoolID := uuid.New()
+	var gotFilters repository.GuardianRelationFilters
+	var gotSchool uuid.UUID
+	mockRepo := &mock.MockGuardianRepository{
+		ListBySchoolFn: func(_ context.Context, sid uuid.UUID, relFilters repository.GuardianRelationFilters, _ sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+			gotSchool = sid
+			gotFilters = relFilters
+			return []*entities.GuardianRelation{
+				{ID: uuid.New(), GuardianID: uuid.New(), StudentID: uuid.New(), RelationshipType: "mother", IsActive: false},
+			}, 7, nil
+		},
+	}
+	svc := newTestGuardianService(mockRepo)
+
+	result, total, err := svc.ListSchoolRelations(context.Background(), schoolID.String(),
+		dto.GuardianRelationListFilters{IncludeInactive: true, RelationshipType: "mother"}, sharedrepo.ListFilters{Page: 2, Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 7, total)
+	assert.Equal(t, schoolID, gotSchool)
+	assert.True(t, gotFilters.IncludeInactive)
+	assert.Equal(t, "mother", gotFilters.RelationshipType)
+
+	_, _, err = svc.ListSchoolRelations(context.Background(), "bad", dto.GuardianRelationListFilters{}, sharedrepo.ListFilters{})
+	require.Error(t, err)
+}
@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// Household sources
+const (
+	HouseholdSourceAssigned = "assigned"
+	HouseholdSourceComputed = "computed"
+)
+
+// maxHouseholdSize bounds the guardian relation walk used to compute a household
+const maxHouseholdSize = 50
+
+// HouseholdService defines the household service interface
+type HouseholdService interface {
+	CreateHousehold(ctx context.Context, req dto.CreateHouseholdRequest, createdBy string) (*dto.HouseholdResponse, error)
+	GetHousehold(ctx context.Context, id string) (*dto.HouseholdResponse, error)
+	GetStudentHousehold(ctx context.Context, studentID string) (*dto.HouseholdResponse, error)
+	AddMember(ctx context.Context, householdID string, req dto.HouseholdMemberRequest) (*dto.HouseholdResponse, error)
+	RemoveMember(ctx context.Context, householdID, userID string) error
+}
+
+type householdService struct {
+	householdRepo  repository.HouseholdRepository
+	guardianRepo   repository.GuardianRepository
+	userRepo       sharedrepo.UserRepository
+	membershipRepo sharedrepo.MembershipRepository
+	logger         logger.Logger
+	auditLogger    audit.AuditLogger
+}
+
+// NewHouseholdService creates a new household service
+func NewHouseholdService(
+	householdRepo repository.HouseholdRepository,
+	guardianRepo repository.GuardianRepository,
+	userRepo sharedrepo.UserRepository,
+	membershipRepo sharedrepo.MembershipRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) HouseholdService {
+	return &householdService{
+		householdRepo:  householdRepo,
+		guardianRepo:   guardianRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		logger:         logger,
+		auditLogger:    auditLogger,
+	}
+}
+
+// householdGroup is the set of users in a household and their roles, in insertion order
+type householdGroup struct {
+	order []uuid.UUID
+	roles map[uuid.UUID]string
+}
+
+func newHouseholdGroup() *householdGroup {
+	return &householdGroup{roles: make(map[uuid.UUID]string)}
+}
+
+func (g *householdGroup) add(userID uuid.UUID, role string) bool {
+	if _, ok := g.roles[userID]; ok {
+		return false
+	}
+	g.order = append(g.order, userID)
+	g.roles[userID] = role
+	return true
+}
+
+func (s *householdService) CreateHousehold(ctx context.Context, req dto.CreateHouseholdRequest, createdBy string) (*dto.HouseholdResponse, error) {
+	if (len(req.Members) == 0) == (req.FromStudentID == nil) {
+		return nil, errors.NewValidationError("provide either members or from_student_id")
+	}
+
+	group := newHouseholdGroup()
+	if req.FromStudentID != nil {
+		studentID, err := uuid.Parse(*req.FromStudentID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid from_student_id")
+		}
+		if group, err = s.computeGroup(ctx, studentID); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, m := range req.Members {
+			userID, err := uuid.Parse(m.UserID)
+			if err != nil {
+				return nil, errors.NewValidationError("invalid user_id").WithField("user_id", m.UserID)
+			}
+			if m.Role != entity.HouseholdRoleGuardian && m.Role != entity.HouseholdRoleStudent {
+				return nil, errors.NewValidationError("invalid role").WithField("allowed", "guardian,student")
+			}
+			if !group.add(userID, m.Role) {
+				return nil, errors.NewValidationError("duplicate member").WithField("user_id", m.UserID)
+			}
+		}
+	}
+
+	hasStudent := false
+	for _, userID := range group.order {
+		if group.roles[userID] == entity.HouseholdRoleStudent {
+			hasStudent = true
+		}
+		if err := s.ensureAssignable(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	if !hasStudent {
+		return nil, errors.NewValidationError("a household needs at least one student")
+	}
+
+	var createdByUUID *uuid.UUID
+	if createdBy != "" {
+		parsed, err := uuid.Parse(createdBy)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid created_by")
+		}
+		createdByUUID = &parsed
+	}
+
+	now := time.Now()
+	household := &entity.Household{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		CreatedBy: createdByUUID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	members := make([]*entity.HouseholdMember, len(group.order))
+	for i, userID := range group.order {
+		members[i] = &entity.HouseholdMember{UserID: userID, HouseholdID: household.ID, Role: group.roles[userID], CreatedAt: now}
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.householdRepo.Create(ctx, household, members); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "create", ResourceType: "household",
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("create household", err)
+	}
+
+	s.logger.Info("entity created", "entity_type", "household", "entity_id", household.ID.String())
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "create", ResourceType: "household", ResourceID: household.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"members": len(members)},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+
+	return s.buildView(ctx, household, group)
+}
+
+func (s *householdService) GetHousehold(ctx context.Context, id string) (*dto.HouseholdResponse, error) {
+	hid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid household ID")
+	}
+	return s.getAssigned(ctx, hid)
+}
+
+func (s *householdService) GetStudentHousehold(ctx context.Context, studentID string) (*dto.HouseholdResponse, error) {
+	sid, err := uuid.Parse(studentID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid student_id")
+	}
+
+	member, err := s.householdRepo.FindMemberByUser(ctx, sid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find household member", err)
+	}
+	if member != nil {
+		return s.getAssigned(ctx, member.HouseholdID)
+	}
+
+	student, err := s.userRepo.FindByID(ctx, sid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find student user", err)
+	}
+	if student == nil {
+		return nil, errors.NewNotFoundError("student")
+	}
+	group, err := s.computeGroup(ctx, sid)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildView(ctx, nil, group)
+}
+
+func (s *householdService) AddMember(ctx context.Context, householdID string, req dto.HouseholdMemberRequest) (*dto.HouseholdResponse, error) {
+	hid, err := uuid.Parse(householdID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid household ID")
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user_id")
+	}
+	if req.Role != entity.HouseholdRoleGuardian && req.Role != entity.HouseholdRoleStudent {
+		return nil, errors.NewValidationError("invalid role").WithField("allowed", "guardian,student")
+	}
+
+	household, err := s.householdRepo.FindByID(ctx, hid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find household", err)
+	}
+	if household == nil {
+		return nil, errors.NewNotFoundError("household")
+	}
+	if err := s.ensureAssignable(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	member := &entity.HouseholdMember{UserID: userID, HouseholdID: hid, Role: req.Role, CreatedAt: time.Now()}
+	if err := s.householdRepo.AddMember(ctx, member); err != nil {
+		return nil, errors.NewDatabaseError("add household member", err)
+	}
+
+	s.logger.Info("entity updated", "entity_type", "household", "entity_id", householdID, "added_user_id", req.UserID)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "add_member", ResourceType: "household", ResourceID: householdID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"user_id": req.UserID, "role": req.Role},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+
+	return s.getAssigned(ctx, hid)
+}
+
+func (s *householdService) RemoveMember(ctx context.Context, householdID, userID string) error {
+	hid, err := uuid.Parse(householdID)
+	if err != nil {
+		return errors.NewValidationError("invalid household ID")
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.NewValidationError("invalid user_id")
+	}
+
+	member, err := s.householdRepo.FindMemberByUser(ctx, uid)
+	if err != nil {
+		return errors.NewDatabaseError("find household member", err)
+	}
+	if member == nil || member.HouseholdID != hid {
+		return errors.NewNotFoundError("household_member")
+	}
+	if err := s.householdRepo.RemoveMember(ctx, hid, uid); err != nil {
+		return errors.NewDatabaseError("remove household member", err)
+	}
+
+	s.logger.Info("entity updated", "entity_type", "household", "entity_id", householdID, "removed_user_id", userID)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "remove_member", ResourceType: "household", ResourceID: householdID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"user_id": userID},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
+
+// getAssigned loads a stored household and builds its view
+func (s *householdService) getAssigned(ctx context.Context, id uuid.UUID) (*dto.HouseholdResponse, error) {
+	household, err := s.householdRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find household", err)
+	}
+	if household == nil {
+		return nil, errors.NewNotFoundError("household")
+	}
+	members, err := s.householdRepo.FindMembers(ctx, id)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find household members", err)
+	}
+	group := newHouseholdGroup()
+	for _, m := range members {
+		group.add(m.UserID, m.Role)
+	}
+	return s.buildView(ctx, household, group)
+}
+
+// ensureAssignable verifies the user exists and is not already in a household
+func (s *householdService) ensureAssignable(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.NewDatabaseError("find user", err)
+	}
+	if user == nil {
+		return errors.NewNotFoundError("user")
+	}
+	existing, err := s.householdRepo.FindMemberByUser(ctx, userID)
+	if err != nil {
+		return errors.NewDatabaseError("find household member", err)
+	}
+	if existing != nil {
+		return errors.NewAlreadyExistsError("household_member").
+			WithField("user_id", userID.String()).
+			WithField("household_id", existing.HouseholdID.String())
+	}
+	return nil
+}
+
+// computeGroup walks active guardian relations from a student, alternating
+// between a student's guardians and a guardian's students, until the family
+// is complete or maxHouseholdSize users have been reached.
+func (s *householdService) computeGroup(ctx context.Context, studentID uuid.UUID) (*householdGroup, error) {
+	group := newHouseholdGroup()
+	group.add(studentID, entity.HouseholdRoleStudent)
+	students := []uuid.UUID{studentID}
+
+	for len(students) > 0 && len(group.order) < maxHouseholdSize {
+		current := students[0]
+		students = students[1:]
+
+		relations, err := s.guardianRepo.FindByStudent(ctx, current)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find student guardians", err)
+		}
+		for _, r := range relations {
+			if !group.add(r.GuardianID, entity.HouseholdRoleGuardian) {
+				continue
+			}
+			children, err := s.guardianRepo.FindByGuardian(ctx, r.GuardianID)
+			if err != nil {
+				return nil, errors.NewDatabaseError("find guardian relations", err)
+			}
+			for _, c := range children {
+				if len(group.order) >= maxHouseholdSize {
+					break
+				}
+				if group.add(c.StudentID, entity.HouseholdRoleStudent) {
+					students = append(students, c.StudentID)
+				}
+			}
+		}
+	}
+	return group, nil
+}
+
+// buildView loads users, their active memberships and the guardian relations
+// between members. household is nil for computed households.
+func (s *householdService) buildView(ctx context.Context, household *entity.Household, group *householdGroup) (*dto.HouseholdResponse, error) {
+	resp := &dto.HouseholdResponse{
+		Source:    HouseholdSourceComputed,
+		Guardians: []dto.HouseholdMemberResponse{},
+		Students:  []dto.HouseholdMemberResponse{},
+		Relations: []dto.HouseholdRelationResponse{},
+	}
+	if household != nil {
+		resp.ID = household.ID.String()
+		resp.Name = household.Name
+		resp.Source = HouseholdSourceAssigned
+	}
+
+	for _, userID := range group.order {
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find user", err)
+		}
+		if user == nil {
+			continue
+		}
+		member, err := s.toMemberResponse(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		if group.roles[userID] == entity.HouseholdRoleStudent {
+			resp.Students = append(resp.Students, member)
+			continue
+		}
+		resp.Guardians = append(resp.Guardians, member)
+
+		relations, err := s.guardianRepo.FindByGuardian(ctx, userID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find guardian relations", err)
+		}
+		for _, r := range relations {
+			if group.roles[r.StudentID] != entity.HouseholdRoleStudent {
+				continue
+			}
+			resp.Relations = append(resp.Relations, dto.HouseholdRelationResponse{
+				RelationID:       r.ID.String(),
+				GuardianID:       r.GuardianID.String(),
+				StudentID:        r.StudentID.String(),
+				RelationshipType: r.RelationshipType,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// toMemberResponse converts a user and their active memberships to a household member
+func (s *householdService) toMemberResponse(ctx context.Context, user *entities.User) (dto.HouseholdMemberResponse, error) {
+	memberships, _, err := s.membershipRepo.FindByUser(ctx, user.ID, sharedrepo.ListFilters{})
+	if err != nil {
+		return dto.HouseholdMemberResponse{}, errors.NewDatabaseError("find user memberships", err)
+	}
+	units := make([]dto.HouseholdUnitResponse, 0, len(memberships))
+	for _, m := range memberships {
+		if !m.IsActive {
+			continue
+		}
+		unit := dto.HouseholdUnitResponse{SchoolID: m.SchoolID.String(), Role: m.Role}
+		if m.AcademicUnitID != nil {
+			unit.AcademicUnitID = m.AcademicUnitID.String()
+		}
+		units = append(units, unit)
+	}
+	return dto.HouseholdMemberResponse{
+		UserID:    user.ID.String(),
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Units:     units,
+	}, nil
+}
@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// familyGuardianRepo models two siblings: studentA has guardian g1, studentB has
+// guardians g1 and g2. g3 is guardian of an unrelated student.
+func familyGuardianRepo(studentA, studentB, g1, g2 uuid.UUID) *mock.MockGuardianRepository {
+	relations := []*entities.GuardianRelation{
+		{ID: uuid.New(), GuardianID: g1, StudentID: studentA, RelationshipType: "mother", IsActive: true},
+		{ID: uuid.New(), GuardianID: g1, StudentID: studentB, RelationshipType: "mother", IsActive: true},
+		{ID: uuid.New(), GuardianID: g2, StudentID: studentB, RelationshipType: "father", IsActive: true},
+		{ID: uuid.New(), GuardianID: uuid.New(), StudentID: uuid.New(), RelationshipType: "other", IsActive: true},
+	}
+	return &mock.MockGuardianRepository{
+		FindByStudentFn: func(_ context.Context, studentID uuid.UUID) ([]*entities.GuardianRelation, error) {
+			var out []*entities.GuardianRelation
+			for _, r := range relations {
+				if r.StudentID == studentID {
+					out = append(out, r)
+				}
+			}
+			return out, nil
+		},
+		FindByGuardianFn: func(_ context.Context, guardianID uuid.UUID) ([]*entities.GuardianRelation, error) {
+			var out []*entities.GuardianRelation
+			for _, r := range relations {
+				if r.GuardianID == guardianID {
+					out = append(out, r)
+				}
+			}
+			return out, nil
+		},
+	}
+}
+
+func newTestHouseholdService(householdRepo *mock.MockHouseholdRepository, guardianRepo *mock.MockGuardianRepository) service.HouseholdService {
+	userRepo, membershipRepo := activeParticipantMocks()
+	return service.NewHouseholdService(householdRepo, guardianRepo, userRepo, membershipRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+}
+
+func TestHouseholdService_GetStudentHousehold_Computed(t *testing.T) {
+	studentA, studentB, g1, g2 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	svc := newTestHouseholdService(&mock.MockHouseholdRepository{}, familyGuardianRepo(studentA, studentB, g1, g2))
+
+	result, err := svc.GetStudentHousehold(context.Background(), studentA.String())
+	require.NoError(t, err)
+
+	assert.Equal(t, service.HouseholdSourceComputed, result.Source)
+	assert.Empty(t, result.ID)
+	assert.Len(t, result.Students, 2)
+	assert.Len(t, result.Guardians, 2)
+	assert.Len(t, result.Relations, 3)
+	require.NotEmpty(t, result.Students[0].Units)
+	assert.Equal(t, "student", result.Students[0].Units[0].Role)
+}
+
+func TestHouseholdService_GetStudentHousehold_Assigned(t *testing.T) {
+	studentA, studentB, g1, g2 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	householdID := uuid.New()
+	householdRepo := &mock.MockHouseholdRepository{
+		FindMemberByUserFn: func(_ context.Context, userID uuid.UUID) (*entity.HouseholdMember, error) {
+			return &entity.HouseholdMember{UserID: userID, HouseholdID: householdID, Role: entity.HouseholdRoleStudent}, nil
+		},
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entity.Household, error) {
+			return &entity.Household{ID: id, Name: "Familia Pérez"}, nil
+		},
+		FindMembersFn: func(_ context.Context, _ uuid.UUID) ([]*entity.HouseholdMember, error) {
+			return []*entity.HouseholdMember{
+				{UserID: studentA, HouseholdID: householdID, Role: entity.HouseholdRoleStudent},
+				{UserID: g1, HouseholdID: householdID, Role: entity.HouseholdRoleGuardian},
+			}, nil
+		},
+	}
+	svc := newTestHouseholdService(householdRepo, familyGuardianRepo(studentA, studentB, g1, g2))
+
+	result, err := svc.GetStudentHousehold(context.Background(), studentA.String())
+	require.NoError(t, err)
+
+	assert.Equal(t, service.HouseholdSourceAssigned, result.Source)
+	assert.Equal(t, householdID.String(), result.ID)
+	assert.Len(t, result.Students, 1)
+	assert.Len(t, result.Guardians, 1)
+	// Only relations between members are listed
+	assert.Len(t, result.Relations, 1)
+}
+
+func TestHouseholdService_CreateHousehold(t *testing.T) {
+	studentA, studentB, g1, g2 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	fromStudent := studentA.String()
+
+	tests := []struct {
+		name       string
+		request    dto.CreateHouseholdRequest
+		memberOf   func(_ context.Context, userID uuid.UUID) (*entity.HouseholdMember, error)
+		wantErr    bool
+		wantStatus int
+		wantSize   int
+	}{
+		{
+			name: "success - explicit members",
+			request: dto.CreateHouseholdRequest{Name: "Familia", Members: []dto.HouseholdMemberRequest{
+				{UserID: studentA.String(), Role: "student"},
+				{UserID: g1.String(), Role: "guardian"},
+			}},
+			wantSize: 2,
+		},
+		{
+			name:     "success - computed from student",
+			request:  dto.CreateHouseholdRequest{Name: "Familia", FromStudentID: &fromStudent},
+			wantSize: 4,
+		},
+		{
+			name:       "error - neither members nor from_student_id",
+			request:    dto.CreateHouseholdRequest{Name: "Familia"},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - no students",
+			request: dto.CreateHouseholdRequest{Name: "Familia", Members: []dto.HouseholdMemberRequest{
+				{UserID: g1.String(), Role: "guardian"},
+			}},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - duplicate member",
+			request: dto.CreateHouseholdRequest{Name: "Familia", Members: []dto.HouseholdMemberRequest{
+				{UserID: studentA.String(), Role: "student"},
+				{UserID: studentA.String(), Role: "guardian"},
+			}},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - member already in another household",
+			request: dto.CreateHouseholdRequest{Name: "Familia", Members: []dto.HouseholdMemberRequest{
+				{UserID: studentA.String(), Role: "student"},
+			}},
+			memberOf: func(_ context.Context, userID uuid.UUID) (*entity.HouseholdMember, error) {
+				return &entity.HouseholdMember{UserID: userID, HouseholdID: uuid.New()}, nil
+			},
+			wantErr:    true,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored []*entity.HouseholdMember
+			householdRepo := &mock.MockHouseholdRepository{
+				FindMemberByUserFn: tt.memberOf,
+				CreateFn: func(_ context.Context, _ *entity.Household, members []*entity.HouseholdMember) error {
+					stored = members
+					return nil
+				},
+			}
+			svc := newTestHouseholdService(householdRepo, familyGuardianRepo(studentA, studentB, g1, g2))
+			result, err := svc.CreateHousehold(context.Background(), tt.request, "")
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, stored, tt.wantSize)
+			assert.Equal(t, service.HouseholdSourceAssigned, result.Source)
+			assert.NotEmpty(t, result.ID)
+		})
+	}
+}
+
+func TestHouseholdService_RemoveMember(t *testing.T) {
+	householdID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		member  *entity.HouseholdMember
+		wantErr bool
+	}{
+		{
+			name:    "success",
+			member:  &entity.HouseholdMember{UserID: userID, HouseholdID: householdID},
+			wantErr: false,
+		},
+		{
+			name:    "error - member of another household",
+			member:  &entity.HouseholdMember{UserID: userID, HouseholdID: uuid.New()},
+			wantErr: true,
+		},
+		{
+			name:    "error - not a member",
+			member:  nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			householdRepo := &mock.MockHouseholdRepository{
+				FindMemberByUserFn: func(_ context.Context, _ uuid.UUID) (*entity.HouseholdMember, error) { return tt.member, nil },
+			}
+			svc := newTestHouseholdService(householdRepo, &mock.MockGuardianRepository{})
+			err := svc.RemoveMember(context.Background(), householdID.String(), userID.String())
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
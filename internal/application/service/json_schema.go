@@ -0,0 +1,312 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// jsonSchema is a compiled JSON Schema. Only the keywords front-ends need to
+// render and check metadata forms are supported: type, enum, const,
+// properties, required, additionalProperties, items, the string and array
+// length bounds, pattern and the numeric bounds. Annotations such as title,
+// description or default are kept in the stored schema but not checked.
+// Keywords that would constrain values in ways this validator cannot check,
+// such as oneOf, $ref or format, are rejected so a schema never promises more
+// than it enforces.
+type jsonSchema struct {
+	types                []string
+	enum                 []interface{}
+	constValue           interface{}
+	hasConst             bool
+	properties           map[string]*jsonSchema
+	required             []string
+	additionalProperties *jsonSchema
+	noAdditional         bool
+	items                *jsonSchema
+	minLength, maxLength *int
+	minItems, maxItems   *int
+	pattern              *regexp.Regexp
+	minimum, maximum     *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+}
+
+var jsonSchemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+// unsupportedJSONSchemaKeywords are validation keywords compileJSONSchema
+// does not implement
+var unsupportedJSONSchemaKeywords = []string{
+	"$ref", "$defs", "definitions", "allOf", "anyOf", "oneOf", "not",
+	"if", "then", "else", "format", "multipleOf", "uniqueItems", "contains",
+	"prefixItems", "minProperties", "maxProperties", "patternProperties",
+	"propertyNames", "dependencies", "dependentRequired", "dependentSchemas",
+}
+
+// compileJSONSchema checks a schema document and compiles it. path names the
+// schema location in errors.
+func compileJSONSchema(raw map[string]interface{}, path string) (*jsonSchema, error) {
+	invalid := func(keyword, msg string) error {
+		return fmt.Errorf("%s: %s %s", path, keyword, msg)
+	}
+	for _, keyword := range unsupportedJSONSchemaKeywords {
+		if _, ok := raw[keyword]; ok {
+			return nil, invalid(keyword, "is not supported")
+		}
+	}
+	schema := &jsonSchema{}
+
+	switch t := raw["type"].(type) {
+	case nil:
+	case string:
+		schema.types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok {
+				return nil, invalid("type", "must list type names")
+			}
+			schema.types = append(schema.types, name)
+		}
+	default:
+		return nil, invalid("type", "must be a type name or a list of them")
+	}
+	for _, name := range schema.types {
+		if !jsonSchemaTypes[name] {
+			return nil, invalid("type", "has unknown type "+name)
+		}
+	}
+
+	if v, ok := raw["enum"]; ok {
+		values, ok := v.([]interface{})
+		if !ok || len(values) == 0 {
+			return nil, invalid("enum", "must be a non-empty list")
+		}
+		schema.enum = values
+	}
+	if v, ok := raw["const"]; ok {
+		schema.constValue, schema.hasConst = v, true
+	}
+
+	if v, ok := raw["properties"]; ok {
+		props, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, invalid("properties", "must be an object")
+		}
+		schema.properties = make(map[string]*jsonSchema, len(props))
+		for name, prop := range props {
+			propRaw, ok := prop.(map[string]interface{})
+			if !ok {
+				return nil, invalid("properties", name+" must be a schema")
+			}
+			compiled, err := compileJSONSchema(propRaw, path+"."+name)
+			if err != nil {
+				return nil, err
+			}
+			schema.properties[name] = compiled
+		}
+	}
+	if v, ok := raw["required"]; ok {
+		names, ok := v.([]interface{})
+		if !ok {
+			return nil, invalid("required", "must be a list of property names")
+		}
+		for _, item := range names {
+			name, ok := item.(string)
+			if !ok {
+				return nil, invalid("required", "must be a list of property names")
+			}
+			schema.required = append(schema.required, name)
+		}
+	}
+	switch v := raw["additionalProperties"].(type) {
+	case nil:
+	case bool:
+		schema.noAdditional = !v
+	case map[string]interface{}:
+		compiled, err := compileJSONSchema(v, path+".additionalProperties")
+		if err != nil {
+			return nil, err
+		}
+		schema.additionalProperties = compiled
+	default:
+		return nil, invalid("additionalProperties", "must be a boolean or a schema")
+	}
+
+	if v, ok := raw["items"]; ok {
+		itemsRaw, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, invalid("items", "must be a schema")
+		}
+		compiled, err := compileJSONSchema(itemsRaw, path+".items")
+		if err != nil {
+			return nil, err
+		}
+		schema.items = compiled
+	}
+
+	for keyword, target := range map[string]**int{
+		"minLength": &schema.minLength, "maxLength": &schema.maxLength,
+		"minItems": &schema.minItems, "maxItems": &schema.maxItems,
+	} {
+		if v, ok := raw[keyword]; ok {
+			n, ok := v.(float64)
+			if !ok || n < 0 || n != math.Trunc(n) {
+				return nil, invalid(keyword, "must be a non-negative integer")
+			}
+			count := int(n)
+			*target = &count
+		}
+	}
+	for keyword, target := range map[string]**float64{
+		"minimum": &schema.minimum, "maximum": &schema.maximum,
+		"exclusiveMinimum": &schema.exclusiveMinimum, "exclusiveMaximum": &schema.exclusiveMaximum,
+	} {
+		if v, ok := raw[keyword]; ok {
+			n, ok := v.(float64)
+			if !ok {
+				return nil, invalid(keyword, "must be a number")
+			}
+			*target = &n
+		}
+	}
+	if v, ok := raw["pattern"]; ok {
+		expr, ok := v.(string)
+		if !ok {
+			return nil, invalid("pattern", "must be a string")
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, invalid("pattern", "is not a valid regular expression")
+		}
+		schema.pattern = re
+	}
+	return schema, nil
+}
+
+// jsonType returns the JSON Schema type of a decoded JSON value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+// matchesType reports whether a value of type actual satisfies the schema type
+func matchesType(types []string, actual string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks a decoded JSON value and adds a message per failing
+// location to errs, keyed by path
+func (s *jsonSchema) validate(value interface{}, path string, errs map[string]string) {
+	actual := jsonType(value)
+	if !matchesType(s.types, actual) {
+		errs[path] = "must be of type " + strings.Join(s.types, " or ")
+		return
+	}
+	if s.enum != nil {
+		found := false
+		for _, allowed := range s.enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs[path] = "must be one of the allowed values"
+			return
+		}
+	}
+	if s.hasConst && !reflect.DeepEqual(s.constValue, value) {
+		errs[path] = "must equal the fixed value"
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := len([]rune(v))
+		if s.minLength != nil && length < *s.minLength {
+			errs[path] = fmt.Sprintf("must be at least %d characters", *s.minLength)
+		} else if s.maxLength != nil && length > *s.maxLength {
+			errs[path] = fmt.Sprintf("must be at most %d characters", *s.maxLength)
+		} else if s.pattern != nil && !s.pattern.MatchString(v) {
+			errs[path] = "must match " + s.pattern.String()
+		}
+	case float64:
+		switch {
+		case s.minimum != nil && v < *s.minimum:
+			errs[path] = fmt.Sprintf("must be at least %v", *s.minimum)
+		case s.maximum != nil && v > *s.maximum:
+			errs[path] = fmt.Sprintf("must be at most %v", *s.maximum)
+		case s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum:
+			errs[path] = fmt.Sprintf("must be greater than %v", *s.exclusiveMinimum)
+		case s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum:
+			errs[path] = fmt.Sprintf("must be less than %v", *s.exclusiveMaximum)
+		}
+	case []interface{}:
+		if s.minItems != nil && len(v) < *s.minItems {
+			errs[path] = fmt.Sprintf("must have at least %d items", *s.minItems)
+			return
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			errs[path] = fmt.Sprintf("must have at most %d items", *s.maxItems)
+			return
+		}
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				errs[path+"."+name] = "is required"
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if prop, ok := s.properties[name]; ok {
+				prop.validate(v[name], path+"."+name, errs)
+				continue
+			}
+			switch {
+			case s.noAdditional:
+				errs[path+"."+name] = "is not allowed"
+			case s.additionalProperties != nil:
+				s.additionalProperties.validate(v[name], path+"."+name, errs)
+			}
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	"github.com/google/uuid"
+)
+
+// MetadataSchemaService defines the metadata schema service interface
+type MetadataSchemaService interface {
+	GetSchema(ctx context.Context, entityType, conceptTypeID string) (*dto.MetadataSchemaResponse, error)
+	PutSchema(ctx context.Context, entityType string, req dto.PutMetadataSchemaRequest) (*dto.MetadataSchemaResponse, error)
+	DeleteSchema(ctx context.Context, entityType, conceptTypeID string) error
+}
+
+type metadataSchemaService struct {
+	schemaRepo      repository.MetadataSchemaRepository
+	conceptTypeRepo repository.ConceptTypeRepository
+	logger          logger.Logger
+	auditLogger     audit.AuditLogger
+}
+
+// NewMetadataSchemaService creates a new metadata schema service
+func NewMetadataSchemaService(
+	schemaRepo repository.MetadataSchemaRepository,
+	conceptTypeRepo repository.ConceptTypeRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) MetadataSchemaService {
+	return &metadataSchemaService{
+		schemaRepo:      schemaRepo,
+		conceptTypeRepo: conceptTypeRepo,
+		logger:          logger,
+		auditLogger:     auditLogger,
+	}
+}
+
+// validMetadataEntity reports whether entityType has schema-checked metadata
+func validMetadataEntity(entityType string) bool {
+	return entityType == entity.MetadataEntitySchool || entityType == entity.MetadataEntityAcademicUnit
+}
+
+// parseSchemaScope checks the entity type and parses the optional concept type ID
+func parseSchemaScope(entityType, conceptTypeID string) (*uuid.UUID, error) {
+	if !validMetadataEntity(entityType) {
+		return nil, errors.NewValidationErrorWithFields("invalid metadata schema", map[string]string{
+			"entity": "must be " + entity.MetadataEntitySchool + " or " + entity.MetadataEntityAcademicUnit,
+		})
+	}
+	if strings.TrimSpace(conceptTypeID) == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(conceptTypeID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid concept_type_id")
+	}
+	return &id, nil
+}
+
+// resolveMetadataSchema returns the schema for the entity type and concept
+// type, falling back to the one without a concept type. Nil means metadata is
+// not checked.
+func resolveMetadataSchema(ctx context.Context, repo repository.MetadataSchemaRepository, entityType string, conceptTypeID *uuid.UUID) (*entity.MetadataSchema, error) {
+	if conceptTypeID != nil {
+		schema, err := repo.Find(ctx, entityType, conceptTypeID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find metadata schema", err)
+		}
+		if schema != nil {
+			return schema, nil
+		}
+	}
+	schema, err := repo.Find(ctx, entityType, nil)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find metadata schema", err)
+	}
+	return schema, nil
+}
+
+// loadMetadataSchema compiles the schema that applies to the entity type and
+// concept type, nil when metadata is not checked
+func loadMetadataSchema(ctx context.Context, repo repository.MetadataSchemaRepository, entityType string, conceptTypeID *uuid.UUID) (*jsonSchema, error) {
+	stored, err := resolveMetadataSchema(ctx, repo, entityType, conceptTypeID)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(stored.Schema, &raw); err != nil {
+		return nil, errors.NewDatabaseError("decode metadata schema", err)
+	}
+	schema, err := compileJSONSchema(raw, "metadata")
+	if err != nil {
+		return nil, errors.NewDatabaseError("compile metadata schema", err)
+	}
+	return schema, nil
+}
+
+// checkMetadata validates metadata against a loaded schema. Missing metadata
+// is checked as an empty object so required keys are enforced.
+func checkMetadata(schema *jsonSchema, entityType string, metadata map[string]interface{}) error {
+	if schema == nil {
+		return nil
+	}
+	// Round-trip through JSON so values have the types the schema is checked against
+	value := map[string]interface{}{}
+	if metadata != nil {
+		encoded, err := json.Marshal(metadata)
+		if err != nil {
+			return errors.NewValidationError("invalid metadata")
+		}
+		if err := json.Unmarshal(encoded, &value); err != nil {
+			return errors.NewValidationError("invalid metadata")
+		}
+	}
+	fields := map[string]string{}
+	schema.validate(value, "metadata", fields)
+	if len(fields) > 0 {
+		return errors.NewValidationErrorWithFields("metadata does not match the "+entityType+" schema", fields)
+	}
+	return nil
+}
+
+// validateMetadata checks metadata against the schema that applies to the
+// entity type and concept type, if any
+func validateMetadata(ctx context.Context, repo repository.MetadataSchemaRepository, entityType string, conceptTypeID *uuid.UUID, metadata map[string]interface{}) error {
+	schema, err := loadMetadataSchema(ctx, repo, entityType, conceptTypeID)
+	if err != nil {
+		return err
+	}
+	return checkMetadata(schema, entityType, metadata)
+}
+
+func toMetadataSchemaResponse(schema *entity.MetadataSchema) *dto.MetadataSchemaResponse {
+	response := &dto.MetadataSchemaResponse{
+		ID:         schema.ID.String(),
+		EntityType: schema.EntityType,
+		Schema:     metadataMap(schema.Schema),
+		UpdatedAt:  schema.UpdatedAt,
+	}
+	if schema.ConceptTypeID != nil {
+		response.ConceptTypeID = schema.ConceptTypeID.String()
+	}
+	return response
+}
+
+// GetSchema returns the schema that applies to the entity type and concept
+// type, so front-ends can render metadata forms
+func (s *metadataSchemaService) GetSchema(ctx context.Context, entityType, conceptTypeID string) (*dto.MetadataSchemaResponse, error) {
+	ctID, err := parseSchemaScope(entityType, conceptTypeID)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := resolveMetadataSchema(ctx, s.schemaRepo, entityType, ctID)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, errors.NewNotFoundError("metadata_schema")
+	}
+	return toMetadataSchemaResponse(schema), nil
+}
+
+// PutSchema registers or replaces the schema of an entity type, optionally
+// for one concept type. Schemas using keywords incorrectly or using unsupported
+// keywords are rejected.
+func (s *metadataSchemaService) PutSchema(ctx context.Context, entityType string, req dto.PutMetadataSchemaRequest) (*dto.MetadataSchemaResponse, error) {
+	ctID, err := parseSchemaScope(entityType, req.ConceptTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if ctID != nil {
+		ct, err := s.conceptTypeRepo.FindByID(ctx, *ctID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find concept type", err)
+		}
+		if ct == nil {
+			return nil, errors.NewNotFoundError("concept_type")
+		}
+	}
+	if t, ok := req.Schema["type"]; ok && t != "object" {
+		return nil, errors.NewValidationErrorWithFields("invalid metadata schema", map[string]string{
+			"schema": "must describe an object",
+		})
+	}
+	if _, err := compileJSONSchema(req.Schema, "schema"); err != nil {
+		return nil, errors.NewValidationErrorWithFields("invalid metadata schema", map[string]string{
+			"schema": err.Error(),
+		})
+	}
+	encoded, err := json.Marshal(req.Schema)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid metadata schema")
+	}
+
+	schema, err := s.schemaRepo.Find(ctx, entityType, ctID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find metadata schema", err)
+	}
+	now := time.Now()
+	if schema == nil {
+		schema = &entity.MetadataSchema{ID: uuid.New(), EntityType: entityType, ConceptTypeID: ctID, CreatedAt: now}
+	}
+	schema.Schema = encoded
+	schema.UpdatedAt = now
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.schemaRepo.Save(ctx, schema); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "update", ResourceType: "metadata_schema", ResourceID: schema.ID.String(),
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("save metadata schema", err)
+	}
+
+	s.logger.Info("metadata schema saved", "entity_type", entityType, "entity_id", schema.ID.String())
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "update", ResourceType: "metadata_schema", ResourceID: schema.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{"entity_type": entityType},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return toMetadataSchemaResponse(schema), nil
+}
+
+// DeleteSchema removes the schema registered for exactly the entity type and
+// concept type; metadata then falls back to the general schema, if any
+func (s *metadataSchemaService) DeleteSchema(ctx context.Context, entityType, conceptTypeID string) error {
+	ctID, err := parseSchemaScope(entityType, conceptTypeID)
+	if err != nil {
+		return err
+	}
+	schema, err := s.schemaRepo.Find(ctx, entityType, ctID)
+	if err != nil {
+		return errors.NewDatabaseError("find metadata schema", err)
+	}
+	if schema == nil {
+		return errors.NewNotFoundError("metadata_schema")
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.schemaRepo.Delete(ctx, schema.ID); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "delete", ResourceType: "metadata_schema", ResourceID: schema.ID.String(),
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return errors.NewDatabaseError("delete metadata schema", err)
+	}
+
+	s.logger.Info("entity deleted", "entity_type", "metadata_schema", "entity_id", schema.ID.String())
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "delete", ResourceType: "metadata_schema", ResourceID: schema.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gradeSchema requires a grade_level between 1 and 12 and rejects other keys
+var gradeSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"grade_level"},
+	"properties": map[string]interface{}{
+		"grade_level": map[string]interface{}{"type": "integer", "minimum": float64(1), "maximum": float64(12)},
+		"shift":       map[string]interface{}{"type": "string", "enum": []interface{}{"morning", "afternoon"}},
+	},
+	"additionalProperties": false,
+}
+
+// schemaRepoWith returns a repository holding the given schemas, keyed by
+// entity type and concept type ("" for none)
+func schemaRepoWith(t *testing.T, schemas map[string]map[string]interface{}) *mock.MockMetadataSchemaRepository {
+	return &mock.MockMetadataSchemaRepository{
+		FindFn: func(_ context.Context, entityType string, conceptTypeID *uuid.UUID) (*entity.MetadataSchema, error) {
+			key := entityType
+			if conceptTypeID != nil {
+				key += "/" + conceptTypeID.String()
+			}
+			raw, ok := schemas[key]
+			if !ok {
+				return nil, nil
+			}
+			encoded, err := json.Marshal(raw)
+			require.NoError(t, err)
+			return &entity.MetadataSchema{ID: uuid.New(), EntityType: entityType, ConceptTypeID: conceptTypeID, Schema: encoded}, nil
+		},
+	}
+}
+
+func TestMetadataSchemaService_PutSchema(t *testing.T) {
+	conceptTypeID := uuid.New()
+	newService := func(saved **entity.MetadataSchema) service.MetadataSchemaService {
+		schemaRepo := &mock.MockMetadataSchemaRepository{
+			SaveFn: func(_ context.Context, schema *entity.MetadataSchema) error {
+				*saved = schema
+				return nil
+			},
+		}
+		conceptTypeRepo := &mock.MockConceptTypeRepository{
+			FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+				if id == conceptTypeID {
+					return &entities.ConceptType{ID: id}, nil
+				}
+				return nil, nil
+			},
+		}
+		return service.NewMetadataSchemaService(schemaRepo, conceptTypeRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+	}
+
+	t.Run("success - registers a concept type schema", func(t *testing.T) {
+		var saved *entity.MetadataSchema
+		result, err := newService(&saved).PutSchema(context.Background(), entity.MetadataEntityAcademicUnit,
+			dto.PutMetadataSchemaRequest{ConceptTypeID: conceptTypeID.String(), Schema: gradeSchema})
+
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, conceptTypeID, *saved.ConceptTypeID)
+		assert.Equal(t, conceptTypeID.String(), result.ConceptTypeID)
+		assert.Equal(t, "object", result.Schema["type"])
+	})
+
+	tests := []struct {
+		name        string
+		entityType  string
+		req         dto.PutMetadataSchemaRequest
+		errContains string
+	}{
+		{"error - unknown entity", "subject", dto.PutMetadataSchemaRequest{Schema: gradeSchema}, "invalid metadata schema"},
+		{"error - unknown concept type", entity.MetadataEntitySchool, dto.PutMetadataSchemaRequest{ConceptTypeID: uuid.New().String(), Schema: gradeSchema}, "not found"},
+		{"error - not an object schema", entity.MetadataEntitySchool, dto.PutMetadataSchemaRequest{Schema: map[string]interface{}{"type": "string"}}, "invalid metadata schema"},
+		{"error - bad keyword", entity.MetadataEntitySchool, dto.PutMetadataSchemaRequest{Schema: map[string]interface{}{"properties": map[string]interface{}{"a": map[string]interface{}{"pattern": "("}}}}, "invalid metadata schema"},
+		{"error - unsupported keyword", entity.MetadataEntitySchool, dto.PutMetadataSchemaRequest{Schema: map[string]interface{}{"oneOf": []interface{}{gradeSchema}}}, "invalid metadata schema"},
+		{"error - unsupported nested format", entity.MetadataEntitySchool, dto.PutMetadataSchemaRequest{Schema: map[string]interface{}{"properties": map[string]interface{}{"email": map[string]interface{}{"type": "string", "format": "email"}}}}, "invalid metadata schema"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *entity.MetadataSchema
+			_, err := newService(&saved).PutSchema(context.Background(), tt.entityType, tt.req)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+			assert.Nil(t, saved)
+		})
+	}
+}
+
+func TestMetadataSchemaService_GetSchema(t *testing.T) {
+	conceptTypeID := uuid.New()
+	repo := schemaRepoWith(t, map[string]map[string]interface{}{
+		"school":                           {"type": "object"},
+		"school/" + conceptTypeID.String(): gradeSchema,
+	})
+	svc := service.NewMetadataSchemaService(repo, &mock.MockConceptTypeRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	result, err := svc.GetSchema(context.Background(), entity.MetadataEntitySchool, conceptTypeID.String())
+	require.NoError(t, err)
+	assert.Equal(t, conceptTypeID.String(), result.ConceptTypeID)
+
+	result, err = svc.GetSchema(context.Background(), entity.MetadataEntitySchool, uuid.New().String())
+	require.NoError(t, err)
+	assert.Empty(t, result.ConceptTypeID, "falls back to the general schema")
+
+	_, err = svc.GetSchema(context.Background(), entity.MetadataEntityAcademicUnit, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestAcademicUnitService_CreateUnit_MetadataSchema(t *testing.T) {
+	schoolID, conceptTypeID := uuid.New(), uuid.New()
+	repo := schemaRepoWith(t, map[string]map[string]interface{}{
+		"academic_unit/" + conceptTypeID.String(): gradeSchema,
+	})
+	unitRepo := &mock.MockAcademicUnitRepository{
+		ExistsBySchoolIDAndCodeFn: func(_ context.Context, _ uuid.UUID, _ string) (bool, error) { return false, nil },
+	}
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) {
+			return &entities.School{ID: schoolID, ConceptTypeID: &conceptTypeID}, nil
+		},
+	}
+	svc := service.NewAcademicUnitService(unitRepo, schoolRepo, repo, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		wantErr  []string
+	}{
+		{"success - matches the schema", map[string]interface{}{"grade_level": 3, "shift": "morning"}, nil},
+		{"error - missing required key", nil, []string{"metadata.grade_level"}},
+		{"error - out of range and unknown values", map[string]interface{}{"grade_level": 13, "shift": "night", "color": "red"},
+			[]string{"metadata.grade_level", "metadata.shift", "metadata.color"}},
+		{"error - wrong type", map[string]interface{}{"grade_level": "three"}, []string{"metadata.grade_level"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateUnit(context.Background(), schoolID.String(),
+				dto.CreateAcademicUnitRequest{Type: "grade", DisplayName: "Grade 3", Code: "G3", Metadata: tt.metadata})
+
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "metadata does not match the academic_unit schema")
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			for _, field := range tt.wantErr {
+				assert.Contains(t, appErr.Fields, field)
+			}
+		})
+	}
+}
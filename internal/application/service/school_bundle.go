@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// SchoolBundleVersion is the school bundle document version produced by
+// exports and accepted by imports
+const SchoolBundleVersion = 1
+
+// Actions reported for the school and each user of an imported bundle
+const (
+	SchoolBundleCreated  = "created"
+	SchoolBundleConflict = "conflict"
+)
+
+// Reasons reported for conflicts. A school code conflict stops the import.
+// A user whose email already exists is skipped together with its memberships:
+// the existing account belongs to someone else and gains no access to the
+// imported school. Repeated emails keep the first user and drop the
+// memberships of the others.
+const (
+	SchoolBundleCodeExists     = "code_exists"
+	SchoolBundleEmailExists    = "email_exists"
+	SchoolBundleDuplicateEmail = "duplicate_email"
+)
+
+// metadataMap decodes stored JSON metadata, nil when empty or malformed
+func metadataMap(raw []byte) map[string]interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// metadataJSON encodes metadata for storage, "{}" when empty
+func metadataJSON(m map[string]interface{}) []byte {
+	if len(m) == 0 {
+		return []byte("{}")
+	}
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return []byte("{}")
+	}
+	return raw
+}
+
+// ExportSchoolBundle builds a school bundle with the school, its concepts,
+// live units, subjects, memberships and the users holding them
+func (s *schoolService) ExportSchoolBundle(ctx context.Context, id string, opts dto.SchoolBundleExportOptions) (*dto.SchoolBundle, error) {
+	schoolID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	data, err := s.bundleRepo.Load(ctx, school, true)
+	if err != nil {
+		return nil, errors.NewDatabaseError("load school bundle", err)
+	}
+
+	now := time.Now().UTC()
+	bundle := &dto.SchoolBundle{
+		Version:    SchoolBundleVersion,
+		ExportedAt: &now,
+		Anonymized: opts.Anonymize,
+		School: dto.SchoolBundleSchool{
+			Name:             school.Name,
+			Code:             school.Code,
+			Address:          school.Address,
+			City:             school.City,
+			Country:          school.Country,
+			ContactEmail:     school.Email,
+			ContactPhone:     school.Phone,
+			SubscriptionTier: school.SubscriptionTier,
+			MaxTeachers:      school.MaxTeachers,
+			MaxStudents:      school.MaxStudents,
+			Metadata:         metadataMap(school.Metadata),
+		},
+		Concepts:    make([]dto.SchoolBundleConcept, len(data.Concepts)),
+		Units:       make([]dto.SchoolBundleUnit, len(data.Units)),
+		Subjects:    make([]dto.SchoolBundleSubject, len(data.Subjects)),
+		Users:       make([]dto.SchoolBundleUser, len(data.Users)),
+		Memberships: make([]dto.SchoolBundleMembership, len(data.Memberships)),
+	}
+	if school.ConceptTypeID != nil {
+		ct, err := s.conceptTypeRepo.FindByID(ctx, *school.ConceptTypeID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find concept type", err)
+		}
+		if ct != nil {
+			bundle.School.ConceptTypeCode = ct.Code
+		}
+	}
+	for i, concept := range data.Concepts {
+		bundle.Concepts[i] = dto.SchoolBundleConcept{TermKey: concept.TermKey, TermValue: concept.TermValue, Category: concept.Category}
+	}
+	// References to soft-deleted units are left out so the bundle imports cleanly
+	exportedUnits := make(map[uuid.UUID]bool, len(data.Units))
+	for _, unit := range data.Units {
+		exportedUnits[unit.ID] = true
+	}
+	for i, unit := range data.Units {
+		bundle.Units[i] = dto.SchoolBundleUnit{
+			ID:           unit.ID.String(),
+			Name:         unit.Name,
+			Code:         unit.Code,
+			Type:         unit.Type,
+			Description:  unit.Description,
+			AcademicYear: unit.AcademicYear,
+			Metadata:     metadataMap(unit.Metadata),
+			IsActive:     unit.IsActive,
+		}
+		if unit.ParentUnitID != nil && exportedUnits[*unit.ParentUnitID] {
+			bundle.Units[i].ParentID = unit.ParentUnitID.String()
+		}
+	}
+	for i, subject := range data.Subjects {
+		bundle.Subjects[i] = dto.SchoolBundleSubject{
+			ID:          subject.ID.String(),
+			Name:        subject.Name,
+			Code:        subject.Code,
+			Description: subject.Description,
+			IsActive:    subject.IsActive,
+		}
+		if subject.AcademicUnitID != nil && exportedUnits[*subject.AcademicUnitID] {
+			bundle.Subjects[i].UnitID = subject.AcademicUnitID.String()
+		}
+	}
+	for i, user := range data.Users {
+		bundleUser := dto.SchoolBundleUser{
+			ID:        user.ID.String(),
+			Email:     user.Email,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			IsActive:  user.IsActive,
+		}
+		switch {
+		case opts.Anonymize:
+			bundleUser.Email = fmt.Sprintf("user%04d@anonymized.invalid", i+1)
+			bundleUser.FirstName = "User"
+			bundleUser.LastName = fmt.Sprintf("%04d", i+1)
+		case opts.IncludePasswordHashes:
+			bundleUser.PasswordHash = user.PasswordHash
+		}
+		bundle.Users[i] = bundleUser
+	}
+	for i, membership := range data.Memberships {
+		bundle.Memberships[i] = dto.SchoolBundleMembership{
+			UserID:      membership.UserID.String(),
+			Role:        membership.Role,
+			Metadata:    metadataMap(membership.Metadata),
+			IsActive:    membership.IsActive,
+			EnrolledAt:  membership.EnrolledAt,
+			WithdrawnAt: membership.WithdrawnAt,
+		}
+		if membership.AcademicUnitID != nil && exportedUnits[*membership.AcademicUnitID] {
+			bundle.Memberships[i].UnitID = membership.AcademicUnitID.String()
+		}
+	}
+
+	withHashes := opts.IncludePasswordHashes && !opts.Anonymize
+	s.logger.Info("school bundle exported", "entity_id", id,
+		"units", len(data.Units), "subjects", len(data.Subjects), "memberships", len(data.Memberships),
+		"anonymized", opts.Anonymize, "password_hashes", withHashes)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	event := audit.AuditEvent{
+		Action: "export", ResourceType: "school", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{"anonymized": opts.Anonymize, "password_hashes": withHashes},
+	}
+	// Exporting password hashes is sensitive enough to stand out in the audit trail
+	if withHashes {
+		event.Severity = audit.SeverityWarning
+	}
+	if err := s.auditLogger.Log(ctx, event); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return bundle, nil
+}
+
+// orderBundleUnits maps the bundle's unit IDs to new ones and returns the
+// units ordered parents first, rejecting unknown parents and cycles
+func orderBundleUnits(units []dto.SchoolBundleUnit) ([]dto.SchoolBundleUnit, map[string]uuid.UUID, error) {
+	invalid := func(msg string) error {
+		return errors.NewValidationErrorWithFields("invalid school bundle", map[string]string{"units": msg})
+	}
+	ids := make(map[string]uuid.UUID, len(units))
+	children := map[string][]dto.SchoolBundleUnit{}
+	var roots []dto.SchoolBundleUnit
+	for _, unit := range units {
+		if _, dup := ids[unit.ID]; dup {
+			return nil, nil, invalid("duplicate unit id " + unit.ID)
+		}
+		ids[unit.ID] = uuid.New()
+	}
+	for _, unit := range units {
+		if unit.ParentID == "" {
+			roots = append(roots, unit)
+			continue
+		}
+		if _, ok := ids[unit.ParentID]; !ok {
+			return nil, nil, invalid("unit " + unit.ID + " refers to unknown parent " + unit.ParentID)
+		}
+		children[unit.ParentID] = append(children[unit.ParentID], unit)
+	}
+	ordered := make([]dto.SchoolBundleUnit, 0, len(units))
+	for queue := roots; len(queue) > 0; queue = queue[1:] {
+		ordered = append(ordered, queue[0])
+		queue = append(queue, children[queue[0].ID]...)
+	}
+	if len(ordered) != len(units) {
+		return nil, nil, invalid("units form a cycle")
+	}
+	return ordered, ids, nil
+}
+
+// ImportSchoolBundle recreates a bundled school under new IDs. The school
+// starts onboarding. Nothing is written on a dry run or when the school code
+// is already taken.
+func (s *schoolService) ImportSchoolBundle(ctx context.Context, bundle *dto.SchoolBundle, dryRun bool) (*dto.SchoolBundleImportResponse, error) {
+	if bundle.Version != SchoolBundleVersion {
+		return nil, errors.NewValidationErrorWithFields("unsupported school bundle", map[string]string{
+			"version": fmt.Sprintf("must be %d", SchoolBundleVersion),
+		})
+	}
+	code := s.codeRules.Normalize(bundle.School.Code)
+	name := strings.TrimSpace(bundle.School.Name)
+	if len(code) < 3 || len(name) < 3 {
+		return nil, errors.NewValidationErrorWithFields("invalid school bundle", map[string]string{
+			"school": "code and name must be at least 3 characters",
+		})
+	}
+	if err := s.codeRules.Validate(code); err != nil {
+		return nil, err
+	}
+
+	units, unitIDs, err := orderBundleUnits(bundle.Units)
+	if err != nil {
+		return nil, err
+	}
+	unitRef := func(field, ref string) (*uuid.UUID, error) {
+		if ref == "" {
+			return nil, nil
+		}
+		id, ok := unitIDs[ref]
+		if !ok {
+			return nil, errors.NewValidationErrorWithFields("invalid school bundle", map[string]string{
+				field: "refers to unknown unit " + ref,
+			})
+		}
+		return &id, nil
+	}
+
+	response := &dto.SchoolBundleImportResponse{
+		Code:     code,
+		Action:   SchoolBundleCreated,
+		DryRun:   dryRun,
+		Concepts: len(bundle.Concepts),
+		Units:    len(units),
+		Subjects: len(bundle.Subjects),
+		Users:    make([]dto.SchoolBundleUserResult, 0, len(bundle.Users)),
+	}
+	exists, err := s.schoolRepo.ExistsByCode(ctx, code)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check school", err)
+	}
+	if exists {
+		response.Action = SchoolBundleConflict
+		response.Reason = SchoolBundleCodeExists
+		response.Conflicts++
+	}
+
+	now := time.Now()
+	school := &entities.School{
+		ID:               uuid.New(),
+		Name:             name,
+		Code:             code,
+		Address:          bundle.School.Address,
+		City:             bundle.School.City,
+		Country:          bundle.School.Country,
+		Phone:            bundle.School.ContactPhone,
+		Email:            bundle.School.ContactEmail,
+		Metadata:         metadataJSON(bundle.School.Metadata),
+		IsActive:         true,
+		SubscriptionTier: bundle.School.SubscriptionTier,
+		MaxTeachers:      bundle.School.MaxTeachers,
+		MaxStudents:      bundle.School.MaxStudents,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if school.Country == "" {
+		school.Country = s.defaults.Country
+	}
+	if school.SubscriptionTier == "" {
+		school.SubscriptionTier = s.defaults.SubscriptionTier
+	}
+	if school.MaxTeachers == 0 {
+		school.MaxTeachers = s.defaults.MaxTeachers
+	}
+	if school.MaxStudents == 0 {
+		school.MaxStudents = s.defaults.MaxStudents
+	}
+	if typeCode := strings.TrimSpace(bundle.School.ConceptTypeCode); typeCode != "" {
+		ct, err := s.conceptTypeRepo.FindByCode(ctx, typeCode)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find concept type", err)
+		}
+		if ct != nil {
+			school.ConceptTypeID = &ct.ID
+		} else {
+			response.UnknownConceptType = typeCode
+		}
+	}
+	if err := validateMetadata(ctx, s.schemaRepo, entity.MetadataEntitySchool, school.ConceptTypeID, bundle.School.Metadata); err != nil {
+		return nil, err
+	}
+	unitSchema, err := loadMetadataSchema(ctx, s.schemaRepo, entity.MetadataEntityAcademicUnit, school.ConceptTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	changedBy := actorUUID(ctx)
+	data := &repository.SchoolBundleData{
+		School:   school,
+		Concepts: make([]*entities.SchoolConcept, 0, len(bundle.Concepts)),
+		Units:    make([]*entities.AcademicUnit, 0, len(units)),
+		Subjects: make([]*entities.Subject, 0, len(bundle.Subjects)),
+		Lifecycle: &entity.SchoolLifecycle{
+			SchoolID: school.ID, State: entity.SchoolStateOnboarding, ChangedBy: changedBy, ChangedAt: now,
+		},
+		Transition: &entity.SchoolLifecycleTransition{
+			ID: uuid.New(), SchoolID: school.ID, ToState: entity.SchoolStateOnboarding, ChangedBy: changedBy, ChangedAt: now,
+		},
+	}
+
+	termKeys := map[string]bool{}
+	for _, item := range bundle.Concepts {
+		key := strings.TrimSpace(item.TermKey)
+		if termKeys[key] {
+			return nil, errors.NewValidationErrorWithFields("invalid school bundle", map[string]string{
+				"concepts": "duplicate term_key " + key,
+			})
+		}
+		termKeys[key] = true
+		category := item.Category
+		if category == "" {
+			category = DefaultConceptCategory
+		}
+		data.Concepts = append(data.Concepts, &entities.SchoolConcept{
+			ID: uuid.New(), SchoolID: school.ID, TermKey: key, TermValue: item.TermValue, Category: category,
+			CreatedAt: now, UpdatedAt: now,
+		})
+	}
+	for _, item := range units {
+		if err := checkMetadata(unitSchema, entity.MetadataEntityAcademicUnit, item.Metadata); err != nil {
+			return nil, err
+		}
+		unit := &entities.AcademicUnit{
+			ID:           unitIDs[item.ID],
+			SchoolID:     school.ID,
+			Name:         item.Name,
+			Code:         item.Code,
+			Type:         item.Type,
+			Description:  item.Description,
+			AcademicYear: item.AcademicYear,
+			Metadata:     metadataJSON(item.Metadata),
+			IsActive:     item.IsActive,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if item.ParentID != "" {
+			parentID := unitIDs[item.ParentID]
+			unit.ParentUnitID = &parentID
+		}
+		data.Units = append(data.Units, unit)
+	}
+	for _, item := range bundle.Subjects {
+		unitID, err := unitRef("subjects", item.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		data.Subjects = append(data.Subjects, &entities.Subject{
+			ID: uuid.New(), SchoolID: school.ID, AcademicUnitID: unitID, Name: item.Name,
+			Code: item.Code, Description: item.Description, IsActive: item.IsActive,
+			CreatedAt: now, UpdatedAt: now,
+		})
+	}
+
+	// Users: map bundle IDs to new accounts; existing emails are conflicts
+	emails := make([]string, 0, len(bundle.Users))
+	for _, item := range bundle.Users {
+		emails = append(emails, strings.ToLower(strings.TrimSpace(item.Email)))
+	}
+	existingUsers, err := s.bundleRepo.FindUsersByEmails(ctx, emails)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find users", err)
+	}
+	existingByEmail := make(map[string]uuid.UUID, len(existingUsers))
+	for _, user := range existingUsers {
+		existingByEmail[strings.ToLower(user.Email)] = user.ID
+	}
+	userIDs := make(map[string]uuid.UUID, len(bundle.Users))
+	knownUsers := map[string]bool{}
+	seenEmails := map[string]bool{}
+	for i, item := range bundle.Users {
+		email := emails[i]
+		if knownUsers[item.ID] {
+			return nil, errors.NewValidationErrorWithFields("invalid school bundle", map[string]string{
+				"users": "duplicate user id " + item.ID,
+			})
+		}
+		knownUsers[item.ID] = true
+		result := dto.SchoolBundleUserResult{Email: email, Action: SchoolBundleCreated}
+		switch {
+		case seenEmails[email]:
+			result.Action, result.Reason = SchoolBundleConflict, SchoolBundleDuplicateEmail
+		case existingByEmail[email] != uuid.Nil:
+			result.Action, result.Reason = SchoolBundleConflict, SchoolBundleEmailExists
+		default:
+			user := &entities.User{
+				ID: uuid.New(), Email: email, FirstName: item.FirstName, LastName: item.LastName,
+				PasswordHash: item.PasswordHash, IsActive: item.IsActive, CreatedAt: now, UpdatedAt: now,
+			}
+			data.Users = append(data.Users, user)
+			userIDs[item.ID] = user.ID
+		}
+		seenEmails[email] = true
+		if result.Action == SchoolBundleConflict {
+			response.Conflicts++
+		}
+		response.Users = append(response.Users, result)
+	}
+	for _, item := range bundle.Memberships {
+		if !knownUsers[item.UserID] {
+			return nil, errors.NewValidationErrorWithFields("invalid school bundle", map[string]string{
+				"memberships": "refers to unknown user " + item.UserID,
+			})
+		}
+		unitID, err := unitRef("memberships", item.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		userID, ok := userIDs[item.UserID]
+		if !ok {
+			continue // the user was dropped as a conflict
+		}
+		data.Memberships = append(data.Memberships, &entities.Membership{
+			ID: uuid.New(), UserID: userID, SchoolID: school.ID, AcademicUnitID: unitID, Role: item.Role,
+			Metadata: metadataJSON(item.Metadata), IsActive: item.IsActive,
+			EnrolledAt: item.EnrolledAt, WithdrawnAt: item.WithdrawnAt, CreatedAt: now, UpdatedAt: now,
+		})
+	}
+	response.Memberships = len(data.Memberships)
+
+	if dryRun || exists {
+		return response, nil
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.bundleRepo.Import(ctx, data); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "import", ResourceType: "school", ResourceID: school.ID.String(),
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("import school bundle", err)
+	}
+	response.SchoolID = school.ID.String()
+
+	s.logger.Info("school bundle imported", "entity_id", response.SchoolID, "code", code,
+		"units", response.Units, "subjects", response.Subjects, "memberships", response.Memberships,
+		"users_created", len(data.Users), "conflicts", response.Conflicts)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "import", ResourceType: "school", ResourceID: response.SchoolID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return response, nil
+}
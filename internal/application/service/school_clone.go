@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// cloneOption resolves an optional clone flag, which defaults to true
+func cloneOption(flag *bool) bool {
+	return flag == nil || *flag
+}
+
+// orderUnitsParentsFirst orders units so every parent comes before its
+// children. Units whose parent is not among them are treated as roots.
+func orderUnitsParentsFirst(units []*entities.AcademicUnit) []*entities.AcademicUnit {
+	known := make(map[uuid.UUID]bool, len(units))
+	for _, unit := range units {
+		known[unit.ID] = true
+	}
+	children := map[uuid.UUID][]*entities.AcademicUnit{}
+	var roots []*entities.AcademicUnit
+	for _, unit := range units {
+		if unit.ParentUnitID == nil || !known[*unit.ParentUnitID] {
+			roots = append(roots, unit)
+			continue
+		}
+		children[*unit.ParentUnitID] = append(children[*unit.ParentUnitID], unit)
+	}
+	ordered := make([]*entities.AcademicUnit, 0, len(units))
+	for queue := roots; len(queue) > 0; queue = queue[1:] {
+		ordered = append(ordered, queue[0])
+		queue = append(queue, children[queue[0].ID]...)
+	}
+	return ordered
+}
+
+// CloneSchool creates a new school from a template school, copying its
+// settings and, as chosen in the request, its terms with their translations,
+// live unit tree and active subjects. Members are never copied. The clone is written in a single
+// transaction and starts onboarding.
+func (s *schoolService) CloneSchool(ctx context.Context, id string, req dto.CloneSchoolRequest) (*dto.SchoolCloneResponse, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 3 {
+		return nil, errors.NewValidationError("name must be at least 3 characters")
+	}
+
+	template, err := s.schoolRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if template == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	var city string
+	if template.City != nil {
+		city = *template.City
+	}
+	code, err := s.resolveSchoolCode(ctx, req.Code, name, city)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.bundleRepo.Load(ctx, template, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("load school", err)
+	}
+
+	now := time.Now()
+	school := &entities.School{
+		ID:               uuid.New(),
+		Name:             name,
+		Code:             code,
+		Country:          template.Country,
+		ConceptTypeID:    template.ConceptTypeID,
+		Metadata:         template.Metadata,
+		IsActive:         true,
+		SubscriptionTier: template.SubscriptionTier,
+		MaxTeachers:      template.MaxTeachers,
+		MaxStudents:      template.MaxStudents,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	changedBy := actorUUID(ctx)
+	data := &repository.SchoolBundleData{
+		School: school,
+		Lifecycle: &entity.SchoolLifecycle{
+			SchoolID: school.ID, State: entity.SchoolStateOnboarding, ChangedBy: changedBy, ChangedAt: now,
+		},
+		Transition: &entity.SchoolLifecycleTransition{
+			ID: uuid.New(), SchoolID: school.ID, ToState: entity.SchoolStateOnboarding, ChangedBy: changedBy, ChangedAt: now,
+		},
+	}
+
+	if cloneOption(req.Concepts) {
+		sources, err := s.schoolConceptRepo.FindSourcesBySchoolID(ctx, template.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find school concept sources", err)
+		}
+		sourceByConcept := make(map[uuid.UUID]*entity.SchoolConceptSource, len(sources))
+		for _, src := range sources {
+			sourceByConcept[src.SchoolConceptID] = src
+		}
+		translations, err := s.schoolConceptRepo.FindTranslationsBySchoolID(ctx, template.ID)
+		if err != nil {
+			return nil, errors.NewDatabaseError("find school concept translations", err)
+		}
+		translationsByConcept := map[uuid.UUID][]*entity.SchoolConceptTranslation{}
+		for _, tr := range translations {
+			translationsByConcept[tr.SchoolConceptID] = append(translationsByConcept[tr.SchoolConceptID], tr)
+		}
+		for _, item := range source.Concepts {
+			concept := &entities.SchoolConcept{
+				ID: uuid.New(), SchoolID: school.ID, TermKey: item.TermKey, TermValue: item.TermValue,
+				Category: item.Category, CreatedAt: now, UpdatedAt: now,
+			}
+			data.Concepts = append(data.Concepts, concept)
+			if src, ok := sourceByConcept[item.ID]; ok {
+				copied := *src
+				copied.SchoolConceptID = concept.ID
+				copied.SchoolID = school.ID
+				copied.CreatedAt, copied.UpdatedAt = now, now
+				data.Sources = append(data.Sources, &copied)
+			}
+			for _, tr := range translationsByConcept[item.ID] {
+				copied := *tr
+				copied.SchoolConceptID = concept.ID
+				copied.SchoolID = school.ID
+				copied.CreatedAt, copied.UpdatedAt = now, now
+				data.Translations = append(data.Translations, &copied)
+			}
+		}
+	} else if school.ConceptTypeID != nil {
+		// Without the template's terms the clone starts from the published defaults, as a new school would
+		set, err := loadPublishedDefinitions(ctx, s.conceptDefRepo, *school.ConceptTypeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, def := range set.Definitions {
+			concept, src := seedSchoolConcept(school.ID, def, set.Version, now)
+			data.Concepts = append(data.Concepts, concept)
+			data.Sources = append(data.Sources, src)
+		}
+	}
+
+	unitIDs := map[uuid.UUID]uuid.UUID{}
+	if cloneOption(req.Units) {
+		for _, item := range orderUnitsParentsFirst(source.Units) {
+			unit := *item
+			unit.ID = uuid.New()
+			unit.SchoolID = school.ID
+			unit.ParentUnitID = nil
+			if item.ParentUnitID != nil {
+				if parentID, ok := unitIDs[*item.ParentUnitID]; ok {
+					unit.ParentUnitID = &parentID
+				}
+			}
+			unit.CreatedAt, unit.UpdatedAt = now, now
+			unitIDs[item.ID] = unit.ID
+			data.Units = append(data.Units, &unit)
+		}
+	}
+	if cloneOption(req.Subjects) {
+		for _, item := range source.Subjects {
+			if !item.IsActive && !req.InactiveSubjects {
+				continue
+			}
+			subject := *item
+			subject.ID = uuid.New()
+			subject.SchoolID = school.ID
+			subject.AcademicUnitID = nil
+			if item.AcademicUnitID != nil {
+				if unitID, ok := unitIDs[*item.AcademicUnitID]; ok {
+					subject.AcademicUnitID = &unitID
+				}
+			}
+			subject.CreatedAt, subject.UpdatedAt = now, now
+			data.Subjects = append(data.Subjects, &subject)
+		}
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.bundleRepo.Import(ctx, data); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "clone", ResourceType: "school", ResourceID: school.ID.String(),
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+			Metadata: map[string]interface{}{"source_school_id": id},
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("clone school", err)
+	}
+
+	s.logger.Info("school cloned", "entity_id", school.ID.String(), "source_school_id", id,
+		"concepts", len(data.Concepts), "units", len(data.Units), "subjects", len(data.Subjects))
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "clone", ResourceType: "school", ResourceID: school.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+		Metadata: map[string]interface{}{"source_school_id": id},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToSchoolResponse(school)
+	response.LifecycleState = entity.SchoolStateOnboarding
+	return &dto.SchoolCloneResponse{
+		School:         response,
+		SourceSchoolID: id,
+		Concepts:       len(data.Concepts),
+		Units:          len(data.Units),
+		Subjects:       len(data.Subjects),
+	}, nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/config"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+)
+
+// Reasons a school code is reported as unavailable
+const (
+	SchoolCodeInvalidFormat = "invalid_format"
+	SchoolCodeTaken         = "taken"
+)
+
+const (
+	defaultSchoolCodeTemplate = "{name}-{seq}"
+	defaultSchoolCodeDigits   = 3
+	// maxSchoolCodeAttempts bounds the sequence numbers tried when generating a code
+	maxSchoolCodeAttempts = 1000
+)
+
+// accentReplacer strips the accents found in school names and cities
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c",
+	"Á", "A", "À", "A", "Â", "A", "Ä", "A", "Ã", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Ö", "O", "Õ", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ñ", "N", "Ç", "C",
+)
+
+// schoolCodeRules normalises, validates and generates school codes as configured
+type schoolCodeRules struct {
+	pattern       *regexp.Regexp
+	normalization string
+	template      string
+	digits        int
+}
+
+// newSchoolCodeRules builds the rules from configuration, which config.Load
+// has already validated. Unset fields fall back to the defaults.
+func newSchoolCodeRules(cfg config.SchoolCodeConfig) *schoolCodeRules {
+	rules := &schoolCodeRules{
+		normalization: cfg.Normalization,
+		template:      cfg.Template,
+		digits:        cfg.SequenceDigits,
+	}
+	if cfg.Pattern != "" {
+		rules.pattern = regexp.MustCompile(cfg.Pattern)
+	}
+	if rules.template == "" {
+		rules.template = defaultSchoolCodeTemplate
+	}
+	if rules.digits <= 0 {
+		rules.digits = defaultSchoolCodeDigits
+	}
+	return rules
+}
+
+// asciiUpper uppercases s without accents and joins its words with hyphens
+func asciiUpper(s string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(accentReplacer.Replace(s))), "-")
+}
+
+// Normalize applies the configured normalisation to a code
+func (r *schoolCodeRules) Normalize(code string) string {
+	code = strings.TrimSpace(code)
+	switch r.normalization {
+	case config.SchoolCodeNormalizeUpper:
+		return strings.ToUpper(code)
+	case config.SchoolCodeNormalizeUpperASCII:
+		return asciiUpper(code)
+	}
+	return code
+}
+
+// Validate checks a normalised code against the length rule and the pattern
+func (r *schoolCodeRules) Validate(code string) error {
+	if len(code) < 3 {
+		return errors.NewValidationError("code must be at least 3 characters")
+	}
+	if r.pattern != nil && !r.pattern.MatchString(code) {
+		return errors.NewValidationErrorWithFields("invalid school code", map[string]string{
+			"code": "must match " + r.pattern.String(),
+		})
+	}
+	return nil
+}
+
+// codePart keeps the letters and digits of s, uppercased and without accents
+func codePart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return -1
+	}, asciiUpper(s))
+}
+
+// nameInitials abbreviates a school name to the initials of its words,
+// skipping short connectors such as "de" or "la". Single-word names keep
+// their first three letters.
+func nameInitials(name string) string {
+	var words []string
+	for _, word := range strings.Fields(name) {
+		if word = codePart(word); len(word) >= 3 {
+			words = append(words, word)
+		}
+	}
+	switch len(words) {
+	case 0:
+		return codePart(name)
+	case 1:
+		return firstChars(words[0], 3)
+	}
+	var initials strings.Builder
+	for _, word := range words {
+		initials.WriteByte(word[0])
+	}
+	return firstChars(initials.String(), 6)
+}
+
+// firstChars returns at most the first n bytes of an ASCII string
+func firstChars(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// candidate fills the template for the given sequence number
+func (r *schoolCodeRules) candidate(name, city string, seq int) string {
+	return r.Normalize(strings.NewReplacer(
+		"{name}", nameInitials(name),
+		"{city}", firstChars(codePart(city), 3),
+		"{seq}", fmt.Sprintf("%0*d", r.digits, seq),
+	).Replace(r.template))
+}
+
+// generateSchoolCode returns the first code built from the template that is
+// valid and not taken, counting the sequence up from 1
+func (s *schoolService) generateSchoolCode(ctx context.Context, name, city string) (string, error) {
+	attempts := maxSchoolCodeAttempts
+	if !strings.Contains(s.codeRules.template, "{seq}") {
+		attempts = 1
+	}
+	for seq := 1; seq <= attempts; seq++ {
+		code := s.codeRules.candidate(name, city, seq)
+		if err := s.codeRules.Validate(code); err != nil {
+			return "", errors.NewValidationErrorWithFields("cannot generate school code", map[string]string{
+				"code": "generated code " + code + " is not valid, provide one",
+			})
+		}
+		exists, err := s.schoolRepo.ExistsByCode(ctx, code)
+		if err != nil {
+			return "", errors.NewDatabaseError("check school", err)
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+	return "", errors.NewValidationErrorWithFields("cannot generate school code", map[string]string{
+		"code": "no free code left for this name, provide one",
+	})
+}
+
+// resolveSchoolCode normalises and validates a requested code, generating one
+// from the name and city when none is given. Requested codes that are taken
+// are reported as conflicts.
+func (s *schoolService) resolveSchoolCode(ctx context.Context, requested, name, city string) (string, error) {
+	if strings.TrimSpace(requested) == "" {
+		return s.generateSchoolCode(ctx, name, city)
+	}
+	code := s.codeRules.Normalize(requested)
+	if err := s.codeRules.Validate(code); err != nil {
+		return "", err
+	}
+	exists, err := s.schoolRepo.ExistsByCode(ctx, code)
+	if err != nil {
+		return "", errors.NewDatabaseError("check school", err)
+	}
+	if exists {
+		return "", errors.NewAlreadyExistsError("school").WithField("code", code)
+	}
+	return code, nil
+}
+
+// CheckSchoolCode reports whether a code is valid and free. Without a code it
+// suggests one generated from the name and city.
+func (s *schoolService) CheckSchoolCode(ctx context.Context, code, name, city string) (*dto.SchoolCodeAvailabilityResponse, error) {
+	if strings.TrimSpace(code) == "" {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.NewValidationError("code or name is required")
+		}
+		generated, err := s.generateSchoolCode(ctx, name, city)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.SchoolCodeAvailabilityResponse{Code: generated, Valid: true, Available: true, Generated: true}, nil
+	}
+
+	response := &dto.SchoolCodeAvailabilityResponse{Code: s.codeRules.Normalize(code)}
+	if err := s.codeRules.Validate(response.Code); err != nil {
+		response.Reason = SchoolCodeInvalidFormat
+		return response, nil
+	}
+	response.Valid = true
+	exists, err := s.schoolRepo.ExistsByCode(ctx, response.Code)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check school", err)
+	}
+	if exists {
+		response.Reason = SchoolCodeTaken
+		return response, nil
+	}
+	response.Available = true
+	return response, nil
+}
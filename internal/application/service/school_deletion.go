@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// deletionToken derives the confirmation token of a deletion preview from the
+// school and its dependent counts, so a preview goes stale as soon as the
+// school or anything counted in it changes
+func deletionToken(school *entities.School, counts *repository.SchoolDependentCounts) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%d|%d|%d|%d|%d|%d|%d|%d|%d|%d|%d|%d",
+		school.ID, school.UpdatedAt.UnixNano(),
+		counts.AcademicUnits, counts.Subjects, counts.Memberships, counts.GuardianRelations,
+		counts.TeachingAssignments, counts.GuardianInvitations, counts.LinkRequests,
+		counts.SchoolConcepts, counts.SubjectPlacements, counts.SubjectPrerequisites,
+		counts.CustomFields, counts.CustomFieldValues)))
+	return hex.EncodeToString(sum[:])
+}
+
+// findSchoolForDeletion loads the school and counts its dependents
+func (s *schoolService) findSchoolForDeletion(ctx context.Context, id string) (*entities.School, *repository.SchoolDependentCounts, error) {
+	schoolID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, nil, errors.NewNotFoundError("school")
+	}
+	counts, err := s.deletionRepo.CountDependents(ctx, schoolID)
+	if err != nil {
+		return nil, nil, errors.NewDatabaseError("count school dependents", err)
+	}
+	return school, counts, nil
+}
+
+// GetDeletionImpact counts the entities deleting the school would affect and
+// returns the token DeleteSchool requires
+func (s *schoolService) GetDeletionImpact(ctx context.Context, id string) (*dto.SchoolDeletionImpactResponse, error) {
+	school, counts, err := s.findSchoolForDeletion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SchoolDeletionImpactResponse{
+		SchoolID:             school.ID.String(),
+		AcademicUnits:        counts.AcademicUnits,
+		Subjects:             counts.Subjects,
+		Memberships:          counts.Memberships,
+		GuardianRelations:    counts.GuardianRelations,
+		TeachingAssignments:  counts.TeachingAssignments,
+		GuardianInvitations:  counts.GuardianInvitations,
+		LinkRequests:         counts.LinkRequests,
+		SchoolConcepts:       counts.SchoolConcepts,
+		SubjectPlacements:    counts.SubjectPlacements,
+		SubjectPrerequisites: counts.SubjectPrerequisites,
+		CustomFields:         counts.CustomFields,
+		CustomFieldValues:    counts.CustomFieldValues,
+		ConfirmationToken:    deletionToken(school, counts),
+	}, nil
+}
+
+// DeleteSchool soft-deletes the school together with its units, subjects,
+// memberships, teaching assignments and guardian relations, revokes its open
+// invitations and rejects pending link requests. Its terms, curriculum,
+// custom fields and lifecycle state are kept with the deleted school. The
+// confirmation token must come from a deletion impact preview that is still
+// current.
+func (s *schoolService) DeleteSchool(ctx context.Context, id string, confirmationToken string) error {
+	if confirmationToken == "" {
+		return errors.NewValidationErrorWithFields("confirmation required", map[string]string{
+			"confirmation_token": "is required, get one from the deletion impact preview",
+		})
+	}
+	school, counts, err := s.findSchoolForDeletion(ctx, id)
+	if err != nil {
+		return err
+	}
+	if confirmationToken != deletionToken(school, counts) {
+		return errors.NewValidationErrorWithFields("confirmation required", map[string]string{
+			"confirmation_token": "does not match the current deletion impact, preview it again",
+		})
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.deletionRepo.DeleteCascade(ctx, school.ID, time.Now()); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "delete", ResourceType: "school", ResourceID: id,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return errors.NewDatabaseError("delete school", err)
+	}
+	s.logger.Info("entity deleted", "entity_type", "school", "entity_id", id,
+		"academic_units", counts.AcademicUnits, "subjects", counts.Subjects,
+		"memberships", counts.Memberships, "guardian_relations", counts.GuardianRelations,
+		"teaching_assignments", counts.TeachingAssignments, "guardian_invitations", counts.GuardianInvitations,
+		"link_requests", counts.LinkRequests)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "delete", ResourceType: "school", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+)
+
+// schoolTransitions lists the states each lifecycle state may move to through
+// ChangeSchoolState. Archived schools leave that state only through RestoreSchool.
+var schoolTransitions = map[string][]string{
+	entity.SchoolStateOnboarding: {entity.SchoolStateActive, entity.SchoolStateArchived},
+	entity.SchoolStateActive:     {entity.SchoolStateSuspended, entity.SchoolStateArchived},
+	entity.SchoolStateSuspended:  {entity.SchoolStateActive, entity.SchoolStateArchived},
+}
+
+// validSchoolState reports whether state is a known lifecycle state
+func validSchoolState(state string) bool {
+	switch state {
+	case entity.SchoolStateOnboarding, entity.SchoolStateActive, entity.SchoolStateSuspended, entity.SchoolStateArchived:
+		return true
+	}
+	return false
+}
+
+// canTransition reports whether a school may move from one state to another
+func canTransition(from, to string) bool {
+	for _, allowed := range schoolTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// stateRequiresReason reports whether moving into state must be justified
+func stateRequiresReason(state string) bool {
+	return state == entity.SchoolStateSuspended || state == entity.SchoolStateArchived
+}
+
+// lifecycleState returns the state held by lifecycle. Schools without a record
+// predate lifecycles; their state follows IsActive.
+func lifecycleState(school *entities.School, lifecycle *entity.SchoolLifecycle) string {
+	if lifecycle != nil {
+		return lifecycle.State
+	}
+	if school.IsActive {
+		return entity.SchoolStateActive
+	}
+	return entity.SchoolStateSuspended
+}
+
+// actorUUID returns the audit actor of ctx as a UUID, nil when it is missing or malformed
+func actorUUID(ctx context.Context) *uuid.UUID {
+	actorID, _, _ := actorFromContext(ctx)
+	id, err := uuid.Parse(actorID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// createOnboardingSchool inserts a new school and records it as onboarding
+// in the same transaction
+func (s *schoolService) createOnboardingSchool(ctx context.Context, school *entities.School, now time.Time) error {
+	changedBy := actorUUID(ctx)
+	lifecycle := &entity.SchoolLifecycle{
+		SchoolID:  school.ID,
+		State:     entity.SchoolStateOnboarding,
+		ChangedBy: changedBy,
+		ChangedAt: now,
+	}
+	transition := &entity.SchoolLifecycleTransition{
+		ID:        uuid.New(),
+		SchoolID:  school.ID,
+		ToState:   entity.SchoolStateOnboarding,
+		ChangedBy: changedBy,
+		ChangedAt: now,
+	}
+	return s.lifecycleRepo.CreateSchool(ctx, school, lifecycle, transition)
+}
+
+// toSchoolResponse converts a school and attaches its lifecycle state
+func (s *schoolService) toSchoolResponse(ctx context.Context, school *entities.School) (*dto.SchoolResponse, error) {
+	lifecycle, err := s.lifecycleRepo.FindBySchoolID(ctx, school.ID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school lifecycle", err)
+	}
+	response := dto.ToSchoolResponse(school)
+	response.LifecycleState = lifecycleState(school, lifecycle)
+	return &response, nil
+}
+
+// toSchoolResponseList converts schools and attaches their lifecycle states
+func (s *schoolService) toSchoolResponseList(ctx context.Context, schools []*entities.School) ([]dto.SchoolResponse, error) {
+	ids := make([]uuid.UUID, len(schools))
+	for i, school := range schools {
+		ids[i] = school.ID
+	}
+	lifecycles, err := s.lifecycleRepo.FindBySchoolIDs(ctx, ids)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school lifecycles", err)
+	}
+	bySchool := make(map[uuid.UUID]*entity.SchoolLifecycle, len(lifecycles))
+	for _, lifecycle := range lifecycles {
+		bySchool[lifecycle.SchoolID] = lifecycle
+	}
+	responses := dto.ToSchoolResponseList(schools)
+	for i := range responses {
+		responses[i].LifecycleState = lifecycleState(schools[i], bySchool[schools[i].ID])
+	}
+	return responses, nil
+}
+
+// ChangeSchoolState moves a school to another lifecycle state. Suspending and
+// archiving require a reason; archived schools can only be restored.
+func (s *schoolService) ChangeSchoolState(ctx context.Context, id string, req dto.ChangeSchoolStateRequest) (*dto.SchoolResponse, error) {
+	target := strings.TrimSpace(req.State)
+	if !validSchoolState(target) {
+		return nil, errors.NewValidationErrorWithFields("invalid school state", map[string]string{
+			"state": "must be one of onboarding, active, suspended, archived",
+		})
+	}
+	return s.transitionSchool(ctx, id, target, req.Reason, "change_state", func(from string) error {
+		if from == entity.SchoolStateArchived {
+			return errors.NewValidationError("archived schools can only be restored")
+		}
+		if !canTransition(from, target) {
+			return errors.NewValidationError("cannot move school from " + from + " to " + target)
+		}
+		return nil
+	})
+}
+
+// RestoreSchool moves an archived school back to active
+func (s *schoolService) RestoreSchool(ctx context.Context, id string, req dto.RestoreSchoolRequest) (*dto.SchoolResponse, error) {
+	return s.transitionSchool(ctx, id, entity.SchoolStateActive, req.Reason, "restore", func(from string) error {
+		if from != entity.SchoolStateArchived {
+			return errors.NewValidationError("only archived schools can be restored")
+		}
+		return nil
+	})
+}
+
+// transitionSchool applies a lifecycle change after check accepts the school's
+// current state, keeping IsActive in step with the new state
+func (s *schoolService) transitionSchool(ctx context.Context, id, target, rawReason, action string, check func(from string) error) (*dto.SchoolResponse, error) {
+	schoolID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	current, err := s.lifecycleRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school lifecycle", err)
+	}
+	from := lifecycleState(school, current)
+	if from == target {
+		return nil, errors.NewValidationError("school is already " + target)
+	}
+	if err := check(from); err != nil {
+		return nil, err
+	}
+	reason := trimOptional(&rawReason)
+	if reason == nil && stateRequiresReason(target) {
+		return nil, errors.NewValidationErrorWithFields("invalid school state change", map[string]string{
+			"reason": "is required to move a school to " + target,
+		})
+	}
+
+	now := time.Now()
+	changedBy := actorUUID(ctx)
+	lifecycle := &entity.SchoolLifecycle{
+		SchoolID:  schoolID,
+		State:     target,
+		Reason:    reason,
+		ChangedBy: changedBy,
+		ChangedAt: now,
+	}
+	transition := &entity.SchoolLifecycleTransition{
+		ID:        uuid.New(),
+		SchoolID:  schoolID,
+		FromState: from,
+		ToState:   target,
+		Reason:    reason,
+		ChangedBy: changedBy,
+		ChangedAt: now,
+	}
+	school.IsActive = target == entity.SchoolStateOnboarding || target == entity.SchoolStateActive
+	school.UpdatedAt = now
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.lifecycleRepo.Transition(ctx, school, lifecycle, transition); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: action, ResourceType: "school", ResourceID: id,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("change school state", err)
+	}
+
+	s.logger.Info("school lifecycle changed", "school_id", id, "from", from, "to", target)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: action, ResourceType: "school", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToSchoolResponse(school)
+	response.LifecycleState = target
+	return &response, nil
+}
+
+// GetSchoolLifecycle returns the current lifecycle state of a school and its
+// transitions, most recent first
+func (s *schoolService) GetSchoolLifecycle(ctx context.Context, id string) (*dto.SchoolLifecycleResponse, error) {
+	schoolID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+	lifecycle, err := s.lifecycleRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school lifecycle", err)
+	}
+	transitions, err := s.lifecycleRepo.FindTransitions(ctx, schoolID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school lifecycle transitions", err)
+	}
+
+	response := &dto.SchoolLifecycleResponse{
+		SchoolID:    id,
+		State:       lifecycleState(school, lifecycle),
+		Transitions: make([]dto.SchoolLifecycleTransitionResponse, len(transitions)),
+	}
+	if lifecycle != nil {
+		response.Reason = lifecycle.Reason
+		if lifecycle.ChangedBy != nil {
+			changedBy := lifecycle.ChangedBy.String()
+			response.ChangedBy = &changedBy
+		}
+		changedAt := lifecycle.ChangedAt
+		response.ChangedAt = &changedAt
+	}
+	for i, t := range transitions {
+		response.Transitions[i] = dto.ToSchoolLifecycleTransitionResponse(t)
+	}
+	return response, nil
+}
+
+// IsSchoolReadOnly reports whether the school's members may only read, which
+// is the case while it is suspended or archived
+func (s *schoolService) IsSchoolReadOnly(ctx context.Context, schoolID uuid.UUID) (bool, error) {
+	lifecycle, err := s.lifecycleRepo.FindBySchoolID(ctx, schoolID)
+	if err != nil {
+		return false, errors.NewDatabaseError("find school lifecycle", err)
+	}
+	if lifecycle != nil {
+		return lifecycle.IsReadOnly(), nil
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolID)
+	if err != nil {
+		return false, errors.NewDatabaseError("find school", err)
+	}
+	return school != nil && lifecycleState(school, nil) == entity.SchoolStateSuspended, nil
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/config"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/audit"
@@ -47,8 +49,17 @@ type SchoolService interface {
 	GetSchool(ctx context.Context, id string) (*dto.SchoolResponse, error)
 	GetSchoolByCode(ctx context.Context, code string) (*dto.SchoolResponse, error)
 	UpdateSchool(ctx context.Context, id string, req dto.UpdateSchoolRequest) (*dto.SchoolResponse, error)
-	ListSchools(ctx context.Context, filters sharedrepo.ListFilters) ([]dto.SchoolResponse, int, error)
-	DeleteSchool(ctx context.Context, id string) error
+	ListSchools(ctx context.Context, filters sharedrepo.ListFilters, includeArchived bool) ([]dto.SchoolResponse, int, error)
+	GetDeletionImpact(ctx context.Context, id string) (*dto.SchoolDeletionImpactResponse, error)
+	DeleteSchool(ctx context.Context, id string, confirmationToken string) error
+	ChangeSchoolState(ctx context.Context, id string, req dto.ChangeSchoolStateRequest) (*dto.SchoolResponse, error)
+	RestoreSchool(ctx context.Context, id string, req dto.RestoreSchoolRequest) (*dto.SchoolResponse, error)
+	GetSchoolLifecycle(ctx context.Context, id string) (*dto.SchoolLifecycleResponse, error)
+	IsSchoolReadOnly(ctx context.Context, schoolID uuid.UUID) (bool, error)
+	ExportSchoolBundle(ctx context.Context, id string, opts dto.SchoolBundleExportOptions) (*dto.SchoolBundle, error)
+	ImportSchoolBundle(ctx context.Context, bundle *dto.SchoolBundle, dryRun bool) (*dto.SchoolBundleImportResponse, error)
+	CloneSchool(ctx context.Context, id string, req dto.CloneSchoolRequest) (*dto.SchoolCloneResponse, error)
+	CheckSchoolCode(ctx context.Context, code, name, city string) (*dto.SchoolCodeAvailabilityResponse, error)
 }
 
 type schoolService struct {
@@ -56,8 +67,13 @@ type schoolService struct {
 	conceptTypeRepo   repository.ConceptTypeRepository
 	conceptDefRepo    repository.ConceptDefinitionRepository
 	schoolConceptRepo repository.SchoolConceptRepository
+	lifecycleRepo     repository.SchoolLifecycleRepository
+	deletionRepo      repository.SchoolDeletionRepository
+	bundleRepo        repository.SchoolBundleRepository
+	schemaRepo        repository.MetadataSchemaRepository
 	logger            logger.Logger
 	defaults          config.SchoolDefaults
+	codeRules         *schoolCodeRules
 	auditLogger       audit.AuditLogger
 }
 
@@ -67,6 +83,10 @@ func NewSchoolService(
 	conceptTypeRepo repository.ConceptTypeRepository,
 	conceptDefRepo repository.ConceptDefinitionRepository,
 	schoolConceptRepo repository.SchoolConceptRepository,
+	lifecycleRepo repository.SchoolLifecycleRepository,
+	deletionRepo repository.SchoolDeletionRepository,
+	bundleRepo repository.SchoolBundleRepository,
+	schemaRepo repository.MetadataSchemaRepository,
 	logger logger.Logger,
 	defaults config.SchoolDefaults,
 	auditLogger audit.AuditLogger,
@@ -76,26 +96,24 @@ func NewSchoolService(
 		conceptTypeRepo:   conceptTypeRepo,
 		conceptDefRepo:    conceptDefRepo,
 		schoolConceptRepo: schoolConceptRepo,
+		lifecycleRepo:     lifecycleRepo,
+		deletionRepo:      deletionRepo,
+		bundleRepo:        bundleRepo,
+		schemaRepo:        schemaRepo,
 		logger:            logger,
 		defaults:          defaults,
+		codeRules:         newSchoolCodeRules(defaults.Code),
 		auditLogger:       auditLogger,
 	}
 }
 
 func (s *schoolService) CreateSchool(ctx context.Context, req dto.CreateSchoolRequest) (*dto.SchoolResponse, error) {
-	exists, err := s.schoolRepo.ExistsByCode(ctx, req.Code)
-	if err != nil {
-		return nil, errors.NewDatabaseError("check school", err)
-	}
-	if exists {
-		return nil, errors.NewAlreadyExistsError("school").WithField("code", req.Code)
-	}
-
 	if req.Name == "" || len(req.Name) < 3 {
 		return nil, errors.NewValidationError("name must be at least 3 characters")
 	}
-	if req.Code == "" || len(req.Code) < 3 {
-		return nil, errors.NewValidationError("code must be at least 3 characters")
+	code, err := s.resolveSchoolCode(ctx, req.Code, req.Name, req.City)
+	if err != nil {
+		return nil, err
 	}
 
 	metadataJSON := []byte("{}")
@@ -146,11 +164,14 @@ func (s *schoolService) CreateSchool(ctx context.Context, req dto.CreateSchoolRe
 		}
 		conceptTypeID = &ctID
 	}
+	if err := validateMetadata(ctx, s.schemaRepo, entity.MetadataEntitySchool, conceptTypeID, req.Metadata); err != nil {
+		return nil, err
+	}
 
 	school := &entities.School{
 		ID:               uuid.New(),
 		Name:             req.Name,
-		Code:             req.Code,
+		Code:             code,
 		Address:          addr,
 		City:             city,
 		Country:          country,
@@ -166,7 +187,7 @@ func (s *schoolService) CreateSchool(ctx context.Context, req dto.CreateSchoolRe
 		UpdatedAt:        now,
 	}
 
-	if err := s.schoolRepo.Create(ctx, school); err != nil {
+	if err := s.createOnboardingSchool(ctx, school, now); err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "create", ResourceType: "school",
@@ -178,27 +199,20 @@ func (s *schoolService) CreateSchool(ctx context.Context, req dto.CreateSchoolRe
 		return nil, errors.NewDatabaseError("create school", err)
 	}
 
-	// Copy concept definitions to school_concepts if concept_type_id was provided
+	// Copy the published concept definitions to school_concepts if concept_type_id was provided
 	if conceptTypeID != nil {
-		defs, err := s.conceptDefRepo.FindByTypeID(ctx, *conceptTypeID)
+		set, err := loadPublishedDefinitions(ctx, s.conceptDefRepo, *conceptTypeID)
 		if err != nil {
 			s.logger.Error("failed to load concept definitions for school", "error", err, "school_id", school.ID.String())
-			return nil, errors.NewDatabaseError("load concept definitions", err)
+			return nil, err
 		}
-		if len(defs) > 0 {
-			concepts := make([]*entities.SchoolConcept, len(defs))
-			for i, def := range defs {
-				concepts[i] = &entities.SchoolConcept{
-					ID:        uuid.New(),
-					SchoolID:  school.ID,
-					TermKey:   def.TermKey,
-					TermValue: def.TermValue,
-					Category:  def.Category,
-					CreatedAt: now,
-					UpdatedAt: now,
-				}
+		if len(set.Definitions) > 0 {
+			concepts := make([]*entities.SchoolConcept, len(set.Definitions))
+			sources := make([]*entity.SchoolConceptSource, len(set.Definitions))
+			for i, def := range set.Definitions {
+				concepts[i], sources[i] = seedSchoolConcept(school.ID, def, set.Version, now)
 			}
-			if err := s.schoolConceptRepo.BulkCreate(ctx, concepts); err != nil {
+			if err := s.schoolConceptRepo.SaveWithSources(ctx, concepts, nil, sources); err != nil {
 				s.logger.Error("failed to copy concept definitions to school", "error", err, "school_id", school.ID.String())
 				return nil, errors.NewDatabaseError("copy concept definitions to school", err)
 			}
@@ -216,6 +230,7 @@ func (s *schoolService) CreateSchool(ctx context.Context, req dto.CreateSchoolRe
 		s.logger.Error("failed to write audit log", "error", err)
 	}
 	response := dto.ToSchoolResponse(school)
+	response.LifecycleState = entity.SchoolStateOnboarding
 	return &response, nil
 }
 
@@ -231,20 +246,26 @@ func (s *schoolService) GetSchool(ctx context.Context, id string) (*dto.SchoolRe
 	if school == nil {
 		return nil, errors.NewNotFoundError("school")
 	}
-	response := dto.ToSchoolResponse(school)
-	return &response, nil
+	return s.toSchoolResponse(ctx, school)
 }
 
+// GetSchoolByCode finds a school by its normalised code, falling back to the
+// code as given for schools stored before normalisation was configured
 func (s *schoolService) GetSchoolByCode(ctx context.Context, code string) (*dto.SchoolResponse, error) {
-	school, err := s.schoolRepo.FindByCode(ctx, code)
+	normalized := s.codeRules.Normalize(code)
+	school, err := s.schoolRepo.FindByCode(ctx, normalized)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find school", err)
 	}
+	if raw := strings.TrimSpace(code); school == nil && raw != normalized {
+		if school, err = s.schoolRepo.FindByCode(ctx, raw); err != nil {
+			return nil, errors.NewDatabaseError("find school", err)
+		}
+	}
 	if school == nil {
 		return nil, errors.NewNotFoundError("school")
 	}
-	response := dto.ToSchoolResponse(school)
-	return &response, nil
+	return s.toSchoolResponse(ctx, school)
 }
 
 func (s *schoolService) UpdateSchool(ctx context.Context, id string, req dto.UpdateSchoolRequest) (*dto.SchoolResponse, error) {
@@ -291,6 +312,9 @@ func (s *schoolService) UpdateSchool(ctx context.Context, id string, req dto.Upd
 		school.MaxStudents = *req.MaxStudents
 	}
 	if req.Metadata != nil {
+		if err := validateMetadata(ctx, s.schemaRepo, entity.MetadataEntitySchool, school.ConceptTypeID, req.Metadata); err != nil {
+			return nil, err
+		}
 		metadataJSON, _ := json.Marshal(req.Metadata)
 		school.Metadata = metadataJSON
 	}
@@ -317,48 +341,23 @@ func (s *schoolService) UpdateSchool(ctx context.Context, id string, req dto.Upd
 	}); err != nil {
 		s.logger.Error("failed to write audit log", "error", err)
 	}
-	response := dto.ToSchoolResponse(school)
-	return &response, nil
+	return s.toSchoolResponse(ctx, school)
 }
 
-func (s *schoolService) ListSchools(ctx context.Context, filters sharedrepo.ListFilters) ([]dto.SchoolResponse, int, error) {
-	schools, total, err := s.schoolRepo.List(ctx, filters)
+// ListSchools lists schools with their lifecycle state. Archived schools are
+// left out unless includeArchived is set.
+func (s *schoolService) ListSchools(ctx context.Context, filters sharedrepo.ListFilters, includeArchived bool) ([]dto.SchoolResponse, int, error) {
+	list := s.lifecycleRepo.ListUnarchivedSchools
+	if includeArchived {
+		list = s.schoolRepo.List
+	}
+	schools, total, err := list(ctx, filters)
 	if err != nil {
 		return nil, 0, errors.NewDatabaseError("list schools", err)
 	}
-	return dto.ToSchoolResponseList(schools), int(total), nil
-}
-
-func (s *schoolService) DeleteSchool(ctx context.Context, id string) error {
-	schoolID, err := uuid.Parse(id)
+	responses, err := s.toSchoolResponseList(ctx, schools)
 	if err != nil {
-		return errors.NewValidationError("invalid school ID")
-	}
-	school, err := s.schoolRepo.FindByID(ctx, schoolID)
-	if err != nil {
-		return errors.NewDatabaseError("find school", err)
-	}
-	if school == nil {
-		return errors.NewNotFoundError("school")
-	}
-	actorID, actorEmail, actorRole := actorFromContext(ctx)
-	if err := s.schoolRepo.Delete(ctx, schoolID); err != nil {
-		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
-			Action: "delete", ResourceType: "school", ResourceID: id,
-			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
-			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryAdmin,
-		}); logErr != nil {
-			s.logger.Error("failed to write audit log", "error", logErr)
-		}
-		return errors.NewDatabaseError("delete school", err)
-	}
-	s.logger.Info("entity deleted", "entity_type", "school", "entity_id", id)
-	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
-		Action: "delete", ResourceType: "school", ResourceID: id,
-		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
-		Severity: audit.SeverityInfo, Category: audit.CategoryAdmin,
-	}); err != nil {
-		s.logger.Error("failed to write audit log", "error", err)
+		return nil, 0, err
 	}
-	return nil
+	return responses, int(total), nil
 }
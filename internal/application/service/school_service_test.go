@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/config"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
@@ -147,7 +150,7 @@ func TestSchoolService_CreateSchool(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{Schools: mockRepo}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
 			result, err := svc.CreateSchool(context.Background(), tt.request)
 
 			if tt.wantErr {
@@ -224,7 +227,7 @@ func TestSchoolService_GetSchool(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
 			result, err := svc.GetSchool(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -288,8 +291,8 @@ func TestSchoolService_ListSchools(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
-			result, _, err := svc.ListSchools(context.Background(), sharedrepo.ListFilters{})
+			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+			result, _, err := svc.ListSchools(context.Background(), sharedrepo.ListFilters{}, true)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -301,6 +304,32 @@ func TestSchoolService_ListSchools(t *testing.T) {
 	}
 }
 
+func TestSchoolService_ListSchools_LeavesOutArchived(t *testing.T) {
+	active := &entities.School{ID: uuid.New(), Name: "School 1", Code: "S1", IsActive: true}
+	inactive := &entities.School{ID: uuid.New(), Name: "School 2", Code: "S2"}
+	lifecycleRepo := &mock.MockSchoolLifecycleRepository{
+		ListUnarchivedSchoolsFn: func(_ context.Context, _ sharedrepo.ListFilters) ([]*entities.School, int64, error) {
+			return []*entities.School{active, inactive}, 2, nil
+		},
+	}
+	schoolRepo := &mock.MockSchoolRepository{
+		ListFn: func(_ context.Context, _ sharedrepo.ListFilters) ([]*entities.School, int64, error) {
+			t.Fatal("archived schools must be filtered by the lifecycle repository")
+			return nil, 0, nil
+		},
+	}
+
+	svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, lifecycleRepo, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+	result, total, err := svc.ListSchools(context.Background(), sharedrepo.ListFilters{}, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, result, 2)
+	// Schools without a lifecycle record take their state from IsActive
+	assert.Equal(t, entity.SchoolStateActive, result[0].LifecycleState)
+	assert.Equal(t, entity.SchoolStateSuspended, result[1].LifecycleState)
+}
+
 func TestSchoolService_DeleteSchool(t *testing.T) {
 	validID := uuid.New()
 
@@ -318,7 +347,6 @@ func TestSchoolService_DeleteSchool(t *testing.T) {
 				m.FindByIDFn = func(_ context.Context, _ uuid.UUID) (*entities.School, error) {
 					return &entities.School{ID: validID, Name: "School"}, nil
 				}
-				m.DeleteFn = func(_ context.Context, _ uuid.UUID) error { return nil }
 			},
 			wantErr: false,
 		},
@@ -349,8 +377,12 @@ func TestSchoolService_DeleteSchool(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
-			err := svc.DeleteSchool(context.Background(), tt.id)
+			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+			token := "unused"
+			if impact, err := svc.GetDeletionImpact(context.Background(), tt.id); err == nil {
+				token = impact.ConfirmationToken
+			}
+			err := svc.DeleteSchool(context.Background(), tt.id, token)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -430,7 +462,7 @@ func TestSchoolService_UpdateSchool(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
 			result, err := svc.UpdateSchool(context.Background(), tt.id, tt.request)
 
 			if tt.wantErr {
@@ -484,7 +516,7 @@ func TestSchoolService_GetSchoolByCode(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+			svc := service.NewSchoolService(mockRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
 			result, err := svc.GetSchoolByCode(context.Background(), tt.code)
 
 			if tt.wantErr {
@@ -499,3 +531,721 @@ func TestSchoolService_GetSchoolByCode(t *testing.T) {
 		})
 	}
 }
+
+func TestSchoolService_CreateSchool_StartsOnboarding(t *testing.T) {
+	var recorded *entity.SchoolLifecycle
+	lifecycleRepo := &mock.MockSchoolLifecycleRepository{
+		CreateSchoolFn: func(_ context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error {
+			// The school and its first state are written together
+			assert.Equal(t, school.ID, lifecycle.SchoolID)
+			recorded = lifecycle
+			assert.Equal(t, entity.SchoolStateOnboarding, transition.ToState)
+			return nil
+		},
+	}
+
+	svc := service.NewSchoolService(&mock.MockSchoolRepository{}, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, lifecycleRepo, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+	result, err := svc.CreateSchool(context.Background(), dto.CreateSchoolRequest{Name: "New School", Code: "NEW001"})
+
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, entity.SchoolStateOnboarding, recorded.State)
+	assert.Equal(t, entity.SchoolStateOnboarding, result.LifecycleState)
+	assert.True(t, result.IsActive)
+}
+
+func TestSchoolService_ChangeSchoolState(t *testing.T) {
+	schoolID := uuid.New()
+
+	tests := []struct {
+		name         string
+		current      string
+		request      dto.ChangeSchoolStateRequest
+		wantErr      bool
+		errContains  string
+		wantIsActive bool
+	}{
+		{
+			name:         "success - activates an onboarding school",
+			current:      entity.SchoolStateOnboarding,
+			request:      dto.ChangeSchoolStateRequest{State: entity.SchoolStateActive},
+			wantIsActive: true,
+		},
+		{
+			name:    "success - suspends an active school with a reason",
+			current: entity.SchoolStateActive,
+			request: dto.ChangeSchoolStateRequest{State: entity.SchoolStateSuspended, Reason: "unpaid invoice"},
+		},
+		{
+			name:         "success - school without lifecycle record is treated as active",
+			request:      dto.ChangeSchoolStateRequest{State: entity.SchoolStateArchived, Reason: "closed"},
+			wantIsActive: false,
+		},
+		{
+			name:        "error - suspending requires a reason",
+			current:     entity.SchoolStateActive,
+			request:     dto.ChangeSchoolStateRequest{State: entity.SchoolStateSuspended, Reason: "  "},
+			wantErr:     true,
+			errContains: "invalid school state change",
+		},
+		{
+			name:        "error - transition not allowed",
+			current:     entity.SchoolStateOnboarding,
+			request:     dto.ChangeSchoolStateRequest{State: entity.SchoolStateSuspended, Reason: "test"},
+			wantErr:     true,
+			errContains: "cannot move school from onboarding to suspended",
+		},
+		{
+			name:        "error - archived schools are only restored",
+			current:     entity.SchoolStateArchived,
+			request:     dto.ChangeSchoolStateRequest{State: entity.SchoolStateActive},
+			wantErr:     true,
+			errContains: "can only be restored",
+		},
+		{
+			name:        "error - same state",
+			current:     entity.SchoolStateActive,
+			request:     dto.ChangeSchoolStateRequest{State: entity.SchoolStateActive},
+			wantErr:     true,
+			errContains: "already active",
+		},
+		{
+			name:        "error - unknown state",
+			current:     entity.SchoolStateActive,
+			request:     dto.ChangeSchoolStateRequest{State: "closed"},
+			wantErr:     true,
+			errContains: "invalid school state",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schoolRepo := &mock.MockSchoolRepository{
+				FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) {
+					return &entities.School{ID: schoolID, Name: "School", IsActive: true}, nil
+				},
+			}
+			var saved *entities.School
+			var transition *entity.SchoolLifecycleTransition
+			lifecycleRepo := &mock.MockSchoolLifecycleRepository{
+				FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID) (*entity.SchoolLifecycle, error) {
+					if tt.current == "" {
+						return nil, nil
+					}
+					return &entity.SchoolLifecycle{SchoolID: schoolID, State: tt.current}, nil
+				},
+				TransitionFn: func(_ context.Context, school *entities.School, _ *entity.SchoolLifecycle, t *entity.SchoolLifecycleTransition) error {
+					saved, transition = school, t
+					return nil
+				},
+			}
+
+			svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, lifecycleRepo, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+			result, err := svc.ChangeSchoolState(context.Background(), schoolID.String(), tt.request)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Nil(t, saved)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, saved)
+			assert.Equal(t, tt.request.State, result.LifecycleState)
+			assert.Equal(t, tt.wantIsActive, saved.IsActive)
+			assert.Equal(t, tt.request.State, transition.ToState)
+			if tt.current == "" {
+				assert.Equal(t, entity.SchoolStateActive, transition.FromState)
+			} else {
+				assert.Equal(t, tt.current, transition.FromState)
+			}
+		})
+	}
+}
+
+func TestSchoolService_RestoreSchool(t *testing.T) {
+	schoolID := uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) {
+			return &entities.School{ID: schoolID, Name: "School"}, nil
+		},
+	}
+
+	t.Run("success - archived school becomes active", func(t *testing.T) {
+		var saved *entity.SchoolLifecycle
+		lifecycleRepo := &mock.MockSchoolLifecycleRepository{
+			FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID) (*entity.SchoolLifecycle, error) {
+				return &entity.SchoolLifecycle{SchoolID: schoolID, State: entity.SchoolStateArchived}, nil
+			},
+			TransitionFn: func(_ context.Context, _ *entities.School, lifecycle *entity.SchoolLifecycle, _ *entity.SchoolLifecycleTransition) error {
+				saved = lifecycle
+				return nil
+			},
+		}
+		svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, lifecycleRepo, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+
+		result, err := svc.RestoreSchool(context.Background(), schoolID.String(), dto.RestoreSchoolRequest{Reason: "reopened"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.SchoolStateActive, result.LifecycleState)
+		assert.True(t, result.IsActive)
+		require.NotNil(t, saved)
+		assert.Equal(t, "reopened", *saved.Reason)
+	})
+
+	t.Run("error - school is not archived", func(t *testing.T) {
+		lifecycleRepo := &mock.MockSchoolLifecycleRepository{
+			FindBySchoolIDFn: func(_ context.Context, _ uuid.UUID) (*entity.SchoolLifecycle, error) {
+				return &entity.SchoolLifecycle{SchoolID: schoolID, State: entity.SchoolStateSuspended}, nil
+			},
+		}
+		svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, lifecycleRepo, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+
+		_, err := svc.RestoreSchool(context.Background(), schoolID.String(), dto.RestoreSchoolRequest{})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only archived schools can be restored")
+	})
+}
+
+func TestSchoolService_ListSchools_LifecycleStates(t *testing.T) {
+	active, suspended := uuid.New(), uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		ListFn: func(_ context.Context, _ sharedrepo.ListFilters) ([]*entities.School, int64, error) {
+			return []*entities.School{{ID: active, Name: "Active", IsActive: true}, {ID: suspended, Name: "Suspended"}}, 2, nil
+		},
+	}
+	lifecycleRepo := &mock.MockSchoolLifecycleRepository{
+		FindBySchoolIDsFn: func(_ context.Context, _ []uuid.UUID) ([]*entity.SchoolLifecycle, error) {
+			return []*entity.SchoolLifecycle{{SchoolID: suspended, State: entity.SchoolStateSuspended}}, nil
+		},
+	}
+	svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, lifecycleRepo, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+
+	result, total, err := svc.ListSchools(context.Background(), sharedrepo.ListFilters{}, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, result, 2)
+	assert.Equal(t, entity.SchoolStateActive, result[0].LifecycleState)
+	assert.Equal(t, entity.SchoolStateSuspended, result[1].LifecycleState)
+}
+
+func TestSchoolService_IsSchoolReadOnly(t *testing.T) {
+	for state, want := range map[string]bool{
+		entity.SchoolStateOnboarding: false,
+		entity.SchoolStateActive:     false,
+		entity.SchoolStateSuspended:  true,
+		entity.SchoolStateArchived:   true,
+	} {
+		t.Run(state, func(t *testing.T) {
+			lifecycleRepo := &mock.MockSchoolLifecycleRepository{
+				FindBySchoolIDFn: func(_ context.Context, schoolID uuid.UUID) (*entity.SchoolLifecycle, error) {
+					return &entity.SchoolLifecycle{SchoolID: schoolID, State: state}, nil
+				},
+			}
+			svc := service.NewSchoolService(&mock.MockSchoolRepository{}, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, lifecycleRepo, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+
+			readOnly, err := svc.IsSchoolReadOnly(context.Background(), uuid.New())
+
+			require.NoError(t, err)
+			assert.Equal(t, want, readOnly)
+		})
+	}
+
+	t.Run("inactive school without a lifecycle record", func(t *testing.T) {
+		schoolRepo := &mock.MockSchoolRepository{
+			FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) {
+				return &entities.School{ID: id, IsActive: false}, nil
+			},
+		}
+		svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+
+		readOnly, err := svc.IsSchoolReadOnly(context.Background(), uuid.New())
+
+		require.NoError(t, err)
+		assert.True(t, readOnly)
+	})
+}
+
+func TestSchoolService_DeleteSchool_Confirmation(t *testing.T) {
+	schoolID := uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) {
+			return &entities.School{ID: schoolID, Name: "School"}, nil
+		},
+	}
+	counts := &repository.SchoolDependentCounts{AcademicUnits: 3, Subjects: 5, Memberships: 40, GuardianRelations: 12, LinkRequests: 2, CustomFieldValues: 7}
+	var cascaded bool
+	deletionRepo := &mock.MockSchoolDeletionRepository{
+		CountDependentsFn: func(_ context.Context, _ uuid.UUID) (*repository.SchoolDependentCounts, error) {
+			return counts, nil
+		},
+		DeleteCascadeFn: func(_ context.Context, id uuid.UUID, _ time.Time) error {
+			assert.Equal(t, schoolID, id)
+			cascaded = true
+			return nil
+		},
+	}
+	svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, deletionRepo, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+
+	impact, err := svc.GetDeletionImpact(context.Background(), schoolID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), impact.AcademicUnits)
+	assert.Equal(t, int64(40), impact.Memberships)
+	assert.Equal(t, int64(12), impact.GuardianRelations)
+	assert.Equal(t, int64(2), impact.LinkRequests)
+	assert.Equal(t, int64(7), impact.CustomFieldValues)
+	require.NotEmpty(t, impact.ConfirmationToken)
+
+	t.Run("error - missing token", func(t *testing.T) {
+		err := svc.DeleteSchool(context.Background(), schoolID.String(), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "confirmation required")
+		assert.False(t, cascaded)
+	})
+
+	t.Run("error - stale token after dependents changed", func(t *testing.T) {
+		counts.Memberships = 41
+		defer func() { counts.Memberships = 40 }()
+		err := svc.DeleteSchool(context.Background(), schoolID.String(), impact.ConfirmationToken)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "confirmation required")
+		assert.False(t, cascaded)
+	})
+
+	t.Run("error - stale token after a new invitation", func(t *testing.T) {
+		counts.GuardianInvitations = 1
+		defer func() { counts.GuardianInvitations = 0 }()
+		err := svc.DeleteSchool(context.Background(), schoolID.String(), impact.ConfirmationToken)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "confirmation required")
+		assert.False(t, cascaded)
+	})
+
+	t.Run("success - cascades with current token", func(t *testing.T) {
+		err := svc.DeleteSchool(context.Background(), schoolID.String(), impact.ConfirmationToken)
+		require.NoError(t, err)
+		assert.True(t, cascaded)
+	})
+}
+
+func TestSchoolService_ExportSchoolBundle(t *testing.T) {
+	schoolID, rootID, childID, userID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) {
+			return &entities.School{ID: schoolID, Name: "School", Code: "SCH001"}, nil
+		},
+	}
+	bundleRepo := &mock.MockSchoolBundleRepository{
+		LoadFn: func(_ context.Context, school *entities.School, _ bool) (*repository.SchoolBundleData, error) {
+			return &repository.SchoolBundleData{
+				School: school,
+				Units: []*entities.AcademicUnit{
+					{ID: rootID, SchoolID: schoolID, Name: "Grade 1", Type: "grade"},
+					{ID: childID, ParentUnitID: &rootID, SchoolID: schoolID, Name: "1-A", Type: "section"},
+				},
+				Users:       []*entities.User{{ID: userID, Email: "ana@school.edu", FirstName: "Ana", PasswordHash: "hash"}},
+				Memberships: []*entities.Membership{{UserID: userID, SchoolID: schoolID, AcademicUnitID: &childID, Role: "student"}},
+			}, nil
+		},
+	}
+	svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, bundleRepo, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+
+	t.Run("success - omits password hashes by default", func(t *testing.T) {
+		bundle, err := svc.ExportSchoolBundle(context.Background(), schoolID.String(), dto.SchoolBundleExportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, service.SchoolBundleVersion, bundle.Version)
+		require.Len(t, bundle.Units, 2)
+		assert.Equal(t, rootID.String(), bundle.Units[1].ParentID)
+		require.Len(t, bundle.Users, 1)
+		assert.Equal(t, "ana@school.edu", bundle.Users[0].Email)
+		assert.Empty(t, bundle.Users[0].PasswordHash)
+		assert.Equal(t, childID.String(), bundle.Memberships[0].UnitID)
+	})
+
+	t.Run("success - includes password hashes on request", func(t *testing.T) {
+		bundle, err := svc.ExportSchoolBundle(context.Background(), schoolID.String(), dto.SchoolBundleExportOptions{IncludePasswordHashes: true})
+		require.NoError(t, err)
+		assert.Equal(t, "hash", bundle.Users[0].PasswordHash)
+	})
+
+	t.Run("success - anonymizes users without hashes", func(t *testing.T) {
+		bundle, err := svc.ExportSchoolBundle(context.Background(), schoolID.String(), dto.SchoolBundleExportOptions{IncludePasswordHashes: true, Anonymize: true})
+		require.NoError(t, err)
+		assert.True(t, bundle.Anonymized)
+		assert.Equal(t, "user0001@anonymized.invalid", bundle.Users[0].Email)
+		assert.NotEqual(t, "Ana", bundle.Users[0].FirstName)
+		assert.Empty(t, bundle.Users[0].PasswordHash)
+	})
+
+	t.Run("success - drops references to deleted units", func(t *testing.T) {
+		deletedID := uuid.New()
+		repo := &mock.MockSchoolBundleRepository{
+			LoadFn: func(_ context.Context, school *entities.School, _ bool) (*repository.SchoolBundleData, error) {
+				return &repository.SchoolBundleData{
+					School:      school,
+					Units:       []*entities.AcademicUnit{{ID: childID, ParentUnitID: &deletedID, SchoolID: schoolID, Name: "1-A", Type: "section"}},
+					Subjects:    []*entities.Subject{{ID: uuid.New(), SchoolID: schoolID, AcademicUnitID: &deletedID, Name: "Math", IsActive: true}},
+					Users:       []*entities.User{{ID: userID, Email: "ana@school.edu"}},
+					Memberships: []*entities.Membership{{UserID: userID, SchoolID: schoolID, AcademicUnitID: &deletedID, Role: "student"}},
+				}, nil
+			},
+		}
+		svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, repo, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+		bundle, err := svc.ExportSchoolBundle(context.Background(), schoolID.String(), dto.SchoolBundleExportOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, bundle.Units[0].ParentID)
+		assert.Empty(t, bundle.Subjects[0].UnitID)
+		assert.Empty(t, bundle.Memberships[0].UnitID)
+	})
+}
+
+func TestSchoolService_ImportSchoolBundle(t *testing.T) {
+	existingUserID := uuid.New()
+	newBundle := func() *dto.SchoolBundle {
+		return &dto.SchoolBundle{
+			Version: service.SchoolBundleVersion,
+			School:  dto.SchoolBundleSchool{Name: "Imported School", Code: "IMP001", ConceptTypeCode: "missing_type"},
+			Concepts: []dto.SchoolBundleConcept{
+				{TermKey: "unit.level", TermValue: "Grade"},
+			},
+			Units: []dto.SchoolBundleUnit{
+				{ID: "u2", ParentID: "u1", Name: "1-A", Type: "section"},
+				{ID: "u1", Name: "Grade 1", Type: "grade"},
+			},
+			Subjects: []dto.SchoolBundleSubject{{ID: "s1", UnitID: "u1", Name: "Math"}},
+			Users: []dto.SchoolBundleUser{
+				{ID: "a", Email: "new@school.edu"},
+				{ID: "b", Email: "Existing@school.edu"},
+				{ID: "c", Email: "new@school.edu"},
+			},
+			Memberships: []dto.SchoolBundleMembership{
+				{UserID: "a", UnitID: "u2", Role: "student"},
+				{UserID: "b", UnitID: "u2", Role: "teacher"},
+				{UserID: "c", Role: "guardian"},
+			},
+		}
+	}
+	bundleRepo := func(imported **repository.SchoolBundleData) *mock.MockSchoolBundleRepository {
+		return &mock.MockSchoolBundleRepository{
+			FindUsersByEmailsFn: func(_ context.Context, _ []string) ([]*entities.User, error) {
+				return []*entities.User{{ID: existingUserID, Email: "existing@school.edu"}}, nil
+			},
+			ImportFn: func(_ context.Context, data *repository.SchoolBundleData) error {
+				*imported = data
+				return nil
+			},
+		}
+	}
+	newService := func(codeTaken bool, repo *mock.MockSchoolBundleRepository) service.SchoolService {
+		schoolRepo := &mock.MockSchoolRepository{
+			ExistsByCodeFn: func(_ context.Context, _ string) (bool, error) { return codeTaken, nil },
+		}
+		return service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, repo, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+	}
+
+	t.Run("success - remaps IDs and skips conflicting emails", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		result, err := newService(false, bundleRepo(&imported)).ImportSchoolBundle(context.Background(), newBundle(), false)
+
+		require.NoError(t, err)
+		require.NotNil(t, imported)
+		assert.Equal(t, service.SchoolBundleCreated, result.Action)
+		assert.Equal(t, imported.School.ID.String(), result.SchoolID)
+		assert.Equal(t, "missing_type", result.UnknownConceptType)
+		assert.Nil(t, imported.School.ConceptTypeID)
+		assert.Equal(t, entity.SchoolStateOnboarding, imported.Lifecycle.State)
+
+		require.Len(t, imported.Units, 2)
+		assert.Equal(t, "Grade 1", imported.Units[0].Name, "parents come first")
+		assert.Equal(t, imported.Units[0].ID, *imported.Units[1].ParentUnitID)
+		assert.Equal(t, imported.Units[0].ID, *imported.Subjects[0].AcademicUnitID)
+
+		require.Len(t, imported.Users, 1)
+		assert.Equal(t, "new@school.edu", imported.Users[0].Email)
+		assert.Equal(t, 2, result.Conflicts)
+		assert.Equal(t, service.SchoolBundleEmailExists, result.Users[1].Reason)
+		assert.Equal(t, service.SchoolBundleDuplicateEmail, result.Users[2].Reason)
+
+		require.Len(t, imported.Memberships, 1, "existing accounts gain no membership")
+		assert.Equal(t, imported.Users[0].ID, imported.Memberships[0].UserID)
+		assert.Equal(t, imported.Units[1].ID, *imported.Memberships[0].AcademicUnitID)
+		for _, membership := range imported.Memberships {
+			assert.NotEqual(t, existingUserID, membership.UserID)
+		}
+	})
+
+	t.Run("success - dry run writes nothing", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		result, err := newService(false, bundleRepo(&imported)).ImportSchoolBundle(context.Background(), newBundle(), true)
+
+		require.NoError(t, err)
+		assert.Nil(t, imported)
+		assert.True(t, result.DryRun)
+		assert.Empty(t, result.SchoolID)
+		assert.Equal(t, 1, result.Memberships)
+	})
+
+	t.Run("conflict - school code taken", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		result, err := newService(true, bundleRepo(&imported)).ImportSchoolBundle(context.Background(), newBundle(), false)
+
+		require.NoError(t, err)
+		assert.Nil(t, imported)
+		assert.Equal(t, service.SchoolBundleConflict, result.Action)
+		assert.Equal(t, service.SchoolBundleCodeExists, result.Reason)
+	})
+
+	t.Run("error - unit cycle", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		bundle := newBundle()
+		bundle.Units[1].ParentID = "u2"
+		_, err := newService(false, bundleRepo(&imported)).ImportSchoolBundle(context.Background(), bundle, false)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid school bundle")
+	})
+
+	t.Run("error - membership of unknown user", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		bundle := newBundle()
+		bundle.Memberships = append(bundle.Memberships, dto.SchoolBundleMembership{UserID: "zz", Role: "student"})
+		_, err := newService(false, bundleRepo(&imported)).ImportSchoolBundle(context.Background(), bundle, false)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid school bundle")
+	})
+
+	t.Run("error - unsupported version", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		bundle := newBundle()
+		bundle.Version = 99
+		_, err := newService(false, bundleRepo(&imported)).ImportSchoolBundle(context.Background(), bundle, false)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported school bundle")
+	})
+}
+
+func TestSchoolService_CloneSchool(t *testing.T) {
+	templateID := uuid.New()
+	rootID, childID, orphanID := uuid.New(), uuid.New(), uuid.New()
+	conceptID, defID := uuid.New(), uuid.New()
+	template := &entities.School{ID: templateID, Name: "Template", Code: "TPL001", Country: "CL", SubscriptionTier: "premium", MaxTeachers: 10, MaxStudents: 100}
+	newService := func(codeTaken bool, imported **repository.SchoolBundleData) service.SchoolService {
+		schoolRepo := &mock.MockSchoolRepository{
+			FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.School, error) {
+				if id == templateID {
+					return template, nil
+				}
+				return nil, nil
+			},
+			ExistsByCodeFn: func(_ context.Context, _ string) (bool, error) { return codeTaken, nil },
+		}
+		conceptRepo := &mock.MockSchoolConceptRepository{
+			FindSourcesBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptSource, error) {
+				return []*entity.SchoolConceptSource{{SchoolConceptID: conceptID, SchoolID: templateID, DefinitionID: defID, DefaultValue: "Grade", Version: 2}}, nil
+			},
+			FindTranslationsBySchoolIDFn: func(_ context.Context, _ uuid.UUID) ([]*entity.SchoolConceptTranslation, error) {
+				return []*entity.SchoolConceptTranslation{{SchoolConceptID: conceptID, SchoolID: templateID, Locale: "en", TermValue: "Year"}}, nil
+			},
+		}
+		bundleRepo := &mock.MockSchoolBundleRepository{
+			LoadFn: func(_ context.Context, school *entities.School, withPeople bool) (*repository.SchoolBundleData, error) {
+				assert.False(t, withPeople)
+				return &repository.SchoolBundleData{
+					School:   school,
+					Concepts: []*entities.SchoolConcept{{ID: conceptID, SchoolID: templateID, TermKey: "unit.level", TermValue: "Year"}},
+					Units: []*entities.AcademicUnit{
+						{ID: childID, ParentUnitID: &rootID, SchoolID: templateID, Name: "1-A", Type: "section"},
+						{ID: rootID, SchoolID: templateID, Name: "Grade 1", Type: "grade"},
+					},
+					Subjects: []*entities.Subject{
+						{ID: uuid.New(), SchoolID: templateID, AcademicUnitID: &childID, Name: "Math", IsActive: true},
+						{ID: uuid.New(), SchoolID: templateID, AcademicUnitID: &orphanID, Name: "Art", IsActive: true},
+						{ID: uuid.New(), SchoolID: templateID, Name: "Latin"},
+					},
+				}, nil
+			},
+			ImportFn: func(_ context.Context, data *repository.SchoolBundleData) error {
+				*imported = data
+				return nil
+			},
+		}
+		return service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, conceptRepo, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, bundleRepo, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), defaultSchoolDefaults, mock.NewNoopAuditLogger())
+	}
+
+	t.Run("success - copies concepts, unit tree and subjects", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		result, err := newService(false, &imported).CloneSchool(context.Background(), templateID.String(), dto.CloneSchoolRequest{Name: "Clone", Code: "CLN001"})
+
+		require.NoError(t, err)
+		require.NotNil(t, imported)
+		newID := imported.School.ID
+		assert.NotEqual(t, templateID, newID)
+		assert.Equal(t, newID.String(), result.School.ID)
+		assert.Equal(t, templateID.String(), result.SourceSchoolID)
+		assert.Equal(t, "premium", imported.School.SubscriptionTier)
+		assert.Equal(t, entity.SchoolStateOnboarding, imported.Lifecycle.State)
+		assert.Empty(t, imported.Memberships)
+		assert.Empty(t, imported.Users)
+
+		require.Len(t, imported.Concepts, 1)
+		require.Len(t, imported.Sources, 1)
+		assert.Equal(t, newID, imported.Concepts[0].SchoolID)
+		assert.Equal(t, imported.Concepts[0].ID, imported.Sources[0].SchoolConceptID)
+		assert.Equal(t, defID, imported.Sources[0].DefinitionID)
+		require.Len(t, imported.Translations, 1)
+		assert.Equal(t, imported.Concepts[0].ID, imported.Translations[0].SchoolConceptID)
+		assert.Equal(t, newID, imported.Translations[0].SchoolID)
+		assert.Equal(t, "Year", imported.Translations[0].TermValue)
+
+		require.Len(t, imported.Units, 2)
+		assert.Equal(t, "Grade 1", imported.Units[0].Name, "parents come first")
+		assert.NotEqual(t, rootID, imported.Units[0].ID)
+		assert.Equal(t, imported.Units[0].ID, *imported.Units[1].ParentUnitID)
+		assert.Equal(t, newID, imported.Units[1].SchoolID)
+
+		require.Len(t, imported.Subjects, 2, "trashed subjects are left out")
+		assert.Equal(t, imported.Units[1].ID, *imported.Subjects[0].AcademicUnitID)
+		assert.Nil(t, imported.Subjects[1].AcademicUnitID, "unit not copied")
+		assert.Equal(t, 1, result.Concepts)
+		assert.Equal(t, 2, result.Units)
+		assert.Equal(t, 2, result.Subjects)
+	})
+
+	t.Run("success - options skip parts", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		skip := false
+		result, err := newService(false, &imported).CloneSchool(context.Background(), templateID.String(),
+			dto.CloneSchoolRequest{Name: "Clone", Code: "CLN001", Concepts: &skip, Units: &skip})
+
+		require.NoError(t, err)
+		assert.Empty(t, imported.Concepts)
+		assert.Empty(t, imported.Units)
+		require.Len(t, imported.Subjects, 2)
+		assert.Nil(t, imported.Subjects[0].AcademicUnitID)
+		assert.Equal(t, 0, result.Units)
+	})
+
+	t.Run("success - inactive subjects on request", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		result, err := newService(false, &imported).CloneSchool(context.Background(), templateID.String(),
+			dto.CloneSchoolRequest{Name: "Clone", Code: "CLN001", InactiveSubjects: true})
+
+		require.NoError(t, err)
+		require.Len(t, imported.Subjects, 3)
+		assert.Equal(t, "Latin", imported.Subjects[2].Name)
+		assert.False(t, imported.Subjects[2].IsActive)
+		assert.Equal(t, 3, result.Subjects)
+	})
+
+	t.Run("error - code taken", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		_, err := newService(true, &imported).CloneSchool(context.Background(), templateID.String(), dto.CloneSchoolRequest{Name: "Clone", Code: "TPL001"})
+
+		require.Error(t, err)
+		assert.Nil(t, imported)
+	})
+
+	t.Run("error - template not found", func(t *testing.T) {
+		var imported *repository.SchoolBundleData
+		_, err := newService(false, &imported).CloneSchool(context.Background(), uuid.New().String(), dto.CloneSchoolRequest{Name: "Clone", Code: "CLN001"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestSchoolService_SchoolCodes(t *testing.T) {
+	codeDefaults := defaultSchoolDefaults
+	codeDefaults.Code = config.SchoolCodeConfig{
+		Pattern:        `^[A-Z]{2,6}-[A-Z]{3}-[0-9]{3}$`,
+		Normalization:  config.SchoolCodeNormalizeUpperASCII,
+		Template:       "{name}-{city}-{seq}",
+		SequenceDigits: 3,
+	}
+	newService := func(taken map[string]bool, created **entities.School) service.SchoolService {
+		schoolRepo := &mock.MockSchoolRepository{
+			ExistsByCodeFn: func(_ context.Context, code string) (bool, error) { return taken[code], nil },
+			CreateFn: func(_ context.Context, school *entities.School) error {
+				*created = school
+				return nil
+			},
+		}
+		return service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{Schools: schoolRepo}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), codeDefaults, mock.NewNoopAuditLogger())
+	}
+
+	t.Run("success - normalises the requested code", func(t *testing.T) {
+		var created *entities.School
+		result, err := newService(nil, &created).CreateSchool(context.Background(), dto.CreateSchoolRequest{Name: "Colegio San José", Code: " csj-bog-001 "})
+
+		require.NoError(t, err)
+		assert.Equal(t, "CSJ-BOG-001", created.Code)
+		assert.Equal(t, "CSJ-BOG-001", result.Code)
+	})
+
+	t.Run("success - generates the next free code", func(t *testing.T) {
+		var created *entities.School
+		taken := map[string]bool{"CSJM-BOG-001": true}
+		_, err := newService(taken, &created).CreateSchool(context.Background(), dto.CreateSchoolRequest{Name: "Colegio San José de la Montaña", City: "Bogotá"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "CSJM-BOG-002", created.Code)
+	})
+
+	t.Run("error - code does not match the pattern", func(t *testing.T) {
+		var created *entities.School
+		_, err := newService(nil, &created).CreateSchool(context.Background(), dto.CreateSchoolRequest{Name: "Test School", Code: "TST001"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid school code")
+		assert.Nil(t, created)
+	})
+
+	t.Run("availability - reports format, taken and free codes", func(t *testing.T) {
+		var created *entities.School
+		svc := newService(map[string]bool{"CSJ-BOG-001": true}, &created)
+
+		result, err := svc.CheckSchoolCode(context.Background(), "bad", "", "")
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, service.SchoolCodeInvalidFormat, result.Reason)
+
+		result, err = svc.CheckSchoolCode(context.Background(), "csj-bog-001", "", "")
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.False(t, result.Available)
+		assert.Equal(t, service.SchoolCodeTaken, result.Reason)
+
+		result, err = svc.CheckSchoolCode(context.Background(), "", "Colegio San José", "Bogotá")
+		require.NoError(t, err)
+		assert.True(t, result.Available)
+		assert.True(t, result.Generated)
+		assert.Equal(t, "CSJ-BOG-002", result.Code)
+
+		_, err = svc.CheckSchoolCode(context.Background(), "", "", "")
+		require.Error(t, err)
+	})
+
+	t.Run("lookup - finds legacy codes stored as given", func(t *testing.T) {
+		var looked []string
+		schoolRepo := &mock.MockSchoolRepository{
+			FindByCodeFn: func(_ context.Context, code string) (*entities.School, error) {
+				looked = append(looked, code)
+				if code == "colegio-ñandú" {
+					return &entities.School{ID: uuid.New(), Name: "Colegio Ñandú", Code: code}, nil
+				}
+				return nil, nil
+			},
+		}
+		svc := service.NewSchoolService(schoolRepo, &mock.MockConceptTypeRepository{}, &mock.MockConceptDefinitionRepository{}, &mock.MockSchoolConceptRepository{}, &mock.MockSchoolLifecycleRepository{}, &mock.MockSchoolDeletionRepository{}, &mock.MockSchoolBundleRepository{}, &mock.MockMetadataSchemaRepository{}, mock.NewMockLogger(), codeDefaults, mock.NewNoopAuditLogger())
+
+		result, err := svc.GetSchoolByCode(context.Background(), " colegio-ñandú ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "colegio-ñandú", result.Code)
+		assert.Equal(t, []string{"COLEGIO-NANDU", "colegio-ñandú"}, looked)
+	})
+}
@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/audit"
@@ -18,20 +20,57 @@ import (
 type SubjectService interface {
 	CreateSubject(ctx context.Context, schoolID string, req dto.CreateSubjectRequest) (*dto.SubjectResponse, error)
 	GetSubject(ctx context.Context, id string) (*dto.SubjectResponse, error)
-	ListSubjects(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.SubjectResponse, int, error)
+	ListSubjects(ctx context.Context, schoolID string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.SubjectResponse, int, error)
 	UpdateSubject(ctx context.Context, id string, req dto.UpdateSubjectRequest) (*dto.SubjectResponse, error)
 	DeleteSubject(ctx context.Context, id string) error
+	RestoreSubject(ctx context.Context, id string) (*dto.SubjectResponse, error)
 }
 
 type subjectService struct {
 	subjectRepo repository.SubjectRepository
+	unitRepo    repository.AcademicUnitRepository
+	fieldRepo   repository.CustomFieldRepository
 	logger      logger.Logger
 	auditLogger audit.AuditLogger
 }
 
 // NewSubjectService creates a new subject service
-func NewSubjectService(subjectRepo repository.SubjectRepository, logger logger.Logger, auditLogger audit.AuditLogger) SubjectService {
-	return &subjectService{subjectRepo: subjectRepo, logger: logger, auditLogger: auditLogger}
+func NewSubjectService(subjectRepo repository.SubjectRepository, unitRepo repository.AcademicUnitRepository, fieldRepo repository.CustomFieldRepository, logger logger.Logger, auditLogger audit.AuditLogger) SubjectService {
+	return &subjectService{subjectRepo: subjectRepo, unitRepo: unitRepo, fieldRepo: fieldRepo, logger: logger, auditLogger: auditLogger}
+}
+
+// withCustomFields converts subjects of one school to responses carrying
+// their custom field values
+func (s *subjectService) withCustomFields(ctx context.Context, set *customFieldSet, subjects []*entities.Subject) ([]dto.SubjectResponse, error) {
+	ids := make([]uuid.UUID, len(subjects))
+	for i, subject := range subjects {
+		ids[i] = subject.ID
+	}
+	values, err := set.values(ctx, s.fieldRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	responses := dto.ToSubjectResponseList(subjects)
+	for i := range responses {
+		responses[i].CustomFields = values[subjects[i].ID]
+	}
+	return responses, nil
+}
+
+// subjectResponse converts a subject to a response carrying its custom field
+// values. The school's field set is loaded when set is nil.
+func (s *subjectService) subjectResponse(ctx context.Context, set *customFieldSet, subject *entities.Subject) (*dto.SubjectResponse, error) {
+	if set == nil {
+		var err error
+		if set, err = loadCustomFieldSet(ctx, s.fieldRepo, subject.SchoolID, entity.CustomFieldEntitySubject); err != nil {
+			return nil, err
+		}
+	}
+	responses, err := s.withCustomFields(ctx, set, []*entities.Subject{subject})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
 }
 
 func (s *subjectService) CreateSubject(ctx context.Context, schoolID string, req dto.CreateSubjectRequest) (*dto.SubjectResponse, error) {
@@ -52,9 +91,19 @@ func (s *subjectService) CreateSubject(ctx context.Context, schoolID string, req
 		return nil, errors.NewAlreadyExistsError("subject").WithField("name", req.Name)
 	}
 
+	subjectID := uuid.New()
+	fieldSet, err := loadCustomFieldSet(ctx, s.fieldRepo, schoolUUID, entity.CustomFieldEntitySubject)
+	if err != nil {
+		return nil, err
+	}
+	fieldValues, _, err := fieldSet.prepare(subjectID, req.CustomFields, true)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	subject := &entities.Subject{
-		ID:        uuid.New(),
+		ID:        subjectID,
 		SchoolID:  schoolUUID,
 		Name:      req.Name,
 		IsActive:  true,
@@ -65,17 +114,21 @@ func (s *subjectService) CreateSubject(ctx context.Context, schoolID string, req
 		subject.Description = &req.Description
 	}
 	if req.AcademicUnitID != "" {
-		auID, err := uuid.Parse(req.AcademicUnitID)
+		auID, err := s.resolveAcademicUnit(ctx, schoolUUID, req.AcademicUnitID)
 		if err != nil {
-			return nil, errors.NewValidationError("invalid academic_unit_id")
+			return nil, err
 		}
-		subject.AcademicUnitID = &auID
+		subject.AcademicUnitID = auID
 	}
-	if req.Code != "" {
-		subject.Code = &req.Code
+	if code := strings.TrimSpace(req.Code); code != "" {
+		if err := s.ensureCodeAvailable(ctx, schoolUUID, code); err != nil {
+			return nil, err
+		}
+		subject.Code = &code
 	}
 
-	if err := s.subjectRepo.Create(ctx, subject); err != nil {
+	create := func() error { return s.subjectRepo.Create(ctx, subject) }
+	if err := createWithCustomFields(ctx, s.fieldRepo, subject, fieldValues, create); err != nil {
 		actorID, actorEmail, actorRole := actorFromContext(ctx)
 		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
 			Action: "create", ResourceType: "subject",
@@ -96,8 +149,7 @@ func (s *subjectService) CreateSubject(ctx context.Context, schoolID string, req
 	}); err != nil {
 		s.logger.Error("failed to write audit log", "error", err)
 	}
-	response := dto.ToSubjectResponse(subject)
-	return &response, nil
+	return s.subjectResponse(ctx, fieldSet, subject)
 }
 
 func (s *subjectService) GetSubject(ctx context.Context, id string) (*dto.SubjectResponse, error) {
@@ -105,27 +157,40 @@ func (s *subjectService) GetSubject(ctx context.Context, id string) (*dto.Subjec
 	if err != nil {
 		return nil, errors.NewValidationError("invalid subject ID")
 	}
-	subject, err := s.subjectRepo.FindByID(ctx, sid)
+	subject, err := s.subjectRepo.FindByID(ctx, sid, false)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find subject", err)
 	}
 	if subject == nil {
 		return nil, errors.NewNotFoundError("subject")
 	}
-	response := dto.ToSubjectResponse(subject)
-	return &response, nil
+	return s.subjectResponse(ctx, nil, subject)
 }
 
-func (s *subjectService) ListSubjects(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.SubjectResponse, int, error) {
+// ListSubjects lists the school's subjects, keeping those whose custom fields
+// match fieldFilters, keyed by field key
+func (s *subjectService) ListSubjects(ctx context.Context, schoolID string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.SubjectResponse, int, error) {
 	schoolUUID, err := uuid.Parse(schoolID)
 	if err != nil {
 		return nil, 0, errors.NewValidationError("invalid school ID")
 	}
-	subjects, total, err := s.subjectRepo.FindBySchoolID(ctx, schoolUUID, filters)
+	fieldSet, err := loadCustomFieldSet(ctx, s.fieldRepo, schoolUUID, entity.CustomFieldEntitySubject)
+	if err != nil {
+		return nil, 0, err
+	}
+	repoFilters, err := fieldSet.filters(fieldFilters)
+	if err != nil {
+		return nil, 0, err
+	}
+	subjects, total, err := s.subjectRepo.FindBySchoolID(ctx, schoolUUID, includeDeleted, filters, repoFilters...)
 	if err != nil {
 		return nil, 0, errors.NewDatabaseError("list subjects", err)
 	}
-	return dto.ToSubjectResponseList(subjects), total, nil
+	responses, err := s.withCustomFields(ctx, fieldSet, subjects)
+	if err != nil {
+		return nil, 0, err
+	}
+	return responses, total, nil
 }
 
 func (s *subjectService) UpdateSubject(ctx context.Context, id string, req dto.UpdateSubjectRequest) (*dto.SubjectResponse, error) {
@@ -133,7 +198,7 @@ func (s *subjectService) UpdateSubject(ctx context.Context, id string, req dto.U
 	if err != nil {
 		return nil, errors.NewValidationError("invalid subject ID")
 	}
-	subject, err := s.subjectRepo.FindByID(ctx, sid)
+	subject, err := s.subjectRepo.FindByID(ctx, sid, false)
 	if err != nil {
 		return nil, errors.NewDatabaseError("find subject", err)
 	}
@@ -151,29 +216,43 @@ func (s *subjectService) UpdateSubject(ctx context.Context, id string, req dto.U
 		if *req.AcademicUnitID == "" {
 			subject.AcademicUnitID = nil
 		} else {
-			auID, err := uuid.Parse(*req.AcademicUnitID)
+			auID, err := s.resolveAcademicUnit(ctx, subject.SchoolID, *req.AcademicUnitID)
 			if err != nil {
-				return nil, errors.NewValidationError("invalid academic_unit_id")
+				return nil, err
 			}
-			subject.AcademicUnitID = &auID
+			subject.AcademicUnitID = auID
 		}
 	}
 	if req.Code != nil {
-		if *req.Code == "" {
+		code := strings.TrimSpace(*req.Code)
+		if code == "" {
 			subject.Code = nil
 		} else {
-			subject.Code = req.Code
+			if subject.Code == nil || *subject.Code != code {
+				if err := s.ensureCodeAvailable(ctx, subject.SchoolID, code); err != nil {
+					return nil, err
+				}
+			}
+			subject.Code = &code
 		}
 	}
+	fieldSet, err := loadCustomFieldSet(ctx, s.fieldRepo, subject.SchoolID, entity.CustomFieldEntitySubject)
+	if err != nil {
+		return nil, err
+	}
+	fieldValues, cleared, err := fieldSet.prepare(subject.ID, req.CustomFields, false)
+	if err != nil {
+		return nil, err
+	}
 	subject.UpdatedAt = time.Now()
 
-	if err := s.subjectRepo.Update(ctx, subject); err != nil {
+	update := func() error { return s.subjectRepo.Update(ctx, subject) }
+	if err := updateWithCustomFields(ctx, s.fieldRepo, subject, subject.ID, fieldValues, cleared, update); err != nil {
 		return nil, errors.NewDatabaseError("update subject", err)
 	}
 
 	s.logger.Info("entity updated", "entity_type", "subject", "entity_id", id)
-	response := dto.ToSubjectResponse(subject)
-	return &response, nil
+	return s.subjectResponse(ctx, fieldSet, subject)
 }
 
 func (s *subjectService) DeleteSubject(ctx context.Context, id string) error {
@@ -181,7 +260,7 @@ func (s *subjectService) DeleteSubject(ctx context.Context, id string) error {
 	if err != nil {
 		return errors.NewValidationError("invalid subject ID")
 	}
-	subject, err := s.subjectRepo.FindByID(ctx, sid)
+	subject, err := s.subjectRepo.FindByID(ctx, sid, false)
 	if err != nil {
 		return errors.NewDatabaseError("find subject", err)
 	}
@@ -210,3 +289,97 @@ func (s *subjectService) DeleteSubject(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// RestoreSubject reactivates a deleted subject unless an active subject of the
+// same school has taken its name or code in the meantime.
+func (s *subjectService) RestoreSubject(ctx context.Context, id string) (*dto.SubjectResponse, error) {
+	sid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid subject ID")
+	}
+	subject, err := s.subjectRepo.FindByID(ctx, sid, true)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find subject", err)
+	}
+	if subject == nil {
+		return nil, errors.NewNotFoundError("subject")
+	}
+	if subject.IsActive {
+		return nil, errors.NewValidationError("subject is not deleted")
+	}
+
+	exists, err := s.subjectRepo.ExistsBySchoolIDAndName(ctx, subject.SchoolID, subject.Name)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check subject", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("subject").WithField("name", subject.Name)
+	}
+	if subject.Code != nil {
+		if err := s.ensureCodeAvailable(ctx, subject.SchoolID, *subject.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.subjectRepo.Restore(ctx, sid); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "restore", ResourceType: "subject", ResourceID: id,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("restore subject", err)
+	}
+	subject.IsActive = true
+	subject.UpdatedAt = time.Now()
+
+	s.logger.Info("entity restored", "entity_type", "subject", "entity_id", id)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "restore", ResourceType: "subject", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return s.subjectResponse(ctx, nil, subject)
+}
+
+// resolveAcademicUnit parses an academic unit ID and checks that the unit
+// exists and belongs to the subject's school.
+func (s *subjectService) resolveAcademicUnit(ctx context.Context, schoolID uuid.UUID, rawID string) (*uuid.UUID, error) {
+	unitID, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, errors.NewValidationErrorWithFields("invalid subject", map[string]string{
+			"academic_unit_id": "must be a valid UUID",
+		})
+	}
+	unit, err := s.unitRepo.FindByID(ctx, unitID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find academic unit", err)
+	}
+	if unit == nil {
+		return nil, errors.NewValidationErrorWithFields("invalid subject", map[string]string{
+			"academic_unit_id": "academic unit not found",
+		})
+	}
+	if unit.SchoolID != schoolID {
+		return nil, errors.NewValidationErrorWithFields("invalid subject", map[string]string{
+			"academic_unit_id": "academic unit belongs to another school",
+		})
+	}
+	return &unitID, nil
+}
+
+// ensureCodeAvailable rejects a code already used by an active subject of the school
+func (s *subjectService) ensureCodeAvailable(ctx context.Context, schoolID uuid.UUID, code string) error {
+	exists, err := s.subjectRepo.ExistsBySchoolIDAndCode(ctx, schoolID, code)
+	if err != nil {
+		return errors.NewDatabaseError("check subject code", err)
+	}
+	if exists {
+		return errors.NewAlreadyExistsError("subject").WithField("code", code)
+	}
+	return nil
+}
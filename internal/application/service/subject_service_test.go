@@ -3,12 +3,15 @@ package service_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -89,7 +92,7 @@ func TestSubjectService_CreateSubject(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSubjectService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := service.NewSubjectService(mockRepo, &mock.MockAcademicUnitRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
 			result, err := svc.CreateSubject(context.Background(), tt.schoolID, tt.request)
 
 			if tt.wantErr {
@@ -120,7 +123,7 @@ func TestSubjectService_GetSubject(t *testing.T) {
 			name: "success",
 			id:   validID.String(),
 			setupMock: func(m *mock.MockSubjectRepository) {
-				m.FindByIDFn = func(_ context.Context, _ uuid.UUID) (*entities.Subject, error) {
+				m.FindByIDFn = func(_ context.Context, _ uuid.UUID, _ bool) (*entities.Subject, error) {
 					return &entities.Subject{ID: validID, Name: "Math"}, nil
 				}
 			},
@@ -136,7 +139,7 @@ func TestSubjectService_GetSubject(t *testing.T) {
 			name: "error - not found",
 			id:   validID.String(),
 			setupMock: func(m *mock.MockSubjectRepository) {
-				m.FindByIDFn = func(_ context.Context, _ uuid.UUID) (*entities.Subject, error) { return nil, nil }
+				m.FindByIDFn = func(_ context.Context, _ uuid.UUID, _ bool) (*entities.Subject, error) { return nil, nil }
 			},
 			wantErr:     true,
 			errContains: "not found",
@@ -150,7 +153,7 @@ func TestSubjectService_GetSubject(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSubjectService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := service.NewSubjectService(mockRepo, &mock.MockAcademicUnitRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
 			result, err := svc.GetSubject(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -177,7 +180,7 @@ func TestSubjectService_ListSubjects(t *testing.T) {
 			name:     "success",
 			schoolID: testSchoolID,
 			setupMock: func(m *mock.MockSubjectRepository) {
-				m.FindBySchoolIDFn = func(_ context.Context, _ uuid.UUID, _ sharedrepo.ListFilters) ([]*entities.Subject, int, error) {
+				m.FindBySchoolIDFn = func(_ context.Context, _ uuid.UUID, _ bool, _ sharedrepo.ListFilters, _ []repository.CustomFieldFilter) ([]*entities.Subject, int, error) {
 					return []*entities.Subject{
 						{ID: uuid.New(), Name: "Math"},
 						{ID: uuid.New(), Name: "Science"},
@@ -191,7 +194,7 @@ func TestSubjectService_ListSubjects(t *testing.T) {
 			name:     "error - database error",
 			schoolID: testSchoolID,
 			setupMock: func(m *mock.MockSubjectRepository) {
-				m.FindBySchoolIDFn = func(_ context.Context, _ uuid.UUID, _ sharedrepo.ListFilters) ([]*entities.Subject, int, error) {
+				m.FindBySchoolIDFn = func(_ context.Context, _ uuid.UUID, _ bool, _ sharedrepo.ListFilters, _ []repository.CustomFieldFilter) ([]*entities.Subject, int, error) {
 					return nil, 0, fmt.Errorf("db error")
 				}
 			},
@@ -212,8 +215,8 @@ func TestSubjectService_ListSubjects(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSubjectService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
-			result, _, err := svc.ListSubjects(context.Background(), tt.schoolID, sharedrepo.ListFilters{})
+			svc := service.NewSubjectService(mockRepo, &mock.MockAcademicUnitRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			result, _, err := svc.ListSubjects(context.Background(), tt.schoolID, false, sharedrepo.ListFilters{}, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -238,7 +241,7 @@ func TestSubjectService_DeleteSubject(t *testing.T) {
 			name: "success",
 			id:   validID.String(),
 			setupMock: func(m *mock.MockSubjectRepository) {
-				m.FindByIDFn = func(_ context.Context, _ uuid.UUID) (*entities.Subject, error) {
+				m.FindByIDFn = func(_ context.Context, _ uuid.UUID, _ bool) (*entities.Subject, error) {
 					return &entities.Subject{ID: validID, Name: "Math"}, nil
 				}
 				m.DeleteFn = func(_ context.Context, _ uuid.UUID) error { return nil }
@@ -255,7 +258,7 @@ func TestSubjectService_DeleteSubject(t *testing.T) {
 			name: "error - not found",
 			id:   validID.String(),
 			setupMock: func(m *mock.MockSubjectRepository) {
-				m.FindByIDFn = func(_ context.Context, _ uuid.UUID) (*entities.Subject, error) { return nil, nil }
+				m.FindByIDFn = func(_ context.Context, _ uuid.UUID, _ bool) (*entities.Subject, error) { return nil, nil }
 			},
 			wantErr: true,
 		},
@@ -268,7 +271,7 @@ func TestSubjectService_DeleteSubject(t *testing.T) {
 				tt.setupMock(mockRepo)
 			}
 
-			svc := service.NewSubjectService(mockRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			svc := service.NewSubjectService(mockRepo, &mock.MockAcademicUnitRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
 			err := svc.DeleteSubject(context.Background(), tt.id)
 
 			if tt.wantErr {
@@ -279,3 +282,179 @@ func TestSubjectService_DeleteSubject(t *testing.T) {
 		})
 	}
 }
+
+func TestSubjectService_RestoreSubject(t *testing.T) {
+	validID := uuid.New()
+
+	tests := []struct {
+		name       string
+		subject    *entities.Subject
+		nameTaken  bool
+		wantErr    bool
+		wantStatus int
+	}{
+		{
+			name:    "success",
+			subject: &entities.Subject{ID: validID, Name: "Math", IsActive: false},
+		},
+		{
+			name:       "error - not found",
+			subject:    nil,
+			wantErr:    true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error - not deleted",
+			subject:    &entities.Subject{ID: validID, Name: "Math", IsActive: true},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - name taken by active subject",
+			subject:    &entities.Subject{ID: validID, Name: "Math", IsActive: false},
+			nameTaken:  true,
+			wantErr:    true,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restored := false
+			mockRepo := &mock.MockSubjectRepository{
+				FindByIDFn: func(_ context.Context, _ uuid.UUID, includeDeleted bool) (*entities.Subject, error) {
+					assert.True(t, includeDeleted)
+					return tt.subject, nil
+				},
+				ExistsBySchoolIDAndNameFn: func(_ context.Context, _ uuid.UUID, _ string) (bool, error) { return tt.nameTaken, nil },
+				RestoreFn: func(_ context.Context, _ uuid.UUID) error {
+					restored = true
+					return nil
+				},
+			}
+
+			svc := service.NewSubjectService(mockRepo, &mock.MockAcademicUnitRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			result, err := svc.RestoreSubject(context.Background(), validID.String())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				assert.False(t, restored)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, restored)
+			assert.True(t, result.IsActive)
+		})
+	}
+}
+
+func TestSubjectService_CreateSubject_UnitAndCode(t *testing.T) {
+	schoolID := uuid.New()
+	ownUnit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: schoolID, IsActive: true}
+	foreignUnit := &entities.AcademicUnit{ID: uuid.New(), SchoolID: uuid.New(), IsActive: true}
+
+	tests := []struct {
+		name       string
+		request    dto.CreateSubjectRequest
+		codeTaken  bool
+		wantStatus int
+		wantField  string
+	}{
+		{name: "success - unit of the same school and free code", request: dto.CreateSubjectRequest{Name: "Física", AcademicUnitID: ownUnit.ID.String(), Code: " FIS-1 "}},
+		{name: "error - malformed unit id", request: dto.CreateSubjectRequest{Name: "Física", AcademicUnitID: "bad-uuid"}, wantStatus: http.StatusBadRequest, wantField: "academic_unit_id"},
+		{name: "error - unknown unit", request: dto.CreateSubjectRequest{Name: "Física", AcademicUnitID: uuid.New().String()}, wantStatus: http.StatusBadRequest, wantField: "academic_unit_id"},
+		{name: "error - unit of another school", request: dto.CreateSubjectRequest{Name: "Física", AcademicUnitID: foreignUnit.ID.String()}, wantStatus: http.StatusBadRequest, wantField: "academic_unit_id"},
+		{name: "error - code already used", request: dto.CreateSubjectRequest{Name: "Física", Code: "FIS-1"}, codeTaken: true, wantStatus: http.StatusConflict, wantField: "code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *entities.Subject
+			subjectRepo := &mock.MockSubjectRepository{
+				ExistsBySchoolIDAndCodeFn: func(_ context.Context, _ uuid.UUID, code string) (bool, error) {
+					assert.Equal(t, "FIS-1", code)
+					return tt.codeTaken, nil
+				},
+				CreateFn: func(_ context.Context, subject *entities.Subject) error {
+					created = subject
+					return nil
+				},
+			}
+			unitRepo := &mock.MockAcademicUnitRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID, _ bool) (*entities.AcademicUnit, error) {
+					for _, u := range []*entities.AcademicUnit{ownUnit, foreignUnit} {
+						if u.ID == id {
+							return u, nil
+						}
+					}
+					return nil, nil
+				},
+			}
+			svc := service.NewSubjectService(subjectRepo, unitRepo, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			_, err := svc.CreateSubject(context.Background(), schoolID.String(), tt.request)
+
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				assert.Contains(t, appErr.Fields, tt.wantField)
+				assert.Nil(t, created)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, created)
+			assert.Equal(t, ownUnit.ID, *created.AcademicUnitID)
+			assert.Equal(t, "FIS-1", *created.Code)
+		})
+	}
+}
+
+func TestSubjectService_UpdateSubject_Code(t *testing.T) {
+	schoolID := uuid.New()
+	current := "MAT-1"
+
+	tests := []struct {
+		name       string
+		code       string
+		codeTaken  bool
+		wantCheck  bool
+		wantStatus int
+	}{
+		{name: "unchanged code is not re-checked", code: "MAT-1"},
+		{name: "new free code", code: "MAT-2", wantCheck: true},
+		{name: "new code already used", code: "MAT-2", codeTaken: true, wantCheck: true, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checked := false
+			subjectRepo := &mock.MockSubjectRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID, _ bool) (*entities.Subject, error) {
+					code := current
+					return &entities.Subject{ID: id, SchoolID: schoolID, Name: "Matemáticas", Code: &code, IsActive: true}, nil
+				},
+				ExistsBySchoolIDAndCodeFn: func(_ context.Context, _ uuid.UUID, _ string) (bool, error) {
+					checked = true
+					return tt.codeTaken, nil
+				},
+			}
+			svc := service.NewSubjectService(subjectRepo, &mock.MockAcademicUnitRepository{}, &mock.MockCustomFieldRepository{}, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+			result, err := svc.UpdateSubject(context.Background(), uuid.New().String(), dto.UpdateSubjectRequest{Code: &tt.code})
+
+			assert.Equal(t, tt.wantCheck, checked)
+			if tt.wantStatus != 0 {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, *result.Code)
+		})
+	}
+}
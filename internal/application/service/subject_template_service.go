@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// Reasons reported for templates skipped when applying them to a school
+const (
+	SubjectTemplateSkipExists     = "subject_already_exists"
+	SubjectTemplateSkipDuplicate  = "duplicate_name_in_selection"
+	SubjectTemplateSkipCodeExists = "subject_code_already_exists"
+)
+
+// SubjectTemplateService defines the subject template service interface
+type SubjectTemplateService interface {
+	CreateTemplate(ctx context.Context, req dto.CreateSubjectTemplateRequest) (*dto.SubjectTemplateResponse, error)
+	GetTemplate(ctx context.Context, id string) (*dto.SubjectTemplateResponse, error)
+	ListTemplates(ctx context.Context, filters dto.SubjectTemplateListFilters) ([]dto.SubjectTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, id string, req dto.UpdateSubjectTemplateRequest) (*dto.SubjectTemplateResponse, error)
+	DeleteTemplate(ctx context.Context, id string) error
+	ApplyToSchool(ctx context.Context, schoolID string, req dto.ApplySubjectTemplatesRequest) (*dto.ApplySubjectTemplatesResponse, error)
+}
+
+type subjectTemplateService struct {
+	templateRepo    repository.SubjectTemplateRepository
+	subjectRepo     repository.SubjectRepository
+	schoolRepo      sharedrepo.SchoolRepository
+	conceptTypeRepo repository.ConceptTypeRepository
+	logger          logger.Logger
+	auditLogger     audit.AuditLogger
+}
+
+// NewSubjectTemplateService creates a new subject template service
+func NewSubjectTemplateService(
+	templateRepo repository.SubjectTemplateRepository,
+	subjectRepo repository.SubjectRepository,
+	schoolRepo sharedrepo.SchoolRepository,
+	conceptTypeRepo repository.ConceptTypeRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) SubjectTemplateService {
+	return &subjectTemplateService{
+		templateRepo:    templateRepo,
+		subjectRepo:     subjectRepo,
+		schoolRepo:      schoolRepo,
+		conceptTypeRepo: conceptTypeRepo,
+		logger:          logger,
+		auditLogger:     auditLogger,
+	}
+}
+
+func (s *subjectTemplateService) CreateTemplate(ctx context.Context, req dto.CreateSubjectTemplateRequest) (*dto.SubjectTemplateResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 2 {
+		return nil, errors.NewValidationError("name must be at least 2 characters")
+	}
+	conceptTypeID, err := s.resolveConceptType(ctx, req.ConceptTypeID)
+	if err != nil {
+		return nil, err
+	}
+	level := trimOptional(req.EducationLevel)
+
+	exists, err := s.templateRepo.ExistsInGroup(ctx, name, conceptTypeID, level)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check subject template", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("subject_template").WithField("name", name)
+	}
+
+	now := time.Now()
+	template := &entity.SubjectTemplate{
+		ID:             uuid.New(),
+		Name:           name,
+		Code:           trimOptional(req.Code),
+		Description:    trimOptional(req.Description),
+		ConceptTypeID:  conceptTypeID,
+		EducationLevel: level,
+		SortOrder:      req.SortOrder,
+		IsActive:       true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "create", ResourceType: "subject_template",
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("create subject template", err)
+	}
+
+	s.logger.Info("entity created", "entity_type", "subject_template", "entity_id", template.ID.String())
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "create", ResourceType: "subject_template", ResourceID: template.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToSubjectTemplateResponse(template)
+	return &response, nil
+}
+
+func (s *subjectTemplateService) GetTemplate(ctx context.Context, id string) (*dto.SubjectTemplateResponse, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.ToSubjectTemplateResponse(template)
+	return &response, nil
+}
+
+func (s *subjectTemplateService) ListTemplates(ctx context.Context, filters dto.SubjectTemplateListFilters) ([]dto.SubjectTemplateResponse, error) {
+	repoFilters := repository.SubjectTemplateFilters{EducationLevel: strings.TrimSpace(filters.EducationLevel)}
+	if filters.ConceptTypeID != "" {
+		ctID, err := uuid.Parse(filters.ConceptTypeID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid concept_type_id")
+		}
+		repoFilters.ConceptTypeID = &ctID
+	}
+	templates, err := s.templateRepo.List(ctx, repoFilters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list subject templates", err)
+	}
+	return dto.ToSubjectTemplateResponseList(templates), nil
+}
+
+func (s *subjectTemplateService) UpdateTemplate(ctx context.Context, id string, req dto.UpdateSubjectTemplateRequest) (*dto.SubjectTemplateResponse, error) {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	regrouped := false
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 2 {
+			return nil, errors.NewValidationError("name must be at least 2 characters")
+		}
+		regrouped = regrouped || !strings.EqualFold(name, template.Name)
+		template.Name = name
+	}
+	if req.ConceptTypeID != nil {
+		conceptTypeID, err := s.resolveConceptType(ctx, req.ConceptTypeID)
+		if err != nil {
+			return nil, err
+		}
+		regrouped = true
+		template.ConceptTypeID = conceptTypeID
+	}
+	if req.EducationLevel != nil {
+		regrouped = true
+		template.EducationLevel = trimOptional(req.EducationLevel)
+	}
+	if regrouped {
+		exists, err := s.templateRepo.ExistsInGroup(ctx, template.Name, template.ConceptTypeID, template.EducationLevel)
+		if err != nil {
+			return nil, errors.NewDatabaseError("check subject template", err)
+		}
+		if exists {
+			return nil, errors.NewAlreadyExistsError("subject_template").WithField("name", template.Name)
+		}
+	}
+	if req.Code != nil {
+		template.Code = trimOptional(req.Code)
+	}
+	if req.Description != nil {
+		template.Description = trimOptional(req.Description)
+	}
+	if req.SortOrder != nil {
+		template.SortOrder = *req.SortOrder
+	}
+	template.UpdatedAt = time.Now()
+
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, errors.NewDatabaseError("update subject template", err)
+	}
+
+	s.logger.Info("entity updated", "entity_type", "subject_template", "entity_id", id)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "update", ResourceType: "subject_template", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToSubjectTemplateResponse(template)
+	return &response, nil
+}
+
+func (s *subjectTemplateService) DeleteTemplate(ctx context.Context, id string) error {
+	template, err := s.findTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.templateRepo.SoftDelete(ctx, template.ID); err != nil {
+		return errors.NewDatabaseError("delete subject template", err)
+	}
+
+	s.logger.Info("entity deleted", "entity_type", "subject_template", "entity_id", id)
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "delete", ResourceType: "subject_template", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
+
+// ApplyToSchool creates one subject per selected template in the school, all in
+// one transaction. Templates whose name already exists as an active subject of
+// the school are skipped.
+func (s *subjectTemplateService) ApplyToSchool(ctx context.Context, schoolID string, req dto.ApplySubjectTemplatesRequest) (*dto.ApplySubjectTemplatesResponse, error) {
+	schoolUUID, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	school, err := s.schoolRepo.FindByID(ctx, schoolUUID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find school", err)
+	}
+	if school == nil {
+		return nil, errors.NewNotFoundError("school")
+	}
+
+	templates, err := s.selectTemplates(ctx, school, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ApplySubjectTemplatesResponse{
+		SchoolID: schoolID,
+		Created:  []dto.SubjectResponse{},
+		Skipped:  []dto.SkippedSubjectTemplate{},
+	}
+	seen := make(map[string]bool, len(templates))
+	seenCodes := make(map[string]bool, len(templates))
+	subjects := make([]*entities.Subject, 0, len(templates))
+	for _, t := range templates {
+		key := strings.ToLower(t.Name)
+		if seen[key] {
+			result.Skipped = append(result.Skipped, dto.SkippedSubjectTemplate{TemplateID: t.ID.String(), Name: t.Name, Reason: SubjectTemplateSkipDuplicate})
+			continue
+		}
+		seen[key] = true
+
+		exists, err := s.subjectRepo.ExistsBySchoolIDAndName(ctx, schoolUUID, t.Name)
+		if err != nil {
+			return nil, errors.NewDatabaseError("check subject", err)
+		}
+		if exists {
+			result.Skipped = append(result.Skipped, dto.SkippedSubjectTemplate{TemplateID: t.ID.String(), Name: t.Name, Reason: SubjectTemplateSkipExists})
+			continue
+		}
+		if t.Code != nil {
+			codeTaken := seenCodes[*t.Code]
+			if !codeTaken {
+				codeTaken, err = s.subjectRepo.ExistsBySchoolIDAndCode(ctx, schoolUUID, *t.Code)
+				if err != nil {
+					return nil, errors.NewDatabaseError("check subject code", err)
+				}
+			}
+			if codeTaken {
+				result.Skipped = append(result.Skipped, dto.SkippedSubjectTemplate{TemplateID: t.ID.String(), Name: t.Name, Reason: SubjectTemplateSkipCodeExists})
+				continue
+			}
+			seenCodes[*t.Code] = true
+		}
+
+		now := time.Now()
+		subject := &entities.Subject{
+			ID:          uuid.New(),
+			SchoolID:    schoolUUID,
+			Name:        t.Name,
+			Code:        t.Code,
+			Description: t.Description,
+			IsActive:    true,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		subjects = append(subjects, subject)
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.subjectRepo.BulkCreate(ctx, subjects); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "apply_templates", ResourceType: "subject", ResourceID: schoolID,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("create subjects", err)
+	}
+	for _, subject := range subjects {
+		result.Created = append(result.Created, dto.ToSubjectResponse(subject))
+	}
+
+	s.logger.Info("subject templates applied", "school_id", schoolID, "created", len(result.Created), "skipped", len(result.Skipped))
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "apply_templates", ResourceType: "subject", ResourceID: schoolID,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"created": len(result.Created), "skipped": len(result.Skipped)},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return result, nil
+}
+
+// selectTemplates resolves the templates requested by ID, or by group falling back to the school's concept type
+func (s *subjectTemplateService) selectTemplates(ctx context.Context, school *entities.School, req dto.ApplySubjectTemplatesRequest) ([]*entity.SubjectTemplate, error) {
+	if len(req.TemplateIDs) > 0 {
+		templates := make([]*entity.SubjectTemplate, 0, len(req.TemplateIDs))
+		for _, id := range req.TemplateIDs {
+			template, err := s.findTemplate(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			templates = append(templates, template)
+		}
+		return templates, nil
+	}
+
+	filters := repository.SubjectTemplateFilters{}
+	if level := trimOptional(req.EducationLevel); level != nil {
+		filters.EducationLevel = *level
+	}
+	if req.ConceptTypeID != nil {
+		ctID, err := uuid.Parse(*req.ConceptTypeID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid concept_type_id")
+		}
+		filters.ConceptTypeID = &ctID
+	} else {
+		filters.ConceptTypeID = school.ConceptTypeID
+	}
+	if filters.ConceptTypeID == nil && filters.EducationLevel == "" {
+		return nil, errors.NewValidationError("template_ids, concept_type_id or education_level is required when the school has no concept type")
+	}
+	templates, err := s.templateRepo.List(ctx, filters)
+	if err != nil {
+		return nil, errors.NewDatabaseError("list subject templates", err)
+	}
+	return templates, nil
+}
+
+// findTemplate parses the ID and loads an active template
+func (s *subjectTemplateService) findTemplate(ctx context.Context, id string) (*entity.SubjectTemplate, error) {
+	tid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid subject template ID")
+	}
+	template, err := s.templateRepo.FindByID(ctx, tid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find subject template", err)
+	}
+	if template == nil {
+		return nil, errors.NewNotFoundError("subject_template")
+	}
+	return template, nil
+}
+
+// resolveConceptType validates an optional concept type reference; an empty value clears it
+func (s *subjectTemplateService) resolveConceptType(ctx context.Context, conceptTypeID *string) (*uuid.UUID, error) {
+	if conceptTypeID == nil || *conceptTypeID == "" {
+		return nil, nil
+	}
+	ctID, err := uuid.Parse(*conceptTypeID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid concept_type_id")
+	}
+	ct, err := s.conceptTypeRepo.FindByID(ctx, ctID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find concept type", err)
+	}
+	if ct == nil {
+		return nil, errors.NewNotFoundError("concept_type")
+	}
+	return &ctID, nil
+}
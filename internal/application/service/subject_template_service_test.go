@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubjectTemplateService(templateRepo *mock.MockSubjectTemplateRepository, subjectRepo *mock.MockSubjectRepository, school *entities.School) service.SubjectTemplateService {
+	schoolRepo := &mock.MockSchoolRepository{
+		FindByIDFn: func(_ context.Context, _ uuid.UUID) (*entities.School, error) { return school, nil },
+	}
+	conceptTypeRepo := &mock.MockConceptTypeRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.ConceptType, error) {
+			return &entities.ConceptType{ID: id, IsActive: true}, nil
+		},
+	}
+	return service.NewSubjectTemplateService(templateRepo, subjectRepo, schoolRepo, conceptTypeRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+}
+
+func TestSubjectTemplateService_ApplyToSchool(t *testing.T) {
+	conceptTypeID := uuid.New()
+	school := &entities.School{ID: uuid.New(), ConceptTypeID: &conceptTypeID, IsActive: true}
+	templates := []*entity.SubjectTemplate{
+		{ID: uuid.New(), Name: "Matemáticas", ConceptTypeID: &conceptTypeID, IsActive: true},
+		{ID: uuid.New(), Name: "Lenguaje", ConceptTypeID: &conceptTypeID, IsActive: true},
+		{ID: uuid.New(), Name: "Ciencias", ConceptTypeID: &conceptTypeID, IsActive: true},
+		{ID: uuid.New(), Name: "matemáticas", ConceptTypeID: &conceptTypeID, IsActive: true},
+	}
+
+	var listedWith repository.SubjectTemplateFilters
+	templateRepo := &mock.MockSubjectTemplateRepository{
+		ListFn: func(_ context.Context, filters repository.SubjectTemplateFilters) ([]*entity.SubjectTemplate, error) {
+			listedWith = filters
+			return templates, nil
+		},
+	}
+	var created []string
+	subjectRepo := &mock.MockSubjectRepository{
+		ExistsBySchoolIDAndNameFn: func(_ context.Context, _ uuid.UUID, name string) (bool, error) {
+			return name == "Lenguaje", nil
+		},
+		BulkCreateFn: func(_ context.Context, subjects []*entities.Subject) error {
+			for _, subject := range subjects {
+				created = append(created, subject.Name)
+			}
+			return nil
+		},
+	}
+	svc := newTestSubjectTemplateService(templateRepo, subjectRepo, school)
+
+	result, err := svc.ApplyToSchool(context.Background(), school.ID.String(), dto.ApplySubjectTemplatesRequest{})
+	require.NoError(t, err)
+
+	// Defaults to the school's concept type
+	require.NotNil(t, listedWith.ConceptTypeID)
+	assert.Equal(t, conceptTypeID, *listedWith.ConceptTypeID)
+
+	assert.Equal(t, []string{"Matemáticas", "Ciencias"}, created)
+	assert.Len(t, result.Created, 2)
+	require.Len(t, result.Skipped, 2)
+	assert.Equal(t, service.SubjectTemplateSkipExists, result.Skipped[0].Reason)
+	assert.Equal(t, service.SubjectTemplateSkipDuplicate, result.Skipped[1].Reason)
+
+	// A failed write creates none of the subjects
+	subjectRepo.BulkCreateFn = func(_ context.Context, _ []*entities.Subject) error { return assert.AnError }
+	_, err = svc.ApplyToSchool(context.Background(), school.ID.String(), dto.ApplySubjectTemplatesRequest{})
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, appErr.StatusCode)
+}
+
+func TestSubjectTemplateService_ApplyToSchool_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		school     *entities.School
+		request    dto.ApplySubjectTemplatesRequest
+		wantStatus int
+	}{
+		{
+			name:       "school not found",
+			school:     nil,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "no selector and school without concept type",
+			school:     &entities.School{ID: uuid.New(), IsActive: true},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown template id",
+			school:     &entities.School{ID: uuid.New(), IsActive: true},
+			request:    dto.ApplySubjectTemplatesRequest{TemplateIDs: []string{uuid.New().String()}},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestSubjectTemplateService(&mock.MockSubjectTemplateRepository{}, &mock.MockSubjectRepository{}, tt.school)
+			_, err := svc.ApplyToSchool(context.Background(), uuid.New().String(), tt.request)
+			require.Error(t, err)
+			appErr, ok := errors.GetAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+		})
+	}
+}
+
+func TestSubjectTemplateService_CreateTemplate(t *testing.T) {
+	level := "primaria"
+	conceptTypeID := uuid.New().String()
+
+	tests := []struct {
+		name       string
+		request    dto.CreateSubjectTemplateRequest
+		exists     bool
+		wantErr    bool
+		wantStatus int
+	}{
+		{
+			name:    "success",
+			request: dto.CreateSubjectTemplateRequest{Name: " Matemáticas ", ConceptTypeID: &conceptTypeID, EducationLevel: &level},
+		},
+		{
+			name:       "error - name too short",
+			request:    dto.CreateSubjectTemplateRequest{Name: " M "},
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - duplicate in group",
+			request:    dto.CreateSubjectTemplateRequest{Name: "Matemáticas", EducationLevel: &level},
+			exists:     true,
+			wantErr:    true,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateRepo := &mock.MockSubjectTemplateRepository{
+				ExistsInGroupFn: func(_ context.Context, _ string, _ *uuid.UUID, _ *string) (bool, error) { return tt.exists, nil },
+			}
+			svc := newTestSubjectTemplateService(templateRepo, &mock.MockSubjectRepository{}, nil)
+			result, err := svc.CreateTemplate(context.Background(), tt.request)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Matemáticas", result.Name)
+			require.NotNil(t, result.ConceptTypeID)
+			assert.Equal(t, conceptTypeID, *result.ConceptTypeID)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-shared/audit"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// membershipRoleTeacher is the membership role that identifies a teacher
+const membershipRoleTeacher = "teacher"
+
+// TeachingAssignmentService defines the teaching assignment service interface
+type TeachingAssignmentService interface {
+	CreateAssignment(ctx context.Context, req dto.CreateTeachingAssignmentRequest, createdBy string) (*dto.TeachingAssignmentResponse, error)
+	GetAssignment(ctx context.Context, id string) (*dto.TeachingAssignmentResponse, error)
+	UpdateAssignment(ctx context.Context, id string, req dto.UpdateTeachingAssignmentRequest) (*dto.TeachingAssignmentResponse, error)
+	DeleteAssignment(ctx context.Context, id string) error
+	ListByTeacher(ctx context.Context, teacherID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error)
+	ListByUnit(ctx context.Context, unitID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error)
+	ListBySubject(ctx context.Context, subjectID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error)
+}
+
+type teachingAssignmentService struct {
+	assignmentRepo repository.TeachingAssignmentRepository
+	subjectRepo    repository.SubjectRepository
+	unitRepo       repository.AcademicUnitRepository
+	userRepo       sharedrepo.UserRepository
+	membershipRepo sharedrepo.MembershipRepository
+	logger         logger.Logger
+	auditLogger    audit.AuditLogger
+}
+
+// NewTeachingAssignmentService creates a new teaching assignment service
+func NewTeachingAssignmentService(
+	assignmentRepo repository.TeachingAssignmentRepository,
+	subjectRepo repository.SubjectRepository,
+	unitRepo repository.AcademicUnitRepository,
+	userRepo sharedrepo.UserRepository,
+	membershipRepo sharedrepo.MembershipRepository,
+	logger logger.Logger,
+	auditLogger audit.AuditLogger,
+) TeachingAssignmentService {
+	return &teachingAssignmentService{
+		assignmentRepo: assignmentRepo,
+		subjectRepo:    subjectRepo,
+		unitRepo:       unitRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		logger:         logger,
+		auditLogger:    auditLogger,
+	}
+}
+
+func (s *teachingAssignmentService) CreateAssignment(ctx context.Context, req dto.CreateTeachingAssignmentRequest, createdBy string) (*dto.TeachingAssignmentResponse, error) {
+	teacherID, err := uuid.Parse(req.TeacherID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid teacher_id")
+	}
+	subjectID, err := uuid.Parse(req.SubjectID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid subject_id")
+	}
+	unitID, err := uuid.Parse(req.AcademicUnitID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid academic_unit_id")
+	}
+
+	subject, err := s.subjectRepo.FindByID(ctx, subjectID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find subject", err)
+	}
+	if subject == nil {
+		return nil, errors.NewNotFoundError("subject")
+	}
+	unit, err := s.unitRepo.FindByID(ctx, unitID, false)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find academic unit", err)
+	}
+	if unit == nil {
+		return nil, errors.NewNotFoundError("academic_unit")
+	}
+	if unit.SchoolID != subject.SchoolID {
+		return nil, errors.NewValidationError("academic unit and subject belong to different schools").
+			WithField("academic_unit_id", unitID.String()).
+			WithField("subject_id", subjectID.String())
+	}
+	if err := s.validateTeacher(ctx, teacherID, subject.SchoolID); err != nil {
+		return nil, err
+	}
+
+	period := trimOptional(req.Period)
+	exists, err := s.assignmentRepo.ExistsActive(ctx, teacherID, subjectID, unitID, period)
+	if err != nil {
+		return nil, errors.NewDatabaseError("check teaching assignment", err)
+	}
+	if exists {
+		return nil, errors.NewAlreadyExistsError("teaching_assignment")
+	}
+
+	var createdByUUID *uuid.UUID
+	if createdBy != "" {
+		parsed, err := uuid.Parse(createdBy)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid created_by")
+		}
+		createdByUUID = &parsed
+	}
+
+	now := time.Now()
+	assignment := &entity.TeachingAssignment{
+		ID:             uuid.New(),
+		TeacherID:      teacherID,
+		SubjectID:      subjectID,
+		AcademicUnitID: unitID,
+		SchoolID:       subject.SchoolID,
+		Period:         period,
+		IsActive:       true,
+		CreatedBy:      createdByUUID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.assignmentRepo.Create(ctx, assignment); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "create", ResourceType: "teaching_assignment",
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("create teaching assignment", err)
+	}
+
+	s.logger.Info("entity created", "entity_type", "teaching_assignment", "entity_id", assignment.ID.String())
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "create", ResourceType: "teaching_assignment", ResourceID: assignment.ID.String(),
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+		Metadata: map[string]interface{}{"teacher_id": teacherID.String(), "subject_id": subjectID.String(), "academic_unit_id": unitID.String()},
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToTeachingAssignmentResponse(assignment)
+	return &response, nil
+}
+
+func (s *teachingAssignmentService) GetAssignment(ctx context.Context, id string) (*dto.TeachingAssignmentResponse, error) {
+	assignment, err := s.findAssignment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.ToTeachingAssignmentResponse(assignment)
+	return &response, nil
+}
+
+func (s *teachingAssignmentService) UpdateAssignment(ctx context.Context, id string, req dto.UpdateTeachingAssignmentRequest) (*dto.TeachingAssignmentResponse, error) {
+	assignment, err := s.findAssignment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previousTeacher, previousPeriod := assignment.TeacherID, assignment.Period
+	if req.TeacherID != nil {
+		teacherID, err := uuid.Parse(*req.TeacherID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid teacher_id")
+		}
+		if err := s.validateTeacher(ctx, teacherID, assignment.SchoolID); err != nil {
+			return nil, err
+		}
+		assignment.TeacherID = teacherID
+	}
+	if req.Period != nil {
+		assignment.Period = trimOptional(req.Period)
+	}
+	if assignment.TeacherID != previousTeacher || !samePeriod(assignment.Period, previousPeriod) {
+		exists, err := s.assignmentRepo.ExistsActive(ctx, assignment.TeacherID, assignment.SubjectID, assignment.AcademicUnitID, assignment.Period)
+		if err != nil {
+			return nil, errors.NewDatabaseError("check teaching assignment", err)
+		}
+		if exists {
+			return nil, errors.NewAlreadyExistsError("teaching_assignment")
+		}
+	}
+	assignment.UpdatedAt = time.Now()
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.assignmentRepo.Update(ctx, assignment); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "update", ResourceType: "teaching_assignment", ResourceID: id,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return nil, errors.NewDatabaseError("update teaching assignment", err)
+	}
+
+	s.logger.Info("entity updated", "entity_type", "teaching_assignment", "entity_id", id)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "update", ResourceType: "teaching_assignment", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	response := dto.ToTeachingAssignmentResponse(assignment)
+	return &response, nil
+}
+
+func (s *teachingAssignmentService) DeleteAssignment(ctx context.Context, id string) error {
+	assignment, err := s.findAssignment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	actorID, actorEmail, actorRole := actorFromContext(ctx)
+	if err := s.assignmentRepo.Delete(ctx, assignment.ID); err != nil {
+		if logErr := s.auditLogger.Log(ctx, audit.AuditEvent{
+			Action: "delete", ResourceType: "teaching_assignment", ResourceID: id,
+			ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+			ErrorMessage: err.Error(), Severity: audit.SeverityWarning, Category: audit.CategoryData,
+		}); logErr != nil {
+			s.logger.Error("failed to write audit log", "error", logErr)
+		}
+		return errors.NewDatabaseError("delete teaching assignment", err)
+	}
+
+	s.logger.Info("entity deleted", "entity_type", "teaching_assignment", "entity_id", id)
+	if err := s.auditLogger.Log(ctx, audit.AuditEvent{
+		Action: "delete", ResourceType: "teaching_assignment", ResourceID: id,
+		ActorID: actorID, ActorEmail: actorEmail, ActorRole: actorRole,
+		Severity: audit.SeverityInfo, Category: audit.CategoryData,
+	}); err != nil {
+		s.logger.Error("failed to write audit log", "error", err)
+	}
+	return nil
+}
+
+func (s *teachingAssignmentService) ListByTeacher(ctx context.Context, teacherID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error) {
+	tid, err := uuid.Parse(teacherID)
+	if err != nil {
+		return nil, 0, errors.NewValidationError("invalid teacher_id")
+	}
+	assignments, total, err := s.assignmentRepo.FindByTeacher(ctx, tid, filters)
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list teaching assignments", err)
+	}
+	return dto.ToTeachingAssignmentResponseList(assignments), total, nil
+}
+
+func (s *teachingAssignmentService) ListByUnit(ctx context.Context, unitID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error) {
+	uid, err := uuid.Parse(unitID)
+	if err != nil {
+		return nil, 0, errors.NewValidationError("invalid unit ID")
+	}
+	assignments, total, err := s.assignmentRepo.FindByUnit(ctx, uid, filters)
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list teaching assignments", err)
+	}
+	return dto.ToTeachingAssignmentResponseList(assignments), total, nil
+}
+
+func (s *teachingAssignmentService) ListBySubject(ctx context.Context, subjectID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error) {
+	sid, err := uuid.Parse(subjectID)
+	if err != nil {
+		return nil, 0, errors.NewValidationError("invalid subject ID")
+	}
+	assignments, total, err := s.assignmentRepo.FindBySubject(ctx, sid, filters)
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("list teaching assignments", err)
+	}
+	return dto.ToTeachingAssignmentResponseList(assignments), total, nil
+}
+
+// findAssignment parses the ID and loads an active assignment
+func (s *teachingAssignmentService) findAssignment(ctx context.Context, id string) (*entity.TeachingAssignment, error) {
+	aid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid teaching assignment ID")
+	}
+	assignment, err := s.assignmentRepo.FindByID(ctx, aid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find teaching assignment", err)
+	}
+	if assignment == nil {
+		return nil, errors.NewNotFoundError("teaching_assignment")
+	}
+	return assignment, nil
+}
+
+// validateTeacher verifies the user is active and holds an active teacher membership in the school
+func (s *teachingAssignmentService) validateTeacher(ctx context.Context, teacherID, schoolID uuid.UUID) error {
+	teacher, err := s.userRepo.FindByID(ctx, teacherID)
+	if err != nil {
+		return errors.NewDatabaseError("find teacher user", err)
+	}
+	if teacher == nil {
+		return errors.NewNotFoundError("teacher")
+	}
+	if !teacher.IsActive {
+		return errors.NewValidationError("teacher user is not active").WithField("teacher_id", teacherID.String())
+	}
+
+	memberships, _, err := s.membershipRepo.FindByUser(ctx, teacherID, sharedrepo.ListFilters{})
+	if err != nil {
+		return errors.NewDatabaseError("find teacher memberships", err)
+	}
+	for _, m := range memberships {
+		if m.IsActive && m.Role == membershipRoleTeacher && m.SchoolID == schoolID {
+			return nil
+		}
+	}
+	return errors.NewValidationError("user has no active teacher membership in this school").
+		WithField("teacher_id", teacherID.String()).
+		WithField("school_id", schoolID.String())
+}
+
+// trimOptional trims an optional string and treats an empty value as absent
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// samePeriod compares two optional periods
+func samePeriod(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/test/mock"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/EduGoGroup/edugo-shared/common/errors"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// teachingFixture wires a subject and unit in the same school and a teacher
+// whose membership role and school can be changed per test.
+type teachingFixture struct {
+	schoolID      uuid.UUID
+	subjectID     uuid.UUID
+	unitID        uuid.UUID
+	teacherID     uuid.UUID
+	unitSchoolID  uuid.UUID
+	teacherRole   string
+	teacherSchool uuid.UUID
+	teacherActive bool
+}
+
+func newTeachingFixture() *teachingFixture {
+	schoolID := uuid.New()
+	return &teachingFixture{
+		schoolID:      schoolID,
+		subjectID:     uuid.New(),
+		unitID:        uuid.New(),
+		teacherID:     uuid.New(),
+		unitSchoolID:  schoolID,
+		teacherRole:   "teacher",
+		teacherSchool: schoolID,
+		teacherActive: true,
+	}
+}
+
+func (f *teachingFixture) service(assignmentRepo *mock.MockTeachingAssignmentRepository) service.TeachingAssignmentService {
+	subjectRepo := &mock.MockSubjectRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID, _ bool) (*entities.Subject, error) {
+			if id != f.subjectID {
+				return nil, nil
+			}
+			return &entities.Subject{ID: id, SchoolID: f.schoolID, Name: "Matemáticas", IsActive: true}, nil
+		},
+	}
+	unitRepo := &mock.MockAcademicUnitRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID, _ bool) (*entities.AcademicUnit, error) {
+			if id != f.unitID {
+				return nil, nil
+			}
+			return &entities.AcademicUnit{ID: id, SchoolID: f.unitSchoolID, IsActive: true}, nil
+		},
+	}
+	userRepo := &mock.MockUserRepository{
+		FindByIDFn: func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+			return &entities.User{ID: id, IsActive: f.teacherActive}, nil
+		},
+	}
+	membershipRepo := &mock.MockMembershipRepository{
+		FindByUserFn: func(_ context.Context, userID uuid.UUID, _ sharedrepo.ListFilters) ([]*entities.Membership, int64, error) {
+			return []*entities.Membership{{ID: uuid.New(), UserID: userID, SchoolID: f.teacherSchool, Role: f.teacherRole, IsActive: true}}, 1, nil
+		},
+	}
+	return service.NewTeachingAssignmentService(assignmentRepo, subjectRepo, unitRepo, userRepo, membershipRepo, mock.NewMockLogger(), mock.NewNoopAuditLogger())
+}
+
+func (f *teachingFixture) request() dto.CreateTeachingAssignmentRequest {
+	return dto.CreateTeachingAssignmentRequest{
+		TeacherID:      f.teacherID.String(),
+		SubjectID:      f.subjectID.String(),
+		AcademicUnitID: f.unitID.String(),
+	}
+}
+
+func TestTeachingAssignmentService_CreateAssignment(t *testing.T) {
+	period := " 2026-1 "
+
+	tests := []struct {
+		name       string
+		setup      func(f *teachingFixture, req *dto.CreateTeachingAssignmentRequest)
+		exists     bool
+		wantErr    bool
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			setup: func(_ *teachingFixture, req *dto.CreateTeachingAssignmentRequest) { req.Period = &period },
+		},
+		{
+			name:       "error - invalid teacher_id",
+			setup:      func(_ *teachingFixture, req *dto.CreateTeachingAssignmentRequest) { req.TeacherID = "bad" },
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - subject not found",
+			setup: func(_ *teachingFixture, req *dto.CreateTeachingAssignmentRequest) {
+				req.SubjectID = uuid.New().String()
+			},
+			wantErr:    true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "error - unit not found",
+			setup: func(_ *teachingFixture, req *dto.CreateTeachingAssignmentRequest) {
+				req.AcademicUnitID = uuid.New().String()
+			},
+			wantErr:    true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "error - unit in another school",
+			setup:      func(f *teachingFixture, _ *dto.CreateTeachingAssignmentRequest) { f.unitSchoolID = uuid.New() },
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - inactive teacher",
+			setup:      func(f *teachingFixture, _ *dto.CreateTeachingAssignmentRequest) { f.teacherActive = false },
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - membership is not teacher",
+			setup:      func(f *teachingFixture, _ *dto.CreateTeachingAssignmentRequest) { f.teacherRole = "student" },
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - teacher membership in another school",
+			setup:      func(f *teachingFixture, _ *dto.CreateTeachingAssignmentRequest) { f.teacherSchool = uuid.New() },
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - duplicate assignment",
+			exists:     true,
+			wantErr:    true,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTeachingFixture()
+			req := f.request()
+			if tt.setup != nil {
+				tt.setup(f, &req)
+			}
+			var created *entity.TeachingAssignment
+			repo := &mock.MockTeachingAssignmentRepository{
+				ExistsActiveFn: func(_ context.Context, _, _, _ uuid.UUID, _ *string) (bool, error) { return tt.exists, nil },
+				CreateFn: func(_ context.Context, a *entity.TeachingAssignment) error {
+					created = a
+					return nil
+				},
+			}
+			result, err := f.service(repo).CreateAssignment(context.Background(), req, uuid.New().String())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				assert.Nil(t, created)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, created)
+			assert.Equal(t, f.schoolID.String(), result.SchoolID)
+			require.NotNil(t, result.Period)
+			assert.Equal(t, "2026-1", *result.Period)
+		})
+	}
+}
+
+func TestTeachingAssignmentService_UpdateAssignment(t *testing.T) {
+	newPeriod := "2026-2"
+	otherTeacher := uuid.New().String()
+
+	tests := []struct {
+		name       string
+		request    dto.UpdateTeachingAssignmentRequest
+		exists     bool
+		role       string
+		wantErr    bool
+		wantStatus int
+	}{
+		{
+			name:    "success - change period",
+			request: dto.UpdateTeachingAssignmentRequest{Period: &newPeriod},
+		},
+		{
+			name:    "success - reassign teacher",
+			request: dto.UpdateTeachingAssignmentRequest{TeacherID: &otherTeacher},
+		},
+		{
+			name:    "success - unchanged keys skip duplicate check",
+			request: dto.UpdateTeachingAssignmentRequest{},
+			exists:  true,
+		},
+		{
+			name:       "error - new teacher lacks membership",
+			request:    dto.UpdateTeachingAssignmentRequest{TeacherID: &otherTeacher},
+			role:       "guardian",
+			wantErr:    true,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "error - collides with existing assignment",
+			request:    dto.UpdateTeachingAssignmentRequest{Period: &newPeriod},
+			exists:     true,
+			wantErr:    true,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTeachingFixture()
+			if tt.role != "" {
+				f.teacherRole = tt.role
+			}
+			repo := &mock.MockTeachingAssignmentRepository{
+				FindByIDFn: func(_ context.Context, id uuid.UUID) (*entity.TeachingAssignment, error) {
+					return &entity.TeachingAssignment{ID: id, TeacherID: f.teacherID, SubjectID: f.subjectID, AcademicUnitID: f.unitID, SchoolID: f.schoolID, IsActive: true}, nil
+				},
+				ExistsActiveFn: func(_ context.Context, _, _, _ uuid.UUID, _ *string) (bool, error) { return tt.exists, nil },
+			}
+			_, err := f.service(repo).UpdateAssignment(context.Background(), uuid.New().String(), tt.request)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				appErr, ok := errors.GetAppError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantStatus, appErr.StatusCode)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTeachingAssignmentService_DeleteAssignment_NotFound(t *testing.T) {
+	f := newTeachingFixture()
+	deleted := false
+	repo := &mock.MockTeachingAssignmentRepository{
+		DeleteFn: func(_ context.Context, _ uuid.UUID) error {
+			deleted = true
+			return nil
+		},
+	}
+	err := f.service(repo).DeleteAssignment(context.Background(), uuid.New().String())
+	require.Error(t, err)
+	appErr, ok := errors.GetAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	assert.False(t, deleted)
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/EduGoGroup/edugo-shared/audit"
 	"github.com/EduGoGroup/edugo-shared/auth"
@@ -16,24 +18,80 @@ import (
 	"github.com/google/uuid"
 )
 
-// UserService defines the user service interface
+// UserService defines the user service interface. Users belong to no single
+// school, so custom fields are read, written and filtered for the school
+// given by schoolID, which the user must be an active member of; without it
+// responses carry no custom fields. New users have no memberships yet, so
+// their custom fields are set by a later update. Listing with a school only
+// returns its active members.
 type UserService interface {
 	CreateUser(ctx context.Context, req dto.CreateUserRequest) (*dto.UserResponse, error)
-	GetUser(ctx context.Context, id string) (*dto.UserResponse, error)
-	ListUsers(ctx context.Context, filters sharedrepo.ListFilters) ([]*dto.UserResponse, int, error)
+	GetUser(ctx context.Context, id, schoolID string) (*dto.UserResponse, error)
+	ListUsers(ctx context.Context, filters sharedrepo.ListFilters, schoolID string, fieldFilters map[string]string) ([]*dto.UserResponse, int, error)
 	UpdateUser(ctx context.Context, id string, req dto.UpdateUserRequest) (*dto.UserResponse, error)
 	DeleteUser(ctx context.Context, id string) error
 }
 
 type userService struct {
-	userRepo    sharedrepo.UserRepository
-	logger      logger.Logger
-	auditLogger audit.AuditLogger
+	userRepo       sharedrepo.UserRepository
+	membershipRepo sharedrepo.MembershipRepository
+	fieldRepo      repository.CustomFieldRepository
+	logger         logger.Logger
+	auditLogger    audit.AuditLogger
 }
 
 // NewUserService creates a new user service
-func NewUserService(userRepo sharedrepo.UserRepository, logger logger.Logger, auditLogger audit.AuditLogger) UserService {
-	return &userService{userRepo: userRepo, logger: logger, auditLogger: auditLogger}
+func NewUserService(userRepo sharedrepo.UserRepository, membershipRepo sharedrepo.MembershipRepository, fieldRepo repository.CustomFieldRepository, logger logger.Logger, auditLogger audit.AuditLogger) UserService {
+	return &userService{userRepo: userRepo, membershipRepo: membershipRepo, fieldRepo: fieldRepo, logger: logger, auditLogger: auditLogger}
+}
+
+// loadFieldSet loads the user custom fields of a school the user is an
+// active member of. It returns nil without a school, which is only allowed
+// when no custom fields are given.
+func (s *userService) loadFieldSet(ctx context.Context, userID uuid.UUID, schoolID string, customFields map[string]interface{}) (*customFieldSet, error) {
+	if schoolID == "" {
+		if len(customFields) > 0 {
+			return nil, errors.NewValidationErrorWithFields("invalid user", map[string]string{
+				"school_id": "is required to set custom fields",
+			})
+		}
+		return nil, nil
+	}
+	sid, err := uuid.Parse(schoolID)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid school ID")
+	}
+	membership, err := s.membershipRepo.FindByUserAndSchool(ctx, userID, sid)
+	if err != nil {
+		return nil, errors.NewDatabaseError("find membership", err)
+	}
+	if membership == nil || !membership.IsActive {
+		return nil, errors.NewValidationErrorWithFields("invalid user", map[string]string{
+			"school_id": "user has no active membership in this school",
+		})
+	}
+	return loadCustomFieldSet(ctx, s.fieldRepo, sid, entity.CustomFieldEntityUser)
+}
+
+// withCustomFields converts users to responses carrying their custom field
+// values in the set's school, if any
+func (s *userService) withCustomFields(ctx context.Context, set *customFieldSet, users []*entities.User) ([]*dto.UserResponse, error) {
+	responses := dto.ToUserResponseList(users)
+	if set == nil {
+		return responses, nil
+	}
+	ids := make([]uuid.UUID, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	values, err := set.values(ctx, s.fieldRepo, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range responses {
+		responses[i].CustomFields = values[users[i].ID]
+	}
+	return responses, nil
 }
 
 func (s *userService) CreateUser(ctx context.Context, req dto.CreateUserRequest) (*dto.UserResponse, error) {
@@ -89,7 +147,7 @@ func (s *userService) CreateUser(ctx context.Context, req dto.CreateUserRequest)
 	return dto.ToUserResponse(user), nil
 }
 
-func (s *userService) GetUser(ctx context.Context, id string) (*dto.UserResponse, error) {
+func (s *userService) GetUser(ctx context.Context, id, schoolID string) (*dto.UserResponse, error) {
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid user ID")
@@ -101,15 +159,54 @@ func (s *userService) GetUser(ctx context.Context, id string) (*dto.UserResponse
 	if user == nil {
 		return nil, errors.NewNotFoundError("user")
 	}
-	return dto.ToUserResponse(user), nil
+	fieldSet, err := s.loadFieldSet(ctx, user.ID, schoolID, nil)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := s.withCustomFields(ctx, fieldSet, []*entities.User{user})
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
 }
 
-func (s *userService) ListUsers(ctx context.Context, filters sharedrepo.ListFilters) ([]*dto.UserResponse, int, error) {
-	users, total, err := s.userRepo.List(ctx, filters)
+func (s *userService) ListUsers(ctx context.Context, filters sharedrepo.ListFilters, schoolID string, fieldFilters map[string]string) ([]*dto.UserResponse, int, error) {
+	if schoolID == "" && len(fieldFilters) > 0 {
+		return nil, 0, errors.NewValidationErrorWithFields("invalid custom field filters", map[string]string{
+			"school_id": "is required to filter by custom fields",
+		})
+	}
+	var users []*entities.User
+	var total int64
+	var fieldSet *customFieldSet
+	var err error
+	if schoolID != "" {
+		sid, err := uuid.Parse(schoolID)
+		if err != nil {
+			return nil, 0, errors.NewValidationError("invalid school ID")
+		}
+		if fieldSet, err = loadCustomFieldSet(ctx, s.fieldRepo, sid, entity.CustomFieldEntityUser); err != nil {
+			return nil, 0, err
+		}
+		repoFilters, err := fieldSet.filters(fieldFilters)
+		if err != nil {
+			return nil, 0, err
+		}
+		users, total, err = s.fieldRepo.ListUsers(ctx, filters, sid, repoFilters)
+		if err != nil {
+			return nil, 0, errors.NewDatabaseError("list users", err)
+		}
+	} else {
+		users, total, err = s.userRepo.List(ctx, filters)
+		if err != nil {
+			return nil, 0, errors.NewDatabaseError("list users", err)
+		}
+	}
+	responses, err := s.withCustomFields(ctx, fieldSet, users)
 	if err != nil {
-		return nil, 0, errors.NewDatabaseError("list users", err)
+		return nil, 0, err
 	}
-	return dto.ToUserResponseList(users), int(total), nil
+	return responses, int(total), nil
 }
 
 func (s *userService) UpdateUser(ctx context.Context, id string, req dto.UpdateUserRequest) (*dto.UserResponse, error) {
@@ -138,14 +235,30 @@ func (s *userService) UpdateUser(ctx context.Context, id string, req dto.UpdateU
 		}
 		user.IsActive = isActive
 	}
+	fieldSet, err := s.loadFieldSet(ctx, user.ID, req.SchoolID, req.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	var fieldValues []*entity.CustomFieldValue
+	var cleared []uuid.UUID
+	if fieldSet != nil {
+		if fieldValues, cleared, err = fieldSet.prepare(user.ID, req.CustomFields, false); err != nil {
+			return nil, err
+		}
+	}
 
 	user.UpdatedAt = time.Now()
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	update := func() error { return s.userRepo.Update(ctx, user) }
+	if err := updateWithCustomFields(ctx, s.fieldRepo, user, user.ID, fieldValues, cleared, update); err != nil {
 		return nil, errors.NewDatabaseError("update user", err)
 	}
 
 	s.logger.Info("entity updated", "entity_type", "user", "entity_id", id)
-	return dto.ToUserResponse(user), nil
+	responses, err := s.withCustomFields(ctx, fieldSet, []*entities.User{user})
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
 }
 
 func (s *userService) DeleteUser(ctx context.Context, id string) error {
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -69,10 +71,49 @@ type DefaultsConfig struct {
 }
 
 type SchoolDefaults struct {
-	Country          string `env:"COUNTRY"           envDefault:"CO"`
-	SubscriptionTier string `env:"SUBSCRIPTION_TIER" envDefault:"free"`
-	MaxTeachers      int    `env:"MAX_TEACHERS"      envDefault:"50"`
-	MaxStudents      int    `env:"MAX_STUDENTS"      envDefault:"500"`
+	Country          string           `env:"COUNTRY"           envDefault:"CO"`
+	SubscriptionTier string           `env:"SUBSCRIPTION_TIER" envDefault:"free"`
+	MaxTeachers      int              `env:"MAX_TEACHERS"      envDefault:"50"`
+	MaxStudents      int              `env:"MAX_STUDENTS"      envDefault:"500"`
+	Code             SchoolCodeConfig `envPrefix:"CODE_"`
+}
+
+// School code normalisations
+const (
+	SchoolCodeNormalizeNone       = "none"
+	SchoolCodeNormalizeUpper      = "upper"
+	SchoolCodeNormalizeUpperASCII = "upper_ascii"
+)
+
+// SchoolCodeConfig sets the format of school codes. Codes are normalised
+// first and must then match Pattern, when set. Codes left out on creation are
+// generated from Template, where {name} stands for the name's initials,
+// {city} for the first letters of the city and {seq} for a sequence number of
+// SequenceDigits digits that makes the code unique.
+type SchoolCodeConfig struct {
+	Pattern        string `env:"PATTERN"`
+	Normalization  string `env:"NORMALIZATION"   envDefault:"none"`
+	Template       string `env:"TEMPLATE"        envDefault:"{name}-{seq}"`
+	SequenceDigits int    `env:"SEQUENCE_DIGITS" envDefault:"3"`
+}
+
+// Validate checks the pattern compiles and the normalisation is known
+func (c *SchoolCodeConfig) Validate() error {
+	if _, err := regexp.Compile(c.Pattern); err != nil {
+		return fmt.Errorf("invalid school code pattern: %w", err)
+	}
+	switch c.Normalization {
+	case SchoolCodeNormalizeNone, SchoolCodeNormalizeUpper, SchoolCodeNormalizeUpperASCII:
+	default:
+		return fmt.Errorf("invalid school code normalization %q", c.Normalization)
+	}
+	if !strings.Contains(c.Template, "{name}") && !strings.Contains(c.Template, "{city}") {
+		return fmt.Errorf("school code template must contain {name} or {city}")
+	}
+	if c.SequenceDigits < 1 || c.SequenceDigits > 6 {
+		return fmt.Errorf("school code sequence digits must be between 1 and 6")
+	}
+	return nil
 }
 
 type CORSConfig struct {
@@ -93,5 +134,8 @@ func Load() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing config from environment: %w", err)
 	}
+	if err := cfg.Defaults.School.Code.Validate(); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
@@ -25,17 +25,27 @@ type Container struct {
 	// Audit
 	AuditLogger audit.AuditLogger
 
+	// Services used directly by middleware
+	SchoolService service.SchoolService
+
 	// Handlers
-	SchoolHandler       *handler.SchoolHandler
-	AcademicUnitHandler *handler.AcademicUnitHandler
-	MembershipHandler   *handler.MembershipHandler
-	SubjectHandler      *handler.SubjectHandler
-	GuardianHandler     *handler.GuardianHandler
-	UserHandler         *handler.UserHandler
-	StatsHandler        *handler.StatsHandler
-	MaterialHandler     *handler.MaterialHandler
-	ConceptTypeHandler  *handler.ConceptTypeHandler
-	HealthHandler       *handler.HealthHandler
+	SchoolHandler             *handler.SchoolHandler
+	AcademicUnitHandler       *handler.AcademicUnitHandler
+	MembershipHandler         *handler.MembershipHandler
+	SubjectHandler            *handler.SubjectHandler
+	SubjectTemplateHandler    *handler.SubjectTemplateHandler
+	CurriculumHandler         *handler.CurriculumHandler
+	TeachingAssignmentHandler *handler.TeachingAssignmentHandler
+	GuardianHandler           *handler.GuardianHandler
+	GuardianInvitationHandler *handler.GuardianInvitationHandler
+	HouseholdHandler          *handler.HouseholdHandler
+	UserHandler               *handler.UserHandler
+	StatsHandler              *handler.StatsHandler
+	MaterialHandler           *handler.MaterialHandler
+	ConceptTypeHandler        *handler.ConceptTypeHandler
+	MetadataSchemaHandler     *handler.MetadataSchemaHandler
+	CustomFieldHandler        *handler.CustomFieldHandler
+	HealthHandler             *handler.HealthHandler
 }
 
 // NewContainer creates a new container and initializes all dependencies
@@ -71,38 +81,64 @@ func NewContainer(db *gorm.DB, log logger.Logger, cfg *config.Config) *Container
 	// Local repositories
 	unitRepo := pgRepo.NewPostgresAcademicUnitRepository(db)
 	subjectRepo := pgRepo.NewPostgresSubjectRepository(db)
+	subjectTemplateRepo := pgRepo.NewPostgresSubjectTemplateRepository(db)
+	curriculumRepo := pgRepo.NewPostgresCurriculumRepository(db)
+	teachingAssignmentRepo := pgRepo.NewPostgresTeachingAssignmentRepository(db)
 	guardianRepo := pgRepo.NewPostgresGuardianRepository(db)
+	guardianInvitationRepo := pgRepo.NewPostgresGuardianInvitationRepository(db)
+	householdRepo := pgRepo.NewPostgresHouseholdRepository(db)
 	statsRepo := pgRepo.NewPostgresStatsRepository(db)
 	materialRepo := pgRepo.NewPostgresMaterialRepository(db)
 	conceptTypeRepo := pgRepo.NewPostgresConceptTypeRepository(db)
 	conceptDefRepo := pgRepo.NewPostgresConceptDefinitionRepository(db)
+	conceptCategoryRepo := pgRepo.NewPostgresConceptCategoryRepository(db)
 	schoolConceptRepo := pgRepo.NewPostgresSchoolConceptRepository(db)
+	schoolLifecycleRepo := pgRepo.NewPostgresSchoolLifecycleRepository(db)
+	schoolDeletionRepo := pgRepo.NewPostgresSchoolDeletionRepository(db)
+	schoolBundleRepo := pgRepo.NewPostgresSchoolBundleRepository(db)
+	metadataSchemaRepo := pgRepo.NewPostgresMetadataSchemaRepository(db)
+	customFieldRepo := pgRepo.NewPostgresCustomFieldRepository(db)
 
 	// Audit logger
 	auditLogger := auditpostgres.NewPostgresAuditLogger(db, "admin-api")
 	c.AuditLogger = auditLogger
 
 	// Services
-	schoolService := service.NewSchoolService(schoolRepo, conceptTypeRepo, conceptDefRepo, schoolConceptRepo, log, cfg.Defaults.School, auditLogger)
-	unitService := service.NewAcademicUnitService(unitRepo, schoolRepo, log, auditLogger)
+	schoolService := service.NewSchoolService(schoolRepo, conceptTypeRepo, conceptDefRepo, schoolConceptRepo, schoolLifecycleRepo, schoolDeletionRepo, schoolBundleRepo, metadataSchemaRepo, log, cfg.Defaults.School, auditLogger)
+	c.SchoolService = schoolService
+	unitService := service.NewAcademicUnitService(unitRepo, schoolRepo, metadataSchemaRepo, customFieldRepo, log, auditLogger)
 	membershipService := service.NewMembershipService(membershipRepo, log, auditLogger)
-	subjectService := service.NewSubjectService(subjectRepo, log, auditLogger)
-	guardianService := service.NewGuardianService(guardianRepo, log, auditLogger)
-	userService := service.NewUserService(userRepo, log, auditLogger)
+	subjectService := service.NewSubjectService(subjectRepo, unitRepo, customFieldRepo, log, auditLogger)
+	subjectTemplateService := service.NewSubjectTemplateService(subjectTemplateRepo, subjectRepo, schoolRepo, conceptTypeRepo, log, auditLogger)
+	curriculumService := service.NewCurriculumService(curriculumRepo, subjectRepo, unitRepo, log, auditLogger)
+	teachingAssignmentService := service.NewTeachingAssignmentService(teachingAssignmentRepo, subjectRepo, unitRepo, userRepo, membershipRepo, log, auditLogger)
+	guardianService := service.NewGuardianService(guardianRepo, userRepo, membershipRepo, log, auditLogger)
+	guardianInvitationService := service.NewGuardianInvitationService(guardianInvitationRepo, guardianRepo, guardianService, userRepo, membershipRepo, log, auditLogger)
+	householdService := service.NewHouseholdService(householdRepo, guardianRepo, userRepo, membershipRepo, log, auditLogger)
+	userService := service.NewUserService(userRepo, membershipRepo, customFieldRepo, log, auditLogger)
 	statsService := service.NewStatsService(statsRepo, log)
 	materialService := service.NewMaterialService(materialRepo, log)
-	conceptTypeService := service.NewConceptTypeService(conceptTypeRepo, conceptDefRepo, schoolConceptRepo, log, auditLogger)
+	conceptTypeService := service.NewConceptTypeService(conceptTypeRepo, conceptDefRepo, conceptCategoryRepo, schoolConceptRepo, schoolRepo, log, auditLogger)
+	metadataSchemaService := service.NewMetadataSchemaService(metadataSchemaRepo, conceptTypeRepo, log, auditLogger)
+	customFieldService := service.NewCustomFieldService(customFieldRepo, schoolRepo, log, auditLogger)
 
 	// Handlers
 	c.SchoolHandler = handler.NewSchoolHandler(schoolService, log)
 	c.AcademicUnitHandler = handler.NewAcademicUnitHandler(unitService, log)
 	c.MembershipHandler = handler.NewMembershipHandler(membershipService, log)
 	c.SubjectHandler = handler.NewSubjectHandler(subjectService, log)
+	c.SubjectTemplateHandler = handler.NewSubjectTemplateHandler(subjectTemplateService, log)
+	c.CurriculumHandler = handler.NewCurriculumHandler(curriculumService, log)
+	c.TeachingAssignmentHandler = handler.NewTeachingAssignmentHandler(teachingAssignmentService, log)
 	c.GuardianHandler = handler.NewGuardianHandler(guardianService, log)
+	c.GuardianInvitationHandler = handler.NewGuardianInvitationHandler(guardianInvitationService, log)
+	c.HouseholdHandler = handler.NewHouseholdHandler(householdService, log)
 	c.UserHandler = handler.NewUserHandler(userService, log)
 	c.StatsHandler = handler.NewStatsHandler(statsService, log)
 	c.MaterialHandler = handler.NewMaterialHandler(materialService, log)
 	c.ConceptTypeHandler = handler.NewConceptTypeHandler(conceptTypeService, log)
+	c.MetadataSchemaHandler = handler.NewMetadataSchemaHandler(metadataSchemaService, log)
+	c.CustomFieldHandler = handler.NewCustomFieldHandler(customFieldService, log)
 	c.HealthHandler = handler.NewHealthHandler(db, "dev")
 
 	return c
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// ConceptCategory is an entry of the managed catalog concept definitions are
+// filed under, identified by its code
+type ConceptCategory struct {
+	Code        string    `gorm:"column:code;primaryKey"`
+	Name        string    `gorm:"column:name;not null"`
+	Description *string   `gorm:"column:description"`
+	SortOrder   int       `gorm:"column:sort_order;not null;default:0"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for ConceptCategory
+func (ConceptCategory) TableName() string {
+	return "academic.concept_categories"
+}
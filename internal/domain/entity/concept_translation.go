@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConceptDefinitionTranslation holds a definition's term value in a locale other
+// than the default one, which stays on ConceptDefinition.TermValue.
+type ConceptDefinitionTranslation struct {
+	DefinitionID uuid.UUID `gorm:"column:definition_id;type:uuid;primaryKey"`
+	Locale       string    `gorm:"column:locale;primaryKey"`
+	TermValue    string    `gorm:"column:term_value;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for ConceptDefinitionTranslation
+func (ConceptDefinitionTranslation) TableName() string {
+	return "academic.concept_definition_translations"
+}
+
+// SchoolConceptTranslation is a school's override of a term in a locale other
+// than the default one.
+type SchoolConceptTranslation struct {
+	SchoolConceptID uuid.UUID `gorm:"column:school_concept_id;type:uuid;primaryKey"`
+	Locale          string    `gorm:"column:locale;primaryKey"`
+	SchoolID        uuid.UUID `gorm:"column:school_id;type:uuid;not null;index"`
+	TermValue       string    `gorm:"column:term_value;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for SchoolConceptTranslation
+func (SchoolConceptTranslation) TableName() string {
+	return "academic.school_concept_translations"
+}
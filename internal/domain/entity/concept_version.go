@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConceptTypeVersion is a published, immutable snapshot of a concept type's
+// definitions. The definitions table holds the draft being edited; versions are
+// numbered from 1 per concept type.
+type ConceptTypeVersion struct {
+	ID            uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	ConceptTypeID uuid.UUID `gorm:"column:concept_type_id;type:uuid;not null;uniqueIndex:idx_concept_type_versions_number"`
+	Version       int       `gorm:"column:version;not null;uniqueIndex:idx_concept_type_versions_number"`
+	Notes         *string   `gorm:"column:notes"`
+	PublishedBy   *string   `gorm:"column:published_by"`
+	PublishedAt   time.Time `gorm:"column:published_at;not null"`
+}
+
+// TableName returns the table name for ConceptTypeVersion
+func (ConceptTypeVersion) TableName() string {
+	return "academic.concept_type_versions"
+}
+
+// ConceptVersionDefinition is a definition as it was published in a version.
+// DefinitionID keeps pointing at the draft definition it was copied from.
+type ConceptVersionDefinition struct {
+	VersionID    uuid.UUID `gorm:"column:version_id;type:uuid;primaryKey"`
+	DefinitionID uuid.UUID `gorm:"column:definition_id;type:uuid;primaryKey"`
+	TermKey      string    `gorm:"column:term_key;not null"`
+	TermValue    string    `gorm:"column:term_value;not null"`
+	Category     string    `gorm:"column:category;not null"`
+	SortOrder    int       `gorm:"column:sort_order;not null"`
+}
+
+// TableName returns the table name for ConceptVersionDefinition
+func (ConceptVersionDefinition) TableName() string {
+	return "academic.concept_version_definitions"
+}
+
+// ConceptVersionTranslation is a definition translation as it was published in a version
+type ConceptVersionTranslation struct {
+	VersionID    uuid.UUID `gorm:"column:version_id;type:uuid;primaryKey"`
+	DefinitionID uuid.UUID `gorm:"column:definition_id;type:uuid;primaryKey"`
+	Locale       string    `gorm:"column:locale;primaryKey"`
+	TermValue    string    `gorm:"column:term_value;not null"`
+}
+
+// TableName returns the table name for ConceptVersionTranslation
+func (ConceptVersionTranslation) TableName() string {
+	return "academic.concept_version_translations"
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubjectPrerequisite states that SubjectID requires PrerequisiteID to be taken first.
+// Both subjects belong to SchoolID; the graph of prerequisites is kept acyclic.
+type SubjectPrerequisite struct {
+	SubjectID      uuid.UUID `gorm:"column:subject_id;type:uuid;primaryKey"`
+	PrerequisiteID uuid.UUID `gorm:"column:prerequisite_id;type:uuid;primaryKey"`
+	SchoolID       uuid.UUID `gorm:"column:school_id;type:uuid;not null;index"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+}
+
+// TableName returns the table name for SubjectPrerequisite
+func (SubjectPrerequisite) TableName() string {
+	return "academic.subject_prerequisites"
+}
+
+// SubjectPlacement places a subject in the curriculum at a grade-level academic
+// unit. Position orders subjects within the level.
+type SubjectPlacement struct {
+	SubjectID   uuid.UUID `gorm:"column:subject_id;type:uuid;primaryKey"`
+	SchoolID    uuid.UUID `gorm:"column:school_id;type:uuid;not null;index"`
+	LevelUnitID uuid.UUID `gorm:"column:level_unit_id;type:uuid;not null"`
+	Position    int       `gorm:"column:position;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for SubjectPlacement
+func (SubjectPlacement) TableName() string {
+	return "academic.subject_placements"
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entity types that can carry custom fields
+const (
+	CustomFieldEntityUser         = "user"
+	CustomFieldEntityAcademicUnit = "academic_unit"
+	CustomFieldEntitySubject      = "subject"
+)
+
+// Custom field value types
+const (
+	CustomFieldTypeText    = "text"
+	CustomFieldTypeNumber  = "number"
+	CustomFieldTypeBoolean = "boolean"
+	CustomFieldTypeDate    = "date"
+	CustomFieldTypeSelect  = "select"
+)
+
+// CustomFieldDefinition is an extra attribute a school defines for one of its
+// entity types. Key identifies the field in requests, responses and filters.
+// Options lists the allowed values of select fields as a JSON array.
+type CustomFieldDefinition struct {
+	ID         uuid.UUID `gorm:"column:id;type:uuid;primaryKey"`
+	SchoolID   uuid.UUID `gorm:"column:school_id;type:uuid;not null;index"`
+	EntityType string    `gorm:"column:entity_type;not null"`
+	Key        string    `gorm:"column:key;not null"`
+	Name       string    `gorm:"column:name;not null"`
+	Type       string    `gorm:"column:type;not null"`
+	Required   bool      `gorm:"column:required;not null"`
+	Options    []byte    `gorm:"column:options;type:jsonb"`
+	SortOrder  int       `gorm:"column:sort_order;not null;default:0"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for CustomFieldDefinition
+func (CustomFieldDefinition) TableName() string {
+	return "academic.custom_field_definitions"
+}
+
+// CustomFieldValue is the value of a custom field for one entity, stored in
+// a canonical text form so values of any type can be compared in filters
+type CustomFieldValue struct {
+	DefinitionID uuid.UUID `gorm:"column:definition_id;type:uuid;primaryKey"`
+	EntityID     uuid.UUID `gorm:"column:entity_id;type:uuid;primaryKey"`
+	SchoolID     uuid.UUID `gorm:"column:school_id;type:uuid;not null;index"`
+	EntityType   string    `gorm:"column:entity_type;not null"`
+	Value        string    `gorm:"column:value;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for CustomFieldValue
+func (CustomFieldValue) TableName() string {
+	return "academic.custom_field_values"
+}
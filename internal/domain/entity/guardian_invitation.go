@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuardianInvitation is a link code a school issues for one student so that a
+// guardian can request to be linked to them. It expires at ExpiresAt and can be
+// redeemed at most MaxUses times.
+type GuardianInvitation struct {
+	ID        uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	StudentID uuid.UUID  `gorm:"column:student_id;type:uuid;not null;index"`
+	SchoolID  uuid.UUID  `gorm:"column:school_id;type:uuid;not null"`
+	Code      string     `gorm:"column:code;not null;uniqueIndex"`
+	MaxUses   int        `gorm:"column:max_uses;not null"`
+	UsesCount int        `gorm:"column:uses_count;not null"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+	CreatedBy *uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for GuardianInvitation
+func (GuardianInvitation) TableName() string {
+	return "academic.guardian_invitations"
+}
+
+// Guardian link request statuses
+const (
+	LinkRequestStatusPending  = "pending"
+	LinkRequestStatusApproved = "approved"
+	LinkRequestStatusRejected = "rejected"
+)
+
+// GuardianLinkRequest is a pending guardian relation created by redeeming an
+// invitation. An admin approves it, which creates the guardian relation, or rejects it.
+type GuardianLinkRequest struct {
+	ID               uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	InvitationID     uuid.UUID  `gorm:"column:invitation_id;type:uuid;not null"`
+	GuardianID       uuid.UUID  `gorm:"column:guardian_id;type:uuid;not null"`
+	StudentID        uuid.UUID  `gorm:"column:student_id;type:uuid;not null"`
+	SchoolID         uuid.UUID  `gorm:"column:school_id;type:uuid;not null;index"`
+	RelationshipType string     `gorm:"column:relationship_type;not null"`
+	Status           string     `gorm:"column:status;not null;index"`
+	RelationID       *uuid.UUID `gorm:"column:relation_id;type:uuid"`
+	ReviewedBy       *uuid.UUID `gorm:"column:reviewed_by;type:uuid"`
+	ReviewedAt       *time.Time `gorm:"column:reviewed_at"`
+	ReviewNote       *string    `gorm:"column:review_note"`
+	CreatedAt        time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt        time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for GuardianLinkRequest
+func (GuardianLinkRequest) TableName() string {
+	return "academic.guardian_link_requests"
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GuardianRelationDetail holds the school-facing attributes of a guardian relation:
+// primary contact, legal custody, pickup and communication permissions.
+// It extends entities.GuardianRelation one-to-one through GuardianRelationID.
+type GuardianRelationDetail struct {
+	GuardianRelationID     uuid.UUID `gorm:"column:guardian_relation_id;type:uuid;primaryKey"`
+	IsPrimaryContact       bool      `gorm:"column:is_primary_contact;not null"`
+	HasLegalCustody        bool      `gorm:"column:has_legal_custody;not null"`
+	CanPickup              bool      `gorm:"column:can_pickup;not null"`
+	ReceivesCommunications bool      `gorm:"column:receives_communications;not null"`
+	CreatedAt              time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt              time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for GuardianRelationDetail
+func (GuardianRelationDetail) TableName() string {
+	return "academic.guardian_relation_details"
+}
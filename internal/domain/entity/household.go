@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Household member roles
+const (
+	HouseholdRoleGuardian = "guardian"
+	HouseholdRoleStudent  = "student"
+)
+
+// Household groups the guardians and students of one family. Households are
+// assigned explicitly; a student without one gets a view computed from
+// guardian relations instead.
+type Household struct {
+	ID        uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	Name      string     `gorm:"column:name;not null"`
+	CreatedBy *uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for Household
+func (Household) TableName() string {
+	return "academic.households"
+}
+
+// HouseholdMember assigns a user to a household. A user belongs to at most one household.
+type HouseholdMember struct {
+	UserID      uuid.UUID `gorm:"column:user_id;type:uuid;primaryKey"`
+	HouseholdID uuid.UUID `gorm:"column:household_id;type:uuid;not null;index"`
+	Role        string    `gorm:"column:role;not null"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null"`
+}
+
+// TableName returns the table name for HouseholdMember
+func (HouseholdMember) TableName() string {
+	return "academic.household_members"
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entity types whose metadata can be described by a schema
+const (
+	MetadataEntitySchool       = "school"
+	MetadataEntityAcademicUnit = "academic_unit"
+)
+
+// MetadataSchema is a JSON Schema the metadata of an entity type must follow.
+// A schema with a ConceptTypeID only applies to schools of that concept type
+// and their units and takes precedence over the schema without one.
+type MetadataSchema struct {
+	ID            uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	EntityType    string     `gorm:"column:entity_type;not null;index"`
+	ConceptTypeID *uuid.UUID `gorm:"column:concept_type_id;type:uuid"`
+	Schema        []byte     `gorm:"column:schema;type:jsonb;not null"`
+	CreatedAt     time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for MetadataSchema
+func (MetadataSchema) TableName() string {
+	return "academic.metadata_schemas"
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchoolConceptSource records the concept definition a school term was seeded
+// from and the default value it was last synced to. Version is the published
+// concept type version that default came from, 0 when the type had none.
+// Overridden is set when the school edits the term and cleared when it is
+// reset; a term whose value differs from DefaultValue is treated as overridden
+// as well.
+type SchoolConceptSource struct {
+	SchoolConceptID uuid.UUID `gorm:"column:school_concept_id;type:uuid;primaryKey"`
+	SchoolID        uuid.UUID `gorm:"column:school_id;type:uuid;not null;index"`
+	DefinitionID    uuid.UUID `gorm:"column:definition_id;type:uuid;not null;index"`
+	DefaultValue    string    `gorm:"column:default_value;not null"`
+	Version         int       `gorm:"column:version;not null;default:0"`
+	Overridden      bool      `gorm:"column:overridden;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for SchoolConceptSource
+func (SchoolConceptSource) TableName() string {
+	return "academic.school_concept_sources"
+}
+
+// IsOverridden reports whether the school's current value departs from the default
+func (s *SchoolConceptSource) IsOverridden(value string) bool {
+	return s.Overridden || value != s.DefaultValue
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// School lifecycle states
+const (
+	SchoolStateOnboarding = "onboarding"
+	SchoolStateActive     = "active"
+	SchoolStateSuspended  = "suspended"
+	SchoolStateArchived   = "archived"
+)
+
+// SchoolLifecycle holds the current lifecycle state of a school. Schools
+// without a record predate lifecycle tracking; they are treated as active
+// when the school is active and as suspended otherwise.
+type SchoolLifecycle struct {
+	SchoolID  uuid.UUID  `gorm:"column:school_id;type:uuid;primaryKey"`
+	State     string     `gorm:"column:state;not null;index"`
+	Reason    *string    `gorm:"column:reason"`
+	ChangedBy *uuid.UUID `gorm:"column:changed_by;type:uuid"`
+	ChangedAt time.Time  `gorm:"column:changed_at;not null"`
+}
+
+// TableName returns the table name for SchoolLifecycle
+func (SchoolLifecycle) TableName() string {
+	return "academic.school_lifecycles"
+}
+
+// IsReadOnly reports whether the state blocks writes by the school's members
+func (l *SchoolLifecycle) IsReadOnly() bool {
+	return l.State == SchoolStateSuspended || l.State == SchoolStateArchived
+}
+
+// SchoolLifecycleTransition records one change of a school's lifecycle state
+type SchoolLifecycleTransition struct {
+	ID        uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	SchoolID  uuid.UUID  `gorm:"column:school_id;type:uuid;not null;index"`
+	FromState string     `gorm:"column:from_state;not null"`
+	ToState   string     `gorm:"column:to_state;not null"`
+	Reason    *string    `gorm:"column:reason"`
+	ChangedBy *uuid.UUID `gorm:"column:changed_by;type:uuid"`
+	ChangedAt time.Time  `gorm:"column:changed_at;not null"`
+}
+
+// TableName returns the table name for SchoolLifecycleTransition
+func (SchoolLifecycleTransition) TableName() string {
+	return "academic.school_lifecycle_transitions"
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubjectTemplate is a platform-level subject definition that can be copied
+// into any school. Templates may be grouped by concept type and/or education
+// level (e.g. "primaria", "secundaria").
+type SubjectTemplate struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	Name           string     `gorm:"column:name;not null"`
+	Code           *string    `gorm:"column:code"`
+	Description    *string    `gorm:"column:description"`
+	ConceptTypeID  *uuid.UUID `gorm:"column:concept_type_id;type:uuid;index"`
+	EducationLevel *string    `gorm:"column:education_level;index"`
+	SortOrder      int        `gorm:"column:sort_order;not null"`
+	IsActive       bool       `gorm:"column:is_active;not null"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for SubjectTemplate
+func (SubjectTemplate) TableName() string {
+	return "academic.subject_templates"
+}
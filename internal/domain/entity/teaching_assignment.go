@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TeachingAssignment records that a teacher teaches a subject in an academic
+// unit (section), optionally for a given period such as "2026-S1".
+type TeachingAssignment struct {
+	ID             uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	TeacherID      uuid.UUID  `gorm:"column:teacher_id;type:uuid;not null;index"`
+	SubjectID      uuid.UUID  `gorm:"column:subject_id;type:uuid;not null;index"`
+	AcademicUnitID uuid.UUID  `gorm:"column:academic_unit_id;type:uuid;not null;index"`
+	SchoolID       uuid.UUID  `gorm:"column:school_id;type:uuid;not null"`
+	Period         *string    `gorm:"column:period"`
+	IsActive       bool       `gorm:"column:is_active;not null"`
+	CreatedBy      *uuid.UUID `gorm:"column:created_by;type:uuid"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null"`
+}
+
+// TableName returns the table name for TeachingAssignment
+func (TeachingAssignment) TableName() string {
+	return "academic.teaching_assignments"
+}
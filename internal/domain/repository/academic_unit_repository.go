@@ -12,8 +12,9 @@ import (
 type AcademicUnitRepository interface {
 	Create(ctx context.Context, unit *entities.AcademicUnit) error
 	FindByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*entities.AcademicUnit, error)
-	FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error)
-	FindByType(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error)
+	// FindBySchoolID and FindByType keep only the units matching every custom field filter given
+	FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...CustomFieldFilter) ([]*entities.AcademicUnit, int, error)
+	FindByType(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...CustomFieldFilter) ([]*entities.AcademicUnit, int, error)
 	Update(ctx context.Context, unit *entities.AcademicUnit) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) error
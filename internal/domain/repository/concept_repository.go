@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
 )
@@ -15,15 +16,69 @@ type ConceptTypeRepository interface {
 	Create(ctx context.Context, ct *entities.ConceptType) error
 	Update(ctx context.Context, ct *entities.ConceptType) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	FindSchools(ctx context.Context, id uuid.UUID) ([]*entities.School, error)
+	// Import applies the changes of a concept pack import in a single transaction
+	Import(ctx context.Context, changes *ConceptPackChanges) error
+	// ReassignAndDelete soft-deletes the concept type in a single transaction
+	// that first locks it and targetID, when given. The schools still using
+	// the type are read under that lock and passed to plan, whose migrations
+	// move them to targetID. An error from plan rolls the deletion back and is
+	// returned as is.
+	ReassignAndDelete(ctx context.Context, id uuid.UUID, targetID *uuid.UUID, plan func(schools []*entities.School) ([]*SchoolConceptMigration, error)) error
+}
+
+// SchoolConceptMigration holds the terms a school is re-seeded with when it
+// moves to another concept type. Translations of surviving terms are kept.
+type SchoolConceptMigration struct {
+	SchoolID uuid.UUID
+	Concepts []*entities.SchoolConcept
+	Sources  []*entity.SchoolConceptSource
+}
+
+// ConceptPackChanges groups the writes of a concept pack import. The type is
+// created when NewType is set and saved otherwise. Translations replaces the
+// translations of each definition it holds a key for.
+type ConceptPackChanges struct {
+	Type         *entities.ConceptType
+	NewType      bool
+	Created      []*entities.ConceptDefinition
+	Updated      []*entities.ConceptDefinition
+	Translations map[uuid.UUID][]*entity.ConceptDefinitionTranslation
 }
 
 // ConceptDefinitionRepository defines persistence operations for ConceptDefinition
 type ConceptDefinitionRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.ConceptDefinition, error)
 	FindByTypeID(ctx context.Context, typeID uuid.UUID) ([]*entities.ConceptDefinition, error)
-	Create(ctx context.Context, def *entities.ConceptDefinition) error
-	Update(ctx context.Context, def *entities.ConceptDefinition) error
+	FindByTermKey(ctx context.Context, typeID uuid.UUID, termKey string) (*entities.ConceptDefinition, error)
+	// Create inserts the definition and its translations in a single transaction
+	Create(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error
+	// Update saves the definition and, unless translations is nil, replaces all
+	// of its translations in a single transaction
+	Update(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	FindTranslations(ctx context.Context, definitionIDs []uuid.UUID) ([]*entity.ConceptDefinitionTranslation, error)
+
+	// Published versions
+	FindVersions(ctx context.Context, typeID uuid.UUID) ([]*entity.ConceptTypeVersion, error)
+	FindVersion(ctx context.Context, typeID uuid.UUID, version int) (*entity.ConceptTypeVersion, error)
+	FindLatestVersion(ctx context.Context, typeID uuid.UUID) (*entity.ConceptTypeVersion, error)
+	FindVersionDefinitions(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionDefinition, error)
+	FindVersionTranslations(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionTranslation, error)
+	// Publish stores a version with its definition and translation snapshots in
+	// a single transaction
+	Publish(ctx context.Context, version *entity.ConceptTypeVersion, defs []*entity.ConceptVersionDefinition, translations []*entity.ConceptVersionTranslation) error
+}
+
+// ConceptCategoryRepository defines persistence operations for the concept category catalog
+type ConceptCategoryRepository interface {
+	FindAll(ctx context.Context) ([]*entity.ConceptCategory, error)
+	FindByCode(ctx context.Context, code string) (*entity.ConceptCategory, error)
+	Create(ctx context.Context, category *entity.ConceptCategory) error
+	Update(ctx context.Context, category *entity.ConceptCategory) error
+	Delete(ctx context.Context, code string) error
+	// CountDefinitions counts the concept definitions filed under a category
+	CountDefinitions(ctx context.Context, code string) (int64, error)
 }
 
 // SchoolConceptRepository defines persistence operations for SchoolConcept
@@ -32,4 +87,18 @@ type SchoolConceptRepository interface {
 	BulkCreate(ctx context.Context, concepts []*entities.SchoolConcept) error
 	Update(ctx context.Context, concept *entities.SchoolConcept) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.SchoolConcept, error)
+	FindSourcesBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptSource, error)
+	FindSourceByConceptID(ctx context.Context, conceptID uuid.UUID) (*entity.SchoolConceptSource, error)
+	// SaveWithSources creates and updates school terms together with their
+	// source records in a single transaction
+	SaveWithSources(ctx context.Context, created, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource) error
+	// ResetWithSources saves reset school terms and their source records and
+	// drops the per-locale overrides of conceptIDs in a single transaction
+	ResetWithSources(ctx context.Context, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, conceptIDs []uuid.UUID) error
+	// ReplaceForSchool switches the school to another concept type and replaces
+	// all of its terms and source records in a single transaction. Translations
+	// of terms that survive the switch are kept only when keepTranslations is set.
+	ReplaceForSchool(ctx context.Context, schoolID, conceptTypeID uuid.UUID, concepts []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, keepTranslations bool) error
+	FindTranslationsBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptTranslation, error)
+	SaveTranslation(ctx context.Context, translation *entity.SchoolConceptTranslation) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// CurriculumRepository defines persistence operations for subject prerequisites and placements
+type CurriculumRepository interface {
+	AddPrerequisite(ctx context.Context, prerequisite *entity.SubjectPrerequisite) error
+	RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error
+	FindPrerequisites(ctx context.Context, subjectID uuid.UUID) ([]*entity.SubjectPrerequisite, error)
+	FindPrerequisitesBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPrerequisite, error)
+	SavePlacement(ctx context.Context, placement *entity.SubjectPlacement) error
+	FindPlacement(ctx context.Context, subjectID uuid.UUID) (*entity.SubjectPlacement, error)
+	DeletePlacement(ctx context.Context, subjectID uuid.UUID) error
+	FindPlacementsBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPlacement, error)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// CustomFieldFilter selects entities whose custom field holds Value, given in
+// its canonical text form
+type CustomFieldFilter struct {
+	DefinitionID uuid.UUID
+	Value        string
+}
+
+// CustomFieldRepository defines persistence operations for custom field
+// definitions and values
+type CustomFieldRepository interface {
+	// ListDefinitions returns the school's definitions for the entity type, or
+	// for every entity type when entityType is empty
+	ListDefinitions(ctx context.Context, schoolID uuid.UUID, entityType string) ([]*entity.CustomFieldDefinition, error)
+	FindDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.CustomFieldDefinition, error)
+	ExistsDefinitionKey(ctx context.Context, schoolID uuid.UUID, entityType, key string) (bool, error)
+	CreateDefinition(ctx context.Context, def *entity.CustomFieldDefinition) error
+	UpdateDefinition(ctx context.Context, def *entity.CustomFieldDefinition) error
+	// DeleteDefinition removes the definition and its values in a single transaction
+	DeleteDefinition(ctx context.Context, id uuid.UUID) error
+	// CountValues counts the values of the definition that equal any of values
+	CountValues(ctx context.Context, definitionID uuid.UUID, values []string) (int64, error)
+	// CountMissingValues counts the live entities of the definition's school
+	// and type (member users, units, active subjects) without a value for it
+	CountMissingValues(ctx context.Context, def *entity.CustomFieldDefinition) (int64, error)
+
+	FindValues(ctx context.Context, schoolID uuid.UUID, entityType string, entityIDs []uuid.UUID) ([]*entity.CustomFieldValue, error)
+	// CreateWithValues inserts the entity record (a unit, subject or user) and
+	// its custom field values in a single transaction
+	CreateWithValues(ctx context.Context, record interface{}, values []*entity.CustomFieldValue) error
+	// UpdateWithValues saves the entity record, stores its custom field values
+	// and removes those of the cleared definitions in a single transaction
+	UpdateWithValues(ctx context.Context, record interface{}, entityID uuid.UUID, values []*entity.CustomFieldValue, cleared []uuid.UUID) error
+
+	// ListUsers lists the users with an active membership in the school like
+	// the shared UserRepository.List, which cannot take extra arguments,
+	// keeping those whose values in the school match every field filter
+	ListUsers(ctx context.Context, filters sharedrepo.ListFilters, schoolID uuid.UUID, fieldFilters []CustomFieldFilter) ([]*entities.User, int64, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+)
+
+// GuardianInvitationRepository defines persistence operations for guardian
+// invitations and the link requests created when they are redeemed
+type GuardianInvitationRepository interface {
+	CreateInvitation(ctx context.Context, invitation *entity.GuardianInvitation) error
+	FindInvitationByID(ctx context.Context, id uuid.UUID) (*entity.GuardianInvitation, error)
+	FindInvitationByCode(ctx context.Context, code string) (*entity.GuardianInvitation, error)
+	FindInvitationsByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.GuardianInvitation, error)
+	UpdateInvitation(ctx context.Context, invitation *entity.GuardianInvitation) error
+	// Redeem consumes one use of the invitation and stores the link request atomically.
+	// It returns false when the invitation has no uses left.
+	Redeem(ctx context.Context, invitationID uuid.UUID, request *entity.GuardianLinkRequest) (bool, error)
+
+	FindLinkRequestByID(ctx context.Context, id uuid.UUID) (*entity.GuardianLinkRequest, error)
+	FindLinkRequests(ctx context.Context, schoolID *uuid.UUID, status string) ([]*entity.GuardianLinkRequest, error)
+	UpdateLinkRequest(ctx context.Context, request *entity.GuardianLinkRequest) error
+	// ApproveLinkRequest creates the approved relation with its detail and saves
+	// the reviewed request in a single transaction
+	ApproveLinkRequest(ctx context.Context, request *entity.GuardianLinkRequest, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error
+	ExistsPendingLinkRequest(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error)
+}
@@ -3,10 +3,18 @@ package repository
 import (
 	"context"
 
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 	"github.com/google/uuid"
 )
 
+// GuardianRelationFilters narrows guardian relation listings
+type GuardianRelationFilters struct {
+	IncludeInactive  bool
+	RelationshipType string
+}
+
 // GuardianRepository defines persistence operations for GuardianRelation
 type GuardianRepository interface {
 	Create(ctx context.Context, relation *entities.GuardianRelation) error
@@ -16,4 +24,20 @@ type GuardianRepository interface {
 	Update(ctx context.Context, relation *entities.GuardianRelation) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	ExistsActiveRelation(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error)
+
+	// Paginated listings
+	ListByGuardian(ctx context.Context, guardianID uuid.UUID, relFilters GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error)
+	ListByStudent(ctx context.Context, studentID uuid.UUID, relFilters GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error)
+	// ListBySchool returns relations whose student has a student membership in the school
+	ListBySchool(ctx context.Context, schoolID uuid.UUID, relFilters GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error)
+
+	// Details (primary contact, custody, pickup, communications)
+	FindDetailsByRelationIDs(ctx context.Context, relationIDs []uuid.UUID) ([]*entity.GuardianRelationDetail, error)
+	// SaveDetail upserts the detail. When it marks a primary contact, the flag is
+	// cleared from the student's other relations in the same transaction.
+	SaveDetail(ctx context.Context, studentID uuid.UUID, detail *entity.GuardianRelationDetail) error
+	// CreateWithDetail inserts the relation and saves its detail in one transaction
+	CreateWithDetail(ctx context.Context, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error
+	// UpdateWithDetail updates the relation and saves its detail in one transaction
+	UpdateWithDetail(ctx context.Context, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// HouseholdRepository defines persistence operations for households and their members
+type HouseholdRepository interface {
+	// Create stores the household and its initial members in a single transaction
+	Create(ctx context.Context, household *entity.Household, members []*entity.HouseholdMember) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Household, error)
+	FindMembers(ctx context.Context, householdID uuid.UUID) ([]*entity.HouseholdMember, error)
+	FindMemberByUser(ctx context.Context, userID uuid.UUID) (*entity.HouseholdMember, error)
+	AddMember(ctx context.Context, member *entity.HouseholdMember) error
+	RemoveMember(ctx context.Context, householdID, userID uuid.UUID) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// MetadataSchemaRepository defines persistence operations for metadata schemas
+type MetadataSchemaRepository interface {
+	// Find returns the schema registered for exactly this entity type and
+	// concept type, nil conceptTypeID meaning the schema without one
+	Find(ctx context.Context, entityType string, conceptTypeID *uuid.UUID) (*entity.MetadataSchema, error)
+	// Save creates or replaces a schema
+	Save(ctx context.Context, schema *entity.MetadataSchema) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+// SchoolBundleData holds a school and everything a school bundle carries.
+// Users are the members' accounts. On import, Units are ordered parents first,
+// Users only holds the accounts to create, Sources links copied terms to
+// their concept definitions and Translations holds their per-locale values.
+type SchoolBundleData struct {
+	School       *entities.School
+	Concepts     []*entities.SchoolConcept
+	Sources      []*entity.SchoolConceptSource
+	Translations []*entity.SchoolConceptTranslation
+	Units        []*entities.AcademicUnit
+	Subjects     []*entities.Subject
+	Users        []*entities.User
+	Memberships  []*entities.Membership
+	// Lifecycle and Transition record the initial state of an imported school
+	Lifecycle  *entity.SchoolLifecycle
+	Transition *entity.SchoolLifecycleTransition
+}
+
+// SchoolBundleRepository defines the persistence operations behind school bundles
+type SchoolBundleRepository interface {
+	// Load reads the school's concepts, live units and subjects and, when
+	// withPeople is set, its memberships and the users holding them
+	Load(ctx context.Context, school *entities.School, withPeople bool) (*SchoolBundleData, error)
+	// FindUsersByEmails matches lowercase emails against stored emails in any case
+	FindUsersByEmails(ctx context.Context, emails []string) ([]*entities.User, error)
+	// Import creates everything in data in a single transaction
+	Import(ctx context.Context, data *SchoolBundleData) error
+}
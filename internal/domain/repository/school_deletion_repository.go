@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SchoolDependentCounts counts the live entities that depend on a school
+type SchoolDependentCounts struct {
+	AcademicUnits        int64
+	Subjects             int64
+	Memberships          int64
+	GuardianRelations    int64
+	TeachingAssignments  int64
+	GuardianInvitations  int64
+	LinkRequests         int64
+	SchoolConcepts       int64
+	SubjectPlacements    int64
+	SubjectPrerequisites int64
+	CustomFields         int64
+	CustomFieldValues    int64
+}
+
+// SchoolDeletionRepository defines the persistence operations behind school deletion
+type SchoolDeletionRepository interface {
+	// CountDependents counts the school's live dependents. Guardian relations
+	// are counted when their student has no active membership in another
+	// school; invitations while they can still be redeemed.
+	CountDependents(ctx context.Context, schoolID uuid.UUID) (*SchoolDependentCounts, error)
+	// DeleteCascade soft-deletes the school and its dependents in a single
+	// transaction: guardian relations, teaching assignments, memberships,
+	// subjects, academic units and finally the school itself. Open invitations
+	// are revoked and pending link requests rejected. Nothing is removed: rows
+	// without a soft-delete flag, such as school terms, subject placements and
+	// prerequisites, custom fields and the lifecycle state, stay with the
+	// deleted school.
+	DeleteCascade(ctx context.Context, schoolID uuid.UUID, at time.Time) error
+}
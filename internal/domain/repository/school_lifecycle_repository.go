@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// SchoolLifecycleRepository defines persistence operations for school lifecycle states
+type SchoolLifecycleRepository interface {
+	FindBySchoolID(ctx context.Context, schoolID uuid.UUID) (*entity.SchoolLifecycle, error)
+	FindBySchoolIDs(ctx context.Context, schoolIDs []uuid.UUID) ([]*entity.SchoolLifecycle, error)
+	FindTransitions(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolLifecycleTransition, error)
+	// CreateSchool inserts a new school through SchoolRepository.Create together
+	// with its initial lifecycle state and transition record in a single transaction
+	CreateSchool(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error
+	// Transition saves the school, its new lifecycle state and the transition
+	// record in a single transaction
+	Transition(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error
+	// ListUnarchivedSchools lists schools through SchoolRepository.List, leaving
+	// out archived schools
+	ListUnarchivedSchools(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.School, int64, error)
+}
@@ -11,11 +11,16 @@ import (
 // SubjectRepository defines persistence operations for Subject
 type SubjectRepository interface {
 	Create(ctx context.Context, subject *entities.Subject) error
-	FindByID(ctx context.Context, id uuid.UUID) (*entities.Subject, error)
-	FindBySchoolID(ctx context.Context, schoolID uuid.UUID, filters sharedrepo.ListFilters) ([]*entities.Subject, int, error)
+	// BulkCreate inserts the subjects in a single transaction
+	BulkCreate(ctx context.Context, subjects []*entities.Subject) error
+	FindByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*entities.Subject, error)
+	// FindBySchoolID keeps only the subjects matching every custom field filter given
+	FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...CustomFieldFilter) ([]*entities.Subject, int, error)
 	Update(ctx context.Context, subject *entities.Subject) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.Subject, error)
 	ExistsByName(ctx context.Context, name string) (bool, error)
 	ExistsBySchoolIDAndName(ctx context.Context, schoolID uuid.UUID, name string) (bool, error)
+	ExistsBySchoolIDAndCode(ctx context.Context, schoolID uuid.UUID, code string) (bool, error)
 }
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// SubjectTemplateFilters narrows template listings; nil/empty fields are not applied
+type SubjectTemplateFilters struct {
+	ConceptTypeID  *uuid.UUID
+	EducationLevel string
+}
+
+// SubjectTemplateRepository defines persistence operations for SubjectTemplate
+type SubjectTemplateRepository interface {
+	Create(ctx context.Context, template *entity.SubjectTemplate) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.SubjectTemplate, error)
+	List(ctx context.Context, filters SubjectTemplateFilters) ([]*entity.SubjectTemplate, error)
+	Update(ctx context.Context, template *entity.SubjectTemplate) error
+	SoftDelete(ctx context.Context, id uuid.UUID) error
+	// ExistsInGroup reports whether an active template with the name exists in the same concept type and education level group
+	ExistsInGroup(ctx context.Context, name string, conceptTypeID *uuid.UUID, educationLevel *string) (bool, error)
+}
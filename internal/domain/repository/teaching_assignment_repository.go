@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+)
+
+// TeachingAssignmentRepository defines persistence operations for TeachingAssignment
+type TeachingAssignmentRepository interface {
+	Create(ctx context.Context, assignment *entity.TeachingAssignment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.TeachingAssignment, error)
+	Update(ctx context.Context, assignment *entity.TeachingAssignment) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByTeacher(ctx context.Context, teacherID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error)
+	FindByUnit(ctx context.Context, unitID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error)
+	FindBySubject(ctx context.Context, subjectID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error)
+	// ExistsActive reports whether an active assignment matches all keys; period nil matches assignments without a period
+	ExistsActive(ctx context.Context, teacherID, subjectID, unitID uuid.UUID, period *string) (bool, error)
+}
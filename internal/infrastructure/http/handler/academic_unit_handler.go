@@ -63,6 +63,7 @@ func (h *AcademicUnitHandler) CreateUnit(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1)
 // @Param search query string false "Search term (ILIKE)"
 // @Param search_fields query string false "Comma-separated fields to search"
+// @Param cf.{key} query string false "Custom field filter, one per field key"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -95,7 +96,7 @@ func (h *AcademicUnitHandler) ListUnitsBySchool(c *gin.Context) {
 			filters.SearchFields = strings.Split(fields, ",")
 		}
 	}
-	units, total, err := h.unitService.ListUnitsBySchool(c.Request.Context(), schoolID, filters)
+	units, total, err := h.unitService.ListUnitsBySchool(c.Request.Context(), schoolID, filters, customFieldFilters(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -140,6 +141,7 @@ func (h *AcademicUnitHandler) GetUnitTree(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1)
 // @Param search query string false "Search term (ILIKE)"
 // @Param search_fields query string false "Comma-separated fields to search"
+// @Param cf.{key} query string false "Custom field filter, one per field key"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -173,7 +175,7 @@ func (h *AcademicUnitHandler) ListUnitsByType(c *gin.Context) {
 			filters.SearchFields = strings.Split(fields, ",")
 		}
 	}
-	units, total, err := h.unitService.ListUnitsByType(c.Request.Context(), schoolID, unitType, filters)
+	units, total, err := h.unitService.ListUnitsByType(c.Request.Context(), schoolID, unitType, filters, customFieldFilters(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
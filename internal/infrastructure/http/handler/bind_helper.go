@@ -6,13 +6,32 @@ import (
 	"unicode"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 
 	sharedErrors "github.com/EduGoGroup/edugo-shared/common/errors"
 )
 
 func bindJSON(c *gin.Context, v interface{}) error {
-	if err := c.ShouldBindJSON(v); err != nil {
+	return bindWith(c, v, binding.JSON)
+}
+
+// bindOptionalJSON binds a JSON body like bindJSON, leaving v at its zero
+// value when the request has no body
+func bindOptionalJSON(c *gin.Context, v interface{}) error {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return nil
+	}
+	return bindJSON(c, v)
+}
+
+// bindYAML binds a YAML body, reporting errors the same way as bindJSON
+func bindYAML(c *gin.Context, v interface{}) error {
+	return bindWith(c, v, binding.YAML)
+}
+
+func bindWith(c *gin.Context, v interface{}, b binding.BindingBody) error {
+	if err := c.ShouldBindWith(v, b); err != nil {
 		var ve validator.ValidationErrors
 		if errors.As(err, &ve) {
 			fields := make(map[string]string, len(ve))
@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -127,12 +130,41 @@ func (h *ConceptTypeHandler) UpdateConceptType(c *gin.Context) {
 	c.JSON(http.StatusOK, ct)
 }
 
+// GetConceptTypeUsage godoc
+// @Summary Get the usage of a concept type
+// @Description Lists the schools referencing the type and counts its definitions per category
+// @Tags concept-types
+// @Produce json
+// @Param id path string true "Concept Type ID (UUID)"
+// @Success 200 {object} dto.ConceptTypeUsageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/{id}/usage [get]
+func (h *ConceptTypeHandler) GetConceptTypeUsage(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	usage, err := h.conceptTypeService.GetConceptTypeUsage(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, usage)
+}
+
 // DeleteConceptType godoc
 // @Summary Delete a concept type
+// @Description Rejected while schools use the type, unless reassign_to names the concept type they are migrated to
 // @Tags concept-types
 // @Accept json
 // @Produce json
 // @Param id path string true "Concept Type ID (UUID)"
+// @Param reassign_to query string false "Concept type ID (UUID) to move the schools to"
 // @Success 204 "No content"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -146,7 +178,16 @@ func (h *ConceptTypeHandler) DeleteConceptType(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
 		return
 	}
-	if err := h.conceptTypeService.DeleteConceptType(withActor(c), id); err != nil {
+	var reassignTo *uuid.UUID
+	if raw := c.Query("reassign_to"); raw != "" {
+		target, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid reassign_to parameter", Code: "INVALID_REQUEST"})
+			return
+		}
+		reassignTo = &target
+	}
+	if err := h.conceptTypeService.DeleteConceptType(withActor(c), id, reassignTo); err != nil {
 		_ = c.Error(err)
 		return
 	}
@@ -188,11 +229,14 @@ func (h *ConceptTypeHandler) CreateDefinition(c *gin.Context) {
 
 // ListDefinitions godoc
 // @Summary List concept definitions by type
+// @Description Definitions are ordered by sort_order. With group_by=category they are returned as groups in catalog order.
 // @Tags concept-types
 // @Accept json
 // @Produce json
 // @Param id path string true "Concept Type ID (UUID)"
+// @Param group_by query string false "Group the definitions" Enums(category)
 // @Success 200 {array} dto.ConceptDefinitionResponse
+// @Success 200 {array} dto.ConceptDefinitionGroupResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
@@ -205,12 +249,24 @@ func (h *ConceptTypeHandler) ListDefinitions(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
 		return
 	}
-	defs, err := h.conceptTypeService.ListDefinitions(c.Request.Context(), typeID)
-	if err != nil {
-		_ = c.Error(err)
-		return
+	switch c.Query("group_by") {
+	case "":
+		defs, err := h.conceptTypeService.ListDefinitions(c.Request.Context(), typeID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, defs)
+	case "category":
+		groups, err := h.conceptTypeService.ListDefinitionGroups(c.Request.Context(), typeID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, groups)
+	default:
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid group_by parameter", Code: "INVALID_REQUEST"})
 	}
-	c.JSON(http.StatusOK, defs)
 }
 
 // UpdateDefinition godoc
@@ -284,12 +340,345 @@ func (h *ConceptTypeHandler) DeleteDefinition(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// SyncDefinitions godoc
+// @Summary Propagate concept definitions to the schools using the type
+// @Description Reports a per-school diff (missing, matches_default, outdated_default, customised). Missing keys are added; uncustomised terms are moved to the current default when update_defaults is set. dry_run only reports.
+// @Tags concept-types
+// @Accept json
+// @Produce json
+// @Param id path string true "Concept Type ID (UUID)"
+// @Param request body dto.SyncConceptDefinitionsRequest true "Sync options"
+// @Success 200 {object} dto.ConceptSyncResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/{id}/sync [post]
+func (h *ConceptTypeHandler) SyncDefinitions(c *gin.Context) {
+	typeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	var req dto.SyncConceptDefinitionsRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.conceptTypeService.SyncDefinitions(withActor(c), typeID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ListVersions godoc
+// @Summary List the published versions of a concept type
+// @Description Returns the published versions, newest first
+// @Tags concept-types
+// @Produce json
+// @Param id path string true "Concept Type ID (UUID)"
+// @Success 200 {array} dto.ConceptTypeVersionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/{id}/versions [get]
+func (h *ConceptTypeHandler) ListVersions(c *gin.Context) {
+	typeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	versions, err := h.conceptTypeService.ListVersions(c.Request.Context(), typeID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, versions)
+}
+
+// GetVersion godoc
+// @Summary Get a published version of a concept type
+// @Description Returns the version with its definitions as they were published
+// @Tags concept-types
+// @Produce json
+// @Param id path string true "Concept Type ID (UUID)"
+// @Param version path int true "Version number"
+// @Success 200 {object} dto.ConceptTypeVersionDetailResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/{id}/versions/{version} [get]
+func (h *ConceptTypeHandler) GetVersion(c *gin.Context) {
+	typeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid version", Code: "INVALID_REQUEST"})
+		return
+	}
+	result, err := h.conceptTypeService.GetVersion(c.Request.Context(), typeID, version)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// DiffDraft godoc
+// @Summary Diff the draft definitions against the published version
+// @Description Reports each term key as added, changed, removed or unchanged compared with the latest published version
+// @Tags concept-types
+// @Produce json
+// @Param id path string true "Concept Type ID (UUID)"
+// @Success 200 {object} dto.ConceptDraftDiffResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/{id}/draft/diff [get]
+func (h *ConceptTypeHandler) DiffDraft(c *gin.Context) {
+	typeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	diff, err := h.conceptTypeService.DiffDraft(c.Request.Context(), typeID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// PublishDefinitions godoc
+// @Summary Publish the draft definitions
+// @Description Snapshots the draft definitions as the next version. New schools and syncs use the latest published version. Rejected when the draft has no changes.
+// @Tags concept-types
+// @Accept json
+// @Produce json
+// @Param id path string true "Concept Type ID (UUID)"
+// @Param request body dto.PublishConceptDefinitionsRequest false "Publish options"
+// @Success 201 {object} dto.ConceptTypeVersionDetailResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/{id}/publish [post]
+func (h *ConceptTypeHandler) PublishDefinitions(c *gin.Context) {
+	typeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	var req dto.PublishConceptDefinitionsRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	version, err := h.conceptTypeService.PublishDefinitions(withActor(c), typeID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, version)
+}
+
+// ListConceptCategories godoc
+// @Summary List the concept category catalog
+// @Tags concept-categories
+// @Produce json
+// @Success 200 {array} dto.ConceptCategoryResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-categories [get]
+func (h *ConceptTypeHandler) ListConceptCategories(c *gin.Context) {
+	categories, err := h.conceptTypeService.ListCategories(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, categories)
+}
+
+// CreateConceptCategory godoc
+// @Summary Add a category to the concept category catalog
+// @Tags concept-categories
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateConceptCategoryRequest true "Category data"
+// @Success 201 {object} dto.ConceptCategoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-categories [post]
+func (h *ConceptTypeHandler) CreateConceptCategory(c *gin.Context) {
+	var req dto.CreateConceptCategoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	category, err := h.conceptTypeService.CreateCategory(withActor(c), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateConceptCategory godoc
+// @Summary Update a concept category
+// @Tags concept-categories
+// @Accept json
+// @Produce json
+// @Param code path string true "Category code"
+// @Param request body dto.UpdateConceptCategoryRequest true "Category data"
+// @Success 200 {object} dto.ConceptCategoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-categories/{code} [put]
+func (h *ConceptTypeHandler) UpdateConceptCategory(c *gin.Context) {
+	var req dto.UpdateConceptCategoryRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	category, err := h.conceptTypeService.UpdateCategory(withActor(c), c.Param("code"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, category)
+}
+
+// DeleteConceptCategory godoc
+// @Summary Delete a concept category
+// @Description Rejected for the default category and for categories used by definitions
+// @Tags concept-categories
+// @Produce json
+// @Param code path string true "Category code"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-categories/{code} [delete]
+func (h *ConceptTypeHandler) DeleteConceptCategory(c *gin.Context) {
+	if err := h.conceptTypeService.DeleteCategory(withActor(c), c.Param("code")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ExportConceptType godoc
+// @Summary Export a concept type as a concept pack
+// @Description Returns the type and all its definitions, with translations, as a versioned JSON or YAML document.
+// @Tags concept-types
+// @Produce json
+// @Produce application/yaml
+// @Param id path string true "Concept Type ID (UUID)"
+// @Param format query string false "Output format" Enums(json, yaml)
+// @Success 200 {object} dto.ConceptPack
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/{id}/export [get]
+func (h *ConceptTypeHandler) ExportConceptType(c *gin.Context) {
+	typeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept type ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "yaml" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "format must be json or yaml", Code: "INVALID_REQUEST"})
+		return
+	}
+	pack, err := h.conceptTypeService.ExportConceptType(c.Request.Context(), typeID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, pack.ConceptType.Code, format))
+	if format == "yaml" {
+		c.YAML(http.StatusOK, pack)
+		return
+	}
+	c.JSON(http.StatusOK, pack)
+}
+
+// ImportConceptPack godoc
+// @Summary Import a concept pack
+// @Description Upserts the concept type by code and its definitions by term_key. Duplicated keys and existing definitions missing from the pack are reported as conflicts and left untouched. Send YAML with a YAML Content-Type.
+// @Tags concept-types
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param dry_run query bool false "Only report the changes"
+// @Param request body dto.ConceptPack true "Concept pack"
+// @Success 200 {object} dto.ConceptPackImportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /concept-types/import [post]
+func (h *ConceptTypeHandler) ImportConceptPack(c *gin.Context) {
+	dryRun := false
+	if dryRunStr := c.Query("dry_run"); dryRunStr != "" {
+		parsed, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid dry_run parameter", Code: "INVALID_REQUEST"})
+			return
+		}
+		dryRun = parsed
+	}
+	var pack dto.ConceptPack
+	bind := bindJSON
+	if strings.Contains(c.ContentType(), "yaml") {
+		bind = bindYAML
+	}
+	if err := bind(c, &pack); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.conceptTypeService.ImportConceptPack(withActor(c), &pack, dryRun)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // GetSchoolConcepts godoc
 // @Summary Get school concepts
+// @Description Terms are returned in the requested locale, falling back to the definition translation and then to the default locale
 // @Tags schools
 // @Accept json
 // @Produce json
 // @Param id path string true "School ID (UUID)"
+// @Param locale query string false "Locale (es, en, pt); overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred languages"
 // @Success 200 {array} dto.SchoolConceptResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -302,7 +691,7 @@ func (h *ConceptTypeHandler) GetSchoolConcepts(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid school ID", Code: "INVALID_REQUEST"})
 		return
 	}
-	concepts, err := h.conceptTypeService.GetSchoolConcepts(c.Request.Context(), schoolID)
+	concepts, err := h.conceptTypeService.GetSchoolConcepts(c.Request.Context(), schoolID, requestLocale(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -316,6 +705,8 @@ func (h *ConceptTypeHandler) GetSchoolConcepts(c *gin.Context) {
 // @Produce json
 // @Param id path string true "School ID (UUID)"
 // @Param conceptId path string true "Concept ID (UUID)"
+// @Param locale query string false "Locale (es, en, pt); overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred languages"
 // @Success 200 {object} dto.SchoolConceptResponse
 // @Security BearerAuth
 // @Router /schools/{id}/concepts/{conceptId} [get]
@@ -330,7 +721,7 @@ func (h *ConceptTypeHandler) GetSchoolConcept(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept ID", Code: "INVALID_REQUEST"})
 		return
 	}
-	concept, err := h.conceptTypeService.GetSchoolConcept(c.Request.Context(), schoolID, conceptID)
+	concept, err := h.conceptTypeService.GetSchoolConcept(c.Request.Context(), schoolID, conceptID, requestLocale(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -340,11 +731,14 @@ func (h *ConceptTypeHandler) GetSchoolConcept(c *gin.Context) {
 
 // UpdateSchoolConcept godoc
 // @Summary Update a school concept
+// @Description In a locale other than the default one, the value is stored as a per-locale override
 // @Tags schools
 // @Accept json
 // @Produce json
 // @Param id path string true "School ID (UUID)"
 // @Param conceptId path string true "Concept ID (UUID)"
+// @Param locale query string false "Locale (es, en, pt); overrides Accept-Language"
+// @Param Accept-Language header string false "Preferred languages"
 // @Param request body dto.UpdateSchoolConceptRequest true "School concept update data"
 // @Success 200 {object} dto.SchoolConceptResponse
 // @Failure 400 {object} dto.ErrorResponse
@@ -369,10 +763,105 @@ func (h *ConceptTypeHandler) UpdateSchoolConcept(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
-	concept, err := h.conceptTypeService.UpdateSchoolConcept(withActor(c), schoolID, conceptID, &req)
+	concept, err := h.conceptTypeService.UpdateSchoolConcept(withActor(c), schoolID, conceptID, requestLocale(c), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, concept)
+}
+
+// ResetSchoolConcept godoc
+// @Summary Reset a school concept to its default value
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param conceptId path string true "Concept ID (UUID)"
+// @Success 200 {object} dto.SchoolConceptResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/concepts/{conceptId}/reset [post]
+func (h *ConceptTypeHandler) ResetSchoolConcept(c *gin.Context) {
+	schoolID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid school ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	conceptID, err := uuid.Parse(c.Param("conceptId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid concept ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	concept, err := h.conceptTypeService.ResetSchoolConcept(withActor(c), schoolID, conceptID)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, concept)
 }
+
+// ResetSchoolConcepts godoc
+// @Summary Reset all school concepts to their default values
+// @Description Terms without a source definition are left unchanged
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Success 200 {array} dto.SchoolConceptResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/concepts/reset [post]
+func (h *ConceptTypeHandler) ResetSchoolConcepts(c *gin.Context) {
+	schoolID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid school ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	concepts, err := h.conceptTypeService.ResetSchoolConcepts(withActor(c), schoolID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, concepts)
+}
+
+// ChangeSchoolConceptType godoc
+// @Summary Change a school's concept type
+// @Description Re-seeds the school's terms from the new type's definitions. keep_overrides preserves customised terms whose key still exists; dry_run previews the changes.
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param request body dto.ChangeSchoolConceptTypeRequest true "Concept type change"
+// @Success 200 {object} dto.ChangeSchoolConceptTypeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/concept-type [put]
+func (h *ConceptTypeHandler) ChangeSchoolConceptType(c *gin.Context) {
+	schoolID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid school ID", Code: "INVALID_REQUEST"})
+		return
+	}
+	var req dto.ChangeSchoolConceptTypeRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.conceptTypeService.ChangeSchoolConceptType(withActor(c), schoolID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+type CurriculumHandler struct {
+	curriculumService service.CurriculumService
+	logger            logger.Logger
+}
+
+func NewCurriculumHandler(curriculumService service.CurriculumService, logger logger.Logger) *CurriculumHandler {
+	return &CurriculumHandler{curriculumService: curriculumService, logger: logger}
+}
+
+// ListPrerequisites godoc
+// @Summary List the direct prerequisites of a subject
+// @Tags curriculum
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject ID (UUID)"
+// @Success 200 {array} dto.SubjectResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subjects/{id}/prerequisites [get]
+func (h *CurriculumHandler) ListPrerequisites(c *gin.Context) {
+	prerequisites, err := h.curriculumService.ListPrerequisites(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, prerequisites)
+}
+
+// AddPrerequisite godoc
+// @Summary Add a prerequisite to a subject
+// @Description Rejects prerequisites from another school and any that would create a cycle
+// @Tags curriculum
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject ID (UUID)"
+// @Param request body dto.AddPrerequisiteRequest true "Prerequisite"
+// @Success 201 {array} dto.SubjectResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subjects/{id}/prerequisites [post]
+func (h *CurriculumHandler) AddPrerequisite(c *gin.Context) {
+	var req dto.AddPrerequisiteRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	prerequisites, err := h.curriculumService.AddPrerequisite(withActor(c), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, prerequisites)
+}
+
+// RemovePrerequisite godoc
+// @Summary Remove a prerequisite from a subject
+// @Tags curriculum
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject ID (UUID)"
+// @Param prerequisite_id path string true "Prerequisite subject ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subjects/{id}/prerequisites/{prerequisite_id} [delete]
+func (h *CurriculumHandler) RemovePrerequisite(c *gin.Context) {
+	if err := h.curriculumService.RemovePrerequisite(withActor(c), c.Param("id"), c.Param("prerequisite_id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetPlacement godoc
+// @Summary Place a subject at a grade-level unit
+// @Tags curriculum
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject ID (UUID)"
+// @Param request body dto.SetSubjectPlacementRequest true "Placement"
+// @Success 200 {object} dto.SubjectPlacementResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subjects/{id}/placement [put]
+func (h *CurriculumHandler) SetPlacement(c *gin.Context) {
+	var req dto.SetSubjectPlacementRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	placement, err := h.curriculumService.SetPlacement(withActor(c), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, placement)
+}
+
+// RemovePlacement godoc
+// @Summary Remove a subject from its curriculum level
+// @Tags curriculum
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subjects/{id}/placement [delete]
+func (h *CurriculumHandler) RemovePlacement(c *gin.Context) {
+	if err := h.curriculumService.RemovePlacement(withActor(c), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetCurriculum godoc
+// @Summary Get the school's curriculum graph
+// @Description Subjects grouped by level and ordered by prerequisites. format=dot returns a Graphviz digraph.
+// @Tags curriculum
+// @Accept json
+// @Produce json
+// @Produce text/vnd.graphviz
+// @Param id path string true "School ID (UUID)"
+// @Param format query string false "Output format" Enums(json, dot)
+// @Success 200 {object} dto.CurriculumResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/curriculum [get]
+func (h *CurriculumHandler) GetCurriculum(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "dot" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "format must be json or dot", Code: "INVALID_REQUEST"})
+		return
+	}
+	curriculum, err := h.curriculumService.GetCurriculum(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if format == "dot" {
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(curriculum.DOT()))
+		return
+	}
+	c.JSON(http.StatusOK, curriculum)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// customFieldFilterPrefix marks the query parameters that filter lists by
+// custom field, as in ?cf.blood_type=O%2B
+const customFieldFilterPrefix = "cf."
+
+// customFieldFilters collects the custom field filters of a list request,
+// keyed by field key
+func customFieldFilters(c *gin.Context) map[string]string {
+	filters := map[string]string{}
+	for param, values := range c.Request.URL.Query() {
+		if key := strings.TrimPrefix(param, customFieldFilterPrefix); key != param && len(values) > 0 {
+			filters[key] = values[0]
+		}
+	}
+	return filters
+}
+
+// CustomFieldHandler handles custom field definition HTTP endpoints
+type CustomFieldHandler struct {
+	fieldService service.CustomFieldService
+	logger       logger.Logger
+}
+
+func NewCustomFieldHandler(fieldService service.CustomFieldService, logger logger.Logger) *CustomFieldHandler {
+	return &CustomFieldHandler{fieldService: fieldService, logger: logger}
+}
+
+// ListFields godoc
+// @Summary List a school's custom fields
+// @Tags custom-fields
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param entity_type query string false "Entity type (user, academic_unit or subject)"
+// @Success 200 {array} dto.CustomFieldResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/custom-fields [get]
+func (h *CustomFieldHandler) ListFields(c *gin.Context) {
+	fields, err := h.fieldService.ListFields(c.Request.Context(), c.Param("id"), c.Query("entity_type"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, fields)
+}
+
+// CreateField godoc
+// @Summary Define a custom field
+// @Description Adds an attribute to the school's users, academic units or subjects. Values are sent and returned under custom_fields and lists filter on them with cf.<key> query parameters.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param request body dto.CreateCustomFieldRequest true "Custom field"
+// @Success 201 {object} dto.CustomFieldResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/custom-fields [post]
+func (h *CustomFieldHandler) CreateField(c *gin.Context) {
+	var req dto.CreateCustomFieldRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	field, err := h.fieldService.CreateField(withActor(c), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, field)
+}
+
+// UpdateField godoc
+// @Summary Update a custom field
+// @Description Changes the name, required flag, order or select options of a custom field. Options still in use cannot be removed.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param fieldId path string true "Custom field ID (UUID)"
+// @Param request body dto.UpdateCustomFieldRequest true "Custom field changes"
+// @Success 200 {object} dto.CustomFieldResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/custom-fields/{fieldId} [put]
+func (h *CustomFieldHandler) UpdateField(c *gin.Context) {
+	var req dto.UpdateCustomFieldRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	field, err := h.fieldService.UpdateField(withActor(c), c.Param("id"), c.Param("fieldId"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, field)
+}
+
+// DeleteField godoc
+// @Summary Delete a custom field
+// @Description Removes the custom field and every value stored for it
+// @Tags custom-fields
+// @Param id path string true "School ID (UUID)"
+// @Param fieldId path string true "Custom field ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/custom-fields/{fieldId} [delete]
+func (h *CustomFieldHandler) DeleteField(c *gin.Context) {
+	if err := h.fieldService.DeleteField(withActor(c), c.Param("id"), c.Param("fieldId")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
 	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 )
 
 type GuardianHandler struct {
@@ -28,6 +31,8 @@ func NewGuardianHandler(guardianService service.GuardianService, logger logger.L
 // @Success 201 {object} dto.GuardianRelationResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Security BearerAuth
 // @Router /guardian-relations [post]
@@ -122,25 +127,75 @@ func (h *GuardianHandler) DeleteGuardianRelation(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// parseRelationListQuery reads pagination, search, include_inactive and relationship_type
+// query parameters. On invalid input it writes a 400 response and returns ok=false.
+func parseRelationListQuery(c *gin.Context) (dto.GuardianRelationListFilters, sharedrepo.ListFilters, bool) {
+	var relFilters dto.GuardianRelationListFilters
+	var filters sharedrepo.ListFilters
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "limit must be a positive integer", Code: "INVALID_REQUEST"})
+			return relFilters, filters, false
+		}
+		filters.Limit = limit
+	}
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page <= 0 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "page must be a positive integer", Code: "INVALID_REQUEST"})
+			return relFilters, filters, false
+		}
+		filters.Page = page
+	}
+	if search := c.Query("search"); search != "" {
+		filters.Search = search
+		if fields := c.Query("search_fields"); fields != "" {
+			filters.SearchFields = strings.Split(fields, ",")
+		}
+	}
+	if includeStr := c.Query("include_inactive"); includeStr != "" {
+		include, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid include_inactive parameter", Code: "INVALID_REQUEST"})
+			return relFilters, filters, false
+		}
+		relFilters.IncludeInactive = include
+	}
+	relFilters.RelationshipType = c.Query("relationship_type")
+	return relFilters, filters, true
+}
+
 // GetGuardianRelations godoc
 // @Summary Get relations for a guardian
 // @Tags guardian-relations
 // @Accept json
 // @Produce json
 // @Param guardian_id path string true "Guardian ID (UUID)"
-// @Success 200 {array} dto.GuardianRelationResponse
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Number of items per page" minimum(1)
+// @Param search query string false "Search term (ILIKE)"
+// @Param search_fields query string false "Comma-separated fields to search"
+// @Param include_inactive query bool false "Include inactive relations"
+// @Param relationship_type query string false "Filter by relationship type"
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Security BearerAuth
 // @Router /guardians/{guardian_id}/relations [get]
 func (h *GuardianHandler) GetGuardianRelations(c *gin.Context) {
+	relFilters, filters, ok := parseRelationListQuery(c)
+	if !ok {
+		return
+	}
 	guardianID := c.Param("guardian_id")
-	relations, err := h.guardianService.GetGuardianRelations(c.Request.Context(), guardianID)
+	relations, total, err := h.guardianService.GetGuardianRelations(c.Request.Context(), guardianID, relFilters, filters)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, relations)
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(relations, total, filters.Page, filters.Limit))
 }
 
 // GetStudentGuardians godoc
@@ -149,17 +204,83 @@ func (h *GuardianHandler) GetGuardianRelations(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param student_id path string true "Student ID (UUID)"
-// @Success 200 {array} dto.GuardianRelationResponse
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Number of items per page" minimum(1)
+// @Param search query string false "Search term (ILIKE)"
+// @Param search_fields query string false "Comma-separated fields to search"
+// @Param include_inactive query bool false "Include inactive relations"
+// @Param relationship_type query string false "Filter by relationship type"
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Security BearerAuth
 // @Router /students/{student_id}/guardians [get]
 func (h *GuardianHandler) GetStudentGuardians(c *gin.Context) {
+	relFilters, filters, ok := parseRelationListQuery(c)
+	if !ok {
+		return
+	}
+	studentID := c.Param("student_id")
+	relations, total, err := h.guardianService.GetStudentGuardians(c.Request.Context(), studentID, relFilters, filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(relations, total, filters.Page, filters.Limit))
+}
+
+// ListSchoolGuardianRelations godoc
+// @Summary List guardian relations of a school's students
+// @Description School-wide listing for audits; includes relations of every student membership in the school
+// @Tags guardian-relations
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Number of items per page" minimum(1)
+// @Param search query string false "Search term (ILIKE)"
+// @Param search_fields query string false "Comma-separated fields to search"
+// @Param include_inactive query bool false "Include inactive relations"
+// @Param relationship_type query string false "Filter by relationship type"
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/guardian-relations [get]
+func (h *GuardianHandler) ListSchoolGuardianRelations(c *gin.Context) {
+	relFilters, filters, ok := parseRelationListQuery(c)
+	if !ok {
+		return
+	}
+	schoolID := c.Param("id")
+	relations, total, err := h.guardianService.ListSchoolRelations(c.Request.Context(), schoolID, relFilters, filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(relations, total, filters.Page, filters.Limit))
+}
+
+// GetAuthorizedPickups godoc
+// @Summary Get guardians authorized to pick up a student
+// @Tags guardian-relations
+// @Accept json
+// @Produce json
+// @Param student_id path string true "Student ID (UUID)"
+// @Success 200 {array} dto.AuthorizedPickupResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /students/{student_id}/authorized-pickups [get]
+func (h *GuardianHandler) GetAuthorizedPickups(c *gin.Context) {
 	studentID := c.Param("student_id")
-	relations, err := h.guardianService.GetStudentGuardians(c.Request.Context(), studentID)
+	pickups, err := h.guardianService.GetAuthorizedPickups(c.Request.Context(), studentID)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, relations)
+	c.JSON(http.StatusOK, pickups)
 }
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/infrastructure/http/middleware"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// GuardianInvitationHandler handles guardian self-service linking endpoints
+type GuardianInvitationHandler struct {
+	invitationService service.GuardianInvitationService
+	logger            logger.Logger
+}
+
+func NewGuardianInvitationHandler(invitationService service.GuardianInvitationService, logger logger.Logger) *GuardianInvitationHandler {
+	return &GuardianInvitationHandler{invitationService: invitationService, logger: logger}
+}
+
+// contextUserID returns the authenticated user ID, or "" if absent
+func contextUserID(c *gin.Context) string {
+	if v, exists := c.Get(middleware.ContextKeyUserID); exists {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// CreateInvitation godoc
+// @Summary Create a guardian link code for a student
+// @Description Issues an expiring, limited-use code a guardian can redeem to request a link to the student
+// @Tags guardian-invitations
+// @Accept json
+// @Produce json
+// @Param student_id path string true "Student ID (UUID)"
+// @Param request body dto.CreateGuardianInvitationRequest true "Invitation settings"
+// @Success 201 {object} dto.GuardianInvitationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /students/{student_id}/guardian-invitations [post]
+func (h *GuardianInvitationHandler) CreateInvitation(c *gin.Context) {
+	var req dto.CreateGuardianInvitationRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	invitation, err := h.invitationService.CreateInvitation(withActor(c), c.Param("student_id"), req, contextUserID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListInvitations godoc
+// @Summary List guardian link codes for a student
+// @Tags guardian-invitations
+// @Accept json
+// @Produce json
+// @Param student_id path string true "Student ID (UUID)"
+// @Success 200 {array} dto.GuardianInvitationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /students/{student_id}/guardian-invitations [get]
+func (h *GuardianInvitationHandler) ListInvitations(c *gin.Context) {
+	invitations, err := h.invitationService.ListInvitations(c.Request.Context(), c.Param("student_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke a guardian link code
+// @Tags guardian-invitations
+// @Accept json
+// @Produce json
+// @Param id path string true "Invitation ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /guardian-invitations/{id} [delete]
+func (h *GuardianInvitationHandler) RevokeInvitation(c *gin.Context) {
+	if err := h.invitationService.RevokeInvitation(withActor(c), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RedeemInvitation godoc
+// @Summary Redeem a guardian link code
+// @Description The authenticated user redeems a code, creating a pending link request for admin approval
+// @Tags guardian-invitations
+// @Accept json
+// @Produce json
+// @Param request body dto.RedeemGuardianInvitationRequest true "Code and relationship type"
+// @Success 201 {object} dto.GuardianLinkRequestResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /guardian-invitations/redeem [post]
+func (h *GuardianInvitationHandler) RedeemInvitation(c *gin.Context) {
+	var req dto.RedeemGuardianInvitationRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	request, err := h.invitationService.RedeemInvitation(withActor(c), req, contextUserID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, request)
+}
+
+// ListLinkRequests godoc
+// @Summary List guardian link requests
+// @Tags guardian-invitations
+// @Accept json
+// @Produce json
+// @Param school_id query string false "Filter by school ID (UUID)"
+// @Param status query string false "Filter by status (pending, approved, rejected)"
+// @Success 200 {array} dto.GuardianLinkRequestResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /guardian-link-requests [get]
+func (h *GuardianInvitationHandler) ListLinkRequests(c *gin.Context) {
+	requests, err := h.invitationService.ListLinkRequests(c.Request.Context(), c.Query("school_id"), c.Query("status"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// ReviewLinkRequest godoc
+// @Summary Approve or reject a guardian link request
+// @Description Approving creates the guardian relation; rejecting closes the request
+// @Tags guardian-invitations
+// @Accept json
+// @Produce json
+// @Param id path string true "Link request ID (UUID)"
+// @Param request body dto.ReviewGuardianLinkRequest true "Review decision"
+// @Success 200 {object} dto.GuardianLinkRequestResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /guardian-link-requests/{id}/review [post]
+func (h *GuardianInvitationHandler) ReviewLinkRequest(c *gin.Context) {
+	var req dto.ReviewGuardianLinkRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	request, err := h.invitationService.ReviewLinkRequest(withActor(c), c.Param("id"), req, contextUserID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
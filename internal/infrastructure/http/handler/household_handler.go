@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// HouseholdHandler handles household HTTP endpoints
+type HouseholdHandler struct {
+	householdService service.HouseholdService
+	logger           logger.Logger
+}
+
+func NewHouseholdHandler(householdService service.HouseholdService, logger logger.Logger) *HouseholdHandler {
+	return &HouseholdHandler{householdService: householdService, logger: logger}
+}
+
+// CreateHousehold godoc
+// @Summary Create a household
+// @Description Assigns guardians and students to a household, either listed explicitly or computed from a student's guardian relations
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateHouseholdRequest true "Household data"
+// @Success 201 {object} dto.HouseholdResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /households [post]
+func (h *HouseholdHandler) CreateHousehold(c *gin.Context) {
+	var req dto.CreateHouseholdRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	household, err := h.householdService.CreateHousehold(withActor(c), req, contextUserID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, household)
+}
+
+// GetHousehold godoc
+// @Summary Get a household with its guardians, students and their units
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID (UUID)"
+// @Success 200 {object} dto.HouseholdResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /households/{id} [get]
+func (h *HouseholdHandler) GetHousehold(c *gin.Context) {
+	household, err := h.householdService.GetHousehold(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, household)
+}
+
+// GetStudentHousehold godoc
+// @Summary Get the household of a student
+// @Description Returns the assigned household, or one computed from guardian relations when none is assigned
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param student_id path string true "Student ID (UUID)"
+// @Success 200 {object} dto.HouseholdResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /students/{student_id}/household [get]
+func (h *HouseholdHandler) GetStudentHousehold(c *gin.Context) {
+	household, err := h.householdService.GetStudentHousehold(c.Request.Context(), c.Param("student_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, household)
+}
+
+// AddHouseholdMember godoc
+// @Summary Add a member to a household
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID (UUID)"
+// @Param request body dto.HouseholdMemberRequest true "Member data"
+// @Success 200 {object} dto.HouseholdResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /households/{id}/members [post]
+func (h *HouseholdHandler) AddHouseholdMember(c *gin.Context) {
+	var req dto.HouseholdMemberRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	household, err := h.householdService.AddMember(withActor(c), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, household)
+}
+
+// RemoveHouseholdMember godoc
+// @Summary Remove a member from a household
+// @Tags households
+// @Accept json
+// @Produce json
+// @Param id path string true "Household ID (UUID)"
+// @Param user_id path string true "User ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /households/{id}/members/{user_id} [delete]
+func (h *HouseholdHandler) RemoveHouseholdMember(c *gin.Context) {
+	if err := h.householdService.RemoveMember(withActor(c), c.Param("id"), c.Param("user_id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+)
+
+// requestLocale returns the locale requested through the "locale" query
+// parameter or, failing that, the best supported language in Accept-Language.
+// An explicit parameter is returned as is so the service can reject unsupported
+// values; an empty result means the default locale.
+func requestLocale(c *gin.Context) string {
+	if locale, ok := c.GetQuery("locale"); ok {
+		return locale
+	}
+	return preferredLocale(c.GetHeader("Accept-Language"))
+}
+
+// preferredLocale picks the supported language with the highest q-value from an
+// Accept-Language header, keeping header order for equal weights
+func preferredLocale(header string) string {
+	type candidate struct {
+		locale string
+		weight float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		locale, ok := service.NormalizeLocale(tag)
+		if !ok || weight <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{locale: locale, weight: weight})
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].weight > candidates[j].weight })
+	return candidates[0].locale
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+// MetadataSchemaHandler handles metadata schema HTTP endpoints
+type MetadataSchemaHandler struct {
+	schemaService service.MetadataSchemaService
+	logger        logger.Logger
+}
+
+func NewMetadataSchemaHandler(schemaService service.MetadataSchemaService, logger logger.Logger) *MetadataSchemaHandler {
+	return &MetadataSchemaHandler{schemaService: schemaService, logger: logger}
+}
+
+// GetSchema godoc
+// @Summary Get the metadata schema of an entity type
+// @Description Returns the JSON Schema metadata of the entity type must follow. With a concept type, its own schema is returned when there is one, otherwise the general one.
+// @Tags schemas
+// @Produce json
+// @Param entity path string true "Entity type (school or academic_unit)"
+// @Param concept_type_id query string false "Concept type ID (UUID)"
+// @Success 200 {object} dto.MetadataSchemaResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schemas/{entity} [get]
+func (h *MetadataSchemaHandler) GetSchema(c *gin.Context) {
+	schema, err := h.schemaService.GetSchema(c.Request.Context(), c.Param("entity"), c.Query("concept_type_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// PutSchema godoc
+// @Summary Register the metadata schema of an entity type
+// @Description Creates or replaces the JSON Schema enforced on the metadata of the entity type when it is created or updated, optionally only for one concept type
+// @Tags schemas
+// @Accept json
+// @Produce json
+// @Param entity path string true "Entity type (school or academic_unit)"
+// @Param request body dto.PutMetadataSchemaRequest true "Schema"
+// @Success 200 {object} dto.MetadataSchemaResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schemas/{entity} [put]
+func (h *MetadataSchemaHandler) PutSchema(c *gin.Context) {
+	var req dto.PutMetadataSchemaRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	schema, err := h.schemaService.PutSchema(withActor(c), c.Param("entity"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, schema)
+}
+
+// DeleteSchema godoc
+// @Summary Remove the metadata schema of an entity type
+// @Description Removes the schema registered for exactly the entity type and concept type; metadata then follows the general schema, if any
+// @Tags schemas
+// @Param entity path string true "Entity type (school or academic_unit)"
+// @Param concept_type_id query string false "Concept type ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schemas/{entity} [delete]
+func (h *MetadataSchemaHandler) DeleteSchema(c *gin.Context) {
+	if err := h.schemaService.DeleteSchema(withActor(c), c.Param("entity"), c.Query("concept_type_id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -37,6 +38,21 @@ func withActor(c *gin.Context) context.Context {
 	return ctx
 }
 
+// boolQuery parses an optional boolean query parameter, answering 400 when it
+// is malformed. Missing parameters are false.
+func boolQuery(c *gin.Context, name string) (bool, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return false, true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid " + name + " parameter", Code: "INVALID_REQUEST"})
+		return false, false
+	}
+	return value, true
+}
+
 // SchoolHandler handles school HTTP endpoints
 type SchoolHandler struct {
 	schoolService service.SchoolService
@@ -128,6 +144,29 @@ func (h *SchoolHandler) GetSchoolByCode(c *gin.Context) {
 	c.JSON(http.StatusOK, school)
 }
 
+// CheckSchoolCode godoc
+// @Summary Check school code availability
+// @Description Normalises a school code and reports whether it matches the configured format and is free. Without a code, suggests one generated from the name and city.
+// @Tags schools
+// @Produce json
+// @Param code query string false "School code to check"
+// @Param name query string false "School name to generate a code from"
+// @Param city query string false "School city to generate a code from"
+// @Success 200 {object} dto.SchoolCodeAvailabilityResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/code-availability [get]
+func (h *SchoolHandler) CheckSchoolCode(c *gin.Context) {
+	result, err := h.schoolService.CheckSchoolCode(c.Request.Context(), c.Query("code"), c.Query("name"), c.Query("city"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // ListSchools godoc
 // @Summary List all schools
 // @Tags schools
@@ -137,6 +176,7 @@ func (h *SchoolHandler) GetSchoolByCode(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1)
 // @Param search query string false "Search term (ILIKE)"
 // @Param search_fields query string false "Comma-separated fields to search"
+// @Param include_archived query bool false "Include archived schools"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -167,7 +207,11 @@ func (h *SchoolHandler) ListSchools(c *gin.Context) {
 			filters.SearchFields = strings.Split(fields, ",")
 		}
 	}
-	schools, total, err := h.schoolService.ListSchools(c.Request.Context(), filters)
+	includeArchived, ok := boolQuery(c, "include_archived")
+	if !ok {
+		return
+	}
+	schools, total, err := h.schoolService.ListSchools(c.Request.Context(), filters, includeArchived)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -212,12 +256,41 @@ func (h *SchoolHandler) UpdateSchool(c *gin.Context) {
 	c.JSON(http.StatusOK, school)
 }
 
+// GetDeletionImpact godoc
+// @Summary Preview what deleting a school affects
+// @Description Counts the school's dependent entities and returns the confirmation token required to delete it
+// @Tags schools
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Success 200 {object} dto.SchoolDeletionImpactResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/deletion-impact [get]
+func (h *SchoolHandler) GetDeletionImpact(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
+		return
+	}
+	impact, err := h.schoolService.GetDeletionImpact(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, impact)
+}
+
 // DeleteSchool godoc
 // @Summary Delete a school
+// @Description Soft-deletes the school with its units, subjects, memberships, teaching assignments and guardian relations, revokes its open invitations and rejects pending link requests. Terms, curriculum, custom fields and the lifecycle state are kept with the deleted school.
 // @Tags schools
 // @Accept json
 // @Produce json
 // @Param id path string true "School ID (UUID)"
+// @Param confirmation_token query string true "Token from the deletion impact preview"
 // @Success 204 "No content"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -231,9 +304,224 @@ func (h *SchoolHandler) DeleteSchool(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
 		return
 	}
-	if err := h.schoolService.DeleteSchool(withActor(c), id); err != nil {
+	if err := h.schoolService.DeleteSchool(withActor(c), id, c.Query("confirmation_token")); err != nil {
 		_ = c.Error(err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// ChangeSchoolState godoc
+// @Summary Change the lifecycle state of a school
+// @Description Moves a school between onboarding, active, suspended and archived. Suspending and archiving require a reason.
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param request body dto.ChangeSchoolStateRequest true "Target state and reason"
+// @Success 200 {object} dto.SchoolResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/lifecycle [post]
+func (h *SchoolHandler) ChangeSchoolState(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
+		return
+	}
+	var req dto.ChangeSchoolStateRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	school, err := h.schoolService.ChangeSchoolState(withActor(c), id, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, school)
+}
+
+// GetSchoolLifecycle godoc
+// @Summary Get the lifecycle state of a school and its history
+// @Tags schools
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Success 200 {object} dto.SchoolLifecycleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/lifecycle [get]
+func (h *SchoolHandler) GetSchoolLifecycle(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
+		return
+	}
+	lifecycle, err := h.schoolService.GetSchoolLifecycle(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, lifecycle)
+}
+
+// RestoreSchool godoc
+// @Summary Restore an archived school
+// @Description Moves an archived school back to active. Only available to platform admins.
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param request body dto.RestoreSchoolRequest true "Restore reason"
+// @Success 200 {object} dto.SchoolResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/restore [post]
+func (h *SchoolHandler) RestoreSchool(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
+		return
+	}
+	var req dto.RestoreSchoolRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	school, err := h.schoolService.RestoreSchool(withActor(c), id, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, school)
+}
+
+// ExportSchoolBundle godoc
+// @Summary Export a school bundle
+// @Description Returns the school with its concepts, unit tree, subjects, memberships and member users as a versioned JSON document. Password hashes are left out unless requested by a platform admin; anonymize replaces user names and emails. References to deleted units are dropped.
+// @Tags schools
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param include_password_hashes query bool false "Include user password hashes (platform admins only)"
+// @Param anonymize query bool false "Replace user names and emails"
+// @Success 200 {object} dto.SchoolBundle
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/bundle [get]
+func (h *SchoolHandler) ExportSchoolBundle(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
+		return
+	}
+	var opts dto.SchoolBundleExportOptions
+	var ok bool
+	if opts.IncludePasswordHashes, ok = boolQuery(c, "include_password_hashes"); !ok {
+		return
+	}
+	if opts.Anonymize, ok = boolQuery(c, "anonymize"); !ok {
+		return
+	}
+	if opts.IncludePasswordHashes && !opts.Anonymize && !middleware.HasPlatformScope(c) {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "password hashes can only be exported by platform admins", Code: "PLATFORM_SCOPE_REQUIRED"})
+		return
+	}
+	bundle, err := h.schoolService.ExportSchoolBundle(withActor(c), id, opts)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.bundle.json"`, bundle.School.Code))
+	c.JSON(http.StatusOK, bundle)
+}
+
+// ImportSchoolBundle godoc
+// @Summary Import a school bundle
+// @Description Recreates a bundled school under new IDs. A taken school code stops the import; users whose email already exists are skipped along with their memberships. Both are reported as conflicts. Bundles carrying password hashes can only be imported by platform admins.
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param dry_run query bool false "Only report the changes"
+// @Param request body dto.SchoolBundle true "School bundle"
+// @Success 200 {object} dto.SchoolBundleImportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/import-bundle [post]
+func (h *SchoolHandler) ImportSchoolBundle(c *gin.Context) {
+	dryRun, ok := boolQuery(c, "dry_run")
+	if !ok {
+		return
+	}
+	var bundle dto.SchoolBundle
+	if err := bindJSON(c, &bundle); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	// A password hash would let the importer sign in as the new account
+	if !middleware.HasPlatformScope(c) {
+		for _, user := range bundle.Users {
+			if user.PasswordHash != "" {
+				c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "password hashes can only be imported by platform admins", Code: "PLATFORM_SCOPE_REQUIRED"})
+				return
+			}
+		}
+	}
+	result, err := h.schoolService.ImportSchoolBundle(withActor(c), &bundle, dryRun)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CloneSchool godoc
+// @Summary Clone a school
+// @Description Creates a new school from a template school, copying its settings and, unless turned off in the request, its concepts with their translations, unit tree and active subjects; subjects in the trash are only copied with inactive_subjects. Members are never copied. The new school starts onboarding.
+// @Tags schools
+// @Accept json
+// @Produce json
+// @Param id path string true "Template school ID (UUID)"
+// @Param request body dto.CloneSchoolRequest true "New school name, code and parts to copy"
+// @Success 201 {object} dto.SchoolCloneResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/clone [post]
+func (h *SchoolHandler) CloneSchool(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "school ID is required", Code: "INVALID_REQUEST"})
+		return
+	}
+	var req dto.CloneSchoolRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.schoolService.CloneSchool(withActor(c), id, req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+}
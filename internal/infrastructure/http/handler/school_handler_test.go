@@ -167,13 +167,14 @@ func TestSchoolHandler_GetSchool(t *testing.T) {
 func TestSchoolHandler_ListSchools(t *testing.T) {
 	tests := []struct {
 		name       string
+		query      string
 		setupMock  func(m *mock.MockSchoolService)
 		wantStatus int
 	}{
 		{
 			name: "success - returns 200",
 			setupMock: func(m *mock.MockSchoolService) {
-				m.ListSchoolsFn = func(_ context.Context, _ sharedrepo.ListFilters) ([]dto.SchoolResponse, int, error) {
+				m.ListSchoolsFn = func(_ context.Context, _ sharedrepo.ListFilters, _ bool) ([]dto.SchoolResponse, int, error) {
 					return []dto.SchoolResponse{{ID: uuid.New().String(), Name: "School 1"}}, 1, nil
 				}
 			},
@@ -182,12 +183,30 @@ func TestSchoolHandler_ListSchools(t *testing.T) {
 		{
 			name: "error - database error returns 500",
 			setupMock: func(m *mock.MockSchoolService) {
-				m.ListSchoolsFn = func(_ context.Context, _ sharedrepo.ListFilters) ([]dto.SchoolResponse, int, error) {
+				m.ListSchoolsFn = func(_ context.Context, _ sharedrepo.ListFilters, _ bool) ([]dto.SchoolResponse, int, error) {
 					return nil, 0, errors.NewDatabaseError("list", nil)
 				}
 			},
 			wantStatus: http.StatusInternalServerError,
 		},
+		{
+			name:  "success - include_archived is passed through",
+			query: "?include_archived=true",
+			setupMock: func(m *mock.MockSchoolService) {
+				m.ListSchoolsFn = func(_ context.Context, _ sharedrepo.ListFilters, includeArchived bool) ([]dto.SchoolResponse, int, error) {
+					if !includeArchived {
+						return nil, 0, errors.NewValidationError("include_archived not set")
+					}
+					return []dto.SchoolResponse{}, 0, nil
+				}
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "error - invalid include_archived returns 400",
+			query:      "?include_archived=maybe",
+			wantStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -201,7 +220,7 @@ func TestSchoolHandler_ListSchools(t *testing.T) {
 			r := newTestRouter()
 			r.GET("/schools", h.ListSchools)
 
-			req, _ := http.NewRequest(http.MethodGet, "/schools", nil)
+			req, _ := http.NewRequest(http.MethodGet, "/schools"+tt.query, nil)
 			w := httptest.NewRecorder()
 			r.ServeHTTP(w, req)
 
@@ -221,7 +240,12 @@ func TestSchoolHandler_DeleteSchool(t *testing.T) {
 			name: "success - returns 204",
 			id:   uuid.New().String(),
 			setupMock: func(m *mock.MockSchoolService) {
-				m.DeleteSchoolFn = func(_ context.Context, _ string) error { return nil }
+				m.DeleteSchoolFn = func(_ context.Context, _ string, token string) error {
+					if token != "preview-token" {
+						return errors.NewValidationError("confirmation required")
+					}
+					return nil
+				}
 			},
 			wantStatus: http.StatusNoContent,
 		},
@@ -229,7 +253,7 @@ func TestSchoolHandler_DeleteSchool(t *testing.T) {
 			name: "error - not found returns 404",
 			id:   uuid.New().String(),
 			setupMock: func(m *mock.MockSchoolService) {
-				m.DeleteSchoolFn = func(_ context.Context, _ string) error {
+				m.DeleteSchoolFn = func(_ context.Context, _ string, _ string) error {
 					return errors.NewNotFoundError("school")
 				}
 			},
@@ -248,7 +272,7 @@ func TestSchoolHandler_DeleteSchool(t *testing.T) {
 			r := newTestRouter()
 			r.DELETE("/schools/:id", h.DeleteSchool)
 
-			req, _ := http.NewRequest(http.MethodDelete, "/schools/"+tt.id, nil)
+			req, _ := http.NewRequest(http.MethodDelete, "/schools/"+tt.id+"?confirmation_token=preview-token", nil)
 			w := httptest.NewRecorder()
 			r.ServeHTTP(w, req)
 
@@ -358,3 +382,91 @@ func TestSchoolHandler_GetSchoolByCode(t *testing.T) {
 		})
 	}
 }
+
+func TestSchoolHandler_ExportSchoolBundle(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		schoolCtx  bool
+		wantStatus int
+	}{
+		{name: "success - platform admin with password hashes", query: "?include_password_hashes=true", wantStatus: http.StatusOK},
+		{name: "success - school admin without password hashes", schoolCtx: true, wantStatus: http.StatusOK},
+		{name: "success - school admin anonymized", query: "?include_password_hashes=true&anonymize=true", schoolCtx: true, wantStatus: http.StatusOK},
+		{name: "error - school admin with password hashes returns 403", query: "?include_password_hashes=true", schoolCtx: true, wantStatus: http.StatusForbidden},
+		{name: "error - malformed flag returns 400", query: "?include_password_hashes=maybe", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schoolID := uuid.New().String()
+			mockSvc := &mock.MockSchoolService{
+				ExportSchoolBundleFn: func(_ context.Context, _ string, _ dto.SchoolBundleExportOptions) (*dto.SchoolBundle, error) {
+					return &dto.SchoolBundle{School: dto.SchoolBundleSchool{Code: "SCH001"}}, nil
+				},
+			}
+
+			h := handler.NewSchoolHandler(mockSvc, mock.NewMockLogger())
+			r := newTestRouter()
+			if tt.schoolCtx {
+				r.Use(withSchoolContext(schoolID))
+			}
+			r.GET("/schools/:id/bundle", h.ExportSchoolBundle)
+
+			req, _ := http.NewRequest(http.MethodGet, "/schools/"+schoolID+"/bundle"+tt.query, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestSchoolHandler_ImportSchoolBundle(t *testing.T) {
+	bundleWith := func(passwordHash string) []byte {
+		body, _ := json.Marshal(dto.SchoolBundle{
+			Version: 1,
+			School:  dto.SchoolBundleSchool{Name: "Imported School", Code: "IMP001"},
+			Users:   []dto.SchoolBundleUser{{ID: "a", Email: "new@school.edu", PasswordHash: passwordHash}},
+		})
+		return body
+	}
+	tests := []struct {
+		name       string
+		body       []byte
+		schoolCtx  bool
+		wantStatus int
+		wantCalled bool
+	}{
+		{name: "success - platform admin with password hashes", body: bundleWith("$2a$10$hash"), wantStatus: http.StatusOK, wantCalled: true},
+		{name: "success - school admin without password hashes", body: bundleWith(""), schoolCtx: true, wantStatus: http.StatusOK, wantCalled: true},
+		{name: "error - school admin with password hashes returns 403", body: bundleWith("$2a$10$hash"), schoolCtx: true, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			mockSvc := &mock.MockSchoolService{
+				ImportSchoolBundleFn: func(_ context.Context, _ *dto.SchoolBundle, _ bool) (*dto.SchoolBundleImportResponse, error) {
+					called = true
+					return &dto.SchoolBundleImportResponse{Code: "IMP001"}, nil
+				},
+			}
+
+			h := handler.NewSchoolHandler(mockSvc, mock.NewMockLogger())
+			r := newTestRouter()
+			if tt.schoolCtx {
+				r.Use(withSchoolContext(uuid.New().String()))
+			}
+			r.POST("/schools/import-bundle", h.ImportSchoolBundle)
+
+			req, _ := http.NewRequest(http.MethodPost, "/schools/import-bundle", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantCalled, called)
+		})
+	}
+}
@@ -49,6 +49,7 @@ func (h *SubjectHandler) extractSchoolID(c *gin.Context) (string, bool) {
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Security BearerAuth
 // @Router /subjects [post]
@@ -79,6 +80,8 @@ func (h *SubjectHandler) CreateSubject(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1)
 // @Param search query string false "Search term (ILIKE)"
 // @Param search_fields query string false "Comma-separated fields to search"
+// @Param include_deleted query bool false "Include deleted subjects"
+// @Param cf.{key} query string false "Custom field filter, one per field key"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -114,7 +117,16 @@ func (h *SubjectHandler) ListSubjects(c *gin.Context) {
 			filters.SearchFields = strings.Split(fields, ",")
 		}
 	}
-	subjects, total, err := h.subjectService.ListSubjects(c.Request.Context(), schoolID, filters)
+	includeDeleted := false
+	if includeStr := c.Query("include_deleted"); includeStr != "" {
+		include, err := strconv.ParseBool(includeStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid include_deleted parameter", Code: "INVALID_REQUEST"})
+			return
+		}
+		includeDeleted = include
+	}
+	subjects, total, err := h.subjectService.ListSubjects(c.Request.Context(), schoolID, includeDeleted, filters, customFieldFilters(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -159,6 +171,7 @@ func (h *SubjectHandler) GetSubject(c *gin.Context) {
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Security BearerAuth
 // @Router /subjects/{id} [patch]
@@ -197,3 +210,28 @@ func (h *SubjectHandler) DeleteSubject(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// RestoreSubject godoc
+// @Summary Restore a deleted subject
+// @Description Fails with 409 if an active subject of the same school already uses the name or code
+// @Tags subjects
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject ID (UUID)"
+// @Success 200 {object} dto.SubjectResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subjects/{id}/restore [post]
+func (h *SubjectHandler) RestoreSubject(c *gin.Context) {
+	id := c.Param("id")
+	subject, err := h.subjectService.RestoreSubject(withActor(c), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, subject)
+}
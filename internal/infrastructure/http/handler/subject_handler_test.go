@@ -166,7 +166,7 @@ func TestSubjectHandler_ListSubjects(t *testing.T) {
 		{
 			name: "success",
 			setupMock: func(m *mock.MockSubjectService) {
-				m.ListSubjectsFn = func(_ context.Context, _ string, _ sharedrepo.ListFilters) ([]dto.SubjectResponse, int, error) {
+				m.ListSubjectsFn = func(_ context.Context, _ string, _ bool, _ sharedrepo.ListFilters, _ map[string]string) ([]dto.SubjectResponse, int, error) {
 					return []dto.SubjectResponse{}, 0, nil
 				}
 			},
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+)
+
+type SubjectTemplateHandler struct {
+	templateService service.SubjectTemplateService
+	logger          logger.Logger
+}
+
+func NewSubjectTemplateHandler(templateService service.SubjectTemplateService, logger logger.Logger) *SubjectTemplateHandler {
+	return &SubjectTemplateHandler{templateService: templateService, logger: logger}
+}
+
+// CreateSubjectTemplate godoc
+// @Summary Create a platform subject template
+// @Tags subject-templates
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateSubjectTemplateRequest true "Subject template data"
+// @Success 201 {object} dto.SubjectTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subject-templates [post]
+func (h *SubjectTemplateHandler) CreateSubjectTemplate(c *gin.Context) {
+	var req dto.CreateSubjectTemplateRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	template, err := h.templateService.CreateTemplate(withActor(c), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, template)
+}
+
+// ListSubjectTemplates godoc
+// @Summary List subject templates
+// @Tags subject-templates
+// @Accept json
+// @Produce json
+// @Param concept_type_id query string false "Filter by concept type ID (UUID)"
+// @Param education_level query string false "Filter by education level"
+// @Success 200 {array} dto.SubjectTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subject-templates [get]
+func (h *SubjectTemplateHandler) ListSubjectTemplates(c *gin.Context) {
+	filters := dto.SubjectTemplateListFilters{
+		ConceptTypeID:  c.Query("concept_type_id"),
+		EducationLevel: c.Query("education_level"),
+	}
+	templates, err := h.templateService.ListTemplates(c.Request.Context(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetSubjectTemplate godoc
+// @Summary Get a subject template by ID
+// @Tags subject-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject template ID (UUID)"
+// @Success 200 {object} dto.SubjectTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subject-templates/{id} [get]
+func (h *SubjectTemplateHandler) GetSubjectTemplate(c *gin.Context) {
+	template, err := h.templateService.GetTemplate(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateSubjectTemplate godoc
+// @Summary Update a subject template
+// @Tags subject-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject template ID (UUID)"
+// @Param request body dto.UpdateSubjectTemplateRequest true "Subject template update data"
+// @Success 200 {object} dto.SubjectTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subject-templates/{id} [put]
+func (h *SubjectTemplateHandler) UpdateSubjectTemplate(c *gin.Context) {
+	var req dto.UpdateSubjectTemplateRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	template, err := h.templateService.UpdateTemplate(withActor(c), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteSubjectTemplate godoc
+// @Summary Delete a subject template
+// @Description Subjects already created from the template are not affected
+// @Tags subject-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject template ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subject-templates/{id} [delete]
+func (h *SubjectTemplateHandler) DeleteSubjectTemplate(c *gin.Context) {
+	if err := h.templateService.DeleteTemplate(withActor(c), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ApplySubjectTemplates godoc
+// @Summary Create subjects in a school from templates
+// @Description Templates are selected by ID or by group (defaulting to the school's concept type). Names already present in the school are skipped and reported.
+// @Tags subject-templates
+// @Accept json
+// @Produce json
+// @Param id path string true "School ID (UUID)"
+// @Param request body dto.ApplySubjectTemplatesRequest true "Template selection"
+// @Success 200 {object} dto.ApplySubjectTemplatesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /schools/{id}/subject-templates/apply [post]
+func (h *SubjectTemplateHandler) ApplySubjectTemplates(c *gin.Context) {
+	var req dto.ApplySubjectTemplatesRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	result, err := h.templateService.ApplyToSchool(withActor(c), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
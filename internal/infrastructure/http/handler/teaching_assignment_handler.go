@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/service"
+	"github.com/EduGoGroup/edugo-shared/logger"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+)
+
+type TeachingAssignmentHandler struct {
+	assignmentService service.TeachingAssignmentService
+	logger            logger.Logger
+}
+
+func NewTeachingAssignmentHandler(assignmentService service.TeachingAssignmentService, logger logger.Logger) *TeachingAssignmentHandler {
+	return &TeachingAssignmentHandler{assignmentService: assignmentService, logger: logger}
+}
+
+// CreateTeachingAssignment godoc
+// @Summary Assign a teacher to a subject in an academic unit
+// @Description The teacher must hold an active teacher membership in the subject's school
+// @Tags teaching-assignments
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateTeachingAssignmentRequest true "Teaching assignment data"
+// @Success 201 {object} dto.TeachingAssignmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /teaching-assignments [post]
+func (h *TeachingAssignmentHandler) CreateTeachingAssignment(c *gin.Context) {
+	var req dto.CreateTeachingAssignmentRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	assignment, err := h.assignmentService.CreateAssignment(withActor(c), req, contextUserID(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// GetTeachingAssignment godoc
+// @Summary Get a teaching assignment by ID
+// @Tags teaching-assignments
+// @Accept json
+// @Produce json
+// @Param id path string true "Teaching assignment ID (UUID)"
+// @Success 200 {object} dto.TeachingAssignmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /teaching-assignments/{id} [get]
+func (h *TeachingAssignmentHandler) GetTeachingAssignment(c *gin.Context) {
+	assignment, err := h.assignmentService.GetAssignment(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, assignment)
+}
+
+// UpdateTeachingAssignment godoc
+// @Summary Update a teaching assignment
+// @Description Reassigns the teacher or changes the period
+// @Tags teaching-assignments
+// @Accept json
+// @Produce json
+// @Param id path string true "Teaching assignment ID (UUID)"
+// @Param request body dto.UpdateTeachingAssignmentRequest true "Teaching assignment update data"
+// @Success 200 {object} dto.TeachingAssignmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /teaching-assignments/{id} [patch]
+func (h *TeachingAssignmentHandler) UpdateTeachingAssignment(c *gin.Context) {
+	var req dto.UpdateTeachingAssignmentRequest
+	if err := bindJSON(c, &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	assignment, err := h.assignmentService.UpdateAssignment(withActor(c), c.Param("id"), req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, assignment)
+}
+
+// DeleteTeachingAssignment godoc
+// @Summary Delete a teaching assignment
+// @Tags teaching-assignments
+// @Accept json
+// @Produce json
+// @Param id path string true "Teaching assignment ID (UUID)"
+// @Success 204 "No content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /teaching-assignments/{id} [delete]
+func (h *TeachingAssignmentHandler) DeleteTeachingAssignment(c *gin.Context) {
+	if err := h.assignmentService.DeleteAssignment(withActor(c), c.Param("id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// parsePaginationQuery reads the page and limit query parameters.
+// On invalid input it writes a 400 response and returns ok=false.
+func parsePaginationQuery(c *gin.Context) (sharedrepo.ListFilters, bool) {
+	var filters sharedrepo.ListFilters
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "limit must be a positive integer", Code: "INVALID_REQUEST"})
+			return filters, false
+		}
+		filters.Limit = limit
+	}
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page <= 0 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "page must be a positive integer", Code: "INVALID_REQUEST"})
+			return filters, false
+		}
+		filters.Page = page
+	}
+	return filters, true
+}
+
+// ListTeacherAssignments godoc
+// @Summary List active teaching assignments of a teacher
+// @Tags teaching-assignments
+// @Accept json
+// @Produce json
+// @Param teacher_id path string true "Teacher user ID (UUID)"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Number of items per page" minimum(1)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /teachers/{teacher_id}/teaching-assignments [get]
+func (h *TeachingAssignmentHandler) ListTeacherAssignments(c *gin.Context) {
+	filters, ok := parsePaginationQuery(c)
+	if !ok {
+		return
+	}
+	assignments, total, err := h.assignmentService.ListByTeacher(c.Request.Context(), c.Param("teacher_id"), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(assignments, total, filters.Page, filters.Limit))
+}
+
+// ListUnitAssignments godoc
+// @Summary List active teaching assignments of an academic unit
+// @Tags teaching-assignments
+// @Accept json
+// @Produce json
+// @Param id path string true "Academic unit ID (UUID)"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Number of items per page" minimum(1)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /units/{id}/teaching-assignments [get]
+func (h *TeachingAssignmentHandler) ListUnitAssignments(c *gin.Context) {
+	filters, ok := parsePaginationQuery(c)
+	if !ok {
+		return
+	}
+	assignments, total, err := h.assignmentService.ListByUnit(c.Request.Context(), c.Param("id"), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(assignments, total, filters.Page, filters.Limit))
+}
+
+// ListSubjectAssignments godoc
+// @Summary List active teaching assignments of a subject
+// @Tags teaching-assignments
+// @Accept json
+// @Produce json
+// @Param id path string true "Subject ID (UUID)"
+// @Param page query int false "Page number (1-based)" minimum(1)
+// @Param limit query int false "Number of items per page" minimum(1)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /subjects/{id}/teaching-assignments [get]
+func (h *TeachingAssignmentHandler) ListSubjectAssignments(c *gin.Context) {
+	filters, ok := parsePaginationQuery(c)
+	if !ok {
+		return
+	}
+	assignments, total, err := h.assignmentService.ListBySubject(c.Request.Context(), c.Param("id"), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewPaginatedResponse(assignments, total, filters.Page, filters.Limit))
+}
@@ -61,6 +61,8 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Param limit query int false "Number of items per page" minimum(1)
 // @Param search query string false "Search term (ILIKE)"
 // @Param search_fields query string false "Comma-separated fields to search"
+// @Param school_id query string false "School whose active members are listed with their custom fields (UUID)"
+// @Param cf.{key} query string false "Custom field filter, one per field key"
 // @Success 200 {object} dto.PaginatedResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -101,7 +103,7 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 		}
 	}
 
-	users, total, err := h.userService.ListUsers(c.Request.Context(), filters)
+	users, total, err := h.userService.ListUsers(c.Request.Context(), filters, c.Query("school_id"), customFieldFilters(c))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -120,6 +122,7 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID (UUID)"
+// @Param school_id query string false "School whose custom fields are returned; the user must be an active member (UUID)"
 // @Success 200 {object} dto.UserResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
@@ -133,7 +136,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "user ID is required", Code: "INVALID_REQUEST"})
 		return
 	}
-	user, err := h.userService.GetUser(c.Request.Context(), id)
+	user, err := h.userService.GetUser(c.Request.Context(), id, c.Query("school_id"))
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/EduGoGroup/edugo-shared/auth"
+)
+
+// SchoolAccessChecker reports whether a school only allows reads by its members
+type SchoolAccessChecker interface {
+	IsSchoolReadOnly(ctx context.Context, schoolID uuid.UUID) (bool, error)
+}
+
+// activeSchoolID returns the school of the caller's active context, if any.
+func activeSchoolID(c *gin.Context) (string, bool) {
+	val, exists := c.Get(ContextKeyActiveContext)
+	if !exists {
+		return "", false
+	}
+	ac, ok := val.(*auth.UserContext)
+	if !ok || ac.SchoolID == "" {
+		return "", false
+	}
+	return ac.SchoolID, true
+}
+
+// SchoolWriteGuard rejects write requests made in the context of a suspended
+// or archived school, leaving its members read-only access. Requests without
+// a school context, such as those of platform admins, pass through.
+func SchoolWriteGuard(checker SchoolAccessChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		schoolIDStr, ok := activeSchoolID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+		schoolID, err := uuid.Parse(schoolIDStr)
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "invalid school context",
+				"code":  "INVALID_SCHOOL_CONTEXT",
+			})
+			c.Abort()
+			return
+		}
+
+		readOnly, err := checker.IsSchoolReadOnly(c.Request.Context(), schoolID)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		if readOnly {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "school is suspended or archived, access is read-only",
+				"code":  "SCHOOL_READ_ONLY",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePlatformScope only lets through callers whose active context is not
+// bound to a school, that is platform admins.
+func RequirePlatformScope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPlatformScope(c) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": "platform scope required",
+				"code":  "PLATFORM_SCOPE_REQUIRED",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasPlatformScope reports whether the caller's active context is not bound
+// to a school, for handlers that only restrict some options to platform admins.
+func HasPlatformScope(c *gin.Context) bool {
+	_, ok := activeSchoolID(c)
+	return !ok
+}
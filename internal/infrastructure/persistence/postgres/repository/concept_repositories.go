@@ -5,10 +5,12 @@ import (
 	"errors"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ==================== ConceptType ====================
@@ -60,6 +62,77 @@ func (r *postgresConceptTypeRepository) SoftDelete(ctx context.Context, id uuid.
 		Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
 }
 
+func (r *postgresConceptTypeRepository) FindSchools(ctx context.Context, id uuid.UUID) ([]*entities.School, error) {
+	var schools []*entities.School
+	err := r.db.WithContext(ctx).Where("concept_type_id = ?", id).Order("name").Find(&schools).Error
+	return schools, err
+}
+
+func (r *postgresConceptTypeRepository) Import(ctx context.Context, changes *repository.ConceptPackChanges) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if changes.NewType {
+			if err := tx.Create(changes.Type).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Save(changes.Type).Error; err != nil {
+			return err
+		}
+		if len(changes.Created) > 0 {
+			if err := tx.Create(&changes.Created).Error; err != nil {
+				return err
+			}
+		}
+		for _, def := range changes.Updated {
+			if err := tx.Save(def).Error; err != nil {
+				return err
+			}
+		}
+		for definitionID, translations := range changes.Translations {
+			if err := tx.Delete(&entity.ConceptDefinitionTranslation{}, "definition_id = ?", definitionID).Error; err != nil {
+				return err
+			}
+			if len(translations) == 0 {
+				continue
+			}
+			if err := tx.Create(&translations).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresConceptTypeRepository) ReassignAndDelete(ctx context.Context, id uuid.UUID, targetID *uuid.UUID, plan func(schools []*entities.School) ([]*repository.SchoolConceptMigration, error)) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock both types in ID order so concurrent deletions cannot deadlock
+		locked := []uuid.UUID{id}
+		if targetID != nil && *targetID != id {
+			locked = append(locked, *targetID)
+		}
+		var types []*entities.ConceptType
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", locked).Order("id").Find(&types).Error; err != nil {
+			return err
+		}
+		var schools []*entities.School
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("concept_type_id = ?", id).Order("name").Find(&schools).Error; err != nil {
+			return err
+		}
+		migrations, err := plan(schools)
+		if err != nil {
+			return err
+		}
+		if targetID != nil {
+			for _, m := range migrations {
+				if err := replaceSchoolConcepts(tx, m.SchoolID, *targetID, m.Concepts, m.Sources, true); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Model(&entities.ConceptType{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
+	})
+}
+
 // ==================== ConceptDefinition ====================
 
 type postgresConceptDefinitionRepository struct{ db *gorm.DB }
@@ -85,16 +158,163 @@ func (r *postgresConceptDefinitionRepository) FindByTypeID(ctx context.Context,
 	return defs, err
 }
 
-func (r *postgresConceptDefinitionRepository) Create(ctx context.Context, def *entities.ConceptDefinition) error {
-	return r.db.WithContext(ctx).Create(def).Error
+func (r *postgresConceptDefinitionRepository) FindByTermKey(ctx context.Context, typeID uuid.UUID, termKey string) (*entities.ConceptDefinition, error) {
+	var def entities.ConceptDefinition
+	if err := r.db.WithContext(ctx).Where("concept_type_id = ? AND term_key = ?", typeID, termKey).First(&def).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &def, nil
 }
 
-func (r *postgresConceptDefinitionRepository) Update(ctx context.Context, def *entities.ConceptDefinition) error {
-	return r.db.WithContext(ctx).Save(def).Error
+func (r *postgresConceptDefinitionRepository) Create(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(def).Error; err != nil {
+			return err
+		}
+		if len(translations) == 0 {
+			return nil
+		}
+		return tx.Create(&translations).Error
+	})
+}
+
+func (r *postgresConceptDefinitionRepository) Update(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(def).Error; err != nil {
+			return err
+		}
+		if translations == nil {
+			return nil
+		}
+		if err := tx.Delete(&entity.ConceptDefinitionTranslation{}, "definition_id = ?", def.ID).Error; err != nil {
+			return err
+		}
+		if len(translations) == 0 {
+			return nil
+		}
+		return tx.Create(&translations).Error
+	})
 }
 
 func (r *postgresConceptDefinitionRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.ConceptDefinition{}, "id = ?", id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.ConceptDefinitionTranslation{}, "definition_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.ConceptDefinition{}, "id = ?", id).Error
+	})
+}
+
+func (r *postgresConceptDefinitionRepository) FindTranslations(ctx context.Context, definitionIDs []uuid.UUID) ([]*entity.ConceptDefinitionTranslation, error) {
+	var translations []*entity.ConceptDefinitionTranslation
+	if len(definitionIDs) == 0 {
+		return translations, nil
+	}
+	err := r.db.WithContext(ctx).Where("definition_id IN ?", definitionIDs).Order("locale").Find(&translations).Error
+	return translations, err
+}
+
+func (r *postgresConceptDefinitionRepository) FindVersions(ctx context.Context, typeID uuid.UUID) ([]*entity.ConceptTypeVersion, error) {
+	var versions []*entity.ConceptTypeVersion
+	err := r.db.WithContext(ctx).Where("concept_type_id = ?", typeID).Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+func (r *postgresConceptDefinitionRepository) FindVersion(ctx context.Context, typeID uuid.UUID, version int) (*entity.ConceptTypeVersion, error) {
+	var v entity.ConceptTypeVersion
+	if err := r.db.WithContext(ctx).Where("concept_type_id = ? AND version = ?", typeID, version).First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *postgresConceptDefinitionRepository) FindLatestVersion(ctx context.Context, typeID uuid.UUID) (*entity.ConceptTypeVersion, error) {
+	var v entity.ConceptTypeVersion
+	if err := r.db.WithContext(ctx).Where("concept_type_id = ?", typeID).Order("version DESC").First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *postgresConceptDefinitionRepository) FindVersionDefinitions(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionDefinition, error) {
+	var defs []*entity.ConceptVersionDefinition
+	err := r.db.WithContext(ctx).Where("version_id = ?", versionID).Order("sort_order, term_key").Find(&defs).Error
+	return defs, err
+}
+
+func (r *postgresConceptDefinitionRepository) FindVersionTranslations(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionTranslation, error) {
+	var translations []*entity.ConceptVersionTranslation
+	err := r.db.WithContext(ctx).Where("version_id = ?", versionID).Order("locale").Find(&translations).Error
+	return translations, err
+}
+
+func (r *postgresConceptDefinitionRepository) Publish(ctx context.Context, version *entity.ConceptTypeVersion, defs []*entity.ConceptVersionDefinition, translations []*entity.ConceptVersionTranslation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+		if len(defs) > 0 {
+			if err := tx.Create(&defs).Error; err != nil {
+				return err
+			}
+		}
+		if len(translations) > 0 {
+			return tx.Create(&translations).Error
+		}
+		return nil
+	})
+}
+
+// ==================== ConceptCategory ====================
+
+type postgresConceptCategoryRepository struct{ db *gorm.DB }
+
+func NewPostgresConceptCategoryRepository(db *gorm.DB) repository.ConceptCategoryRepository {
+	return &postgresConceptCategoryRepository{db: db}
+}
+
+func (r *postgresConceptCategoryRepository) FindAll(ctx context.Context) ([]*entity.ConceptCategory, error) {
+	var categories []*entity.ConceptCategory
+	err := r.db.WithContext(ctx).Order("sort_order, code").Find(&categories).Error
+	return categories, err
+}
+
+func (r *postgresConceptCategoryRepository) FindByCode(ctx context.Context, code string) (*entity.ConceptCategory, error) {
+	var category entity.ConceptCategory
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *postgresConceptCategoryRepository) Create(ctx context.Context, category *entity.ConceptCategory) error {
+	return r.db.WithContext(ctx).Create(category).Error
+}
+
+func (r *postgresConceptCategoryRepository) Update(ctx context.Context, category *entity.ConceptCategory) error {
+	return r.db.WithContext(ctx).Save(category).Error
+}
+
+func (r *postgresConceptCategoryRepository) Delete(ctx context.Context, code string) error {
+	return r.db.WithContext(ctx).Where("code = ?", code).Delete(&entity.ConceptCategory{}).Error
+}
+
+func (r *postgresConceptCategoryRepository) CountDefinitions(ctx context.Context, code string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.ConceptDefinition{}).Where("category = ?", code).Count(&count).Error
+	return count, err
 }
 
 // ==================== SchoolConcept ====================
@@ -132,3 +352,115 @@ func (r *postgresSchoolConceptRepository) FindByID(ctx context.Context, id uuid.
 	}
 	return &sc, nil
 }
+
+func (r *postgresSchoolConceptRepository) FindSourcesBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptSource, error) {
+	var sources []*entity.SchoolConceptSource
+	err := r.db.WithContext(ctx).Where("school_id = ?", schoolID).Find(&sources).Error
+	return sources, err
+}
+
+func (r *postgresSchoolConceptRepository) FindSourceByConceptID(ctx context.Context, conceptID uuid.UUID) (*entity.SchoolConceptSource, error) {
+	var source entity.SchoolConceptSource
+	if err := r.db.WithContext(ctx).First(&source, "school_concept_id = ?", conceptID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &source, nil
+}
+
+func (r *postgresSchoolConceptRepository) SaveWithSources(ctx context.Context, created, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(created) > 0 {
+			if err := tx.Create(&created).Error; err != nil {
+				return err
+			}
+		}
+		for _, concept := range updated {
+			if err := tx.Save(concept).Error; err != nil {
+				return err
+			}
+		}
+		for _, source := range sources {
+			if err := tx.Save(source).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresSchoolConceptRepository) ReplaceForSchool(ctx context.Context, schoolID, conceptTypeID uuid.UUID, concepts []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, keepTranslations bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceSchoolConcepts(tx, schoolID, conceptTypeID, concepts, sources, keepTranslations)
+	})
+}
+
+// replaceSchoolConcepts swaps all terms and source records of a school and
+// points it to conceptTypeID within an open transaction. Terms that keep their
+// ID are updated in place: deleting them would cascade to their translations.
+func replaceSchoolConcepts(tx *gorm.DB, schoolID, conceptTypeID uuid.UUID, concepts []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, keepTranslations bool) error {
+	ids := make([]uuid.UUID, len(concepts))
+	for i, concept := range concepts {
+		ids[i] = concept.ID
+	}
+	translations := tx.Where("school_id = ?", schoolID)
+	if keepTranslations && len(ids) > 0 {
+		translations = translations.Where("school_concept_id NOT IN ?", ids)
+	}
+	if err := translations.Delete(&entity.SchoolConceptTranslation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("school_id = ?", schoolID).Delete(&entity.SchoolConceptSource{}).Error; err != nil {
+		return err
+	}
+	dropped := tx.Where("school_id = ?", schoolID)
+	if len(ids) > 0 {
+		dropped = dropped.Where("id NOT IN ?", ids)
+	}
+	if err := dropped.Delete(&entities.SchoolConcept{}).Error; err != nil {
+		return err
+	}
+	if len(concepts) > 0 {
+		if err := tx.Save(&concepts).Error; err != nil {
+			return err
+		}
+	}
+	if len(sources) > 0 {
+		if err := tx.Create(&sources).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&entities.School{}).Where("id = ?", schoolID).
+		Updates(map[string]interface{}{"concept_type_id": conceptTypeID, "updated_at": time.Now()}).Error
+}
+
+func (r *postgresSchoolConceptRepository) FindTranslationsBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptTranslation, error) {
+	var translations []*entity.SchoolConceptTranslation
+	err := r.db.WithContext(ctx).Where("school_id = ?", schoolID).Find(&translations).Error
+	return translations, err
+}
+
+func (r *postgresSchoolConceptRepository) SaveTranslation(ctx context.Context, translation *entity.SchoolConceptTranslation) error {
+	return r.db.WithContext(ctx).Save(translation).Error
+}
+
+func (r *postgresSchoolConceptRepository) ResetWithSources(ctx context.Context, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, conceptIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, concept := range updated {
+			if err := tx.Save(concept).Error; err != nil {
+				return err
+			}
+		}
+		for _, source := range sources {
+			if err := tx.Save(source).Error; err != nil {
+				return err
+			}
+		}
+		if len(conceptIDs) == 0 {
+			return nil
+		}
+		return tx.Delete(&entity.SchoolConceptTranslation{}, "school_concept_id IN ?", conceptIDs).Error
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresCurriculumRepository struct{ db *gorm.DB }
+
+func NewPostgresCurriculumRepository(db *gorm.DB) repository.CurriculumRepository {
+	return &postgresCurriculumRepository{db: db}
+}
+
+func (r *postgresCurriculumRepository) AddPrerequisite(ctx context.Context, prerequisite *entity.SubjectPrerequisite) error {
+	return r.db.WithContext(ctx).Create(prerequisite).Error
+}
+
+func (r *postgresCurriculumRepository) RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("subject_id = ? AND prerequisite_id = ?", subjectID, prerequisiteID).
+		Delete(&entity.SubjectPrerequisite{}).Error
+}
+
+func (r *postgresCurriculumRepository) FindPrerequisites(ctx context.Context, subjectID uuid.UUID) ([]*entity.SubjectPrerequisite, error) {
+	var prerequisites []*entity.SubjectPrerequisite
+	err := r.db.WithContext(ctx).Where("subject_id = ?", subjectID).Order("created_at").Find(&prerequisites).Error
+	return prerequisites, err
+}
+
+func (r *postgresCurriculumRepository) FindPrerequisitesBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPrerequisite, error) {
+	var prerequisites []*entity.SubjectPrerequisite
+	err := r.db.WithContext(ctx).Where("school_id = ?", schoolID).Find(&prerequisites).Error
+	return prerequisites, err
+}
+
+func (r *postgresCurriculumRepository) SavePlacement(ctx context.Context, placement *entity.SubjectPlacement) error {
+	return r.db.WithContext(ctx).Save(placement).Error
+}
+
+func (r *postgresCurriculumRepository) FindPlacement(ctx context.Context, subjectID uuid.UUID) (*entity.SubjectPlacement, error) {
+	var placement entity.SubjectPlacement
+	if err := r.db.WithContext(ctx).First(&placement, "subject_id = ?", subjectID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &placement, nil
+}
+
+func (r *postgresCurriculumRepository) DeletePlacement(ctx context.Context, subjectID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("subject_id = ?", subjectID).Delete(&entity.SubjectPlacement{}).Error
+}
+
+func (r *postgresCurriculumRepository) FindPlacementsBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPlacement, error) {
+	var placements []*entity.SubjectPlacement
+	err := r.db.WithContext(ctx).Where("school_id = ?", schoolID).Find(&placements).Error
+	return placements, err
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresCustomFieldRepository struct{ db *gorm.DB }
+
+func NewPostgresCustomFieldRepository(db *gorm.DB) repository.CustomFieldRepository {
+	return &postgresCustomFieldRepository{db: db}
+}
+
+func (r *postgresCustomFieldRepository) ListDefinitions(ctx context.Context, schoolID uuid.UUID, entityType string) ([]*entity.CustomFieldDefinition, error) {
+	query := r.db.WithContext(ctx).Where("school_id = ?", schoolID)
+	if entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	var defs []*entity.CustomFieldDefinition
+	err := query.Order("entity_type, sort_order, key").Find(&defs).Error
+	return defs, err
+}
+
+func (r *postgresCustomFieldRepository) FindDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.CustomFieldDefinition, error) {
+	var def entity.CustomFieldDefinition
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&def).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &def, nil
+}
+
+func (r *postgresCustomFieldRepository) ExistsDefinitionKey(ctx context.Context, schoolID uuid.UUID, entityType, key string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.CustomFieldDefinition{}).
+		Where("school_id = ? AND entity_type = ? AND key = ?", schoolID, entityType, key).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *postgresCustomFieldRepository) CreateDefinition(ctx context.Context, def *entity.CustomFieldDefinition) error {
+	return r.db.WithContext(ctx).Create(def).Error
+}
+
+func (r *postgresCustomFieldRepository) UpdateDefinition(ctx context.Context, def *entity.CustomFieldDefinition) error {
+	return r.db.WithContext(ctx).Save(def).Error
+}
+
+func (r *postgresCustomFieldRepository) DeleteDefinition(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.CustomFieldValue{}, "definition_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.CustomFieldDefinition{}, "id = ?", id).Error
+	})
+}
+
+func (r *postgresCustomFieldRepository) CountValues(ctx context.Context, definitionID uuid.UUID, values []string) (int64, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.CustomFieldValue{}).
+		Where("definition_id = ? AND value IN ?", definitionID, values).Count(&count).Error
+	return count, err
+}
+
+func (r *postgresCustomFieldRepository) CountMissingValues(ctx context.Context, def *entity.CustomFieldDefinition) (int64, error) {
+	db := r.db.WithContext(ctx)
+	var query *gorm.DB
+	switch def.EntityType {
+	case entity.CustomFieldEntityUser:
+		members := db.Session(&gorm.Session{NewDB: true}).Model(&entities.Membership{}).Select("user_id").
+			Where("school_id = ? AND is_active = true", def.SchoolID)
+		query = db.Model(&entities.User{}).Where("users.id IN (?)", members)
+	case entity.CustomFieldEntityAcademicUnit:
+		query = db.Model(&entities.AcademicUnit{}).Where("school_id = ?", def.SchoolID)
+	case entity.CustomFieldEntitySubject:
+		query = db.Model(&entities.Subject{}).Where("school_id = ? AND is_active = true", def.SchoolID)
+	default:
+		return 0, nil
+	}
+	withValue := db.Session(&gorm.Session{NewDB: true}).Model(&entity.CustomFieldValue{}).Select("entity_id").
+		Where("definition_id = ?", def.ID)
+	var count int64
+	err := query.Where("id NOT IN (?)", withValue).Count(&count).Error
+	return count, err
+}
+
+func (r *postgresCustomFieldRepository) FindValues(ctx context.Context, schoolID uuid.UUID, entityType string, entityIDs []uuid.UUID) ([]*entity.CustomFieldValue, error) {
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+	var values []*entity.CustomFieldValue
+	err := r.db.WithContext(ctx).
+		Where("school_id = ? AND entity_type = ? AND entity_id IN ?", schoolID, entityType, entityIDs).
+		Find(&values).Error
+	return values, err
+}
+
+func (r *postgresCustomFieldRepository) CreateWithValues(ctx context.Context, record interface{}, values []*entity.CustomFieldValue) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		return saveCustomFieldValues(tx, uuid.Nil, values, nil)
+	})
+}
+
+func (r *postgresCustomFieldRepository) UpdateWithValues(ctx context.Context, record interface{}, entityID uuid.UUID, values []*entity.CustomFieldValue, cleared []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(record).Error; err != nil {
+			return err
+		}
+		return saveCustomFieldValues(tx, entityID, values, cleared)
+	})
+}
+
+// saveCustomFieldValues upserts values and removes the entity's values of the
+// cleared definitions inside tx
+func saveCustomFieldValues(tx *gorm.DB, entityID uuid.UUID, values []*entity.CustomFieldValue, cleared []uuid.UUID) error {
+	if len(cleared) > 0 {
+		if err := tx.Delete(&entity.CustomFieldValue{}, "entity_id = ? AND definition_id IN ?", entityID, cleared).Error; err != nil {
+			return err
+		}
+	}
+	for _, value := range values {
+		if err := tx.Save(value).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyCustomFieldFilters keeps the rows of table whose custom field values
+// match every filter
+func applyCustomFieldFilters(query *gorm.DB, table string, fieldFilters []repository.CustomFieldFilter) *gorm.DB {
+	for _, filter := range fieldFilters {
+		query = query.Where(table+".id IN (?)",
+			query.Session(&gorm.Session{NewDB: true}).Model(&entity.CustomFieldValue{}).Select("entity_id").
+				Where("definition_id = ? AND value = ?", filter.DefinitionID, filter.Value))
+	}
+	return query
+}
+
+func (r *postgresCustomFieldRepository) ListUsers(ctx context.Context, filters sharedrepo.ListFilters, schoolID uuid.UUID, fieldFilters []repository.CustomFieldFilter) ([]*entities.User, int64, error) {
+	members := r.db.WithContext(ctx).Model(&entities.Membership{}).Select("user_id").
+		Where("school_id = ? AND is_active = true", schoolID)
+	baseQuery := r.db.WithContext(ctx).Model(&entities.User{}).Where("users.id IN (?)", members)
+	if filters.IsActive != nil {
+		baseQuery = baseQuery.Where("is_active = ?", *filters.IsActive)
+	}
+	baseQuery = applyCustomFieldFilters(baseQuery, "users", fieldFilters)
+	baseQuery = filters.ApplySearch(baseQuery)
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := baseQuery.Order("created_at DESC")
+	query = filters.ApplyPagination(query)
+	var users []*entities.User
+	if err := query.Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresGuardianInvitationRepository struct{ db *gorm.DB }
+
+func NewPostgresGuardianInvitationRepository(db *gorm.DB) repository.GuardianInvitationRepository {
+	return &postgresGuardianInvitationRepository{db: db}
+}
+
+func (r *postgresGuardianInvitationRepository) CreateInvitation(ctx context.Context, invitation *entity.GuardianInvitation) error {
+	return r.db.WithContext(ctx).Create(invitation).Error
+}
+
+func (r *postgresGuardianInvitationRepository) FindInvitationByID(ctx context.Context, id uuid.UUID) (*entity.GuardianInvitation, error) {
+	var invitation entity.GuardianInvitation
+	if err := r.db.WithContext(ctx).First(&invitation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *postgresGuardianInvitationRepository) FindInvitationByCode(ctx context.Context, code string) (*entity.GuardianInvitation, error) {
+	var invitation entity.GuardianInvitation
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *postgresGuardianInvitationRepository) FindInvitationsByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.GuardianInvitation, error) {
+	var invitations []*entity.GuardianInvitation
+	err := r.db.WithContext(ctx).Where("student_id = ?", studentID).Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *postgresGuardianInvitationRepository) UpdateInvitation(ctx context.Context, invitation *entity.GuardianInvitation) error {
+	return r.db.WithContext(ctx).Save(invitation).Error
+}
+
+func (r *postgresGuardianInvitationRepository) Redeem(ctx context.Context, invitationID uuid.UUID, request *entity.GuardianLinkRequest) (bool, error) {
+	redeemed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The guard in the WHERE clause keeps concurrent redemptions from exceeding max_uses
+		result := tx.Model(&entity.GuardianInvitation{}).
+			Where("id = ? AND uses_count < max_uses AND revoked_at IS NULL AND expires_at > ?", invitationID, time.Now()).
+			Updates(map[string]interface{}{"uses_count": gorm.Expr("uses_count + 1"), "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(request).Error; err != nil {
+			return err
+		}
+		redeemed = true
+		return nil
+	})
+	return redeemed, err
+}
+
+func (r *postgresGuardianInvitationRepository) FindLinkRequestByID(ctx context.Context, id uuid.UUID) (*entity.GuardianLinkRequest, error) {
+	var request entity.GuardianLinkRequest
+	if err := r.db.WithContext(ctx).First(&request, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *postgresGuardianInvitationRepository) FindLinkRequests(ctx context.Context, schoolID *uuid.UUID, status string) ([]*entity.GuardianLinkRequest, error) {
+	var requests []*entity.GuardianLinkRequest
+	query := r.db.WithContext(ctx)
+	if schoolID != nil {
+		query = query.Where("school_id = ?", *schoolID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at").Find(&requests).Error
+	return requests, err
+}
+
+func (r *postgresGuardianInvitationRepository) UpdateLinkRequest(ctx context.Context, request *entity.GuardianLinkRequest) error {
+	return r.db.WithContext(ctx).Save(request).Error
+}
+
+func (r *postgresGuardianInvitationRepository) ApproveLinkRequest(ctx context.Context, request *entity.GuardianLinkRequest, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(relation).Error; err != nil {
+			return err
+		}
+		if err := saveGuardianDetail(tx, relation.StudentID, detail); err != nil {
+			return err
+		}
+		return tx.Save(request).Error
+	})
+}
+
+func (r *postgresGuardianInvitationRepository) ExistsPendingLinkRequest(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.GuardianLinkRequest{}).
+		Where("guardian_id = ? AND student_id = ? AND status = ?", guardianID, studentID, entity.LinkRequestStatusPending).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresHouseholdRepository struct{ db *gorm.DB }
+
+func NewPostgresHouseholdRepository(db *gorm.DB) repository.HouseholdRepository {
+	return &postgresHouseholdRepository{db: db}
+}
+
+func (r *postgresHouseholdRepository) Create(ctx context.Context, household *entity.Household, members []*entity.HouseholdMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(household).Error; err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		return tx.Create(&members).Error
+	})
+}
+
+func (r *postgresHouseholdRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Household, error) {
+	var household entity.Household
+	if err := r.db.WithContext(ctx).First(&household, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &household, nil
+}
+
+func (r *postgresHouseholdRepository) FindMembers(ctx context.Context, householdID uuid.UUID) ([]*entity.HouseholdMember, error) {
+	var members []*entity.HouseholdMember
+	err := r.db.WithContext(ctx).Where("household_id = ?", householdID).Order("created_at").Find(&members).Error
+	return members, err
+}
+
+func (r *postgresHouseholdRepository) FindMemberByUser(ctx context.Context, userID uuid.UUID) (*entity.HouseholdMember, error) {
+	var member entity.HouseholdMember
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *postgresHouseholdRepository) AddMember(ctx context.Context, member *entity.HouseholdMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+func (r *postgresHouseholdRepository) RemoveMember(ctx context.Context, householdID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("household_id = ? AND user_id = ?", householdID, userID).
+		Delete(&entity.HouseholdMember{}).Error
+}
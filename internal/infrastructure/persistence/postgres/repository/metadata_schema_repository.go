@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresMetadataSchemaRepository struct{ db *gorm.DB }
+
+func NewPostgresMetadataSchemaRepository(db *gorm.DB) repository.MetadataSchemaRepository {
+	return &postgresMetadataSchemaRepository{db: db}
+}
+
+func (r *postgresMetadataSchemaRepository) Find(ctx context.Context, entityType string, conceptTypeID *uuid.UUID) (*entity.MetadataSchema, error) {
+	query := r.db.WithContext(ctx).Where("entity_type = ?", entityType)
+	if conceptTypeID != nil {
+		query = query.Where("concept_type_id = ?", *conceptTypeID)
+	} else {
+		query = query.Where("concept_type_id IS NULL")
+	}
+	var schema entity.MetadataSchema
+	if err := query.First(&schema).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func (r *postgresMetadataSchemaRepository) Save(ctx context.Context, schema *entity.MetadataSchema) error {
+	return r.db.WithContext(ctx).Save(schema).Error
+}
+
+func (r *postgresMetadataSchemaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entity.MetadataSchema{}, "id = ?", id).Error
+}
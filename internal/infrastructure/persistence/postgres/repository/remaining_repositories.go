@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
@@ -39,12 +40,13 @@ func (r *postgresAcademicUnitRepository) FindByID(ctx context.Context, id uuid.U
 	return &u, nil
 }
 
-func (r *postgresAcademicUnitRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error) {
+func (r *postgresAcademicUnitRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error) {
 	baseQuery := r.db.WithContext(ctx).Model(&entities.AcademicUnit{})
 	if includeDeleted {
 		baseQuery = baseQuery.Unscoped()
 	}
 	baseQuery = baseQuery.Where("school_id = ?", schoolID)
+	baseQuery = applyCustomFieldFilters(baseQuery, "academic_units", fieldFilters)
 	baseQuery = filters.ApplySearch(baseQuery)
 
 	var total int64
@@ -61,12 +63,13 @@ func (r *postgresAcademicUnitRepository) FindBySchoolID(ctx context.Context, sch
 	return units, int(total), nil
 }
 
-func (r *postgresAcademicUnitRepository) FindByType(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error) {
+func (r *postgresAcademicUnitRepository) FindByType(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error) {
 	baseQuery := r.db.WithContext(ctx).Model(&entities.AcademicUnit{})
 	if includeDeleted {
 		baseQuery = baseQuery.Unscoped()
 	}
 	baseQuery = baseQuery.Where("school_id = ? AND type = ?", schoolID, unitType)
+	baseQuery = applyCustomFieldFilters(baseQuery, "academic_units", fieldFilters)
 	baseQuery = filters.ApplySearch(baseQuery)
 
 	var total int64
@@ -136,9 +139,22 @@ func (r *postgresSubjectRepository) Create(ctx context.Context, s *entities.Subj
 	return r.db.WithContext(ctx).Create(s).Error
 }
 
-func (r *postgresSubjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Subject, error) {
+func (r *postgresSubjectRepository) BulkCreate(ctx context.Context, subjects []*entities.Subject) error {
+	if len(subjects) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&subjects).Error
+	})
+}
+
+func (r *postgresSubjectRepository) FindByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*entities.Subject, error) {
 	var s entities.Subject
-	if err := r.db.WithContext(ctx).Where("is_active = true").First(&s, "id = ?", id).Error; err != nil {
+	query := r.db.WithContext(ctx)
+	if !includeDeleted {
+		query = query.Where("is_active = true")
+	}
+	if err := query.First(&s, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -147,8 +163,12 @@ func (r *postgresSubjectRepository) FindByID(ctx context.Context, id uuid.UUID)
 	return &s, nil
 }
 
-func (r *postgresSubjectRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, filters sharedrepo.ListFilters) ([]*entities.Subject, int, error) {
-	baseQuery := r.db.WithContext(ctx).Model(&entities.Subject{}).Where("school_id = ? AND is_active = true", schoolID)
+func (r *postgresSubjectRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...repository.CustomFieldFilter) ([]*entities.Subject, int, error) {
+	baseQuery := r.db.WithContext(ctx).Model(&entities.Subject{}).Where("school_id = ?", schoolID)
+	if !includeDeleted {
+		baseQuery = baseQuery.Where("is_active = true")
+	}
+	baseQuery = applyCustomFieldFilters(baseQuery, "subjects", fieldFilters)
 	baseQuery = filters.ApplySearch(baseQuery)
 
 	var total int64
@@ -174,6 +194,11 @@ func (r *postgresSubjectRepository) Delete(ctx context.Context, id uuid.UUID) er
 		Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
 }
 
+func (r *postgresSubjectRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entities.Subject{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_active": true, "updated_at": time.Now()}).Error
+}
+
 func (r *postgresSubjectRepository) List(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.Subject, error) {
 	query := r.db.WithContext(ctx).Where("is_active = true")
 	query = filters.ApplySearch(query)
@@ -195,6 +220,12 @@ func (r *postgresSubjectRepository) ExistsBySchoolIDAndName(ctx context.Context,
 	return count > 0, err
 }
 
+func (r *postgresSubjectRepository) ExistsBySchoolIDAndCode(ctx context.Context, schoolID uuid.UUID, code string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.Subject{}).Where("school_id = ? AND code = ? AND is_active = true", schoolID, code).Count(&count).Error
+	return count > 0, err
+}
+
 // ==================== Guardian ====================
 
 type postgresGuardianRepository struct{ db *gorm.DB }
@@ -245,3 +276,92 @@ func (r *postgresGuardianRepository) ExistsActiveRelation(ctx context.Context, g
 		Where("guardian_id = ? AND student_id = ? AND is_active = true", guardianID, studentID).Count(&count).Error
 	return count > 0, err
 }
+
+func (r *postgresGuardianRepository) ListByGuardian(ctx context.Context, guardianID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+	baseQuery := r.db.WithContext(ctx).Model(&entities.GuardianRelation{}).Where("guardian_id = ?", guardianID)
+	return r.list(baseQuery, relFilters, filters)
+}
+
+func (r *postgresGuardianRepository) ListByStudent(ctx context.Context, studentID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+	baseQuery := r.db.WithContext(ctx).Model(&entities.GuardianRelation{}).Where("student_id = ?", studentID)
+	return r.list(baseQuery, relFilters, filters)
+}
+
+func (r *postgresGuardianRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+	students := r.db.WithContext(ctx).Model(&entities.Membership{}).Select("user_id").
+		Where("school_id = ? AND role = ?", schoolID, "student")
+	baseQuery := r.db.WithContext(ctx).Model(&entities.GuardianRelation{}).Where("student_id IN (?)", students)
+	return r.list(baseQuery, relFilters, filters)
+}
+
+// list applies relation filters, search and pagination to a guardian relation query
+func (r *postgresGuardianRepository) list(baseQuery *gorm.DB, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+	if !relFilters.IncludeInactive {
+		baseQuery = baseQuery.Where("is_active = true")
+	}
+	if relFilters.RelationshipType != "" {
+		baseQuery = baseQuery.Where("relationship_type = ?", relFilters.RelationshipType)
+	}
+	baseQuery = filters.ApplySearch(baseQuery)
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := baseQuery.Order("created_at")
+	query = filters.ApplyPagination(query)
+	var relations []*entities.GuardianRelation
+	if err := query.Find(&relations).Error; err != nil {
+		return nil, 0, err
+	}
+	return relations, int(total), nil
+}
+
+func (r *postgresGuardianRepository) FindDetailsByRelationIDs(ctx context.Context, relationIDs []uuid.UUID) ([]*entity.GuardianRelationDetail, error) {
+	if len(relationIDs) == 0 {
+		return []*entity.GuardianRelationDetail{}, nil
+	}
+	var details []*entity.GuardianRelationDetail
+	err := r.db.WithContext(ctx).Where("guardian_relation_id IN ?", relationIDs).Find(&details).Error
+	return details, err
+}
+
+func (r *postgresGuardianRepository) SaveDetail(ctx context.Context, studentID uuid.UUID, detail *entity.GuardianRelationDetail) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveGuardianDetail(tx, studentID, detail)
+	})
+}
+
+func (r *postgresGuardianRepository) CreateWithDetail(ctx context.Context, g *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(g).Error; err != nil {
+			return err
+		}
+		return saveGuardianDetail(tx, g.StudentID, detail)
+	})
+}
+
+func (r *postgresGuardianRepository) UpdateWithDetail(ctx context.Context, g *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(g).Error; err != nil {
+			return err
+		}
+		return saveGuardianDetail(tx, g.StudentID, detail)
+	})
+}
+
+// saveGuardianDetail upserts a relation detail inside tx, clearing the primary
+// contact flag from the student's other relations when the detail sets it
+func saveGuardianDetail(tx *gorm.DB, studentID uuid.UUID, detail *entity.GuardianRelationDetail) error {
+	if detail.IsPrimaryContact {
+		err := tx.Model(&entity.GuardianRelationDetail{}).
+			Where("guardian_relation_id <> ? AND guardian_relation_id IN (?)", detail.GuardianRelationID,
+				tx.Model(&entities.GuardianRelation{}).Select("id").Where("student_id = ?", studentID)).
+			Updates(map[string]interface{}{"is_primary_contact": false, "updated_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Save(detail).Error
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"gorm.io/gorm"
+)
+
+type postgresSchoolBundleRepository struct{ db *gorm.DB }
+
+func NewPostgresSchoolBundleRepository(db *gorm.DB) repository.SchoolBundleRepository {
+	return &postgresSchoolBundleRepository{db: db}
+}
+
+func (r *postgresSchoolBundleRepository) Load(ctx context.Context, school *entities.School, withPeople bool) (*repository.SchoolBundleData, error) {
+	db := r.db.WithContext(ctx)
+	data := &repository.SchoolBundleData{School: school}
+	if err := db.Where("school_id = ?", school.ID).Order("term_key").Find(&data.Concepts).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("school_id = ?", school.ID).Order("created_at").Find(&data.Units).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("school_id = ?", school.ID).Order("created_at").Find(&data.Subjects).Error; err != nil {
+		return nil, err
+	}
+	if !withPeople {
+		return data, nil
+	}
+	if err := db.Where("school_id = ?", school.ID).Order("created_at").Find(&data.Memberships).Error; err != nil {
+		return nil, err
+	}
+	members := db.Model(&entities.Membership{}).Select("user_id").Where("school_id = ?", school.ID)
+	if err := db.Where("id IN (?)", members).Order("email").Find(&data.Users).Error; err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (r *postgresSchoolBundleRepository) FindUsersByEmails(ctx context.Context, emails []string) ([]*entities.User, error) {
+	if len(emails) == 0 {
+		return []*entities.User{}, nil
+	}
+	var users []*entities.User
+	err := r.db.WithContext(ctx).Where("LOWER(email) IN ?", emails).Find(&users).Error
+	return users, err
+}
+
+func (r *postgresSchoolBundleRepository) Import(ctx context.Context, data *repository.SchoolBundleData) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(data.School).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(data.Lifecycle).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(data.Transition).Error; err != nil {
+			return err
+		}
+		if len(data.Concepts) > 0 {
+			if err := tx.Create(&data.Concepts).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Sources) > 0 {
+			if err := tx.Create(&data.Sources).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Translations) > 0 {
+			if err := tx.Create(&data.Translations).Error; err != nil {
+				return err
+			}
+		}
+		// One at a time so each parent exists before its children
+		for _, unit := range data.Units {
+			if err := tx.Create(unit).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Subjects) > 0 {
+			if err := tx.Create(&data.Subjects).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Users) > 0 {
+			if err := tx.Create(&data.Users).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.Memberships) > 0 {
+			if err := tx.Create(&data.Memberships).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
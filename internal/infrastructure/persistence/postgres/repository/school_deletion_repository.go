@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresSchoolDeletionRepository struct{ db *gorm.DB }
+
+func NewPostgresSchoolDeletionRepository(db *gorm.DB) repository.SchoolDeletionRepository {
+	return &postgresSchoolDeletionRepository{db: db}
+}
+
+// scopedGuardianRelations selects the active guardian relations of the school's
+// students that have no active membership in another school
+func scopedGuardianRelations(tx *gorm.DB, schoolID uuid.UUID) *gorm.DB {
+	students := tx.Session(&gorm.Session{NewDB: true}).Model(&entities.Membership{}).Select("user_id").
+		Where("school_id = ? AND role = ? AND is_active = true", schoolID, "student")
+	elsewhere := tx.Session(&gorm.Session{NewDB: true}).Model(&entities.Membership{}).Select("user_id").
+		Where("school_id <> ? AND is_active = true", schoolID)
+	return tx.Model(&entities.GuardianRelation{}).
+		Where("is_active = true AND student_id IN (?) AND student_id NOT IN (?)", students, elsewhere)
+}
+
+// openInvitations selects the school's invitations that can still be redeemed
+func openInvitations(tx *gorm.DB, schoolID uuid.UUID, at time.Time) *gorm.DB {
+	return tx.Model(&entity.GuardianInvitation{}).
+		Where("school_id = ? AND revoked_at IS NULL AND expires_at > ? AND uses_count < max_uses", schoolID, at)
+}
+
+func (r *postgresSchoolDeletionRepository) CountDependents(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolDependentCounts, error) {
+	db := r.db.WithContext(ctx)
+	counts := &repository.SchoolDependentCounts{}
+	queries := []struct {
+		query *gorm.DB
+		into  *int64
+	}{
+		{db.Model(&entities.AcademicUnit{}).Where("school_id = ?", schoolID), &counts.AcademicUnits},
+		{db.Model(&entities.Subject{}).Where("school_id = ? AND is_active = true", schoolID), &counts.Subjects},
+		{db.Model(&entities.Membership{}).Where("school_id = ? AND is_active = true", schoolID), &counts.Memberships},
+		{scopedGuardianRelations(db, schoolID), &counts.GuardianRelations},
+		{db.Model(&entity.TeachingAssignment{}).Where("school_id = ? AND is_active = true", schoolID), &counts.TeachingAssignments},
+		{openInvitations(db, schoolID, time.Now()), &counts.GuardianInvitations},
+		{db.Model(&entity.GuardianLinkRequest{}).Where("school_id = ? AND status = ?", schoolID, entity.LinkRequestStatusPending), &counts.LinkRequests},
+		{db.Model(&entities.SchoolConcept{}).Where("school_id = ?", schoolID), &counts.SchoolConcepts},
+		{db.Model(&entity.SubjectPlacement{}).Where("school_id = ?", schoolID), &counts.SubjectPlacements},
+		{db.Model(&entity.SubjectPrerequisite{}).Where("school_id = ?", schoolID), &counts.SubjectPrerequisites},
+		{db.Model(&entity.CustomFieldDefinition{}).Where("school_id = ?", schoolID), &counts.CustomFields},
+		{db.Model(&entity.CustomFieldValue{}).Where("school_id = ?", schoolID), &counts.CustomFieldValues},
+	}
+	for _, q := range queries {
+		if err := q.query.Count(q.into).Error; err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+func (r *postgresSchoolDeletionRepository) DeleteCascade(ctx context.Context, schoolID uuid.UUID, at time.Time) error {
+	deactivate := map[string]interface{}{"is_active": false, "updated_at": at}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Guardian relations go first: they are scoped through the memberships
+		// deactivated below.
+		if err := scopedGuardianRelations(tx, schoolID).Updates(deactivate).Error; err != nil {
+			return err
+		}
+		if err := openInvitations(tx, schoolID, at).
+			Updates(map[string]interface{}{"revoked_at": at, "updated_at": at}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.GuardianLinkRequest{}).
+			Where("school_id = ? AND status = ?", schoolID, entity.LinkRequestStatusPending).
+			Updates(map[string]interface{}{"status": entity.LinkRequestStatusRejected, "reviewed_at": at, "updated_at": at}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.TeachingAssignment{}).
+			Where("school_id = ? AND is_active = true", schoolID).Updates(deactivate).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.Membership{}).
+			Where("school_id = ? AND is_active = true", schoolID).
+			Updates(map[string]interface{}{"is_active": false, "withdrawn_at": at, "updated_at": at}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.Subject{}).
+			Where("school_id = ? AND is_active = true", schoolID).Updates(deactivate).Error; err != nil {
+			return err
+		}
+		// Units and the school carry deleted_at, so these deletes are soft
+		if err := tx.Where("school_id = ?", schoolID).Delete(&entities.AcademicUnit{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.School{}, "id = ?", schoolID).Error
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresSchoolLifecycleRepository struct{ db *gorm.DB }
+
+func NewPostgresSchoolLifecycleRepository(db *gorm.DB) repository.SchoolLifecycleRepository {
+	return &postgresSchoolLifecycleRepository{db: db}
+}
+
+func (r *postgresSchoolLifecycleRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) (*entity.SchoolLifecycle, error) {
+	var lifecycle entity.SchoolLifecycle
+	if err := r.db.WithContext(ctx).First(&lifecycle, "school_id = ?", schoolID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &lifecycle, nil
+}
+
+func (r *postgresSchoolLifecycleRepository) FindBySchoolIDs(ctx context.Context, schoolIDs []uuid.UUID) ([]*entity.SchoolLifecycle, error) {
+	if len(schoolIDs) == 0 {
+		return []*entity.SchoolLifecycle{}, nil
+	}
+	var lifecycles []*entity.SchoolLifecycle
+	err := r.db.WithContext(ctx).Where("school_id IN ?", schoolIDs).Find(&lifecycles).Error
+	return lifecycles, err
+}
+
+func (r *postgresSchoolLifecycleRepository) FindTransitions(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolLifecycleTransition, error) {
+	var transitions []*entity.SchoolLifecycleTransition
+	err := r.db.WithContext(ctx).Where("school_id = ?", schoolID).Order("changed_at DESC").Find(&transitions).Error
+	return transitions, err
+}
+
+func (r *postgresSchoolLifecycleRepository) CreateSchool(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := sharedrepo.NewPostgresSchoolRepository(tx).Create(ctx, school); err != nil {
+			return err
+		}
+		if err := tx.Create(lifecycle).Error; err != nil {
+			return err
+		}
+		return tx.Create(transition).Error
+	})
+}
+
+func (r *postgresSchoolLifecycleRepository) Transition(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(school).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(lifecycle).Error; err != nil {
+			return err
+		}
+		return tx.Create(transition).Error
+	})
+}
+
+func (r *postgresSchoolLifecycleRepository) ListUnarchivedSchools(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.School, int64, error) {
+	// The session keeps the condition on every query the shared repository builds from it
+	unarchived := r.db.Where("NOT EXISTS (?)",
+		r.db.Model(&entity.SchoolLifecycle{}).Select("1").
+			Where("school_lifecycles.school_id = schools.id AND school_lifecycles.state = ?", entity.SchoolStateArchived)).
+		Session(&gorm.Session{})
+	return sharedrepo.NewPostgresSchoolRepository(unarchived).List(ctx, filters)
+}
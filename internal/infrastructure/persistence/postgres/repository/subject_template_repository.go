@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresSubjectTemplateRepository struct{ db *gorm.DB }
+
+func NewPostgresSubjectTemplateRepository(db *gorm.DB) repository.SubjectTemplateRepository {
+	return &postgresSubjectTemplateRepository{db: db}
+}
+
+func (r *postgresSubjectTemplateRepository) Create(ctx context.Context, template *entity.SubjectTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+func (r *postgresSubjectTemplateRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.SubjectTemplate, error) {
+	var template entity.SubjectTemplate
+	if err := r.db.WithContext(ctx).Where("is_active = true").First(&template, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *postgresSubjectTemplateRepository) List(ctx context.Context, filters repository.SubjectTemplateFilters) ([]*entity.SubjectTemplate, error) {
+	query := r.db.WithContext(ctx).Where("is_active = true")
+	if filters.ConceptTypeID != nil {
+		query = query.Where("concept_type_id = ?", *filters.ConceptTypeID)
+	}
+	if filters.EducationLevel != "" {
+		query = query.Where("education_level = ?", filters.EducationLevel)
+	}
+	var templates []*entity.SubjectTemplate
+	err := query.Order("sort_order, name").Find(&templates).Error
+	return templates, err
+}
+
+func (r *postgresSubjectTemplateRepository) Update(ctx context.Context, template *entity.SubjectTemplate) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
+
+func (r *postgresSubjectTemplateRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entity.SubjectTemplate{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
+}
+
+func (r *postgresSubjectTemplateRepository) ExistsInGroup(ctx context.Context, name string, conceptTypeID *uuid.UUID, educationLevel *string) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&entity.SubjectTemplate{}).Where("LOWER(name) = LOWER(?) AND is_active = true", name)
+	if conceptTypeID == nil {
+		query = query.Where("concept_type_id IS NULL")
+	} else {
+		query = query.Where("concept_type_id = ?", *conceptTypeID)
+	}
+	if educationLevel == nil {
+		query = query.Where("education_level IS NULL")
+	} else {
+		query = query.Where("education_level = ?", *educationLevel)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
+	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type postgresTeachingAssignmentRepository struct{ db *gorm.DB }
+
+func NewPostgresTeachingAssignmentRepository(db *gorm.DB) repository.TeachingAssignmentRepository {
+	return &postgresTeachingAssignmentRepository{db: db}
+}
+
+func (r *postgresTeachingAssignmentRepository) Create(ctx context.Context, assignment *entity.TeachingAssignment) error {
+	return r.db.WithContext(ctx).Create(assignment).Error
+}
+
+func (r *postgresTeachingAssignmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.TeachingAssignment, error) {
+	var assignment entity.TeachingAssignment
+	if err := r.db.WithContext(ctx).Where("is_active = true").First(&assignment, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *postgresTeachingAssignmentRepository) Update(ctx context.Context, assignment *entity.TeachingAssignment) error {
+	return r.db.WithContext(ctx).Save(assignment).Error
+}
+
+func (r *postgresTeachingAssignmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&entity.TeachingAssignment{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
+}
+
+func (r *postgresTeachingAssignmentRepository) FindByTeacher(ctx context.Context, teacherID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error) {
+	return r.list(r.db.WithContext(ctx).Model(&entity.TeachingAssignment{}).Where("teacher_id = ?", teacherID), filters)
+}
+
+func (r *postgresTeachingAssignmentRepository) FindByUnit(ctx context.Context, unitID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error) {
+	return r.list(r.db.WithContext(ctx).Model(&entity.TeachingAssignment{}).Where("academic_unit_id = ?", unitID), filters)
+}
+
+func (r *postgresTeachingAssignmentRepository) FindBySubject(ctx context.Context, subjectID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error) {
+	return r.list(r.db.WithContext(ctx).Model(&entity.TeachingAssignment{}).Where("subject_id = ?", subjectID), filters)
+}
+
+// list restricts to active assignments and applies search and pagination
+func (r *postgresTeachingAssignmentRepository) list(baseQuery *gorm.DB, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error) {
+	baseQuery = baseQuery.Where("is_active = true")
+	baseQuery = filters.ApplySearch(baseQuery)
+
+	var total int64
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := baseQuery.Order("created_at")
+	query = filters.ApplyPagination(query)
+	var assignments []*entity.TeachingAssignment
+	if err := query.Find(&assignments).Error; err != nil {
+		return nil, 0, err
+	}
+	return assignments, int(total), nil
+}
+
+func (r *postgresTeachingAssignmentRepository) ExistsActive(ctx context.Context, teacherID, subjectID, unitID uuid.UUID, period *string) (bool, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&entity.TeachingAssignment{}).
+		Where("teacher_id = ? AND subject_id = ? AND academic_unit_id = ? AND is_active = true", teacherID, subjectID, unitID)
+	if period == nil {
+		query = query.Where("period IS NULL")
+	} else {
+		query = query.Where("period = ?", *period)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
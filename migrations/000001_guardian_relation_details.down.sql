@@ -0,0 +1 @@
+DROP TABLE IF EXISTS academic.guardian_relation_details;
@@ -0,0 +1,10 @@
+-- Contact, custody and pickup attributes of a guardian relation
+CREATE TABLE IF NOT EXISTS academic.guardian_relation_details (
+    guardian_relation_id    UUID PRIMARY KEY REFERENCES academic.guardian_relations (id) ON DELETE CASCADE,
+    is_primary_contact      BOOLEAN NOT NULL DEFAULT FALSE,
+    has_legal_custody       BOOLEAN NOT NULL DEFAULT FALSE,
+    can_pickup              BOOLEAN NOT NULL DEFAULT FALSE,
+    receives_communications BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS academic.guardian_link_requests;
+DROP TABLE IF EXISTS academic.guardian_invitations;
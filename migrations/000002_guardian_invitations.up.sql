@@ -0,0 +1,41 @@
+-- Invitation codes a guardian redeems to request a link to a student
+CREATE TABLE IF NOT EXISTS academic.guardian_invitations (
+    id         UUID PRIMARY KEY,
+    student_id UUID NOT NULL,
+    school_id  UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    code       VARCHAR(32) NOT NULL,
+    max_uses   INTEGER NOT NULL,
+    uses_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guardian_invitations_code ON academic.guardian_invitations (code);
+CREATE INDEX IF NOT EXISTS idx_guardian_invitations_student_id ON academic.guardian_invitations (student_id);
+
+-- Link requests created by redeeming an invitation, pending admin review
+CREATE TABLE IF NOT EXISTS academic.guardian_link_requests (
+    id                UUID PRIMARY KEY,
+    invitation_id     UUID NOT NULL REFERENCES academic.guardian_invitations (id) ON DELETE CASCADE,
+    guardian_id       UUID NOT NULL,
+    student_id        UUID NOT NULL,
+    school_id         UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    relationship_type VARCHAR(50) NOT NULL,
+    status            VARCHAR(20) NOT NULL,
+    relation_id       UUID REFERENCES academic.guardian_relations (id) ON DELETE SET NULL,
+    reviewed_by       UUID,
+    reviewed_at       TIMESTAMPTZ,
+    review_note       TEXT,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guardian_link_requests_school_id ON academic.guardian_link_requests (school_id);
+CREATE INDEX IF NOT EXISTS idx_guardian_link_requests_status ON academic.guardian_link_requests (status);
+-- A guardian has at most one pending request per student
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guardian_link_requests_pending
+    ON academic.guardian_link_requests (guardian_id, student_id)
+    WHERE status = 'pending';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS academic.household_members;
+DROP TABLE IF EXISTS academic.households;
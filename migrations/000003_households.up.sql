@@ -0,0 +1,17 @@
+-- Households assigned by admins; members belong to at most one household
+CREATE TABLE IF NOT EXISTS academic.households (
+    id         UUID PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS academic.household_members (
+    user_id      UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES academic.households (id) ON DELETE CASCADE,
+    role         VARCHAR(20) NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_household_members_household_id ON academic.household_members (household_id);
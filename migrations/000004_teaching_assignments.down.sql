@@ -0,0 +1 @@
+DROP TABLE IF EXISTS academic.teaching_assignments;
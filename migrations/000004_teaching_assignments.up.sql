@@ -0,0 +1,21 @@
+-- Teachers assigned to teach a subject in an academic unit
+CREATE TABLE IF NOT EXISTS academic.teaching_assignments (
+    id               UUID PRIMARY KEY,
+    teacher_id       UUID NOT NULL,
+    subject_id       UUID NOT NULL REFERENCES academic.subjects (id) ON DELETE CASCADE,
+    academic_unit_id UUID NOT NULL REFERENCES academic.academic_units (id) ON DELETE CASCADE,
+    school_id        UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    period           VARCHAR(50),
+    is_active        BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by       UUID,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_teaching_assignments_teacher_id ON academic.teaching_assignments (teacher_id);
+CREATE INDEX IF NOT EXISTS idx_teaching_assignments_subject_id ON academic.teaching_assignments (subject_id);
+CREATE INDEX IF NOT EXISTS idx_teaching_assignments_academic_unit_id ON academic.teaching_assignments (academic_unit_id);
+-- One active assignment per teacher, subject, unit and period
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teaching_assignments_active
+    ON academic.teaching_assignments (teacher_id, subject_id, academic_unit_id, COALESCE(period, ''))
+    WHERE is_active;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS academic.subject_templates;
@@ -0,0 +1,24 @@
+-- Platform subject templates applied to schools
+CREATE TABLE IF NOT EXISTS academic.subject_templates (
+    id              UUID PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    code            VARCHAR(50),
+    description     TEXT,
+    concept_type_id UUID,
+    education_level VARCHAR(50),
+    sort_order      INTEGER NOT NULL DEFAULT 0,
+    is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_subject_templates_concept_type_id ON academic.subject_templates (concept_type_id);
+CREATE INDEX IF NOT EXISTS idx_subject_templates_education_level ON academic.subject_templates (education_level);
+-- Active template names are unique within their concept type and education level
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subject_templates_active_name
+    ON academic.subject_templates (
+        LOWER(name),
+        COALESCE(concept_type_id, '00000000-0000-0000-0000-000000000000'::uuid),
+        COALESCE(education_level, '')
+    )
+    WHERE is_active;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS academic.subject_placements;
+DROP TABLE IF EXISTS academic.subject_prerequisites;
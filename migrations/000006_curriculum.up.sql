@@ -0,0 +1,22 @@
+-- Subject prerequisites and placement in the curriculum grid
+CREATE TABLE IF NOT EXISTS academic.subject_prerequisites (
+    subject_id      UUID NOT NULL REFERENCES academic.subjects (id) ON DELETE CASCADE,
+    prerequisite_id UUID NOT NULL REFERENCES academic.subjects (id) ON DELETE CASCADE,
+    school_id       UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (subject_id, prerequisite_id),
+    CHECK (subject_id <> prerequisite_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subject_prerequisites_school_id ON academic.subject_prerequisites (school_id);
+
+CREATE TABLE IF NOT EXISTS academic.subject_placements (
+    subject_id    UUID PRIMARY KEY REFERENCES academic.subjects (id) ON DELETE CASCADE,
+    school_id     UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    level_unit_id UUID NOT NULL REFERENCES academic.academic_units (id) ON DELETE CASCADE,
+    position      INTEGER NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_subject_placements_school_id ON academic.subject_placements (school_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS academic.school_concept_sources;
@@ -0,0 +1,16 @@
+-- The platform definition each school concept was seeded from. definition_id
+-- has no foreign key: a source outlives the definition so reset and sync can
+-- report it as removed.
+CREATE TABLE IF NOT EXISTS academic.school_concept_sources (
+    school_concept_id UUID PRIMARY KEY REFERENCES academic.school_concepts (id) ON DELETE CASCADE,
+    school_id         UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    definition_id     UUID NOT NULL,
+    default_value     TEXT NOT NULL,
+    version           INTEGER NOT NULL DEFAULT 0,
+    overridden        BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_school_concept_sources_school_id ON academic.school_concept_sources (school_id);
+CREATE INDEX IF NOT EXISTS idx_school_concept_sources_definition_id ON academic.school_concept_sources (definition_id);
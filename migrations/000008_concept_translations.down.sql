@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS academic.school_concept_translations;
+DROP TABLE IF EXISTS academic.concept_definition_translations;
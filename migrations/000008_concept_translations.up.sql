@@ -0,0 +1,21 @@
+-- Per-locale values of concept definitions and school concepts
+CREATE TABLE IF NOT EXISTS academic.concept_definition_translations (
+    definition_id UUID NOT NULL REFERENCES academic.concept_definitions (id) ON DELETE CASCADE,
+    locale        VARCHAR(10) NOT NULL,
+    term_value    TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (definition_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS academic.school_concept_translations (
+    school_concept_id UUID NOT NULL REFERENCES academic.school_concepts (id) ON DELETE CASCADE,
+    locale            VARCHAR(10) NOT NULL,
+    school_id         UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    term_value        TEXT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (school_concept_id, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_school_concept_translations_school_id ON academic.school_concept_translations (school_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS academic.idx_concept_definitions_type_term_key;
+DROP TABLE IF EXISTS academic.concept_version_translations;
+DROP TABLE IF EXISTS academic.concept_version_definitions;
+DROP TABLE IF EXISTS academic.concept_type_versions;
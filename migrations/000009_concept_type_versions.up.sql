@@ -0,0 +1,61 @@
+-- Published snapshots of a concept type's definitions. Snapshot rows keep the
+-- definition ID without a foreign key so drafts can be deleted after publishing.
+CREATE TABLE IF NOT EXISTS academic.concept_type_versions (
+    id              UUID PRIMARY KEY,
+    concept_type_id UUID NOT NULL REFERENCES academic.concept_types (id) ON DELETE CASCADE,
+    version         INTEGER NOT NULL,
+    notes           TEXT,
+    published_by    VARCHAR(255),
+    published_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_concept_type_versions_number ON academic.concept_type_versions (concept_type_id, version);
+
+CREATE TABLE IF NOT EXISTS academic.concept_version_definitions (
+    version_id    UUID NOT NULL REFERENCES academic.concept_type_versions (id) ON DELETE CASCADE,
+    definition_id UUID NOT NULL,
+    term_key      VARCHAR(100) NOT NULL,
+    term_value    TEXT NOT NULL,
+    category      VARCHAR(50) NOT NULL,
+    sort_order    INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (version_id, definition_id)
+);
+
+CREATE TABLE IF NOT EXISTS academic.concept_version_translations (
+    version_id    UUID NOT NULL REFERENCES academic.concept_type_versions (id) ON DELETE CASCADE,
+    definition_id UUID NOT NULL,
+    locale        VARCHAR(10) NOT NULL,
+    term_value    TEXT NOT NULL,
+    PRIMARY KEY (version_id, definition_id, locale)
+);
+
+-- Term keys are unique within a concept type, so a published version holds one
+-- value per key. Keys repeated before this rule keep their oldest definition:
+-- the sources and translations of the others move to it and they are removed.
+CREATE TEMPORARY TABLE concept_definition_duplicates AS
+SELECT id, keep_id
+FROM (
+    SELECT id, FIRST_VALUE(id) OVER (PARTITION BY concept_type_id, term_key ORDER BY created_at, id) AS keep_id
+    FROM academic.concept_definitions
+) ranked
+WHERE id <> keep_id;
+
+UPDATE academic.school_concept_sources s
+SET definition_id = d.keep_id
+FROM concept_definition_duplicates d
+WHERE s.definition_id = d.id;
+
+INSERT INTO academic.concept_definition_translations (definition_id, locale, term_value, created_at, updated_at)
+SELECT d.keep_id, t.locale, t.term_value, t.created_at, t.updated_at
+FROM academic.concept_definition_translations t
+JOIN concept_definition_duplicates d ON d.id = t.definition_id
+ON CONFLICT (definition_id, locale) DO NOTHING;
+
+DELETE FROM academic.concept_definitions c
+USING concept_definition_duplicates d
+WHERE c.id = d.id;
+
+DROP TABLE concept_definition_duplicates;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_concept_definitions_type_term_key
+    ON academic.concept_definitions (concept_type_id, term_key);
@@ -0,0 +1,9 @@
+-- Managed catalog of concept definition categories
+CREATE TABLE IF NOT EXISTS academic.concept_categories (
+    code        VARCHAR(50) PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT,
+    sort_order  INTEGER NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS academic.school_lifecycle_transitions;
+DROP TABLE IF EXISTS academic.school_lifecycles;
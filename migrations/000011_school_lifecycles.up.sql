@@ -0,0 +1,22 @@
+-- Lifecycle state of each school and the history of its transitions
+CREATE TABLE IF NOT EXISTS academic.school_lifecycles (
+    school_id  UUID PRIMARY KEY REFERENCES academic.schools (id) ON DELETE CASCADE,
+    state      VARCHAR(20) NOT NULL,
+    reason     TEXT,
+    changed_by UUID,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_school_lifecycles_state ON academic.school_lifecycles (state);
+
+CREATE TABLE IF NOT EXISTS academic.school_lifecycle_transitions (
+    id         UUID PRIMARY KEY,
+    school_id  UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    from_state VARCHAR(20) NOT NULL,
+    to_state   VARCHAR(20) NOT NULL,
+    reason     TEXT,
+    changed_by UUID,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_school_lifecycle_transitions_school_id ON academic.school_lifecycle_transitions (school_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS academic.metadata_schemas;
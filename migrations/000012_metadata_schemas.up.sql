@@ -0,0 +1,14 @@
+-- JSON Schemas that school and academic unit metadata must satisfy
+CREATE TABLE IF NOT EXISTS academic.metadata_schemas (
+    id              UUID PRIMARY KEY,
+    entity_type     VARCHAR(20) NOT NULL,
+    concept_type_id UUID REFERENCES academic.concept_types (id) ON DELETE CASCADE,
+    schema          JSONB NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_metadata_schemas_entity_type ON academic.metadata_schemas (entity_type);
+-- One schema per entity type and concept type, plus one default per entity type
+CREATE UNIQUE INDEX IF NOT EXISTS idx_metadata_schemas_scope
+    ON academic.metadata_schemas (entity_type, COALESCE(concept_type_id, '00000000-0000-0000-0000-000000000000'::uuid));
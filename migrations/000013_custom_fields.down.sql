@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS academic.custom_field_values;
+DROP TABLE IF EXISTS academic.custom_field_definitions;
@@ -0,0 +1,32 @@
+-- Per-school custom fields of users, academic units and subjects
+CREATE TABLE IF NOT EXISTS academic.custom_field_definitions (
+    id          UUID PRIMARY KEY,
+    school_id   UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    entity_type VARCHAR(20) NOT NULL,
+    key         VARCHAR(50) NOT NULL,
+    name        VARCHAR(255) NOT NULL,
+    type        VARCHAR(20) NOT NULL,
+    required    BOOLEAN NOT NULL DEFAULT FALSE,
+    options     JSONB,
+    sort_order  INTEGER NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_field_definitions_school_id ON academic.custom_field_definitions (school_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_custom_field_definitions_key
+    ON academic.custom_field_definitions (school_id, entity_type, key);
+
+CREATE TABLE IF NOT EXISTS academic.custom_field_values (
+    definition_id UUID NOT NULL REFERENCES academic.custom_field_definitions (id) ON DELETE CASCADE,
+    entity_id     UUID NOT NULL,
+    school_id     UUID NOT NULL REFERENCES academic.schools (id) ON DELETE CASCADE,
+    entity_type   VARCHAR(20) NOT NULL,
+    value         TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (definition_id, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_custom_field_values_school_id ON academic.custom_field_values (school_id);
+CREATE INDEX IF NOT EXISTS idx_custom_field_values_entity_id ON academic.custom_field_values (entity_id);
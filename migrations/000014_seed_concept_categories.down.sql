@@ -0,0 +1,3 @@
+-- Seeded categories are left in place: they cannot be told apart from ones
+-- created through the API afterwards
+SELECT 1;
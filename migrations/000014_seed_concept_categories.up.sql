@@ -0,0 +1,7 @@
+-- Seed the category catalog with the categories definitions already use, so
+-- existing definitions keep a valid category
+INSERT INTO academic.concept_categories (code, name)
+SELECT DISTINCT category, category
+FROM academic.concept_definitions
+WHERE category IS NOT NULL AND category <> '' AND category <> 'general'
+ON CONFLICT (code) DO NOTHING;
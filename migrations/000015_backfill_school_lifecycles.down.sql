@@ -0,0 +1,4 @@
+-- Backfilled states have no transition record; remove only those
+DELETE FROM academic.school_lifecycles l
+WHERE l.changed_by IS NULL
+  AND NOT EXISTS (SELECT 1 FROM academic.school_lifecycle_transitions t WHERE t.school_id = l.school_id);
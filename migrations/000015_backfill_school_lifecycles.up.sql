@@ -0,0 +1,6 @@
+-- Give schools created before lifecycles a state that matches is_active
+INSERT INTO academic.school_lifecycles (school_id, state, changed_at)
+SELECT s.id, CASE WHEN s.is_active THEN 'active' ELSE 'suspended' END, NOW()
+FROM academic.schools s
+WHERE NOT EXISTS (SELECT 1 FROM academic.school_lifecycles l WHERE l.school_id = s.id)
+ON CONFLICT (school_id) DO NOTHING;
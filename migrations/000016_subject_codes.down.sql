@@ -0,0 +1 @@
+DROP INDEX IF EXISTS academic.idx_subjects_school_code_active;
@@ -0,0 +1,14 @@
+-- Active subject codes are unique per school. Codes repeated before this rule
+-- stay with the oldest subject; the others get a suffix taken from their ID.
+UPDATE academic.subjects s
+SET code = LEFT(s.code, 41) || '-' || LEFT(s.id::text, 8), updated_at = NOW()
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY school_id, code ORDER BY created_at, id) AS n
+    FROM academic.subjects
+    WHERE is_active AND code IS NOT NULL
+) ranked
+WHERE s.id = ranked.id AND ranked.n > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subjects_school_code_active
+    ON academic.subjects (school_id, code)
+    WHERE is_active AND code IS NOT NULL;
@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/infrastructure/persistence/postgres/repository"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
+)
+
+func TestSchoolConceptRepository_ReplaceForSchool(t *testing.T) {
+	db := openDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	oldType := &entities.ConceptType{ID: uuid.New(), Name: "Old type", Code: "it_" + uuid.NewString()[:8], IsActive: true, CreatedAt: now, UpdatedAt: now}
+	newType := &entities.ConceptType{ID: uuid.New(), Name: "New type", Code: "it_" + uuid.NewString()[:8], IsActive: true, CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, db.Create([]*entities.ConceptType{oldType, newType}).Error)
+	school := &entities.School{
+		ID: uuid.New(), Name: "Integration School", Code: "IT-" + uuid.NewString()[:8], Country: "CL",
+		ConceptTypeID: &oldType.ID, IsActive: true, SubscriptionTier: "free", CreatedAt: now, UpdatedAt: now,
+	}
+	require.NoError(t, db.Create(school).Error)
+	t.Cleanup(func() {
+		db.Delete(&entities.School{}, "id = ?", school.ID)
+		db.Delete(&entities.ConceptType{}, "id IN ?", []uuid.UUID{oldType.ID, newType.ID})
+	})
+
+	kept := &entities.SchoolConcept{ID: uuid.New(), SchoolID: school.ID, TermKey: "member.teacher", TermValue: "Maestro", Category: "members", CreatedAt: now, UpdatedAt: now}
+	dropped := &entities.SchoolConcept{ID: uuid.New(), SchoolID: school.ID, TermKey: "unit.section", TermValue: "Sección", Category: "units", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, db.Create([]*entities.SchoolConcept{kept, dropped}).Error)
+	require.NoError(t, db.Create([]*entity.SchoolConceptTranslation{
+		{SchoolConceptID: kept.ID, SchoolID: school.ID, Locale: "en", TermValue: "Teacher", CreatedAt: now, UpdatedAt: now},
+		{SchoolConceptID: dropped.ID, SchoolID: school.ID, Locale: "en", TermValue: "Section", CreatedAt: now, UpdatedAt: now},
+	}).Error)
+
+	added := &entities.SchoolConcept{ID: uuid.New(), SchoolID: school.ID, TermKey: "member.student", TermValue: "Alumno", Category: "members", CreatedAt: now, UpdatedAt: now}
+	keptNow := *kept
+	keptNow.UpdatedAt = time.Now()
+	sources := []*entity.SchoolConceptSource{
+		{SchoolConceptID: kept.ID, SchoolID: school.ID, DefinitionID: uuid.New(), DefaultValue: "Docente", Overridden: true, CreatedAt: now, UpdatedAt: now},
+		{SchoolConceptID: added.ID, SchoolID: school.ID, DefinitionID: uuid.New(), DefaultValue: "Alumno", CreatedAt: now, UpdatedAt: now},
+	}
+
+	repo := repository.NewPostgresSchoolConceptRepository(db)
+	require.NoError(t, repo.ReplaceForSchool(ctx, school.ID, newType.ID, []*entities.SchoolConcept{&keptNow, added}, sources, true))
+
+	concepts, err := repo.FindBySchoolID(ctx, school.ID)
+	require.NoError(t, err)
+	ids := map[uuid.UUID]string{}
+	for _, concept := range concepts {
+		ids[concept.ID] = concept.TermValue
+	}
+	assert.Equal(t, map[uuid.UUID]string{kept.ID: "Maestro", added.ID: "Alumno"}, ids)
+
+	translations, err := repo.FindTranslationsBySchoolID(ctx, school.ID)
+	require.NoError(t, err)
+	require.Len(t, translations, 1, "the kept term keeps its translation, the dropped one loses it")
+	assert.Equal(t, kept.ID, translations[0].SchoolConceptID)
+	assert.Equal(t, "Teacher", translations[0].TermValue)
+
+	var reloaded entities.School
+	require.NoError(t, db.First(&reloaded, "id = ?", school.ID).Error)
+	require.NotNil(t, reloaded.ConceptTypeID)
+	assert.Equal(t, newType.ID, *reloaded.ConceptTypeID)
+}
@@ -0,0 +1,46 @@
+//go:build integration
+
+package integration
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/config"
+)
+
+// openDB connects to the database configured through the usual
+// DATABASE_POSTGRES_* variables. The database must already be migrated
+// (make migrate-up). Tests are skipped unless RUN_INTEGRATION_TESTS is true.
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	if os.Getenv("RUN_INTEGRATION_TESTS") != "true" {
+		t.Skip("RUN_INTEGRATION_TESTS is not set")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	pgxConfig, err := pgx.ParseConfig(cfg.Database.Postgres.DSN())
+	if err != nil {
+		t.Fatalf("parse DSN: %v", err)
+	}
+	pgxConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+	pgxConfig.RuntimeParams["search_path"] = "auth,iam,academic,content,assessment,ui_config,public"
+
+	sqlDB := stdlib.OpenDB(*pgxConfig)
+	sqlDB.SetConnMaxLifetime(time.Minute)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	return db
+}
@@ -2,7 +2,10 @@ package mock
 
 import (
 	"context"
+	"time"
 
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/repository"
 	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
 	"github.com/google/uuid"
@@ -71,6 +74,121 @@ func (m *MockSchoolRepository) ExistsByCode(ctx context.Context, code string) (b
 	return false, nil
 }
 
+// ---------------------------------------------------------------------------
+// MockSchoolLifecycleRepository
+// ---------------------------------------------------------------------------
+
+type MockSchoolLifecycleRepository struct {
+	FindBySchoolIDFn        func(ctx context.Context, schoolID uuid.UUID) (*entity.SchoolLifecycle, error)
+	FindBySchoolIDsFn       func(ctx context.Context, schoolIDs []uuid.UUID) ([]*entity.SchoolLifecycle, error)
+	FindTransitionsFn       func(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolLifecycleTransition, error)
+	CreateSchoolFn          func(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error
+	TransitionFn            func(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error
+	ListUnarchivedSchoolsFn func(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.School, int64, error)
+
+	// Schools receives the school insert of CreateSchool when CreateSchoolFn is unset
+	Schools sharedrepo.SchoolRepository
+}
+
+func (m *MockSchoolLifecycleRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) (*entity.SchoolLifecycle, error) {
+	if m.FindBySchoolIDFn != nil {
+		return m.FindBySchoolIDFn(ctx, schoolID)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolLifecycleRepository) FindBySchoolIDs(ctx context.Context, schoolIDs []uuid.UUID) ([]*entity.SchoolLifecycle, error) {
+	if m.FindBySchoolIDsFn != nil {
+		return m.FindBySchoolIDsFn(ctx, schoolIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolLifecycleRepository) FindTransitions(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolLifecycleTransition, error) {
+	if m.FindTransitionsFn != nil {
+		return m.FindTransitionsFn(ctx, schoolID)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolLifecycleRepository) CreateSchool(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error {
+	if m.CreateSchoolFn != nil {
+		return m.CreateSchoolFn(ctx, school, lifecycle, transition)
+	}
+	if m.Schools != nil {
+		return m.Schools.Create(ctx, school)
+	}
+	return nil
+}
+
+func (m *MockSchoolLifecycleRepository) Transition(ctx context.Context, school *entities.School, lifecycle *entity.SchoolLifecycle, transition *entity.SchoolLifecycleTransition) error {
+	if m.TransitionFn != nil {
+		return m.TransitionFn(ctx, school, lifecycle, transition)
+	}
+	return nil
+}
+
+func (m *MockSchoolLifecycleRepository) ListUnarchivedSchools(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.School, int64, error) {
+	if m.ListUnarchivedSchoolsFn != nil {
+		return m.ListUnarchivedSchoolsFn(ctx, filters)
+	}
+	return nil, 0, nil
+}
+
+// ---------------------------------------------------------------------------
+// MockSchoolDeletionRepository
+// ---------------------------------------------------------------------------
+
+type MockSchoolDeletionRepository struct {
+	CountDependentsFn func(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolDependentCounts, error)
+	DeleteCascadeFn   func(ctx context.Context, schoolID uuid.UUID, at time.Time) error
+}
+
+func (m *MockSchoolDeletionRepository) CountDependents(ctx context.Context, schoolID uuid.UUID) (*repository.SchoolDependentCounts, error) {
+	if m.CountDependentsFn != nil {
+		return m.CountDependentsFn(ctx, schoolID)
+	}
+	return &repository.SchoolDependentCounts{}, nil
+}
+
+func (m *MockSchoolDeletionRepository) DeleteCascade(ctx context.Context, schoolID uuid.UUID, at time.Time) error {
+	if m.DeleteCascadeFn != nil {
+		return m.DeleteCascadeFn(ctx, schoolID, at)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// MockSchoolBundleRepository
+// ---------------------------------------------------------------------------
+
+type MockSchoolBundleRepository struct {
+	LoadFn              func(ctx context.Context, school *entities.School, withPeople bool) (*repository.SchoolBundleData, error)
+	FindUsersByEmailsFn func(ctx context.Context, emails []string) ([]*entities.User, error)
+	ImportFn            func(ctx context.Context, data *repository.SchoolBundleData) error
+}
+
+func (m *MockSchoolBundleRepository) Load(ctx context.Context, school *entities.School, withPeople bool) (*repository.SchoolBundleData, error) {
+	if m.LoadFn != nil {
+		return m.LoadFn(ctx, school, withPeople)
+	}
+	return &repository.SchoolBundleData{School: school}, nil
+}
+
+func (m *MockSchoolBundleRepository) FindUsersByEmails(ctx context.Context, emails []string) ([]*entities.User, error) {
+	if m.FindUsersByEmailsFn != nil {
+		return m.FindUsersByEmailsFn(ctx, emails)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolBundleRepository) Import(ctx context.Context, data *repository.SchoolBundleData) error {
+	if m.ImportFn != nil {
+		return m.ImportFn(ctx, data)
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // MockAcademicUnitRepository
 // ---------------------------------------------------------------------------
@@ -78,8 +196,8 @@ func (m *MockSchoolRepository) ExistsByCode(ctx context.Context, code string) (b
 type MockAcademicUnitRepository struct {
 	CreateFn                  func(ctx context.Context, unit *entities.AcademicUnit) error
 	FindByIDFn                func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*entities.AcademicUnit, error)
-	FindBySchoolIDFn          func(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error)
-	FindByTypeFn              func(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error)
+	FindBySchoolIDFn          func(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters []repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error)
+	FindByTypeFn              func(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters []repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error)
 	UpdateFn                  func(ctx context.Context, unit *entities.AcademicUnit) error
 	SoftDeleteFn              func(ctx context.Context, id uuid.UUID) error
 	RestoreFn                 func(ctx context.Context, id uuid.UUID) error
@@ -101,16 +219,16 @@ func (m *MockAcademicUnitRepository) FindByID(ctx context.Context, id uuid.UUID,
 	return nil, nil
 }
 
-func (m *MockAcademicUnitRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error) {
+func (m *MockAcademicUnitRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error) {
 	if m.FindBySchoolIDFn != nil {
-		return m.FindBySchoolIDFn(ctx, schoolID, includeDeleted, filters)
+		return m.FindBySchoolIDFn(ctx, schoolID, includeDeleted, filters, fieldFilters)
 	}
 	return nil, 0, nil
 }
 
-func (m *MockAcademicUnitRepository) FindByType(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters) ([]*entities.AcademicUnit, int, error) {
+func (m *MockAcademicUnitRepository) FindByType(ctx context.Context, schoolID uuid.UUID, unitType string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...repository.CustomFieldFilter) ([]*entities.AcademicUnit, int, error) {
 	if m.FindByTypeFn != nil {
-		return m.FindByTypeFn(ctx, schoolID, unitType, includeDeleted, filters)
+		return m.FindByTypeFn(ctx, schoolID, unitType, includeDeleted, filters, fieldFilters)
 	}
 	return nil, 0, nil
 }
@@ -227,13 +345,16 @@ func (m *MockMembershipRepository) Delete(ctx context.Context, id uuid.UUID) err
 
 type MockSubjectRepository struct {
 	CreateFn                  func(ctx context.Context, subject *entities.Subject) error
-	FindByIDFn                func(ctx context.Context, id uuid.UUID) (*entities.Subject, error)
-	FindBySchoolIDFn          func(ctx context.Context, schoolID uuid.UUID, filters sharedrepo.ListFilters) ([]*entities.Subject, int, error)
+	BulkCreateFn              func(ctx context.Context, subjects []*entities.Subject) error
+	FindByIDFn                func(ctx context.Context, id uuid.UUID, includeDeleted bool) (*entities.Subject, error)
+	FindBySchoolIDFn          func(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters []repository.CustomFieldFilter) ([]*entities.Subject, int, error)
 	UpdateFn                  func(ctx context.Context, subject *entities.Subject) error
 	DeleteFn                  func(ctx context.Context, id uuid.UUID) error
+	RestoreFn                 func(ctx context.Context, id uuid.UUID) error
 	ListFn                    func(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.Subject, error)
 	ExistsByNameFn            func(ctx context.Context, name string) (bool, error)
 	ExistsBySchoolIDAndNameFn func(ctx context.Context, schoolID uuid.UUID, name string) (bool, error)
+	ExistsBySchoolIDAndCodeFn func(ctx context.Context, schoolID uuid.UUID, code string) (bool, error)
 }
 
 func (m *MockSubjectRepository) Create(ctx context.Context, subject *entities.Subject) error {
@@ -243,16 +364,23 @@ func (m *MockSubjectRepository) Create(ctx context.Context, subject *entities.Su
 	return nil
 }
 
-func (m *MockSubjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Subject, error) {
+func (m *MockSubjectRepository) BulkCreate(ctx context.Context, subjects []*entities.Subject) error {
+	if m.BulkCreateFn != nil {
+		return m.BulkCreateFn(ctx, subjects)
+	}
+	return nil
+}
+
+func (m *MockSubjectRepository) FindByID(ctx context.Context, id uuid.UUID, includeDeleted bool) (*entities.Subject, error) {
 	if m.FindByIDFn != nil {
-		return m.FindByIDFn(ctx, id)
+		return m.FindByIDFn(ctx, id, includeDeleted)
 	}
 	return nil, nil
 }
 
-func (m *MockSubjectRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, filters sharedrepo.ListFilters) ([]*entities.Subject, int, error) {
+func (m *MockSubjectRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters ...repository.CustomFieldFilter) ([]*entities.Subject, int, error) {
 	if m.FindBySchoolIDFn != nil {
-		return m.FindBySchoolIDFn(ctx, schoolID, filters)
+		return m.FindBySchoolIDFn(ctx, schoolID, includeDeleted, filters, fieldFilters)
 	}
 	return nil, 0, nil
 }
@@ -271,6 +399,13 @@ func (m *MockSubjectRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+func (m *MockSubjectRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if m.RestoreFn != nil {
+		return m.RestoreFn(ctx, id)
+	}
+	return nil
+}
+
 func (m *MockSubjectRepository) List(ctx context.Context, filters sharedrepo.ListFilters) ([]*entities.Subject, error) {
 	if m.ListFn != nil {
 		return m.ListFn(ctx, filters)
@@ -292,18 +427,32 @@ func (m *MockSubjectRepository) ExistsBySchoolIDAndName(ctx context.Context, sch
 	return false, nil
 }
 
+func (m *MockSubjectRepository) ExistsBySchoolIDAndCode(ctx context.Context, schoolID uuid.UUID, code string) (bool, error) {
+	if m.ExistsBySchoolIDAndCodeFn != nil {
+		return m.ExistsBySchoolIDAndCodeFn(ctx, schoolID, code)
+	}
+	return false, nil
+}
+
 // ---------------------------------------------------------------------------
 // MockGuardianRepository
 // ---------------------------------------------------------------------------
 
 type MockGuardianRepository struct {
-	CreateFn               func(ctx context.Context, relation *entities.GuardianRelation) error
-	FindByIDFn             func(ctx context.Context, id uuid.UUID) (*entities.GuardianRelation, error)
-	FindByGuardianFn       func(ctx context.Context, guardianID uuid.UUID) ([]*entities.GuardianRelation, error)
-	FindByStudentFn        func(ctx context.Context, studentID uuid.UUID) ([]*entities.GuardianRelation, error)
-	UpdateFn               func(ctx context.Context, relation *entities.GuardianRelation) error
-	DeleteFn               func(ctx context.Context, id uuid.UUID) error
-	ExistsActiveRelationFn func(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error)
+	CreateFn                   func(ctx context.Context, relation *entities.GuardianRelation) error
+	FindByIDFn                 func(ctx context.Context, id uuid.UUID) (*entities.GuardianRelation, error)
+	FindByGuardianFn           func(ctx context.Context, guardianID uuid.UUID) ([]*entities.GuardianRelation, error)
+	FindByStudentFn            func(ctx context.Context, studentID uuid.UUID) ([]*entities.GuardianRelation, error)
+	UpdateFn                   func(ctx context.Context, relation *entities.GuardianRelation) error
+	DeleteFn                   func(ctx context.Context, id uuid.UUID) error
+	ExistsActiveRelationFn     func(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error)
+	FindDetailsByRelationIDsFn func(ctx context.Context, relationIDs []uuid.UUID) ([]*entity.GuardianRelationDetail, error)
+	SaveDetailFn               func(ctx context.Context, studentID uuid.UUID, detail *entity.GuardianRelationDetail) error
+	CreateWithDetailFn         func(ctx context.Context, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error
+	UpdateWithDetailFn         func(ctx context.Context, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error
+	ListByGuardianFn           func(ctx context.Context, guardianID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error)
+	ListByStudentFn            func(ctx context.Context, studentID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error)
+	ListBySchoolFn             func(ctx context.Context, schoolID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error)
 }
 
 func (m *MockGuardianRepository) Create(ctx context.Context, relation *entities.GuardianRelation) error {
@@ -355,6 +504,410 @@ func (m *MockGuardianRepository) ExistsActiveRelation(ctx context.Context, guard
 	return false, nil
 }
 
+func (m *MockGuardianRepository) FindDetailsByRelationIDs(ctx context.Context, relationIDs []uuid.UUID) ([]*entity.GuardianRelationDetail, error) {
+	if m.FindDetailsByRelationIDsFn != nil {
+		return m.FindDetailsByRelationIDsFn(ctx, relationIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianRepository) SaveDetail(ctx context.Context, studentID uuid.UUID, detail *entity.GuardianRelationDetail) error {
+	if m.SaveDetailFn != nil {
+		return m.SaveDetailFn(ctx, studentID, detail)
+	}
+	return nil
+}
+
+// CreateWithDetail falls back to Create and SaveDetail so tests can hook either write
+func (m *MockGuardianRepository) CreateWithDetail(ctx context.Context, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error {
+	if m.CreateWithDetailFn != nil {
+		return m.CreateWithDetailFn(ctx, relation, detail)
+	}
+	if err := m.Create(ctx, relation); err != nil {
+		return err
+	}
+	return m.SaveDetail(ctx, relation.StudentID, detail)
+}
+
+// UpdateWithDetail falls back to Update and SaveDetail so tests can hook either write
+func (m *MockGuardianRepository) UpdateWithDetail(ctx context.Context, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error {
+	if m.UpdateWithDetailFn != nil {
+		return m.UpdateWithDetailFn(ctx, relation, detail)
+	}
+	if err := m.Update(ctx, relation); err != nil {
+		return err
+	}
+	return m.SaveDetail(ctx, relation.StudentID, detail)
+}
+
+func (m *MockGuardianRepository) ListByGuardian(ctx context.Context, guardianID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+	if m.ListByGuardianFn != nil {
+		return m.ListByGuardianFn(ctx, guardianID, relFilters, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockGuardianRepository) ListByStudent(ctx context.Context, studentID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+	if m.ListByStudentFn != nil {
+		return m.ListByStudentFn(ctx, studentID, relFilters, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockGuardianRepository) ListBySchool(ctx context.Context, schoolID uuid.UUID, relFilters repository.GuardianRelationFilters, filters sharedrepo.ListFilters) ([]*entities.GuardianRelation, int, error) {
+	if m.ListBySchoolFn != nil {
+		return m.ListBySchoolFn(ctx, schoolID, relFilters, filters)
+	}
+	return nil, 0, nil
+}
+
+// ---------------------------------------------------------------------------
+// MockGuardianInvitationRepository
+// ---------------------------------------------------------------------------
+
+type MockGuardianInvitationRepository struct {
+	CreateInvitationFn         func(ctx context.Context, invitation *entity.GuardianInvitation) error
+	FindInvitationByIDFn       func(ctx context.Context, id uuid.UUID) (*entity.GuardianInvitation, error)
+	FindInvitationByCodeFn     func(ctx context.Context, code string) (*entity.GuardianInvitation, error)
+	FindInvitationsByStudentFn func(ctx context.Context, studentID uuid.UUID) ([]*entity.GuardianInvitation, error)
+	UpdateInvitationFn         func(ctx context.Context, invitation *entity.GuardianInvitation) error
+	RedeemFn                   func(ctx context.Context, invitationID uuid.UUID, request *entity.GuardianLinkRequest) (bool, error)
+	FindLinkRequestByIDFn      func(ctx context.Context, id uuid.UUID) (*entity.GuardianLinkRequest, error)
+	FindLinkRequestsFn         func(ctx context.Context, schoolID *uuid.UUID, status string) ([]*entity.GuardianLinkRequest, error)
+	UpdateLinkRequestFn        func(ctx context.Context, request *entity.GuardianLinkRequest) error
+	ApproveLinkRequestFn       func(ctx context.Context, request *entity.GuardianLinkRequest, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error
+	ExistsPendingLinkRequestFn func(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error)
+}
+
+func (m *MockGuardianInvitationRepository) CreateInvitation(ctx context.Context, invitation *entity.GuardianInvitation) error {
+	if m.CreateInvitationFn != nil {
+		return m.CreateInvitationFn(ctx, invitation)
+	}
+	return nil
+}
+
+func (m *MockGuardianInvitationRepository) FindInvitationByID(ctx context.Context, id uuid.UUID) (*entity.GuardianInvitation, error) {
+	if m.FindInvitationByIDFn != nil {
+		return m.FindInvitationByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationRepository) FindInvitationByCode(ctx context.Context, code string) (*entity.GuardianInvitation, error) {
+	if m.FindInvitationByCodeFn != nil {
+		return m.FindInvitationByCodeFn(ctx, code)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationRepository) FindInvitationsByStudent(ctx context.Context, studentID uuid.UUID) ([]*entity.GuardianInvitation, error) {
+	if m.FindInvitationsByStudentFn != nil {
+		return m.FindInvitationsByStudentFn(ctx, studentID)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationRepository) UpdateInvitation(ctx context.Context, invitation *entity.GuardianInvitation) error {
+	if m.UpdateInvitationFn != nil {
+		return m.UpdateInvitationFn(ctx, invitation)
+	}
+	return nil
+}
+
+func (m *MockGuardianInvitationRepository) Redeem(ctx context.Context, invitationID uuid.UUID, request *entity.GuardianLinkRequest) (bool, error) {
+	if m.RedeemFn != nil {
+		return m.RedeemFn(ctx, invitationID, request)
+	}
+	return true, nil
+}
+
+func (m *MockGuardianInvitationRepository) FindLinkRequestByID(ctx context.Context, id uuid.UUID) (*entity.GuardianLinkRequest, error) {
+	if m.FindLinkRequestByIDFn != nil {
+		return m.FindLinkRequestByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationRepository) FindLinkRequests(ctx context.Context, schoolID *uuid.UUID, status string) ([]*entity.GuardianLinkRequest, error) {
+	if m.FindLinkRequestsFn != nil {
+		return m.FindLinkRequestsFn(ctx, schoolID, status)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationRepository) UpdateLinkRequest(ctx context.Context, request *entity.GuardianLinkRequest) error {
+	if m.UpdateLinkRequestFn != nil {
+		return m.UpdateLinkRequestFn(ctx, request)
+	}
+	return nil
+}
+
+func (m *MockGuardianInvitationRepository) ApproveLinkRequest(ctx context.Context, request *entity.GuardianLinkRequest, relation *entities.GuardianRelation, detail *entity.GuardianRelationDetail) error {
+	if m.ApproveLinkRequestFn != nil {
+		return m.ApproveLinkRequestFn(ctx, request, relation, detail)
+	}
+	return nil
+}
+
+func (m *MockGuardianInvitationRepository) ExistsPendingLinkRequest(ctx context.Context, guardianID, studentID uuid.UUID) (bool, error) {
+	if m.ExistsPendingLinkRequestFn != nil {
+		return m.ExistsPendingLinkRequestFn(ctx, guardianID, studentID)
+	}
+	return false, nil
+}
+
+// ---------------------------------------------------------------------------
+// MockHouseholdRepository
+// ---------------------------------------------------------------------------
+
+type MockHouseholdRepository struct {
+	CreateFn           func(ctx context.Context, household *entity.Household, members []*entity.HouseholdMember) error
+	FindByIDFn         func(ctx context.Context, id uuid.UUID) (*entity.Household, error)
+	FindMembersFn      func(ctx context.Context, householdID uuid.UUID) ([]*entity.HouseholdMember, error)
+	FindMemberByUserFn func(ctx context.Context, userID uuid.UUID) (*entity.HouseholdMember, error)
+	AddMemberFn        func(ctx context.Context, member *entity.HouseholdMember) error
+	RemoveMemberFn     func(ctx context.Context, householdID, userID uuid.UUID) error
+}
+
+func (m *MockHouseholdRepository) Create(ctx context.Context, household *entity.Household, members []*entity.HouseholdMember) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, household, members)
+	}
+	return nil
+}
+
+func (m *MockHouseholdRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Household, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockHouseholdRepository) FindMembers(ctx context.Context, householdID uuid.UUID) ([]*entity.HouseholdMember, error) {
+	if m.FindMembersFn != nil {
+		return m.FindMembersFn(ctx, householdID)
+	}
+	return nil, nil
+}
+
+func (m *MockHouseholdRepository) FindMemberByUser(ctx context.Context, userID uuid.UUID) (*entity.HouseholdMember, error) {
+	if m.FindMemberByUserFn != nil {
+		return m.FindMemberByUserFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockHouseholdRepository) AddMember(ctx context.Context, member *entity.HouseholdMember) error {
+	if m.AddMemberFn != nil {
+		return m.AddMemberFn(ctx, member)
+	}
+	return nil
+}
+
+func (m *MockHouseholdRepository) RemoveMember(ctx context.Context, householdID, userID uuid.UUID) error {
+	if m.RemoveMemberFn != nil {
+		return m.RemoveMemberFn(ctx, householdID, userID)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// MockTeachingAssignmentRepository
+// ---------------------------------------------------------------------------
+
+type MockTeachingAssignmentRepository struct {
+	CreateFn        func(ctx context.Context, assignment *entity.TeachingAssignment) error
+	FindByIDFn      func(ctx context.Context, id uuid.UUID) (*entity.TeachingAssignment, error)
+	UpdateFn        func(ctx context.Context, assignment *entity.TeachingAssignment) error
+	DeleteFn        func(ctx context.Context, id uuid.UUID) error
+	FindByTeacherFn func(ctx context.Context, teacherID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error)
+	FindByUnitFn    func(ctx context.Context, unitID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error)
+	FindBySubjectFn func(ctx context.Context, subjectID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error)
+	ExistsActiveFn  func(ctx context.Context, teacherID, subjectID, unitID uuid.UUID, period *string) (bool, error)
+}
+
+func (m *MockTeachingAssignmentRepository) Create(ctx context.Context, assignment *entity.TeachingAssignment) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, assignment)
+	}
+	return nil
+}
+
+func (m *MockTeachingAssignmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.TeachingAssignment, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTeachingAssignmentRepository) Update(ctx context.Context, assignment *entity.TeachingAssignment) error {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, assignment)
+	}
+	return nil
+}
+
+func (m *MockTeachingAssignmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockTeachingAssignmentRepository) FindByTeacher(ctx context.Context, teacherID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error) {
+	if m.FindByTeacherFn != nil {
+		return m.FindByTeacherFn(ctx, teacherID, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockTeachingAssignmentRepository) FindByUnit(ctx context.Context, unitID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error) {
+	if m.FindByUnitFn != nil {
+		return m.FindByUnitFn(ctx, unitID, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockTeachingAssignmentRepository) FindBySubject(ctx context.Context, subjectID uuid.UUID, filters sharedrepo.ListFilters) ([]*entity.TeachingAssignment, int, error) {
+	if m.FindBySubjectFn != nil {
+		return m.FindBySubjectFn(ctx, subjectID, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockTeachingAssignmentRepository) ExistsActive(ctx context.Context, teacherID, subjectID, unitID uuid.UUID, period *string) (bool, error) {
+	if m.ExistsActiveFn != nil {
+		return m.ExistsActiveFn(ctx, teacherID, subjectID, unitID, period)
+	}
+	return false, nil
+}
+
+// ---------------------------------------------------------------------------
+// MockSubjectTemplateRepository
+// ---------------------------------------------------------------------------
+
+type MockSubjectTemplateRepository struct {
+	CreateFn        func(ctx context.Context, template *entity.SubjectTemplate) error
+	FindByIDFn      func(ctx context.Context, id uuid.UUID) (*entity.SubjectTemplate, error)
+	ListFn          func(ctx context.Context, filters repository.SubjectTemplateFilters) ([]*entity.SubjectTemplate, error)
+	UpdateFn        func(ctx context.Context, template *entity.SubjectTemplate) error
+	SoftDeleteFn    func(ctx context.Context, id uuid.UUID) error
+	ExistsInGroupFn func(ctx context.Context, name string, conceptTypeID *uuid.UUID, educationLevel *string) (bool, error)
+}
+
+func (m *MockSubjectTemplateRepository) Create(ctx context.Context, template *entity.SubjectTemplate) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, template)
+	}
+	return nil
+}
+
+func (m *MockSubjectTemplateRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.SubjectTemplate, error) {
+	if m.FindByIDFn != nil {
+		return m.FindByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockSubjectTemplateRepository) List(ctx context.Context, filters repository.SubjectTemplateFilters) ([]*entity.SubjectTemplate, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, filters)
+	}
+	return nil, nil
+}
+
+func (m *MockSubjectTemplateRepository) Update(ctx context.Context, template *entity.SubjectTemplate) error {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, template)
+	}
+	return nil
+}
+
+func (m *MockSubjectTemplateRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	if m.SoftDeleteFn != nil {
+		return m.SoftDeleteFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockSubjectTemplateRepository) ExistsInGroup(ctx context.Context, name string, conceptTypeID *uuid.UUID, educationLevel *string) (bool, error) {
+	if m.ExistsInGroupFn != nil {
+		return m.ExistsInGroupFn(ctx, name, conceptTypeID, educationLevel)
+	}
+	return false, nil
+}
+
+// ---------------------------------------------------------------------------
+// MockCurriculumRepository
+// ---------------------------------------------------------------------------
+
+type MockCurriculumRepository struct {
+	AddPrerequisiteFn           func(ctx context.Context, prerequisite *entity.SubjectPrerequisite) error
+	RemovePrerequisiteFn        func(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error
+	FindPrerequisitesFn         func(ctx context.Context, subjectID uuid.UUID) ([]*entity.SubjectPrerequisite, error)
+	FindPrerequisitesBySchoolFn func(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPrerequisite, error)
+	SavePlacementFn             func(ctx context.Context, placement *entity.SubjectPlacement) error
+	FindPlacementFn             func(ctx context.Context, subjectID uuid.UUID) (*entity.SubjectPlacement, error)
+	DeletePlacementFn           func(ctx context.Context, subjectID uuid.UUID) error
+	FindPlacementsBySchoolFn    func(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPlacement, error)
+}
+
+func (m *MockCurriculumRepository) AddPrerequisite(ctx context.Context, prerequisite *entity.SubjectPrerequisite) error {
+	if m.AddPrerequisiteFn != nil {
+		return m.AddPrerequisiteFn(ctx, prerequisite)
+	}
+	return nil
+}
+
+func (m *MockCurriculumRepository) RemovePrerequisite(ctx context.Context, subjectID, prerequisiteID uuid.UUID) error {
+	if m.RemovePrerequisiteFn != nil {
+		return m.RemovePrerequisiteFn(ctx, subjectID, prerequisiteID)
+	}
+	return nil
+}
+
+func (m *MockCurriculumRepository) FindPrerequisites(ctx context.Context, subjectID uuid.UUID) ([]*entity.SubjectPrerequisite, error) {
+	if m.FindPrerequisitesFn != nil {
+		return m.FindPrerequisitesFn(ctx, subjectID)
+	}
+	return nil, nil
+}
+
+func (m *MockCurriculumRepository) FindPrerequisitesBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPrerequisite, error) {
+	if m.FindPrerequisitesBySchoolFn != nil {
+		return m.FindPrerequisitesBySchoolFn(ctx, schoolID)
+	}
+	return nil, nil
+}
+
+func (m *MockCurriculumRepository) SavePlacement(ctx context.Context, placement *entity.SubjectPlacement) error {
+	if m.SavePlacementFn != nil {
+		return m.SavePlacementFn(ctx, placement)
+	}
+	return nil
+}
+
+func (m *MockCurriculumRepository) FindPlacement(ctx context.Context, subjectID uuid.UUID) (*entity.SubjectPlacement, error) {
+	if m.FindPlacementFn != nil {
+		return m.FindPlacementFn(ctx, subjectID)
+	}
+	return nil, nil
+}
+
+func (m *MockCurriculumRepository) DeletePlacement(ctx context.Context, subjectID uuid.UUID) error {
+	if m.DeletePlacementFn != nil {
+		return m.DeletePlacementFn(ctx, subjectID)
+	}
+	return nil
+}
+
+func (m *MockCurriculumRepository) FindPlacementsBySchool(ctx context.Context, schoolID uuid.UUID) ([]*entity.SubjectPlacement, error) {
+	if m.FindPlacementsBySchoolFn != nil {
+		return m.FindPlacementsBySchoolFn(ctx, schoolID)
+	}
+	return nil, nil
+}
+
 // ---------------------------------------------------------------------------
 // MockUserRepository
 // ---------------------------------------------------------------------------
@@ -423,12 +976,15 @@ func (m *MockUserRepository) List(ctx context.Context, filters sharedrepo.ListFi
 // ---------------------------------------------------------------------------
 
 type MockConceptTypeRepository struct {
-	FindAllFn    func(ctx context.Context) ([]*entities.ConceptType, error)
-	FindByIDFn   func(ctx context.Context, id uuid.UUID) (*entities.ConceptType, error)
-	FindByCodeFn func(ctx context.Context, code string) (*entities.ConceptType, error)
-	CreateFn     func(ctx context.Context, ct *entities.ConceptType) error
-	UpdateFn     func(ctx context.Context, ct *entities.ConceptType) error
-	SoftDeleteFn func(ctx context.Context, id uuid.UUID) error
+	FindAllFn           func(ctx context.Context) ([]*entities.ConceptType, error)
+	FindByIDFn          func(ctx context.Context, id uuid.UUID) (*entities.ConceptType, error)
+	FindByCodeFn        func(ctx context.Context, code string) (*entities.ConceptType, error)
+	CreateFn            func(ctx context.Context, ct *entities.ConceptType) error
+	UpdateFn            func(ctx context.Context, ct *entities.ConceptType) error
+	SoftDeleteFn        func(ctx context.Context, id uuid.UUID) error
+	FindSchoolsFn       func(ctx context.Context, id uuid.UUID) ([]*entities.School, error)
+	ImportFn            func(ctx context.Context, changes *repository.ConceptPackChanges) error
+	ReassignAndDeleteFn func(ctx context.Context, id uuid.UUID, targetID *uuid.UUID, plan func(schools []*entities.School) ([]*repository.SchoolConceptMigration, error)) error
 }
 
 func (m *MockConceptTypeRepository) FindAll(ctx context.Context) ([]*entities.ConceptType, error) {
@@ -473,16 +1029,45 @@ func (m *MockConceptTypeRepository) SoftDelete(ctx context.Context, id uuid.UUID
 	return nil
 }
 
+func (m *MockConceptTypeRepository) FindSchools(ctx context.Context, id uuid.UUID) ([]*entities.School, error) {
+	if m.FindSchoolsFn != nil {
+		return m.FindSchoolsFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptTypeRepository) Import(ctx context.Context, changes *repository.ConceptPackChanges) error {
+	if m.ImportFn != nil {
+		return m.ImportFn(ctx, changes)
+	}
+	return nil
+}
+
+func (m *MockConceptTypeRepository) ReassignAndDelete(ctx context.Context, id uuid.UUID, targetID *uuid.UUID, plan func(schools []*entities.School) ([]*repository.SchoolConceptMigration, error)) error {
+	if m.ReassignAndDeleteFn != nil {
+		return m.ReassignAndDeleteFn(ctx, id, targetID, plan)
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // MockConceptDefinitionRepository
 // ---------------------------------------------------------------------------
 
 type MockConceptDefinitionRepository struct {
-	FindByIDFn     func(ctx context.Context, id uuid.UUID) (*entities.ConceptDefinition, error)
-	FindByTypeIDFn func(ctx context.Context, typeID uuid.UUID) ([]*entities.ConceptDefinition, error)
-	CreateFn       func(ctx context.Context, def *entities.ConceptDefinition) error
-	UpdateFn       func(ctx context.Context, def *entities.ConceptDefinition) error
-	DeleteFn       func(ctx context.Context, id uuid.UUID) error
+	FindByIDFn                func(ctx context.Context, id uuid.UUID) (*entities.ConceptDefinition, error)
+	FindByTypeIDFn            func(ctx context.Context, typeID uuid.UUID) ([]*entities.ConceptDefinition, error)
+	FindByTermKeyFn           func(ctx context.Context, typeID uuid.UUID, termKey string) (*entities.ConceptDefinition, error)
+	CreateFn                  func(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error
+	UpdateFn                  func(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error
+	DeleteFn                  func(ctx context.Context, id uuid.UUID) error
+	FindTranslationsFn        func(ctx context.Context, definitionIDs []uuid.UUID) ([]*entity.ConceptDefinitionTranslation, error)
+	FindVersionsFn            func(ctx context.Context, typeID uuid.UUID) ([]*entity.ConceptTypeVersion, error)
+	FindVersionFn             func(ctx context.Context, typeID uuid.UUID, version int) (*entity.ConceptTypeVersion, error)
+	FindLatestVersionFn       func(ctx context.Context, typeID uuid.UUID) (*entity.ConceptTypeVersion, error)
+	FindVersionDefinitionsFn  func(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionDefinition, error)
+	FindVersionTranslationsFn func(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionTranslation, error)
+	PublishFn                 func(ctx context.Context, version *entity.ConceptTypeVersion, defs []*entity.ConceptVersionDefinition, translations []*entity.ConceptVersionTranslation) error
 }
 
 func (m *MockConceptDefinitionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.ConceptDefinition, error) {
@@ -499,16 +1084,23 @@ func (m *MockConceptDefinitionRepository) FindByTypeID(ctx context.Context, type
 	return nil, nil
 }
 
-func (m *MockConceptDefinitionRepository) Create(ctx context.Context, def *entities.ConceptDefinition) error {
+func (m *MockConceptDefinitionRepository) FindByTermKey(ctx context.Context, typeID uuid.UUID, termKey string) (*entities.ConceptDefinition, error) {
+	if m.FindByTermKeyFn != nil {
+		return m.FindByTermKeyFn(ctx, typeID, termKey)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptDefinitionRepository) Create(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error {
 	if m.CreateFn != nil {
-		return m.CreateFn(ctx, def)
+		return m.CreateFn(ctx, def, translations)
 	}
 	return nil
 }
 
-func (m *MockConceptDefinitionRepository) Update(ctx context.Context, def *entities.ConceptDefinition) error {
+func (m *MockConceptDefinitionRepository) Update(ctx context.Context, def *entities.ConceptDefinition, translations []*entity.ConceptDefinitionTranslation) error {
 	if m.UpdateFn != nil {
-		return m.UpdateFn(ctx, def)
+		return m.UpdateFn(ctx, def, translations)
 	}
 	return nil
 }
@@ -520,15 +1112,126 @@ func (m *MockConceptDefinitionRepository) Delete(ctx context.Context, id uuid.UU
 	return nil
 }
 
+func (m *MockConceptDefinitionRepository) FindTranslations(ctx context.Context, definitionIDs []uuid.UUID) ([]*entity.ConceptDefinitionTranslation, error) {
+	if m.FindTranslationsFn != nil {
+		return m.FindTranslationsFn(ctx, definitionIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptDefinitionRepository) FindVersions(ctx context.Context, typeID uuid.UUID) ([]*entity.ConceptTypeVersion, error) {
+	if m.FindVersionsFn != nil {
+		return m.FindVersionsFn(ctx, typeID)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptDefinitionRepository) FindVersion(ctx context.Context, typeID uuid.UUID, version int) (*entity.ConceptTypeVersion, error) {
+	if m.FindVersionFn != nil {
+		return m.FindVersionFn(ctx, typeID, version)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptDefinitionRepository) FindLatestVersion(ctx context.Context, typeID uuid.UUID) (*entity.ConceptTypeVersion, error) {
+	if m.FindLatestVersionFn != nil {
+		return m.FindLatestVersionFn(ctx, typeID)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptDefinitionRepository) FindVersionDefinitions(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionDefinition, error) {
+	if m.FindVersionDefinitionsFn != nil {
+		return m.FindVersionDefinitionsFn(ctx, versionID)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptDefinitionRepository) FindVersionTranslations(ctx context.Context, versionID uuid.UUID) ([]*entity.ConceptVersionTranslation, error) {
+	if m.FindVersionTranslationsFn != nil {
+		return m.FindVersionTranslationsFn(ctx, versionID)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptDefinitionRepository) Publish(ctx context.Context, version *entity.ConceptTypeVersion, defs []*entity.ConceptVersionDefinition, translations []*entity.ConceptVersionTranslation) error {
+	if m.PublishFn != nil {
+		return m.PublishFn(ctx, version, defs, translations)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// MockConceptCategoryRepository
+// ---------------------------------------------------------------------------
+
+type MockConceptCategoryRepository struct {
+	FindAllFn          func(ctx context.Context) ([]*entity.ConceptCategory, error)
+	FindByCodeFn       func(ctx context.Context, code string) (*entity.ConceptCategory, error)
+	CreateFn           func(ctx context.Context, category *entity.ConceptCategory) error
+	UpdateFn           func(ctx context.Context, category *entity.ConceptCategory) error
+	DeleteFn           func(ctx context.Context, code string) error
+	CountDefinitionsFn func(ctx context.Context, code string) (int64, error)
+}
+
+func (m *MockConceptCategoryRepository) FindAll(ctx context.Context) ([]*entity.ConceptCategory, error) {
+	if m.FindAllFn != nil {
+		return m.FindAllFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptCategoryRepository) FindByCode(ctx context.Context, code string) (*entity.ConceptCategory, error) {
+	if m.FindByCodeFn != nil {
+		return m.FindByCodeFn(ctx, code)
+	}
+	return nil, nil
+}
+
+func (m *MockConceptCategoryRepository) Create(ctx context.Context, category *entity.ConceptCategory) error {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, category)
+	}
+	return nil
+}
+
+func (m *MockConceptCategoryRepository) Update(ctx context.Context, category *entity.ConceptCategory) error {
+	if m.UpdateFn != nil {
+		return m.UpdateFn(ctx, category)
+	}
+	return nil
+}
+
+func (m *MockConceptCategoryRepository) Delete(ctx context.Context, code string) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, code)
+	}
+	return nil
+}
+
+func (m *MockConceptCategoryRepository) CountDefinitions(ctx context.Context, code string) (int64, error) {
+	if m.CountDefinitionsFn != nil {
+		return m.CountDefinitionsFn(ctx, code)
+	}
+	return 0, nil
+}
+
 // ---------------------------------------------------------------------------
 // MockSchoolConceptRepository
 // ---------------------------------------------------------------------------
 
 type MockSchoolConceptRepository struct {
-	FindBySchoolIDFn func(ctx context.Context, schoolID uuid.UUID) ([]*entities.SchoolConcept, error)
-	BulkCreateFn     func(ctx context.Context, concepts []*entities.SchoolConcept) error
-	UpdateFn         func(ctx context.Context, concept *entities.SchoolConcept) error
-	FindByIDFn       func(ctx context.Context, id uuid.UUID) (*entities.SchoolConcept, error)
+	FindBySchoolIDFn             func(ctx context.Context, schoolID uuid.UUID) ([]*entities.SchoolConcept, error)
+	BulkCreateFn                 func(ctx context.Context, concepts []*entities.SchoolConcept) error
+	UpdateFn                     func(ctx context.Context, concept *entities.SchoolConcept) error
+	FindByIDFn                   func(ctx context.Context, id uuid.UUID) (*entities.SchoolConcept, error)
+	FindSourcesBySchoolIDFn      func(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptSource, error)
+	FindSourceByConceptIDFn      func(ctx context.Context, conceptID uuid.UUID) (*entity.SchoolConceptSource, error)
+	SaveWithSourcesFn            func(ctx context.Context, created, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource) error
+	ResetWithSourcesFn           func(ctx context.Context, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, conceptIDs []uuid.UUID) error
+	ReplaceForSchoolFn           func(ctx context.Context, schoolID, conceptTypeID uuid.UUID, concepts []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, keepTranslations bool) error
+	FindTranslationsBySchoolIDFn func(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptTranslation, error)
+	SaveTranslationFn            func(ctx context.Context, translation *entity.SchoolConceptTranslation) error
 }
 
 func (m *MockSchoolConceptRepository) FindBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entities.SchoolConcept, error) {
@@ -558,3 +1261,191 @@ func (m *MockSchoolConceptRepository) FindByID(ctx context.Context, id uuid.UUID
 	}
 	return nil, nil
 }
+
+func (m *MockSchoolConceptRepository) FindSourcesBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptSource, error) {
+	if m.FindSourcesBySchoolIDFn != nil {
+		return m.FindSourcesBySchoolIDFn(ctx, schoolID)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolConceptRepository) FindSourceByConceptID(ctx context.Context, conceptID uuid.UUID) (*entity.SchoolConceptSource, error) {
+	if m.FindSourceByConceptIDFn != nil {
+		return m.FindSourceByConceptIDFn(ctx, conceptID)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolConceptRepository) SaveWithSources(ctx context.Context, created, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource) error {
+	if m.SaveWithSourcesFn != nil {
+		return m.SaveWithSourcesFn(ctx, created, updated, sources)
+	}
+	return nil
+}
+
+// ResetWithSources falls back to SaveWithSourcesFn so tests that only record
+// saved terms keep seeing resets
+func (m *MockSchoolConceptRepository) ResetWithSources(ctx context.Context, updated []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, conceptIDs []uuid.UUID) error {
+	if m.ResetWithSourcesFn != nil {
+		return m.ResetWithSourcesFn(ctx, updated, sources, conceptIDs)
+	}
+	if m.SaveWithSourcesFn != nil {
+		return m.SaveWithSourcesFn(ctx, nil, updated, sources)
+	}
+	return nil
+}
+
+func (m *MockSchoolConceptRepository) ReplaceForSchool(ctx context.Context, schoolID, conceptTypeID uuid.UUID, concepts []*entities.SchoolConcept, sources []*entity.SchoolConceptSource, keepTranslations bool) error {
+	if m.ReplaceForSchoolFn != nil {
+		return m.ReplaceForSchoolFn(ctx, schoolID, conceptTypeID, concepts, sources, keepTranslations)
+	}
+	return nil
+}
+
+func (m *MockSchoolConceptRepository) FindTranslationsBySchoolID(ctx context.Context, schoolID uuid.UUID) ([]*entity.SchoolConceptTranslation, error) {
+	if m.FindTranslationsBySchoolIDFn != nil {
+		return m.FindTranslationsBySchoolIDFn(ctx, schoolID)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolConceptRepository) SaveTranslation(ctx context.Context, translation *entity.SchoolConceptTranslation) error {
+	if m.SaveTranslationFn != nil {
+		return m.SaveTranslationFn(ctx, translation)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// MockMetadataSchemaRepository
+// ---------------------------------------------------------------------------
+
+type MockMetadataSchemaRepository struct {
+	FindFn   func(ctx context.Context, entityType string, conceptTypeID *uuid.UUID) (*entity.MetadataSchema, error)
+	SaveFn   func(ctx context.Context, schema *entity.MetadataSchema) error
+	DeleteFn func(ctx context.Context, id uuid.UUID) error
+}
+
+func (m *MockMetadataSchemaRepository) Find(ctx context.Context, entityType string, conceptTypeID *uuid.UUID) (*entity.MetadataSchema, error) {
+	if m.FindFn != nil {
+		return m.FindFn(ctx, entityType, conceptTypeID)
+	}
+	return nil, nil
+}
+
+func (m *MockMetadataSchemaRepository) Save(ctx context.Context, schema *entity.MetadataSchema) error {
+	if m.SaveFn != nil {
+		return m.SaveFn(ctx, schema)
+	}
+	return nil
+}
+
+func (m *MockMetadataSchemaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteFn != nil {
+		return m.DeleteFn(ctx, id)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// MockCustomFieldRepository
+// ---------------------------------------------------------------------------
+
+type MockCustomFieldRepository struct {
+	ListDefinitionsFn     func(ctx context.Context, schoolID uuid.UUID, entityType string) ([]*entity.CustomFieldDefinition, error)
+	FindDefinitionByIDFn  func(ctx context.Context, id uuid.UUID) (*entity.CustomFieldDefinition, error)
+	ExistsDefinitionKeyFn func(ctx context.Context, schoolID uuid.UUID, entityType, key string) (bool, error)
+	CreateDefinitionFn    func(ctx context.Context, def *entity.CustomFieldDefinition) error
+	UpdateDefinitionFn    func(ctx context.Context, def *entity.CustomFieldDefinition) error
+	DeleteDefinitionFn    func(ctx context.Context, id uuid.UUID) error
+	CountValuesFn         func(ctx context.Context, definitionID uuid.UUID, values []string) (int64, error)
+	CountMissingValuesFn  func(ctx context.Context, def *entity.CustomFieldDefinition) (int64, error)
+	FindValuesFn          func(ctx context.Context, schoolID uuid.UUID, entityType string, entityIDs []uuid.UUID) ([]*entity.CustomFieldValue, error)
+	CreateWithValuesFn    func(ctx context.Context, record interface{}, values []*entity.CustomFieldValue) error
+	UpdateWithValuesFn    func(ctx context.Context, record interface{}, entityID uuid.UUID, values []*entity.CustomFieldValue, cleared []uuid.UUID) error
+	ListUsersFn           func(ctx context.Context, filters sharedrepo.ListFilters, schoolID uuid.UUID, fieldFilters []repository.CustomFieldFilter) ([]*entities.User, int64, error)
+}
+
+func (m *MockCustomFieldRepository) ListDefinitions(ctx context.Context, schoolID uuid.UUID, entityType string) ([]*entity.CustomFieldDefinition, error) {
+	if m.ListDefinitionsFn != nil {
+		return m.ListDefinitionsFn(ctx, schoolID, entityType)
+	}
+	return nil, nil
+}
+
+func (m *MockCustomFieldRepository) FindDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.CustomFieldDefinition, error) {
+	if m.FindDefinitionByIDFn != nil {
+		return m.FindDefinitionByIDFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockCustomFieldRepository) ExistsDefinitionKey(ctx context.Context, schoolID uuid.UUID, entityType, key string) (bool, error) {
+	if m.ExistsDefinitionKeyFn != nil {
+		return m.ExistsDefinitionKeyFn(ctx, schoolID, entityType, key)
+	}
+	return false, nil
+}
+
+func (m *MockCustomFieldRepository) CreateDefinition(ctx context.Context, def *entity.CustomFieldDefinition) error {
+	if m.CreateDefinitionFn != nil {
+		return m.CreateDefinitionFn(ctx, def)
+	}
+	return nil
+}
+
+func (m *MockCustomFieldRepository) UpdateDefinition(ctx context.Context, def *entity.CustomFieldDefinition) error {
+	if m.UpdateDefinitionFn != nil {
+		return m.UpdateDefinitionFn(ctx, def)
+	}
+	return nil
+}
+
+func (m *MockCustomFieldRepository) DeleteDefinition(ctx context.Context, id uuid.UUID) error {
+	if m.DeleteDefinitionFn != nil {
+		return m.DeleteDefinitionFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockCustomFieldRepository) CountValues(ctx context.Context, definitionID uuid.UUID, values []string) (int64, error) {
+	if m.CountValuesFn != nil {
+		return m.CountValuesFn(ctx, definitionID, values)
+	}
+	return 0, nil
+}
+
+func (m *MockCustomFieldRepository) CountMissingValues(ctx context.Context, def *entity.CustomFieldDefinition) (int64, error) {
+	if m.CountMissingValuesFn != nil {
+		return m.CountMissingValuesFn(ctx, def)
+	}
+	return 0, nil
+}
+
+func (m *MockCustomFieldRepository) FindValues(ctx context.Context, schoolID uuid.UUID, entityType string, entityIDs []uuid.UUID) ([]*entity.CustomFieldValue, error) {
+	if m.FindValuesFn != nil {
+		return m.FindValuesFn(ctx, schoolID, entityType, entityIDs)
+	}
+	return nil, nil
+}
+
+func (m *MockCustomFieldRepository) CreateWithValues(ctx context.Context, record interface{}, values []*entity.CustomFieldValue) error {
+	if m.CreateWithValuesFn != nil {
+		return m.CreateWithValuesFn(ctx, record, values)
+	}
+	return nil
+}
+
+func (m *MockCustomFieldRepository) UpdateWithValues(ctx context.Context, record interface{}, entityID uuid.UUID, values []*entity.CustomFieldValue, cleared []uuid.UUID) error {
+	if m.UpdateWithValuesFn != nil {
+		return m.UpdateWithValuesFn(ctx, record, entityID, values, cleared)
+	}
+	return nil
+}
+
+func (m *MockCustomFieldRepository) ListUsers(ctx context.Context, filters sharedrepo.ListFilters, schoolID uuid.UUID, fieldFilters []repository.CustomFieldFilter) ([]*entities.User, int64, error) {
+	if m.ListUsersFn != nil {
+		return m.ListUsersFn(ctx, filters, schoolID, fieldFilters)
+	}
+	return nil, 0, nil
+}
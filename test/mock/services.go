@@ -4,7 +4,10 @@ import (
 	"context"
 
 	"github.com/EduGoGroup/edugo-api-admin-new/internal/application/dto"
+	"github.com/EduGoGroup/edugo-api-admin-new/internal/domain/entity"
+	"github.com/EduGoGroup/edugo-infrastructure/postgres/entities"
 	sharedrepo "github.com/EduGoGroup/edugo-shared/repository"
+	"github.com/google/uuid"
 )
 
 // ---------------------------------------------------------------------------
@@ -12,12 +15,21 @@ import (
 // ---------------------------------------------------------------------------
 
 type MockSchoolService struct {
-	CreateSchoolFn    func(ctx context.Context, req dto.CreateSchoolRequest) (*dto.SchoolResponse, error)
-	GetSchoolFn       func(ctx context.Context, id string) (*dto.SchoolResponse, error)
-	GetSchoolByCodeFn func(ctx context.Context, code string) (*dto.SchoolResponse, error)
-	UpdateSchoolFn    func(ctx context.Context, id string, req dto.UpdateSchoolRequest) (*dto.SchoolResponse, error)
-	ListSchoolsFn     func(ctx context.Context, filters sharedrepo.ListFilters) ([]dto.SchoolResponse, int, error)
-	DeleteSchoolFn    func(ctx context.Context, id string) error
+	CreateSchoolFn       func(ctx context.Context, req dto.CreateSchoolRequest) (*dto.SchoolResponse, error)
+	GetSchoolFn          func(ctx context.Context, id string) (*dto.SchoolResponse, error)
+	GetSchoolByCodeFn    func(ctx context.Context, code string) (*dto.SchoolResponse, error)
+	UpdateSchoolFn       func(ctx context.Context, id string, req dto.UpdateSchoolRequest) (*dto.SchoolResponse, error)
+	ListSchoolsFn        func(ctx context.Context, filters sharedrepo.ListFilters, includeArchived bool) ([]dto.SchoolResponse, int, error)
+	GetDeletionImpactFn  func(ctx context.Context, id string) (*dto.SchoolDeletionImpactResponse, error)
+	DeleteSchoolFn       func(ctx context.Context, id string, confirmationToken string) error
+	ChangeSchoolStateFn  func(ctx context.Context, id string, req dto.ChangeSchoolStateRequest) (*dto.SchoolResponse, error)
+	RestoreSchoolFn      func(ctx context.Context, id string, req dto.RestoreSchoolRequest) (*dto.SchoolResponse, error)
+	GetSchoolLifecycleFn func(ctx context.Context, id string) (*dto.SchoolLifecycleResponse, error)
+	IsSchoolReadOnlyFn   func(ctx context.Context, schoolID uuid.UUID) (bool, error)
+	ExportSchoolBundleFn func(ctx context.Context, id string, opts dto.SchoolBundleExportOptions) (*dto.SchoolBundle, error)
+	ImportSchoolBundleFn func(ctx context.Context, bundle *dto.SchoolBundle, dryRun bool) (*dto.SchoolBundleImportResponse, error)
+	CloneSchoolFn        func(ctx context.Context, id string, req dto.CloneSchoolRequest) (*dto.SchoolCloneResponse, error)
+	CheckSchoolCodeFn    func(ctx context.Context, code, name, city string) (*dto.SchoolCodeAvailabilityResponse, error)
 }
 
 func (m *MockSchoolService) CreateSchool(ctx context.Context, req dto.CreateSchoolRequest) (*dto.SchoolResponse, error) {
@@ -48,20 +60,83 @@ func (m *MockSchoolService) UpdateSchool(ctx context.Context, id string, req dto
 	return nil, nil
 }
 
-func (m *MockSchoolService) ListSchools(ctx context.Context, filters sharedrepo.ListFilters) ([]dto.SchoolResponse, int, error) {
+func (m *MockSchoolService) ListSchools(ctx context.Context, filters sharedrepo.ListFilters, includeArchived bool) ([]dto.SchoolResponse, int, error) {
 	if m.ListSchoolsFn != nil {
-		return m.ListSchoolsFn(ctx, filters)
+		return m.ListSchoolsFn(ctx, filters, includeArchived)
 	}
 	return nil, 0, nil
 }
 
-func (m *MockSchoolService) DeleteSchool(ctx context.Context, id string) error {
+func (m *MockSchoolService) GetDeletionImpact(ctx context.Context, id string) (*dto.SchoolDeletionImpactResponse, error) {
+	if m.GetDeletionImpactFn != nil {
+		return m.GetDeletionImpactFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolService) DeleteSchool(ctx context.Context, id string, confirmationToken string) error {
 	if m.DeleteSchoolFn != nil {
-		return m.DeleteSchoolFn(ctx, id)
+		return m.DeleteSchoolFn(ctx, id, confirmationToken)
 	}
 	return nil
 }
 
+func (m *MockSchoolService) ChangeSchoolState(ctx context.Context, id string, req dto.ChangeSchoolStateRequest) (*dto.SchoolResponse, error) {
+	if m.ChangeSchoolStateFn != nil {
+		return m.ChangeSchoolStateFn(ctx, id, req)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolService) RestoreSchool(ctx context.Context, id string, req dto.RestoreSchoolRequest) (*dto.SchoolResponse, error) {
+	if m.RestoreSchoolFn != nil {
+		return m.RestoreSchoolFn(ctx, id, req)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolService) GetSchoolLifecycle(ctx context.Context, id string) (*dto.SchoolLifecycleResponse, error) {
+	if m.GetSchoolLifecycleFn != nil {
+		return m.GetSchoolLifecycleFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolService) IsSchoolReadOnly(ctx context.Context, schoolID uuid.UUID) (bool, error) {
+	if m.IsSchoolReadOnlyFn != nil {
+		return m.IsSchoolReadOnlyFn(ctx, schoolID)
+	}
+	return false, nil
+}
+
+func (m *MockSchoolService) ExportSchoolBundle(ctx context.Context, id string, opts dto.SchoolBundleExportOptions) (*dto.SchoolBundle, error) {
+	if m.ExportSchoolBundleFn != nil {
+		return m.ExportSchoolBundleFn(ctx, id, opts)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolService) ImportSchoolBundle(ctx context.Context, bundle *dto.SchoolBundle, dryRun bool) (*dto.SchoolBundleImportResponse, error) {
+	if m.ImportSchoolBundleFn != nil {
+		return m.ImportSchoolBundleFn(ctx, bundle, dryRun)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolService) CloneSchool(ctx context.Context, id string, req dto.CloneSchoolRequest) (*dto.SchoolCloneResponse, error) {
+	if m.CloneSchoolFn != nil {
+		return m.CloneSchoolFn(ctx, id, req)
+	}
+	return nil, nil
+}
+
+func (m *MockSchoolService) CheckSchoolCode(ctx context.Context, code, name, city string) (*dto.SchoolCodeAvailabilityResponse, error) {
+	if m.CheckSchoolCodeFn != nil {
+		return m.CheckSchoolCodeFn(ctx, code, name, city)
+	}
+	return nil, nil
+}
+
 // ---------------------------------------------------------------------------
 // MockAcademicUnitService
 // ---------------------------------------------------------------------------
@@ -69,9 +144,9 @@ func (m *MockSchoolService) DeleteSchool(ctx context.Context, id string) error {
 type MockAcademicUnitService struct {
 	CreateUnitFn        func(ctx context.Context, schoolID string, req dto.CreateAcademicUnitRequest) (*dto.AcademicUnitResponse, error)
 	GetUnitFn           func(ctx context.Context, id string) (*dto.AcademicUnitResponse, error)
-	ListUnitsBySchoolFn func(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error)
+	ListUnitsBySchoolFn func(ctx context.Context, schoolID string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error)
 	GetUnitTreeFn       func(ctx context.Context, schoolID string) ([]*dto.UnitTreeNode, error)
-	ListUnitsByTypeFn   func(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error)
+	ListUnitsByTypeFn   func(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error)
 	UpdateUnitFn        func(ctx context.Context, id string, req dto.UpdateAcademicUnitRequest) (*dto.AcademicUnitResponse, error)
 	DeleteUnitFn        func(ctx context.Context, id string) error
 	RestoreUnitFn       func(ctx context.Context, id string) (*dto.AcademicUnitResponse, error)
@@ -92,9 +167,9 @@ func (m *MockAcademicUnitService) GetUnit(ctx context.Context, id string) (*dto.
 	return nil, nil
 }
 
-func (m *MockAcademicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error) {
+func (m *MockAcademicUnitService) ListUnitsBySchool(ctx context.Context, schoolID string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error) {
 	if m.ListUnitsBySchoolFn != nil {
-		return m.ListUnitsBySchoolFn(ctx, schoolID, filters)
+		return m.ListUnitsBySchoolFn(ctx, schoolID, filters, fieldFilters)
 	}
 	return nil, 0, nil
 }
@@ -106,9 +181,9 @@ func (m *MockAcademicUnitService) GetUnitTree(ctx context.Context, schoolID stri
 	return nil, nil
 }
 
-func (m *MockAcademicUnitService) ListUnitsByType(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters) ([]dto.AcademicUnitResponse, int, error) {
+func (m *MockAcademicUnitService) ListUnitsByType(ctx context.Context, schoolID, unitType string, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.AcademicUnitResponse, int, error) {
 	if m.ListUnitsByTypeFn != nil {
-		return m.ListUnitsByTypeFn(ctx, schoolID, unitType, filters)
+		return m.ListUnitsByTypeFn(ctx, schoolID, unitType, filters, fieldFilters)
 	}
 	return nil, 0, nil
 }
@@ -217,11 +292,12 @@ func (m *MockMembershipService) ExpireMembership(ctx context.Context, id string)
 // ---------------------------------------------------------------------------
 
 type MockSubjectService struct {
-	CreateSubjectFn func(ctx context.Context, schoolID string, req dto.CreateSubjectRequest) (*dto.SubjectResponse, error)
-	GetSubjectFn    func(ctx context.Context, id string) (*dto.SubjectResponse, error)
-	ListSubjectsFn  func(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.SubjectResponse, int, error)
-	UpdateSubjectFn func(ctx context.Context, id string, req dto.UpdateSubjectRequest) (*dto.SubjectResponse, error)
-	DeleteSubjectFn func(ctx context.Context, id string) error
+	CreateSubjectFn  func(ctx context.Context, schoolID string, req dto.CreateSubjectRequest) (*dto.SubjectResponse, error)
+	GetSubjectFn     func(ctx context.Context, id string) (*dto.SubjectResponse, error)
+	ListSubjectsFn   func(ctx context.Context, schoolID string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.SubjectResponse, int, error)
+	UpdateSubjectFn  func(ctx context.Context, id string, req dto.UpdateSubjectRequest) (*dto.SubjectResponse, error)
+	DeleteSubjectFn  func(ctx context.Context, id string) error
+	RestoreSubjectFn func(ctx context.Context, id string) (*dto.SubjectResponse, error)
 }
 
 func (m *MockSubjectService) CreateSubject(ctx context.Context, schoolID string, req dto.CreateSubjectRequest) (*dto.SubjectResponse, error) {
@@ -238,9 +314,9 @@ func (m *MockSubjectService) GetSubject(ctx context.Context, id string) (*dto.Su
 	return nil, nil
 }
 
-func (m *MockSubjectService) ListSubjects(ctx context.Context, schoolID string, filters sharedrepo.ListFilters) ([]dto.SubjectResponse, int, error) {
+func (m *MockSubjectService) ListSubjects(ctx context.Context, schoolID string, includeDeleted bool, filters sharedrepo.ListFilters, fieldFilters map[string]string) ([]dto.SubjectResponse, int, error) {
 	if m.ListSubjectsFn != nil {
-		return m.ListSubjectsFn(ctx, schoolID, filters)
+		return m.ListSubjectsFn(ctx, schoolID, includeDeleted, filters, fieldFilters)
 	}
 	return nil, 0, nil
 }
@@ -259,17 +335,27 @@ func (m *MockSubjectService) DeleteSubject(ctx context.Context, id string) error
 	return nil
 }
 
+func (m *MockSubjectService) RestoreSubject(ctx context.Context, id string) (*dto.SubjectResponse, error) {
+	if m.RestoreSubjectFn != nil {
+		return m.RestoreSubjectFn(ctx, id)
+	}
+	return nil, nil
+}
+
 // ---------------------------------------------------------------------------
 // MockGuardianService
 // ---------------------------------------------------------------------------
 
 type MockGuardianService struct {
 	CreateRelationFn       func(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*dto.GuardianRelationResponse, error)
+	PrepareRelationFn      func(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*entities.GuardianRelation, *entity.GuardianRelationDetail, error)
 	GetRelationFn          func(ctx context.Context, id string) (*dto.GuardianRelationResponse, error)
 	UpdateRelationFn       func(ctx context.Context, id string, req dto.UpdateGuardianRelationRequest) (*dto.GuardianRelationResponse, error)
 	DeleteRelationFn       func(ctx context.Context, id string) error
-	GetGuardianRelationsFn func(ctx context.Context, guardianID string) ([]*dto.GuardianRelationResponse, error)
-	GetStudentGuardiansFn  func(ctx context.Context, studentID string) ([]*dto.GuardianRelationResponse, error)
+	GetGuardianRelationsFn func(ctx context.Context, guardianID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error)
+	GetStudentGuardiansFn  func(ctx context.Context, studentID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error)
+	ListSchoolRelationsFn  func(ctx context.Context, schoolID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error)
+	GetAuthorizedPickupsFn func(ctx context.Context, studentID string) ([]dto.AuthorizedPickupResponse, error)
 }
 
 func (m *MockGuardianService) CreateRelation(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*dto.GuardianRelationResponse, error) {
@@ -279,6 +365,13 @@ func (m *MockGuardianService) CreateRelation(ctx context.Context, req dto.Create
 	return nil, nil
 }
 
+func (m *MockGuardianService) PrepareRelation(ctx context.Context, req dto.CreateGuardianRelationRequest, createdBy string) (*entities.GuardianRelation, *entity.GuardianRelationDetail, error) {
+	if m.PrepareRelationFn != nil {
+		return m.PrepareRelationFn(ctx, req, createdBy)
+	}
+	return nil, nil, nil
+}
+
 func (m *MockGuardianService) GetRelation(ctx context.Context, id string) (*dto.GuardianRelationResponse, error) {
 	if m.GetRelationFn != nil {
 		return m.GetRelationFn(ctx, id)
@@ -300,28 +393,160 @@ func (m *MockGuardianService) DeleteRelation(ctx context.Context, id string) err
 	return nil
 }
 
-func (m *MockGuardianService) GetGuardianRelations(ctx context.Context, guardianID string) ([]*dto.GuardianRelationResponse, error) {
+func (m *MockGuardianService) GetGuardianRelations(ctx context.Context, guardianID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error) {
 	if m.GetGuardianRelationsFn != nil {
-		return m.GetGuardianRelationsFn(ctx, guardianID)
+		return m.GetGuardianRelationsFn(ctx, guardianID, relFilters, filters)
 	}
-	return nil, nil
+	return nil, 0, nil
 }
 
-func (m *MockGuardianService) GetStudentGuardians(ctx context.Context, studentID string) ([]*dto.GuardianRelationResponse, error) {
+func (m *MockGuardianService) GetStudentGuardians(ctx context.Context, studentID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error) {
 	if m.GetStudentGuardiansFn != nil {
-		return m.GetStudentGuardiansFn(ctx, studentID)
+		return m.GetStudentGuardiansFn(ctx, studentID, relFilters, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockGuardianService) ListSchoolRelations(ctx context.Context, schoolID string, relFilters dto.GuardianRelationListFilters, filters sharedrepo.ListFilters) ([]*dto.GuardianRelationResponse, int, error) {
+	if m.ListSchoolRelationsFn != nil {
+		return m.ListSchoolRelationsFn(ctx, schoolID, relFilters, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockGuardianService) GetAuthorizedPickups(ctx context.Context, studentID string) ([]dto.AuthorizedPickupResponse, error) {
+	if m.GetAuthorizedPickupsFn != nil {
+		return m.GetAuthorizedPickupsFn(ctx, studentID)
+	}
+	return nil, nil
+}
+
+// ---------------------------------------------------------------------------
+// MockGuardianInvitationService
+// ---------------------------------------------------------------------------
+
+type MockGuardianInvitationService struct {
+	CreateInvitationFn  func(ctx context.Context, studentID string, req dto.CreateGuardianInvitationRequest, createdBy string) (*dto.GuardianInvitationResponse, error)
+	ListInvitationsFn   func(ctx context.Context, studentID string) ([]dto.GuardianInvitationResponse, error)
+	RevokeInvitationFn  func(ctx context.Context, id string) error
+	RedeemInvitationFn  func(ctx context.Context, req dto.RedeemGuardianInvitationRequest, guardianID string) (*dto.GuardianLinkRequestResponse, error)
+	ListLinkRequestsFn  func(ctx context.Context, schoolID, status string) ([]dto.GuardianLinkRequestResponse, error)
+	ReviewLinkRequestFn func(ctx context.Context, id string, req dto.ReviewGuardianLinkRequest, reviewerID string) (*dto.GuardianLinkRequestResponse, error)
+}
+
+func (m *MockGuardianInvitationService) CreateInvitation(ctx context.Context, studentID string, req dto.CreateGuardianInvitationRequest, createdBy string) (*dto.GuardianInvitationResponse, error) {
+	if m.CreateInvitationFn != nil {
+		return m.CreateInvitationFn(ctx, studentID, req, createdBy)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationService) ListInvitations(ctx context.Context, studentID string) ([]dto.GuardianInvitationResponse, error) {
+	if m.ListInvitationsFn != nil {
+		return m.ListInvitationsFn(ctx, studentID)
 	}
 	return nil, nil
 }
 
+func (m *MockGuardianInvitationService) RevokeInvitation(ctx context.Context, id string) error {
+	if m.RevokeInvitationFn != nil {
+		return m.RevokeInvitationFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockGuardianInvitationService) RedeemInvitation(ctx context.Context, req dto.RedeemGuardianInvitationRequest, guardianID string) (*dto.GuardianLinkRequestResponse, error) {
+	if m.RedeemInvitationFn != nil {
+		return m.RedeemInvitationFn(ctx, req, guardianID)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationService) ListLinkRequests(ctx context.Context, schoolID, status string) ([]dto.GuardianLinkRequestResponse, error) {
+	if m.ListLinkRequestsFn != nil {
+		return m.ListLinkRequestsFn(ctx, schoolID, status)
+	}
+	return nil, nil
+}
+
+func (m *MockGuardianInvitationService) ReviewLinkRequest(ctx context.Context, id string, req dto.ReviewGuardianLinkRequest, reviewerID string) (*dto.GuardianLinkRequestResponse, error) {
+	if m.ReviewLinkRequestFn != nil {
+		return m.ReviewLinkRequestFn(ctx, id, req, reviewerID)
+	}
+	return nil, nil
+}
+
+// ---------------------------------------------------------------------------
+// MockTeachingAssignmentService
+// ---------------------------------------------------------------------------
+
+type MockTeachingAssignmentService struct {
+	CreateAssignmentFn func(ctx context.Context, req dto.CreateTeachingAssignmentRequest, createdBy string) (*dto.TeachingAssignmentResponse, error)
+	GetAssignmentFn    func(ctx context.Context, id string) (*dto.TeachingAssignmentResponse, error)
+	UpdateAssignmentFn func(ctx context.Context, id string, req dto.UpdateTeachingAssignmentRequest) (*dto.TeachingAssignmentResponse, error)
+	DeleteAssignmentFn func(ctx context.Context, id string) error
+	ListByTeacherFn    func(ctx context.Context, teacherID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error)
+	ListByUnitFn       func(ctx context.Context, unitID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error)
+	ListBySubjectFn    func(ctx context.Context, subjectID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error)
+}
+
+func (m *MockTeachingAssignmentService) CreateAssignment(ctx context.Context, req dto.CreateTeachingAssignmentRequest, createdBy string) (*dto.TeachingAssignmentResponse, error) {
+	if m.CreateAssignmentFn != nil {
+		return m.CreateAssignmentFn(ctx, req, createdBy)
+	}
+	return nil, nil
+}
+
+func (m *MockTeachingAssignmentService) GetAssignment(ctx context.Context, id string) (*dto.TeachingAssignmentResponse, error) {
+	if m.GetAssignmentFn != nil {
+		return m.GetAssignmentFn(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockTeachingAssignmentService) UpdateAssignment(ctx context.Context, id string, req dto.UpdateTeachingAssignmentRequest) (*dto.TeachingAssignmentResponse, error) {
+	if m.UpdateAssignmentFn != nil {
+		return m.UpdateAssignmentFn(ctx, id, req)
+	}
+	return nil, nil
+}
+
+func (m *MockTeachingAssignmentService) DeleteAssignment(ctx context.Context, id string) error {
+	if m.DeleteAssignmentFn != nil {
+		return m.DeleteAssignmentFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockTeachingAssignmentService) ListByTeacher(ctx context.Context, teacherID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error) {
+	if m.ListByTeacherFn != nil {
+		return m.ListByTeacherFn(ctx, teacherID, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockTeachingAssignmentService) ListByUnit(ctx context.Context, unitID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error) {
+	if m.ListByUnitFn != nil {
+		return m.ListByUnitFn(ctx, unitID, filters)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockTeachingAssignmentService) ListBySubject(ctx context.Context, subjectID string, filters sharedrepo.ListFilters) ([]dto.TeachingAssignmentResponse, int, error) {
+	if m.ListBySubjectFn != nil {
+		return m.ListBySubjectFn(ctx, subjectID, filters)
+	}
+	return nil, 0, nil
+}
+
 // ---------------------------------------------------------------------------
 // MockUserService
 // ---------------------------------------------------------------------------
 
 type MockUserService struct {
 	CreateUserFn func(ctx context.Context, req dto.CreateUserRequest) (*dto.UserResponse, error)
-	GetUserFn    func(ctx context.Context, id string) (*dto.UserResponse, error)
-	ListUsersFn  func(ctx context.Context, filters sharedrepo.ListFilters) ([]*dto.UserResponse, int, error)
+	GetUserFn    func(ctx context.Context, id, schoolID string) (*dto.UserResponse, error)
+	ListUsersFn  func(ctx context.Context, filters sharedrepo.ListFilters, schoolID string, fieldFilters map[string]string) ([]*dto.UserResponse, int, error)
 	UpdateUserFn func(ctx context.Context, id string, req dto.UpdateUserRequest) (*dto.UserResponse, error)
 	DeleteUserFn func(ctx context.Context, id string) error
 }
@@ -333,16 +558,16 @@ func (m *MockUserService) CreateUser(ctx context.Context, req dto.CreateUserRequ
 	return nil, nil
 }
 
-func (m *MockUserService) GetUser(ctx context.Context, id string) (*dto.UserResponse, error) {
+func (m *MockUserService) GetUser(ctx context.Context, id, schoolID string) (*dto.UserResponse, error) {
 	if m.GetUserFn != nil {
-		return m.GetUserFn(ctx, id)
+		return m.GetUserFn(ctx, id, schoolID)
 	}
 	return nil, nil
 }
 
-func (m *MockUserService) ListUsers(ctx context.Context, filters sharedrepo.ListFilters) ([]*dto.UserResponse, int, error) {
+func (m *MockUserService) ListUsers(ctx context.Context, filters sharedrepo.ListFilters, schoolID string, fieldFilters map[string]string) ([]*dto.UserResponse, int, error) {
 	if m.ListUsersFn != nil {
-		return m.ListUsersFn(ctx, filters)
+		return m.ListUsersFn(ctx, filters, schoolID, fieldFilters)
 	}
 	return nil, 0, nil
 }